@@ -1,6 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 
 	"gosl/pkg/config"
@@ -18,7 +22,7 @@ func setupDBConn(
 ) (*db.SafeConn, error) {
 	if args["test"] == "true" {
 		logger.Debug().Msg("Server in test mode, using test database")
-		ver, err := strconv.ParseInt(config.DBName, 10, 0)
+		ver, err := strconv.ParseInt(config.DBVersion, 10, 0)
 		if err != nil {
 			return nil, errors.Wrap(err, "strconv.ParseInt")
 		}
@@ -29,10 +33,36 @@ func setupDBConn(
 		conn := db.MakeSafe(wconn, rconn, logger)
 		return conn, nil
 	} else {
-		conn, err := db.ConnectToDatabase(config.DBName, logger)
+		err := checkLegacyDBFile(config)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := db.ConnectToDatabase(config.DBFile, logger)
 		if err != nil {
 			return nil, errors.Wrap(err, "db.ConnectToDatabase")
 		}
 		return conn, nil
 	}
 }
+
+// Databases used to be named after their schema version, e.g. 00007.db. If
+// the database file doesn't exist yet but one of those does, refuse to start
+// rather than creating an empty database and leaving the league data behind
+func checkLegacyDBFile(config *config.Config) error {
+	_, err := os.Stat(config.DBFile)
+	if err == nil || !os.IsNotExist(err) {
+		return nil
+	}
+	dir := filepath.Dir(config.DBFile)
+	legacy, err := filepath.Glob(filepath.Join(dir, "[0-9][0-9][0-9][0-9][0-9].db"))
+	if err != nil {
+		return errors.Wrap(err, "filepath.Glob")
+	}
+	if len(legacy) == 0 {
+		return nil
+	}
+	sort.Strings(legacy)
+	return errors.New(fmt.Sprintf(
+		"Database file %s not found but %s exists from an older version. Rename it to %s or set DB_FILE to use it",
+		config.DBFile, legacy[len(legacy)-1], config.DBFile))
+}
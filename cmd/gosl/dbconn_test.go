@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gosl/pkg/config"
+	"gosl/pkg/db"
+	"gosl/pkg/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkSchema(t *testing.T) {
+	ctx := context.Background()
+	logger := tests.NilLogger()
+	dir := t.TempDir()
+	cfg := &config.Config{
+		DBVersion:     "00002",
+		DBFile:        filepath.Join(dir, "gosl.db"),
+		DBLockTimeout: 5,
+		BackupDir:     filepath.Join(dir, "backups"),
+	}
+	connect := func() *db.SafeConn {
+		require.NoError(t, checkLegacyDBFile(cfg))
+		conn, err := db.ConnectToDatabase(cfg.DBFile, logger)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+
+	t.Run("Database file is migrated in place between versions", func(t *testing.T) {
+		conn := connect()
+		require.NoError(t, checkSchema(ctx, conn, cfg, logger, true))
+		conn.Close()
+
+		cfg.DBVersion = "00003"
+		conn = connect()
+		require.Error(t, checkSchema(ctx, conn, cfg, logger, false))
+		require.NoError(t, checkSchema(ctx, conn, cfg, logger, true))
+		version, err := conn.SchemaVersion(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), version)
+		backups, err := filepath.Glob(filepath.Join(cfg.BackupDir, "00002-*.db"))
+		require.NoError(t, err)
+		assert.Len(t, backups, 1)
+	})
+
+	t.Run("Database newer than required is not downgraded", func(t *testing.T) {
+		cfg.DBVersion = "00002"
+		conn := connect()
+		err := checkSchema(ctx, conn, cfg, logger, true)
+		assert.ErrorContains(t, err, "Refusing to downgrade")
+	})
+
+	t.Run("Database named after its version is not left behind", func(t *testing.T) {
+		legacy := filepath.Join(dir, "00007.db")
+		require.NoError(t, os.WriteFile(legacy, []byte{}, 0644))
+		cfg.DBFile = filepath.Join(dir, "new.db")
+		err := checkLegacyDBFile(cfg)
+		assert.ErrorContains(t, err, legacy)
+	})
+}
@@ -12,21 +12,29 @@ func setupFlags() map[string]string {
 	test := flag.Bool("test", false, "Run server in test mode")
 	tester := flag.Bool("tester", false, "Run tester function instead of main program")
 	dbver := flag.Bool("dbver", false, "Get the version of the database required")
+	migratestatus := flag.Bool("migrate-status", false, "Show the database version and any pending migrations")
+	migrate := flag.Bool("migrate", false, "Apply any pending database migrations on startup")
 	nobot := flag.Bool("no-bot", false, "Run the program without launching the discord bot")
+	backup := flag.Bool("backup", false, "Take a backup of the database and exit")
+	restore := flag.String("restore", "", "Restore the database from the backup file and exit")
 	loglevel := flag.String("loglevel", "", "Set log level")
 	logoutput := flag.String("logoutput", "", "Set log destination (file, console or both)")
 	flag.Parse()
 
 	// Map the args for easy access
 	args := map[string]string{
-		"host":      *host,
-		"port":      *port,
-		"test":      strconv.FormatBool(*test),
-		"tester":    strconv.FormatBool(*tester),
-		"nobot":     strconv.FormatBool(*nobot),
-		"dbver":     strconv.FormatBool(*dbver),
-		"loglevel":  *loglevel,
-		"logoutput": *logoutput,
+		"host":          *host,
+		"port":          *port,
+		"test":          strconv.FormatBool(*test),
+		"tester":        strconv.FormatBool(*tester),
+		"nobot":         strconv.FormatBool(*nobot),
+		"dbver":         strconv.FormatBool(*dbver),
+		"migratestatus": strconv.FormatBool(*migratestatus),
+		"migrate":       strconv.FormatBool(*migrate),
+		"backup":        strconv.FormatBool(*backup),
+		"restore":       *restore,
+		"loglevel":      *loglevel,
+		"logoutput":     *logoutput,
 	}
 	return args
 }
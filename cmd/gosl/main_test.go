@@ -3,10 +3,13 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -14,12 +17,30 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// Buffer that can be written to by the server while the test reads it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
 func Test_main(t *testing.T) {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	t.Cleanup(cancel)
 	args := map[string]string{"test": "true"}
-	var stdout bytes.Buffer
+	var stdout syncBuffer
 	os.Setenv("SECRET_KEY", ".")
 	os.Setenv("DISCORD_BOT_TOKEN", ".")
 	os.Setenv("DISCORD_GUILD_ID", ".")
@@ -27,6 +48,7 @@ func Test_main(t *testing.T) {
 	os.Setenv("SLAPSHOT_API_KEY", ".")
 	os.Setenv("HOST", "127.0.0.1")
 	os.Setenv("PORT", "3232")
+	os.Setenv("ADMIN_API_TOKEN", "test-token")
 	runSrvErr := make(chan error)
 	go func() {
 		if err := run(ctx, &stdout, args); err != nil {
@@ -52,6 +74,35 @@ func Test_main(t *testing.T) {
 		t.Log("Test server started")
 	}
 
+	t.Run("Metrics endpoint serves request metrics", func(t *testing.T) {
+		resp, err := http.Get("http://127.0.0.1:3232/metrics")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body),
+			`gosl_http_requests_total{method="GET",route="/healthz",status="200"}`)
+		require.Contains(t, string(body), "# TYPE gosl_db_transaction_wait_seconds histogram")
+	})
+
+	t.Run("Readiness endpoint reports checks", func(t *testing.T) {
+		resp, err := http.Get("http://127.0.0.1:3232/readyz")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var body struct {
+			Status string
+			Checks map[string]struct{ Status string }
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, "ok", body.Status)
+		require.Equal(t, "ok", body.Checks["database"].Status)
+		require.Equal(t, "ok", body.Checks["maintenance"].Status)
+		// the bot isn't run during tests
+		require.Equal(t, "skipped", body.Checks["discord"].Status)
+	})
+
 	t.Run("SIGUSR1 puts database into global lock", func(t *testing.T) {
 		done := make(chan bool)
 		go func() {
@@ -100,6 +151,43 @@ func Test_main(t *testing.T) {
 			t.Errorf("Not found")
 		}
 	})
+
+	t.Run("Admin API toggles maintenance mode", func(t *testing.T) {
+		adminRequest := func(method string, body string, token string) *http.Response {
+			req, err := http.NewRequest(method, "http://127.0.0.1:3232/admin/maintenance",
+				strings.NewReader(body))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			return resp
+		}
+		resp := adminRequest(http.MethodGet, "", "wrong-token")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		eta := time.Now().Add(time.Hour).Format(time.RFC3339)
+		resp = adminRequest(http.MethodPost,
+			`{"reason":"Testing","eta":"`+eta+`"}`, "test-token")
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.Eventually(t, func() bool {
+			resp, err := http.Get("http://127.0.0.1:3232/")
+			if err != nil {
+				return false
+			}
+			resp.Body.Close()
+			return resp.StatusCode == http.StatusServiceUnavailable
+		}, 2*time.Second, 50*time.Millisecond)
+
+		resp = adminRequest(http.MethodDelete, "", "test-token")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp, err := http.Get("http://127.0.0.1:3232/")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = adminRequest(http.MethodDelete, "", "test-token")
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
 }
 
 func waitForReady(
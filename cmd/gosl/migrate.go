@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"gosl/internal/backup"
+	"gosl/pkg/config"
+	"gosl/pkg/db"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Print the version of the database schema and any pending migrations
+func printMigrationStatus(
+	ctx context.Context,
+	w io.Writer,
+	conn *db.SafeConn,
+	config *config.Config,
+) error {
+	required, err := strconv.ParseInt(config.DBVersion, 10, 0)
+	if err != nil {
+		return errors.Wrap(err, "strconv.ParseInt")
+	}
+	current, err := conn.SchemaVersion(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.SchemaVersion")
+	}
+	fmt.Fprintf(w, "Database version: %05d\n", current)
+	fmt.Fprintf(w, "Required version: %05d\n", required)
+	if current > required {
+		fmt.Fprintln(w, "Database is newer than required, downgrade it with the migrate tool")
+		return nil
+	}
+	pending, err := conn.PendingMigrations(ctx, required)
+	if err != nil {
+		return errors.Wrap(err, "conn.PendingMigrations")
+	}
+	if len(pending) == 0 {
+		fmt.Fprintln(w, "No pending migrations")
+		return nil
+	}
+	fmt.Fprintln(w, "Pending migrations:")
+	for _, migration := range pending {
+		fmt.Fprintf(w, "  %s\n", migration.Name)
+	}
+	return nil
+}
+
+// Check the database schema is at the required version. If migrate is true
+// any pending migrations are applied after taking a backup. Refuses to
+// continue if the database is newer than the required version
+func checkSchema(
+	ctx context.Context,
+	conn *db.SafeConn,
+	config *config.Config,
+	logger *zerolog.Logger,
+	migrate bool,
+) error {
+	required, err := strconv.ParseInt(config.DBVersion, 10, 0)
+	if err != nil {
+		return errors.Wrap(err, "strconv.ParseInt")
+	}
+	current, err := conn.SchemaVersion(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.SchemaVersion")
+	}
+	if current == required {
+		return nil
+	}
+	if current > required {
+		return errors.New(fmt.Sprintf(
+			"Database is at version %05d which is newer than the required version %05d. Refusing to downgrade",
+			current, required))
+	}
+	if !migrate {
+		return errors.New(fmt.Sprintf(
+			"Database is at version %05d but version %05d is required. Run with -migrate to apply pending migrations",
+			current, required))
+	}
+	backupPath := ""
+	if current > 0 {
+		backupPath, err = backup.PreMigrationPath(config, current)
+		if err != nil {
+			return errors.Wrap(err, "backup.PreMigrationPath")
+		}
+	}
+	logger.Info().Int64("from", current).Int64("to", required).
+		Msg("Migrating database")
+	applied, err := conn.Migrate(ctx, required, backupPath, config.DBLockTimeout*time.Second)
+	if err != nil {
+		return errors.Wrap(err, "conn.Migrate")
+	}
+	logger.Info().Int("applied", len(applied)).Msg("Database migrated")
+	_, err = backup.Prune(config)
+	if err != nil {
+		return errors.Wrap(err, "backup.Prune")
+	}
+	return nil
+}
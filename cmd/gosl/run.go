@@ -10,9 +10,12 @@ import (
 	"sync"
 	"time"
 
+	"gosl/internal/backup"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/startup"
+	"gosl/internal/health"
 	"gosl/internal/httpserver"
+	"gosl/internal/maintenance"
 	"gosl/pkg/config"
 	"gosl/pkg/embedfs"
 	"gosl/pkg/logging"
@@ -34,7 +37,7 @@ func run(ctx context.Context, w io.Writer, args map[string]string) error {
 
 	// Return the version of the database required
 	if args["dbver"] == "true" {
-		fmt.Fprintf(w, "Database version: %s\n", config.DBName)
+		fmt.Fprintf(w, "Database version: %s\n", config.DBVersion)
 		return nil
 	}
 
@@ -65,6 +68,18 @@ func run(ctx context.Context, w io.Writer, args map[string]string) error {
 		return errors.Wrap(err, "logging.GetLogger")
 	}
 
+	// Restore the database from a backup. Done before connecting so the
+	// database file isn't open while it's swapped out
+	if args["restore"] != "" {
+		movedTo, err := backup.Restore(config, args["restore"])
+		if err != nil {
+			return errors.Wrap(err, "backup.Restore")
+		}
+		logger.Info().Str("backup", args["restore"]).Str("previous", movedTo).
+			Msg("Database restored from backup")
+		return nil
+	}
+
 	// Setup the database connection
 	logger.Debug().Msg("Config loaded and logger started")
 	logger.Debug().Msg("Connecting to database")
@@ -74,6 +89,31 @@ func run(ctx context.Context, w io.Writer, args map[string]string) error {
 	}
 	defer conn.Close()
 
+	// Show the database version and any pending migrations
+	if args["migratestatus"] == "true" {
+		err = printMigrationStatus(ctx, w, conn, config)
+		if err != nil {
+			return errors.Wrap(err, "printMigrationStatus")
+		}
+		return nil
+	}
+
+	// Make sure the database is at the required version before using it
+	err = checkSchema(ctx, conn, config, logger, args["migrate"] == "true")
+	if err != nil {
+		return errors.Wrap(err, "checkSchema")
+	}
+
+	// Take a backup of the database and exit
+	if args["backup"] == "true" {
+		snapshot, err := backup.Create(ctx, conn, config, logger)
+		if err != nil {
+			return errors.Wrap(err, "backup.Create")
+		}
+		fmt.Fprintf(w, "Backup created: %s\n", snapshot.Path)
+		return nil
+	}
+
 	// Setup embedded files
 	logger.Debug().Msg("Getting embedded files")
 	staticFS, err := embedfs.GetEmbeddedFS()
@@ -81,8 +121,13 @@ func run(ctx context.Context, w io.Writer, args map[string]string) error {
 		return errors.Wrap(err, "embedfs.GetEmbeddedFS")
 	}
 
+	// Shared by the discord bot and the readiness endpoint
+	status := health.NewStatus(args["nobot"] == "false")
+	// Controls maintenance mode from signals, the bot and the admin API
+	mode := maintenance.New(&maint, conn, config, logger)
+
 	logger.Debug().Msg("Setting up HTTP server")
-	httpServer := httpserver.NewServer(config, logger, conn, &staticFS, &maint)
+	httpServer := httpserver.NewServer(config, logger, conn, &staticFS, mode, status)
 
 	// Runs function for testing in dev if --tester flag true
 	if args["tester"] == "true" {
@@ -92,7 +137,10 @@ func run(ctx context.Context, w io.Writer, args map[string]string) error {
 	}
 
 	// Setups a channel to listen for os.Signal
-	handleMaintSignals(ctx, conn, config, logger, &maint)
+	handleMaintSignals(ctx, logger, mode)
+
+	// Start taking scheduled backups
+	backup.Schedule(ctx, conn, config, logger)
 
 	// Initialize the discord bot
 	discordBot, err := bot.NewBot(
@@ -100,6 +148,8 @@ func run(ctx context.Context, w io.Writer, args map[string]string) error {
 		&staticFS,
 		conn,
 		config,
+		status,
+		mode,
 	)
 	if err != nil {
 		return errors.Wrap(err, "bot.NewBot")
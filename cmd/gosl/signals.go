@@ -4,12 +4,9 @@ import (
 	"context"
 	"os"
 	"os/signal"
-	"sync/atomic"
 	"syscall"
-	"time"
 
-	"gosl/pkg/config"
-	"gosl/pkg/db"
+	"gosl/internal/maintenance"
 
 	"github.com/rs/zerolog"
 )
@@ -17,10 +14,8 @@ import (
 // Handle SIGUSR1 and SIGUSR2 syscalls to toggle maintenance mode
 func handleMaintSignals(
 	ctx context.Context,
-	conn *db.SafeConn,
-	config *config.Config,
 	logger *zerolog.Logger,
-	maint *uint32,
+	mode *maintenance.Mode,
 ) {
 	logger.Debug().Msg("Starting signal listener")
 	ch := make(chan os.Signal, 1)
@@ -36,18 +31,22 @@ func handleMaintSignals(
 			case sig := <-ch:
 				switch sig {
 				case syscall.SIGUSR1:
-					if atomic.LoadUint32(maint) != 1 {
-						atomic.StoreUint32(maint, 1)
-						logger.Info().Msg("Signal received: Starting maintenance")
-						logger.Info().Msg("Attempting to acquire database lock")
-						conn.Pause(config.DBLockTimeout * time.Second)
+					if mode.Active() {
+						continue
+					}
+					logger.Info().Msg("Signal received: Starting maintenance")
+					err := mode.Start("", nil, "signal")
+					if err != nil {
+						logger.Error().Err(err).Msg("Failed to start maintenance")
 					}
 				case syscall.SIGUSR2:
-					if atomic.LoadUint32(maint) != 0 {
-						logger.Info().Msg("Signal received: Maintenance over")
-						logger.Info().Msg("Releasing database lock")
-						conn.Resume()
-						atomic.StoreUint32(maint, 0)
+					if !mode.Active() {
+						continue
+					}
+					logger.Info().Msg("Signal received: Maintenance over")
+					err := mode.End("signal")
+					if err != nil {
+						logger.Error().Err(err).Msg("Failed to end maintenance")
 					}
 				}
 			}
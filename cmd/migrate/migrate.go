@@ -3,21 +3,16 @@ package main
 import (
 	"context"
 	"database/sql"
-	"embed"
 	"fmt"
-	"io/fs"
 	"log"
 	"os"
 	"strconv"
 
-	"github.com/pressly/goose/v3"
+	"gosl/pkg/migrations"
 
 	_ "modernc.org/sqlite"
 )
 
-//go:embed migrations
-var migrationsFS embed.FS
-
 func main() {
 	if len(os.Args) != 4 {
 		fmt.Println("Usage: migrate <file_path> up-to|down-to <version>")
@@ -41,11 +36,7 @@ func main() {
 	}
 	defer db.Close()
 
-	migrations, err := fs.Sub(migrationsFS, "migrations")
-	if err != nil {
-		log.Fatalf("Failed to get migrations from embedded filesystem")
-	}
-	provider, err := goose.NewProvider(goose.DialectSQLite3, db, migrations)
+	provider, err := migrations.NewProvider(db)
 	if err != nil {
 		log.Fatalf("Failed to create migration provider: %v", err)
 	}
@@ -0,0 +1,264 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gosl/pkg/config"
+	"gosl/pkg/db"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Format of the timestamp in the snapshot filenames. Sorts chronologically
+const timeFormat = "20060102T150405Z"
+
+// Used to move the database files during a restore. Replaced in tests to make
+// the moves fail
+var rename = os.Rename
+
+// A snapshot of the database in the backup directory
+type Snapshot struct {
+	Path    string    // path to the snapshot file
+	Version string    // version of the database the snapshot was taken from
+	Taken   time.Time // time the snapshot was taken
+}
+
+// Take a snapshot of the database and store it in the backup directory, then
+// remove any old snapshots outside the retention limit.
+// Returns the snapshot that was taken
+func Create(
+	ctx context.Context,
+	conn *db.SafeConn,
+	cfg *config.Config,
+	logger *zerolog.Logger,
+) (*Snapshot, error) {
+	taken := time.Now().UTC()
+	path, err := snapshotPath(cfg, cfg.DBVersion, taken)
+	if err != nil {
+		return nil, errors.Wrap(err, "snapshotPath")
+	}
+	snapshot := &Snapshot{Path: path, Version: cfg.DBVersion, Taken: taken}
+	err = conn.Backup(ctx, snapshot.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.Backup")
+	}
+	logger.Info().Str("path", snapshot.Path).Msg("Database backup created")
+	removed, err := Prune(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "Prune")
+	}
+	for _, old := range removed {
+		logger.Info().Str("path", old.Path).Msg("Old database backup removed")
+	}
+	return snapshot, nil
+}
+
+// Get the path to write a snapshot of the database at the version to before
+// it is migrated
+func PreMigrationPath(cfg *config.Config, version int64) (string, error) {
+	path, err := snapshotPath(cfg, fmt.Sprintf("%05d", version), time.Now().UTC())
+	if err != nil {
+		return "", errors.Wrap(err, "snapshotPath")
+	}
+	return path, nil
+}
+
+// Get the path of a snapshot taken at the time, creating the backup directory
+// if it doesn't exist
+func snapshotPath(cfg *config.Config, version string, taken time.Time) (string, error) {
+	err := os.MkdirAll(cfg.BackupDir, 0755)
+	if err != nil {
+		return "", errors.Wrap(err, "os.MkdirAll")
+	}
+	return filepath.Join(cfg.BackupDir,
+		fmt.Sprintf("%s-%s.db", version, taken.Format(timeFormat))), nil
+}
+
+// Get the snapshots in the backup directory, newest first
+func List(cfg *config.Config) ([]Snapshot, error) {
+	files, err := filepath.Glob(filepath.Join(cfg.BackupDir, "*-*.db"))
+	if err != nil {
+		return nil, errors.Wrap(err, "filepath.Glob")
+	}
+	snapshots := []Snapshot{}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".db")
+		version, timestamp, found := strings.Cut(name, "-")
+		if !found {
+			continue
+		}
+		if _, err := strconv.Atoi(version); err != nil {
+			continue
+		}
+		taken, err := time.Parse(timeFormat, timestamp)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{Path: file, Version: version, Taken: taken})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Taken.After(snapshots[j].Taken)
+	})
+	return snapshots, nil
+}
+
+// Remove the oldest snapshots so only the number set by the retention limit
+// are kept. Returns the snapshots that were removed
+func Prune(cfg *config.Config) ([]Snapshot, error) {
+	snapshots, err := List(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "List")
+	}
+	if cfg.BackupRetention <= 0 || len(snapshots) <= cfg.BackupRetention {
+		return []Snapshot{}, nil
+	}
+	removed := snapshots[cfg.BackupRetention:]
+	for _, snapshot := range removed {
+		err = os.Remove(snapshot.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "os.Remove")
+		}
+	}
+	return removed, nil
+}
+
+// Take a snapshot on the interval set in the config until the context is
+// cancelled. Does nothing if the interval is 0
+func Schedule(
+	ctx context.Context,
+	conn *db.SafeConn,
+	cfg *config.Config,
+	logger *zerolog.Logger,
+) {
+	if cfg.BackupInterval <= 0 {
+		logger.Info().Msg("Scheduled database backups disabled")
+		return
+	}
+	interval := cfg.BackupInterval * time.Hour
+	logger.Info().Dur("interval", interval).Msg("Scheduling database backups")
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				timeout, cancel := context.WithTimeout(ctx, cfg.DBLockTimeout*time.Second)
+				_, err := Create(timeout, conn, cfg, logger)
+				cancel()
+				if err != nil {
+					logger.Error().Err(err).Msg("Scheduled database backup failed")
+				}
+			}
+		}
+	}()
+}
+
+// Replace the database with the snapshot at the path. The snapshot must be
+// the same version as the database. The current database is kept alongside
+// it with the suffix ".pre-restore-<time>" rather than being deleted.
+// The snapshot is copied next to the database before anything is moved, and
+// the current database is put back if the swap fails, so a failed restore
+// leaves the database as it was.
+// Must not be run while the database is open.
+// Returns the path the current database was moved to
+func Restore(cfg *config.Config, path string) (string, error) {
+	version, err := strconv.Atoi(cfg.DBVersion)
+	if err != nil {
+		return "", errors.Wrap(err, "strconv.Atoi")
+	}
+	err = db.CheckDBFileVersion(path, version)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf(
+			"Snapshot is not a valid database at version %s", cfg.DBVersion))
+	}
+	dbFile := cfg.DBFile
+	tmpFile := dbFile + ".restoring"
+	// clear out the copy left by a restore that was interrupted
+	err = os.Remove(tmpFile)
+	if err != nil && !os.IsNotExist(err) {
+		return "", errors.Wrap(err, "os.Remove")
+	}
+	err = copyFile(path, tmpFile)
+	if err != nil {
+		os.Remove(tmpFile)
+		return "", errors.Wrap(err, "copyFile")
+	}
+	movedTo := fmt.Sprintf("%s.pre-restore-%s", dbFile, time.Now().UTC().Format(timeFormat))
+	// move the WAL and shared memory files with the database so they don't
+	// get applied to the restored database
+	moved := []string{}
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		err = rename(dbFile+suffix, movedTo+suffix)
+		if err != nil && !os.IsNotExist(err) {
+			return "", putBack(errors.Wrap(err, "os.Rename"), dbFile, movedTo, moved, tmpFile)
+		}
+		if err == nil {
+			moved = append(moved, suffix)
+		}
+	}
+	err = rename(tmpFile, dbFile)
+	if err != nil {
+		return "", putBack(errors.Wrap(err, "os.Rename"), dbFile, movedTo, moved, tmpFile)
+	}
+	return movedTo, nil
+}
+
+// Undo a failed restore by moving the files that were moved out of the way
+// back to the database path and removing the copy of the snapshot.
+// Returns the error that failed the restore, or an error naming the files
+// that could not be moved back so they can be put back by hand
+func putBack(
+	restoreErr error,
+	dbFile string,
+	movedTo string,
+	moved []string,
+	tmpFile string,
+) error {
+	failed := []string{}
+	for _, suffix := range moved {
+		err := rename(movedTo+suffix, dbFile+suffix)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%s)", movedTo+suffix, err))
+		}
+	}
+	os.Remove(tmpFile)
+	if len(failed) > 0 {
+		return errors.Wrap(restoreErr, fmt.Sprintf(
+			"Restore failed and could not move back %s", strings.Join(failed, ", ")))
+	}
+	return restoreErr
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrap(err, "os.Open")
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Wrap(err, "os.OpenFile")
+	}
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return errors.Wrap(err, "io.Copy")
+	}
+	err = out.Sync()
+	if err != nil {
+		out.Close()
+		return errors.Wrap(err, "out.Sync")
+	}
+	return out.Close()
+}
@@ -0,0 +1,183 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gosl/pkg/config"
+	"gosl/pkg/db"
+	"gosl/pkg/tests"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	cfg.BackupDir = t.TempDir()
+	cfg.BackupRetention = 2
+	return cfg
+}
+
+// Take a snapshot of a fresh test database at the current version
+func testSnapshot(t *testing.T, cfg *config.Config) *Snapshot {
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	wconn, rconn, err := tests.SetupTestDB(ver)
+	require.NoError(t, err)
+	conn := db.MakeSafe(wconn, rconn, tests.NilLogger())
+	defer conn.Close()
+	snapshot, err := Create(t.Context(), conn, cfg, tests.NilLogger())
+	require.NoError(t, err)
+	return snapshot
+}
+
+func writeFiles(t *testing.T, files map[string]string) {
+	for name, contents := range files {
+		require.NoError(t, os.WriteFile(name, []byte(contents), 0644))
+	}
+}
+
+func assertFiles(t *testing.T, files map[string]string) {
+	for name, contents := range files {
+		actual, err := os.ReadFile(name)
+		if contents == "" {
+			assert.True(t, os.IsNotExist(err), name+" should not exist")
+			continue
+		}
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, contents, string(actual), name)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cfg := testConfig(t)
+	snapshot := testSnapshot(t, cfg)
+	assert.Equal(t, cfg.BackupDir, filepath.Dir(snapshot.Path))
+	assert.Equal(t, cfg.DBVersion, snapshot.Version)
+	ver, err := strconv.Atoi(cfg.DBVersion)
+	require.NoError(t, err)
+	assert.NoError(t, db.CheckDBFileVersion(snapshot.Path, ver))
+
+	snapshots, err := List(cfg)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, snapshot.Path, snapshots[0].Path)
+	assert.True(t, snapshot.Taken.Truncate(time.Second).Equal(snapshots[0].Taken))
+}
+
+func TestPrune(t *testing.T) {
+	cfg := testConfig(t)
+	taken := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	paths := []string{}
+	for i := range 4 {
+		path, err := snapshotPath(cfg, cfg.DBVersion, taken.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, []byte{}, 0644))
+		paths = append(paths, path)
+	}
+	// files that aren't snapshots are left alone
+	others := []string{
+		filepath.Join(cfg.BackupDir, "notes-20250101T000000Z.db"),
+		filepath.Join(cfg.BackupDir, "00001-yesterday.db"),
+	}
+	for _, path := range others {
+		require.NoError(t, os.WriteFile(path, []byte{}, 0644))
+	}
+
+	removed, err := Prune(cfg)
+	require.NoError(t, err)
+	require.Len(t, removed, 2)
+	assert.Equal(t, paths[1], removed[0].Path)
+	assert.Equal(t, paths[0], removed[1].Path)
+	for i, path := range append(paths, others...) {
+		_, err := os.Stat(path)
+		assert.Equal(t, i < 2, os.IsNotExist(err), path)
+	}
+
+	cfg.BackupRetention = 0
+	removed, err = Prune(cfg)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+	snapshots, err := List(cfg)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+}
+
+func TestRestore(t *testing.T) {
+	cfg := testConfig(t)
+	snapshot := testSnapshot(t, cfg)
+	contents, err := os.ReadFile(snapshot.Path)
+	require.NoError(t, err)
+	dbFile := cfg.DBFile
+
+	t.Run("Restore swaps the snapshot in and keeps the old database", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		writeFiles(t, map[string]string{dbFile: "old", dbFile + "-wal": "old wal"})
+		movedTo, err := Restore(cfg, snapshot.Path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(movedTo, dbFile+".pre-restore-"))
+		assertFiles(t, map[string]string{
+			dbFile:                string(contents),
+			dbFile + "-wal":       "",
+			dbFile + "-shm":       "",
+			dbFile + ".restoring": "",
+			movedTo:               "old",
+			movedTo + "-wal":      "old wal",
+		})
+	})
+
+	t.Run("Restore refuses a snapshot at the wrong version", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		writeFiles(t, map[string]string{dbFile: "old", "bad.db": "not a database"})
+		_, err := Restore(cfg, "bad.db")
+		assert.ErrorContains(t, err, "Snapshot is not a valid database")
+		assertFiles(t, map[string]string{dbFile: "old", dbFile + ".restoring": ""})
+	})
+
+	t.Run("A failed swap puts the old database back", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		writeFiles(t, map[string]string{dbFile: "old", dbFile + "-wal": "old wal"})
+		rename = func(from, to string) error {
+			if from == dbFile+".restoring" {
+				return errors.New("disk full")
+			}
+			return os.Rename(from, to)
+		}
+		t.Cleanup(func() { rename = os.Rename })
+		_, err := Restore(cfg, snapshot.Path)
+		assert.EqualError(t, err, "os.Rename: disk full")
+		assertFiles(t, map[string]string{
+			dbFile:                "old",
+			dbFile + "-wal":       "old wal",
+			dbFile + ".restoring": "",
+		})
+		leftover, err := filepath.Glob(dbFile + ".pre-restore-*")
+		require.NoError(t, err)
+		assert.Empty(t, leftover)
+	})
+
+	t.Run("A failed put back reports the files left behind", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		writeFiles(t, map[string]string{dbFile: "old", dbFile + "-wal": "old wal"})
+		rename = func(from, to string) error {
+			if from == dbFile+".restoring" || strings.HasSuffix(from, "-wal") && to == dbFile+"-wal" {
+				return errors.New("disk full")
+			}
+			return os.Rename(from, to)
+		}
+		t.Cleanup(func() { rename = os.Rename })
+		_, err := Restore(cfg, snapshot.Path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Restore failed and could not move back "+dbFile+".pre-restore-")
+		assert.True(t, strings.HasSuffix(err.Error(), "-wal (disk full): os.Rename: disk full"))
+		assertFiles(t, map[string]string{dbFile: "old", dbFile + "-wal": ""})
+	})
+}
@@ -0,0 +1,20 @@
+package bot
+
+import (
+	"gosl/internal/models"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Get the user that triggered the interaction as the actor for an audit log
+// entry. Works for interactions in both the guild and direct messages
+func AuditActor(i *discordgo.InteractionCreate) models.AuditActor {
+	user := i.User
+	if i.Member != nil {
+		user = i.Member.User
+	}
+	if user == nil {
+		return models.AuditActor{}
+	}
+	return models.AuditActor{DiscordID: user.ID, Name: user.Username}
+}
@@ -3,9 +3,13 @@ package bot
 import (
 	"context"
 	"fmt"
+	"gosl/internal/health"
+	"gosl/internal/maintenance"
 	"gosl/pkg/config"
 	"gosl/pkg/db"
+	"gosl/pkg/slapshotapi"
 	"io/fs"
+	"net/http"
 	"sync"
 	"time"
 
@@ -16,7 +20,8 @@ import (
 
 // Contains the session objects for a created bot
 type Bot struct {
-	Session         *discordgo.Session
+	Session         Session
+	GuildID         string // the guild served by this bot
 	Logger          *zerolog.Logger
 	Files           *fs.FS
 	Conn            *db.SafeConn
@@ -24,8 +29,14 @@ type Bot struct {
 	Channels        map[uint16]*Channel
 	DirectMessages  map[string]*DirectMessage
 	DynamicMessages map[string]*DynamicMessage
-	pool            *requestPool
+	Health          *health.Status
+	Maintenance     *maintenance.Mode
+	Slapshot        *slapshotapi.Client
+	scheduler       *scheduler
+	messagesLock    sync.Mutex // guards DirectMessages and DynamicMessages
 	statusMsg       string
+	guilds          *guildGroup
+	languages       *languageCache
 }
 
 // Function for setting up a bot package
@@ -42,13 +53,47 @@ func NewBot(
 	f *fs.FS,
 	c *db.SafeConn,
 	cfg *config.Config,
+	status *health.Status,
+	maint *maintenance.Mode,
 ) (*Bot, error) {
 	session, err := discordgo.New("Bot " + cfg.DiscordBotToken)
 	if err != nil {
 		return nil, errors.Wrap(err, "discordgo.New")
 	}
+	bot, err := NewBotWithSession(&liveSession{session}, l, f, c, cfg, status, maint)
+	if err != nil {
+		return nil, err
+	}
+	transport := session.Client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	session.Client.Transport = &rateLimitTransport{next: transport, scheduler: bot.scheduler}
+	return bot, nil
+}
+
+// Create a new bot using the provided session, such as the fake session from
+// discordtest
+func NewBotWithSession(
+	session Session,
+	l *zerolog.Logger,
+	f *fs.FS,
+	c *db.SafeConn,
+	cfg *config.Config,
+	status *health.Status,
+	maint *maintenance.Mode,
+) (*Bot, error) {
+	var err error
+	slapshotURL := cfg.SlapshotAPIURL
+	if slapshotURL == "" {
+		slapshotURL, err = slapshotapi.EnvURL(cfg.SlapshotAPIEnv)
+		if err != nil {
+			return nil, errors.Wrap(err, "slapshotapi.EnvURL")
+		}
+	}
 	bot := &Bot{
 		Session:         session,
+		GuildID:         cfg.DiscordGuildID,
 		Logger:          l,
 		Files:           f,
 		Conn:            c,
@@ -56,8 +101,13 @@ func NewBot(
 		Channels:        make(map[uint16]*Channel),
 		DirectMessages:  make(map[string]*DirectMessage),
 		DynamicMessages: make(map[string]*DynamicMessage),
-		pool:            newRequestPool(),
+		Health:          status,
+		Maintenance:     maint,
+		Slapshot:        slapshotapi.NewClient(slapshotURL, cfg.SlapshotAPIKey, cfg.SlapshotRegions),
+		scheduler:       newScheduler(globalRequestLimit, globalRequestWindow),
+		languages:       newLanguageCache(),
 	}
+	bot.guilds = &guildGroup{bots: []*Bot{bot}}
 	return bot, nil
 }
 
@@ -87,28 +137,56 @@ func (b *Bot) GetMessage(
 	return msg, nil
 }
 
-func (b *Bot) addDirectMessage(dm *DirectMessage) error {
+// Track the direct message, storing it in the transaction provided or in one
+// of its own if it is nil. The lock isn't held while it is stored, as the
+// transaction could be waiting on another handler that is tracking a message
+func (b *Bot) addDirectMessage(tx *db.SafeWTX, dm *DirectMessage) error {
+	b.messagesLock.Lock()
 	_, exists := b.DirectMessages[dm.ID]
 	if exists {
+		b.messagesLock.Unlock()
 		return nil
 	}
 	b.DirectMessages[dm.ID] = dm
-	return nil
+	messagesTracked.Set(float64(len(b.DirectMessages)), "direct")
+	b.messagesLock.Unlock()
+	return b.trackDirectMessage(tx, dm)
 }
 
-func (b *Bot) removeDirectMessage(messageID string) error {
+// Set when the direct message expires after it has been updated
+func (b *Bot) setDirectMessageExpiry(tx *db.SafeWTX, dm *DirectMessage, expiresAt time.Time) error {
+	b.messagesLock.Lock()
+	dm.ExpiresAt = expiresAt
+	_, exists := b.DirectMessages[dm.ID]
+	b.messagesLock.Unlock()
+	if !exists {
+		return nil
+	}
+	return b.trackDirectMessage(tx, dm)
+}
+
+func (b *Bot) removeDirectMessage(tx *db.SafeWTX, messageID string) error {
+	b.messagesLock.Lock()
 	_, exists := b.DirectMessages[messageID]
 	if !exists {
+		b.messagesLock.Unlock()
 		return nil
 	}
 	delete(b.DirectMessages, messageID)
-	return nil
+	messagesTracked.Set(float64(len(b.DirectMessages)), "direct")
+	b.messagesLock.Unlock()
+	return b.untrackMessage(tx, messageID)
 }
 
-func (b *Bot) SendDirectMessage(title, message string, userID string) error {
-	channel, err := b.Session.UserChannelCreate(userID)
+func (b *Bot) SendDirectMessage(ctx context.Context, title, message string, userID string) error {
+	channel, err := b.userChannel(ctx, userID)
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("session.UserChannelCreate (%s)", userID))
+		return errors.Wrap(err, fmt.Sprintf("bot.userChannel (%s)", userID))
+	}
+	err = b.Schedule(ctx, PriorityInteraction,
+		http.MethodPost, discordgo.EndpointChannelMessages(channel.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
 	}
 	_, err = b.Session.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
 		Embed: &discordgo.MessageEmbed{
@@ -122,22 +200,28 @@ func (b *Bot) SendDirectMessage(title, message string, userID string) error {
 	return nil
 }
 
+// Get the tracked direct message, tracking it again in the transaction
+// provided if it isn't tracked but still exists in discord
 func (b *Bot) GetDirectMessage(
+	ctx context.Context,
+	tx *db.SafeWTX,
 	messageID string,
 	userID string,
 	label string,
 	expiry time.Duration,
 	deleteAfter bool,
 ) (*DirectMessage, error) {
+	b.messagesLock.Lock()
 	msg, exists := b.DirectMessages[messageID]
+	b.messagesLock.Unlock()
 	if !exists {
-		channel, err := b.Session.UserChannelCreate(userID)
+		channel, err := b.userChannel(ctx, userID)
 		if err != nil {
-			return nil, errors.Wrap(err, fmt.Sprintf("session.UserChannelCreate (%s, %s)", label, userID))
+			return nil, errors.Wrap(err, fmt.Sprintf("bot.userChannel (%s, %s)", label, userID))
 		}
-		exists = checkMessageExists(messageID, channel.ID, b)
+		exists = checkMessageExists(ctx, PriorityInteraction, messageID, channel.ID, b)
 		if exists {
-			msg, err = reAddDirectMessage(label, messageID, userID, channel, expiry, deleteAfter, b)
+			msg, err = reAddDirectMessage(tx, label, messageID, userID, channel, expiry, deleteAfter, b)
 			if err != nil {
 				return nil, errors.Wrap(err, "reAddDirectMessage")
 			}
@@ -148,31 +232,51 @@ func (b *Bot) GetDirectMessage(
 	return msg, nil
 }
 
-func (b *Bot) addDynamicMessage(dy *DynamicMessage) error {
+// Track the dynamic message, storing it in the transaction provided or in
+// one of its own if it is nil
+func (b *Bot) addDynamicMessage(tx *db.SafeWTX, dy *DynamicMessage) error {
+	b.messagesLock.Lock()
 	_, exists := b.DynamicMessages[dy.ID]
 	if exists {
+		b.messagesLock.Unlock()
 		return nil
 	}
 	b.DynamicMessages[dy.ID] = dy
-	return nil
+	messagesTracked.Set(float64(len(b.DynamicMessages)), "dynamic")
+	b.messagesLock.Unlock()
+	return b.trackDynamicMessage(tx, dy)
 }
 
-func (b *Bot) removeDynamicMessage(messageID string) error {
+func (b *Bot) removeDynamicMessage(tx *db.SafeWTX, messageID string) error {
+	b.messagesLock.Lock()
 	_, exists := b.DynamicMessages[messageID]
 	if !exists {
+		b.messagesLock.Unlock()
 		return nil
 	}
 	delete(b.DynamicMessages, messageID)
-	return nil
+	messagesTracked.Set(float64(len(b.DynamicMessages)), "dynamic")
+	b.messagesLock.Unlock()
+	return b.untrackMessage(tx, messageID)
 }
 
-func (b *Bot) GetDynamicMessage(label, messageID, channelID string,
+// Get the tracked dynamic message, tracking it again in the transaction
+// provided if it isn't tracked but still exists in discord
+func (b *Bot) GetDynamicMessage(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	label, messageID, channelID string,
 ) (*DynamicMessage, error) {
+	b.messagesLock.Lock()
 	msg, exists := b.DynamicMessages[messageID]
+	b.messagesLock.Unlock()
 	if !exists {
-		exists = checkMessageExists(messageID, channelID, b)
+		exists = checkMessageExists(ctx, PriorityInteraction, messageID, channelID, b)
 		if exists {
-			msg = reAddDynamicMessage(label, messageID, channelID, b)
+			msg, err := reAddDynamicMessage(tx, label, messageID, channelID, b)
+			if err != nil {
+				return nil, errors.Wrap(err, "reAddDynamicMessage")
+			}
 			return msg, nil
 		}
 		return nil, errors.New("Message not found")
@@ -0,0 +1,317 @@
+package bot_test
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/discordtest"
+	"gosl/internal/health"
+	"gosl/internal/maintenance"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/embedfs"
+	"gosl/pkg/i18n"
+	"gosl/pkg/tests"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ bot.Session = (*discordtest.Session)(nil)
+
+const testPurpose uint16 = 900
+
+// A fake discord guild and test database for bots to share
+type testEnv struct {
+	session *discordtest.Session
+	newBot  func() *bot.Bot
+	// Set to 1 to put the bots into maintenance mode
+	maint *uint32
+}
+
+func setupTestEnv(t *testing.T) *testEnv {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	logger := tests.NilLogger()
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	wconn, rconn, err := tests.SetupTestDB(ver)
+	require.NoError(t, err)
+	conn := db.MakeSafe(wconn, rconn, logger)
+	t.Cleanup(func() { conn.Close() })
+	files, err := embedfs.GetEmbeddedFS()
+	require.NoError(t, err)
+
+	env := &testEnv{session: discordtest.NewSession(cfg.DiscordGuildID), maint: new(uint32)}
+	mode := maintenance.New(env.maint, conn, cfg, logger)
+	env.newBot = func() *bot.Bot {
+		b, err := bot.NewBotWithSession(
+			env.session, logger, &files, conn, cfg, health.NewStatus(true), mode)
+		require.NoError(t, err)
+		return b
+	}
+	return env
+}
+
+func TestBot(t *testing.T) {
+	env := setupTestEnv(t)
+	session, newBot, maint := env.session, env.newBot, env.maint
+	session.AddMember("300", "player")
+	// Sets up a channel with a single message with a button, returning the
+	// channel once the message has been sent or updated
+	setupChannel := func(b *bot.Bot, forceCreate bool, handler bot.Handler) *bot.Channel {
+		channel := &bot.Channel{
+			Purpose: testPurpose,
+			Name:    "test-channel",
+			Label:   "Test channel",
+			Handler: handler,
+		}
+		require.NoError(t, b.AddChannel(channel))
+		require.NoError(t, channel.Setup(t.Context(), forceCreate))
+		require.NoError(t, channel.RegisterMessage(&bot.Message{
+			Label:   "Test message",
+			Purpose: testPurpose,
+			GetContents: func(ctx context.Context, b *bot.Bot) (*bot.MessageContents, error) {
+				return &bot.MessageContents{
+					Embed: &discordgo.MessageEmbed{Title: "Test"},
+					Components: []discordgo.MessageComponent{discordgo.ActionsRow{
+						Components: []discordgo.MessageComponent{discordgo.Button{
+							Label: "Press", CustomID: "test_button",
+						}},
+					}},
+				}, nil
+			},
+		}))
+		var wg sync.WaitGroup
+		errch := make(chan error)
+		wg.Add(1)
+		go channel.SetupMessages(t.Context(), &wg, errch)
+		go func() {
+			wg.Wait()
+			close(errch)
+		}()
+		for err := range errch {
+			require.NoError(t, err)
+		}
+		return channel
+	}
+
+	var channelID, messageID string
+	t.Run("Channel setup creates the channel and sends its messages", func(t *testing.T) {
+		b := newBot()
+		channel := setupChannel(b, true, func(i *discordgo.InteractionCreate) {
+			if b.UnderMaintenance(i) {
+				return
+			}
+			require.NoError(t, b.Acknowledge(i, nil))
+			require.NoError(t, b.FollowUp("Pressed", i))
+		})
+		created := session.ChannelByName("test-channel")
+		require.NotNil(t, created)
+		assert.Equal(t, created.ID, channel.ID)
+		messages := session.Messages(channel.ID)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "Test", messages[0].Embeds[0].Title)
+		channelID, messageID = channel.ID, messages[0].ID
+	})
+
+	t.Run("Channel setup reuses the existing channel and message", func(t *testing.T) {
+		b := newBot()
+		channel := setupChannel(b, true, func(i *discordgo.InteractionCreate) {})
+		assert.Equal(t, channelID, channel.ID)
+		messages := session.Messages(channelID)
+		require.Len(t, messages, 1)
+		assert.Equal(t, messageID, messages[0].ID)
+	})
+
+	t.Run("Interactions are dispatched to the channel handler", func(t *testing.T) {
+		i := session.ComponentInteraction(channelID, messageID, "300", "test_button")
+		session.Interact(i)
+		resp := session.Response(i)
+		require.NotNil(t, resp)
+		assert.Equal(t, discordgo.InteractionResponseDeferredChannelMessageWithSource, resp.Type)
+		followups := session.Followups(i)
+		require.Len(t, followups, 1)
+		assert.Equal(t, "Pressed", followups[0].Content)
+	})
+
+	t.Run("Interactions are turned away during maintenance", func(t *testing.T) {
+		atomic.StoreUint32(maint, 1)
+		defer atomic.StoreUint32(maint, 0)
+		i := session.ComponentInteraction(channelID, messageID, "300", "test_button")
+		session.Interact(i)
+		resp := session.Response(i)
+		require.NotNil(t, resp)
+		assert.Equal(t, discordgo.InteractionResponseChannelMessageWithSource, resp.Type)
+		assert.Equal(t, "League system under maintenance", resp.Data.Embeds[0].Title)
+		assert.Empty(t, session.Followups(i))
+	})
+
+	t.Run("Direct messages are sent to the user", func(t *testing.T) {
+		b := newBot()
+		require.NoError(t, b.SendDirectMessage(t.Context(), "Hello", "Welcome to the league", "300"))
+		messages := session.DirectMessages("300")
+		require.Len(t, messages, 1)
+		assert.Equal(t, "Welcome to the league", messages[0].Embeds[0].Description)
+	})
+
+	t.Run("Each guild has its own bot and configuration", func(t *testing.T) {
+		b := newBot()
+		other := b.ForGuild("other-guild")
+		assert.Same(t, other, b.ForGuild("other-guild"))
+		assert.Same(t, other, b.Guild("other-guild"))
+		assert.Equal(t, []*bot.Bot{b, other}, b.Guilds())
+		assert.True(t, b.IsPrimary())
+		assert.False(t, other.IsPrimary())
+		assert.Empty(t, other.Channels)
+
+		tx, err := b.Conn.RBegin(t.Context(), "TestBot")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		channels, err := models.GetChannels(t.Context(), tx, b.GuildID, testPurpose)
+		require.NoError(t, err)
+		assert.Equal(t, []string{channelID}, channels)
+		channels, err = models.GetChannels(t.Context(), tx, other.GuildID, testPurpose)
+		require.NoError(t, err)
+		assert.Empty(t, channels)
+	})
+
+	t.Run("Replies use the user's language, then their locale, then the guild's", func(t *testing.T) {
+		b := newBot()
+		other := b.ForGuild("other-guild")
+		i := session.ComponentInteraction(channelID, messageID, "300", "test_button")
+		assert.Equal(t, i18n.Default, b.Lang(i))
+		i.Locale = discordgo.German
+		assert.Equal(t, "de", b.Lang(i))
+		i.Locale = discordgo.Japanese
+
+		tx, err := b.Conn.Begin(t.Context(), "TestBot")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		require.NoError(t, b.SetGuildLanguage(t.Context(), tx, "fr"))
+		assert.Equal(t, "fr", b.Lang(i))
+		assert.Equal(t, i18n.Default, other.Lang(i))
+		assert.Equal(t, "fr", b.UserLang("300"))
+		require.NoError(t, b.SetUserLanguage(t.Context(), tx, "300", "es"))
+		assert.Equal(t, "es", b.Lang(i))
+		assert.Equal(t, "es", other.Lang(i))
+		assert.Equal(t, "es", other.UserLang("300"))
+		assert.Equal(t, "Prohibido", other.T(i, "error.forbidden.title"))
+		assert.Equal(t, "fr", b.Lang(nil))
+	})
+}
+
+func TestMessageSweeper(t *testing.T) {
+	env := setupTestEnv(t)
+	session := env.session
+	session.AddMember("300", "player")
+	channel, err := session.GuildChannelCreate(session.GuildID, "dynamic", discordgo.ChannelTypeGuildText)
+	require.NoError(t, err)
+	contents := func() *bot.MessageContents {
+		return &bot.MessageContents{
+			Embed: &discordgo.MessageEmbed{Title: "Panel"},
+			Components: []discordgo.MessageComponent{discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{discordgo.Button{
+					Label: "Press", CustomID: "panel_button",
+				}},
+			}},
+		}
+	}
+	trackedMessages := func() map[string]models.TrackedMessage {
+		b := env.newBot()
+		tx, err := b.Conn.RBegin(t.Context(), "TestMessageSweeper")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		msgs, err := models.GetTrackedMessages(t.Context(), tx, b.GuildID)
+		require.NoError(t, err)
+		tracked := make(map[string]models.TrackedMessage)
+		for _, msg := range *msgs {
+			tracked[msg.MessageID] = msg
+		}
+		return tracked
+	}
+
+	// Send the messages, then shut the bot down before they expire. The
+	// messages are saved as soon as they are sent
+	ctx, cancel := context.WithCancel(t.Context())
+	b := env.newBot()
+	b.StartMessageSweeper(ctx)
+	locked := bot.NewDirectMessage("Locked", "300", time.Second, false, b)
+	require.NoError(t, locked.Send(ctx, nil, contents()))
+	deleted := bot.NewDirectMessage("Deleted", "300", time.Second, true, b)
+	require.NoError(t, deleted.Send(ctx, nil, contents()))
+	kept := bot.NewDirectMessage("Kept", "300", 0, false, b)
+	require.NoError(t, kept.Send(ctx, nil, contents()))
+	// sent from a handler holding the write transaction
+	tx, err := b.Conn.Begin(t.Context(), "TestMessageSweeper send")
+	require.NoError(t, err)
+	dynamic := bot.NewDynamicMessage("Dynamic", channel.ID, b)
+	require.NoError(t, dynamic.Send(ctx, tx, contents()))
+	require.NoError(t, tx.Commit())
+	cancel()
+
+	tracked := trackedMessages()
+	require.Len(t, tracked, 4)
+	assert.Equal(t, models.TrackedDirect, tracked[locked.ID].Kind)
+	assert.Equal(t, "300", tracked[locked.ID].UserID)
+	assert.Equal(t, time.Second, tracked[locked.ID].Expiry)
+	assert.NotNil(t, tracked[locked.ID].ExpiresAt)
+	assert.True(t, tracked[deleted.ID].DeleteAfterExpiry)
+	assert.Nil(t, tracked[kept.ID].ExpiresAt)
+	assert.Equal(t, models.TrackedDynamic, tracked[dynamic.ID].Kind)
+	assert.Equal(t, dynamic.ChannelID, tracked[dynamic.ID].ChannelID)
+
+	t.Run("Messages that expired while offline are expired on startup", func(t *testing.T) {
+		time.Sleep(time.Until(locked.ExpiresAt))
+		b := env.newBot()
+		b.StartMessageSweeper(t.Context())
+		require.Eventually(t, func() bool { return len(trackedMessages()) == 2 },
+			5*time.Second, 10*time.Millisecond)
+		tracked := trackedMessages()
+		assert.Contains(t, tracked, kept.ID)
+		assert.Contains(t, tracked, dynamic.ID)
+
+		messages := map[string]*discordgo.Message{}
+		for _, msg := range session.DirectMessages("300") {
+			messages[msg.ID] = msg
+		}
+		assert.NotContains(t, messages, deleted.ID)
+		require.Contains(t, messages, locked.ID)
+		assert.Equal(t, "*Message is locked*", messages[locked.ID].Content)
+		assert.Equal(t, "Panel", messages[locked.ID].Embeds[0].Title)
+		row := messages[locked.ID].Components[0].(discordgo.ActionsRow)
+		assert.True(t, row.Components[0].(discordgo.Button).Disabled)
+		require.Contains(t, messages, kept.ID)
+		assert.Empty(t, messages[kept.ID].Content)
+
+		dm, err := b.GetDirectMessage(t.Context(), nil, kept.ID, "300", "Kept", 0, false)
+		require.NoError(t, err)
+		assert.Equal(t, "Kept", dm.Label)
+	})
+
+	t.Run("Messages that never expire are pruned and tracked again when found", func(t *testing.T) {
+		tx, err := env.newBot().Conn.Begin(t.Context(), "TestMessageSweeper prune")
+		require.NoError(t, err)
+		_, err = tx.Exec(t.Context(), `UPDATE tracked_message SET tracked_at = ?;`,
+			time.Now().AddDate(0, -2, 0).UTC().Format(time.RFC3339))
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+
+		b := env.newBot()
+		b.StartMessageSweeper(t.Context())
+		require.Eventually(t, func() bool { return len(trackedMessages()) == 0 },
+			5*time.Second, 10*time.Millisecond)
+
+		dy, err := b.GetDynamicMessage(t.Context(), nil, "Dynamic", dynamic.ID, dynamic.ChannelID)
+		require.NoError(t, err)
+		assert.Equal(t, dynamic.ID, dy.ID)
+		tracked := trackedMessages()
+		require.Contains(t, tracked, dynamic.ID)
+		assert.WithinDuration(t, time.Now(), tracked[dynamic.ID].TrackedAt, time.Minute)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"gosl/internal/models"
+	"net/http"
 	"sync"
 	"time"
 
@@ -51,7 +52,7 @@ func (m *Message) Setup(ctx context.Context, wg *sync.WaitGroup, errch chan erro
 
 	// find an existing message in the database
 	m.bot.Logger.Debug().Str("msg", m.Label).Msg("Finding existing message")
-	messageID, channelID, err := models.GetMessageForPurpose(ctx, tx, m.Purpose)
+	messageID, channelID, err := models.GetMessageForPurpose(ctx, tx, m.bot.GuildID, m.Purpose)
 	if err != nil {
 		errch <- errors.Wrap(err, "models.GetMessageForPurpose")
 		return
@@ -59,7 +60,7 @@ func (m *Message) Setup(ctx context.Context, wg *sync.WaitGroup, errch chan erro
 
 	// Make sure the channel ID matches the expected channel and the message exists
 	if channelID == m.channel.ID {
-		if checkMessageExists(messageID, channelID, m.bot) {
+		if checkMessageExists(timeout, PriorityNormal, messageID, channelID, m.bot) {
 			m.ID = messageID
 		}
 	}
@@ -146,7 +147,12 @@ func (m *Message) Update(ctx context.Context, errch chan error) {
 	m.bot.Logger.Debug().Str("msg", m.Label).Msg("Updating message")
 
 	// send the api request to edit the message
-	m.bot.pool.queue()
+	err = m.bot.Schedule(ctx, PriorityBulk,
+		http.MethodPatch, discordgo.EndpointChannelMessage(m.channel.ID, m.ID))
+	if err != nil {
+		errch <- errors.Wrap(err, "bot.Schedule")
+		return
+	}
 	starttime := time.Now()
 	_, err = m.bot.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 		ID:         m.ID,
@@ -176,7 +182,12 @@ func (m *Message) SendNew(ctx context.Context, errch chan error) {
 	m.bot.Logger.Debug().Str("msg", m.Label).Msg("Sending message")
 
 	// send the api request to send the message
-	m.bot.pool.queue()
+	err = m.bot.Schedule(ctx, PriorityNormal,
+		http.MethodPost, discordgo.EndpointChannelMessages(m.channel.ID))
+	if err != nil {
+		errch <- errors.Wrap(err, "bot.Schedule")
+		return
+	}
 	starttime := time.Now()
 	message, err := m.bot.Session.ChannelMessageSendComplex(m.channel.ID, &discordgo.MessageSend{
 		Embeds:     []*discordgo.MessageEmbed{contents.Embed},
@@ -198,7 +209,7 @@ func (m *Message) SendNew(ctx context.Context, errch chan error) {
 		return
 	}
 	defer tx.Rollback()
-	err = models.SetMessage(ctx, tx, message.ID, m.channel.ID, m.Purpose)
+	err = models.SetMessage(ctx, tx, m.bot.GuildID, message.ID, m.channel.ID, m.Purpose)
 	if err != nil {
 		errch <- errors.Wrap(err, "models.SetMessage")
 		return
@@ -213,8 +224,11 @@ func (m *Message) SendNew(ctx context.Context, errch chan error) {
 // ===========================================================================
 
 // Check if a message exists with the discord API
-func checkMessageExists(messageID, channelID string, b *Bot) bool {
-	// b.apiQueue.queue()
-	_, err := b.Session.ChannelMessage(channelID, messageID)
+func checkMessageExists(ctx context.Context, p Priority, messageID, channelID string, b *Bot) bool {
+	err := b.Schedule(ctx, p, http.MethodGet, discordgo.EndpointChannelMessage(channelID, messageID))
+	if err != nil {
+		return false
+	}
+	_, err = b.Session.ChannelMessage(channelID, messageID)
 	return err == nil
 }
@@ -6,6 +6,7 @@ import (
 	"gosl/internal/models"
 	"gosl/pkg/db"
 	"io"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -54,13 +55,13 @@ func (c *Channel) Setup(ctx context.Context, forceCreate bool) error {
 
 // Updates the channel ID of the channel and saves it in the database
 func (c *Channel) UpdateTarget(ctx context.Context, tx *db.SafeWTX, newID string) error {
-	err := models.SetChannel(ctx, tx, newID, c.Purpose)
+	err := models.SetChannel(ctx, tx, c.bot.GuildID, newID, c.Purpose)
 	if err != nil {
 		return errors.Wrap(err, "models.SetChannel")
 	}
 	for _, message := range c.Messages {
-		c.DeleteMessage(message)
-		err = models.RemoveMessage(ctx, tx, message.ID, c.ID, message.Purpose)
+		c.DeleteMessage(ctx, message)
+		err = models.RemoveMessage(ctx, tx, c.bot.GuildID, message.ID, c.ID, message.Purpose)
 		if err != nil {
 			return errors.Wrap(err, "models.RemoveMessage")
 		}
@@ -97,9 +98,15 @@ func (c *Channel) RegisterMessage(m *Message) error {
 	if _, exists := c.Messages[m.Purpose]; exists {
 		return errors.New("Message with that purpose already registered")
 	}
-	m.channel = c
-	m.bot = c.bot
-	c.Messages[m.Purpose] = m
+	// The message is copied so the same template can be registered by the
+	// bot for each guild
+	c.Messages[m.Purpose] = &Message{
+		Label:       m.Label,
+		Purpose:     m.Purpose,
+		GetContents: m.GetContents,
+		channel:     c,
+		bot:         c.bot,
+	}
 	return nil
 }
 
@@ -130,15 +137,23 @@ func (c *Channel) SetupMessages(ctx context.Context, swg *sync.WaitGroup, errch
 		}()
 	}
 	wg.Wait()
-	c.bot.Session.AddHandler(c.Handler)
+	c.bot.Health.ChannelSetup(c.Label)
+	if c.Handler != nil {
+		c.bot.AddInteractionHandler(c.Handler)
+	}
 }
 
 // Sends a message to the channel
 func (c *Channel) SendMessage(
+	ctx context.Context,
 	contents MessageContents,
 ) error {
-	c.bot.pool.queue()
-	_, err := c.bot.Session.ChannelMessageSendComplex(c.ID, &discordgo.MessageSend{
+	err := c.bot.Schedule(ctx, PriorityNormal,
+		http.MethodPost, discordgo.EndpointChannelMessages(c.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
+	_, err = c.bot.Session.ChannelMessageSendComplex(c.ID, &discordgo.MessageSend{
 		Embeds:     []*discordgo.MessageEmbed{contents.Embed},
 		Components: contents.Components,
 	})
@@ -149,8 +164,16 @@ func (c *Channel) SendMessage(
 }
 
 // Sends a message with a file to the channel
-func (c *Channel) SendFile(message, filename string, file io.Reader) (*discordgo.Message, error) {
-	c.bot.pool.queue()
+func (c *Channel) SendFile(
+	ctx context.Context,
+	message, filename string,
+	file io.Reader,
+) (*discordgo.Message, error) {
+	err := c.bot.Schedule(ctx, PriorityNormal,
+		http.MethodPost, discordgo.EndpointChannelMessages(c.ID))
+	if err != nil {
+		return nil, errors.Wrap(err, "bot.Schedule")
+	}
 	msg, err := c.bot.Session.ChannelFileSendWithMessage(c.ID, message, filename, file)
 	if err != nil {
 		return nil, errors.Wrap(err, "session.ChannelFileSendWithMessage")
@@ -158,8 +181,15 @@ func (c *Channel) SendFile(message, filename string, file io.Reader) (*discordgo
 	return msg, nil
 }
 
-func (c *Channel) DeleteMessage(m *Message) {
-	err := c.bot.Session.ChannelMessageDelete(c.ID, m.ID)
+func (c *Channel) DeleteMessage(ctx context.Context, m *Message) {
+	err := c.bot.Schedule(ctx, PriorityNormal,
+		http.MethodDelete, discordgo.EndpointChannelMessage(c.ID, m.ID))
+	if err != nil {
+		c.bot.Logger.Warn().Err(err).Str("msg", m.Label).
+			Msg("Failed to delete message in discord")
+		return
+	}
+	err = c.bot.Session.ChannelMessageDelete(c.ID, m.ID)
 	if err != nil {
 		if !strings.Contains(err.Error(), "HTTP 404 Not Found") {
 			c.bot.Logger.Warn().Err(err).Str("msg", m.Label).
@@ -182,14 +212,14 @@ func (c *Channel) findExisting(ctx context.Context) (string, error) {
 	}
 	defer tx.Rollback()
 	c.bot.Logger.Debug().Str("channel", c.Label).Msg("Getting channel ids")
-	channelIDs, err := models.GetChannels(ctx, tx, c.Purpose)
+	channelIDs, err := models.GetChannels(ctx, tx, c.bot.GuildID, c.Purpose)
 	if err != nil {
 		return "", errors.Wrap(err, "models.GetChannels")
 	}
 	var selectedChannelID string
 	deadChannels := []string{}
 	for _, channelID := range channelIDs {
-		if exists := checkChannelExists(channelID, c.bot); exists {
+		if exists := checkChannelExists(timeout, channelID, c.bot); exists {
 			c.bot.Logger.Debug().Str("channel", c.Label).Msg("Channel found")
 			selectedChannelID = channelID
 		} else {
@@ -212,14 +242,18 @@ func (c *Channel) createNew(ctx context.Context) (string, error) {
 	defer tx.Rollback()
 
 	c.bot.Logger.Debug().Str("channel", c.Label).Msg("Creating new channel")
-	c.bot.pool.queue()
+	err = c.bot.Schedule(timeout, PriorityNormal,
+		http.MethodPost, discordgo.EndpointGuildChannels(c.bot.GuildID))
+	if err != nil {
+		return "", errors.Wrap(err, "bot.Schedule")
+	}
 	channel, err := c.bot.Session.GuildChannelCreate(
-		c.bot.Config.DiscordGuildID, c.Name, discordgo.ChannelTypeGuildText)
+		c.bot.GuildID, c.Name, discordgo.ChannelTypeGuildText)
 	if err != nil {
 		return "", errors.Wrap(err, "session.GuildChannelCreate")
 	}
 	c.bot.Logger.Debug().Str("channel", c.Label).Msg("Adding new channel to database")
-	err = models.AddChannel(ctx, tx, channel.ID, c.Purpose)
+	err = models.AddChannel(ctx, tx, c.bot.GuildID, channel.ID, c.Purpose)
 	if err != nil {
 		return "", errors.Wrap(err, "models.AddPurpose")
 	}
@@ -228,12 +262,15 @@ func (c *Channel) createNew(ctx context.Context) (string, error) {
 }
 
 // Check with the discord API if the channel exists
-func checkChannelExists(channelID string, b *Bot) bool {
+func checkChannelExists(ctx context.Context, channelID string, b *Bot) bool {
 	if channelID == "" {
 		return false
 	}
-	// b.apiQueue.queue()
-	_, err := b.Session.Channel(channelID)
+	err := b.Schedule(ctx, PriorityNormal, http.MethodGet, discordgo.EndpointChannel(channelID))
+	if err != nil {
+		return false
+	}
+	_, err = b.Session.Channel(channelID)
 	return err == nil
 }
 
@@ -254,7 +291,7 @@ func cleanupDeadChannels(
 	}
 	for _, channelID := range channelIDs {
 		b.Logger.Debug().Msg("Removing dead channel ID from database")
-		models.RemoveChannel(ctx, tx, channelID, purpose)
+		models.RemoveChannel(ctx, tx, b.GuildID, channelID, purpose)
 	}
 	tx.Commit()
 }
@@ -1,7 +1,11 @@
 package bot
 
 import (
+	"context"
 	"fmt"
+	"gosl/pkg/db"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -37,6 +41,7 @@ func NewDirectMessage(
 }
 
 func reAddDirectMessage(
+	tx *db.SafeWTX,
 	label string,
 	messageID string,
 	userID string,
@@ -54,14 +59,17 @@ func reAddDirectMessage(
 		b:                 b,
 		c:                 channel,
 	}
-	err := b.addDirectMessage(msg)
+	err := b.addDirectMessage(tx, msg)
 	if err != nil {
 		return nil, errors.Wrap(
 			err, fmt.Sprintf("bot.addDirectMessage (%s, %s)", label, userID))
 	}
 	return msg, nil
 }
-func (dm *DirectMessage) Send(contents *MessageContents) error {
+
+// Send the message to the user. It is tracked in the transaction provided,
+// or in one of its own if it is nil
+func (dm *DirectMessage) Send(ctx context.Context, tx *db.SafeWTX, contents *MessageContents) error {
 	msg := ""
 	if dm.Expiry != 0 {
 		action := "lock"
@@ -72,11 +80,16 @@ func (dm *DirectMessage) Send(contents *MessageContents) error {
 		dm.ExpiresAt = expiresAt
 		msg = fmt.Sprintf("*Message will %s %s*", action, DiscordUntil(&expiresAt))
 	}
-	channel, err := dm.b.Session.UserChannelCreate(dm.UserID)
+	channel, err := dm.b.userChannel(ctx, dm.UserID)
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("session.UserChannelCreate (%s, %s)", dm.Label, dm.UserID))
+		return errors.Wrap(err, fmt.Sprintf("bot.userChannel (%s, %s)", dm.Label, dm.UserID))
 	}
 	dm.c = channel
+	err = dm.b.Schedule(ctx, PriorityInteraction,
+		http.MethodPost, discordgo.EndpointChannelMessages(dm.c.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
 	message, err := dm.b.Session.ChannelMessageSendComplex(dm.c.ID, &discordgo.MessageSend{
 		Content:    msg,
 		Embeds:     []*discordgo.MessageEmbed{contents.Embed},
@@ -85,47 +98,35 @@ func (dm *DirectMessage) Send(contents *MessageContents) error {
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageSendComplex (%s, %s)", dm.Label, dm.UserID))
 	}
+	messagesSent.Inc("direct")
 	dm.ID = message.ID
-	err = dm.b.addDirectMessage(dm)
+	err = dm.b.addDirectMessage(tx, dm)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("bot.addDirectMessage (%s, %s)", dm.Label, dm.UserID))
 	}
-	if dm.Expiry != 0 {
-		go dm.waitForExpiry(contents)
-	}
 	return nil
 }
 
-func (dm *DirectMessage) waitForExpiry(contents *MessageContents) {
-	time.Sleep(time.Until(dm.ExpiresAt))
-	if time.Now().Before(dm.ExpiresAt) {
-		go dm.waitForExpiry(contents)
-		return
-	}
-	err := dm.Expire(contents)
-	if err != nil {
-		dm.b.Logger.Warn().Err(err).
-			Str("msg", dm.Label).
-			Str("user", dm.UserID).
-			Msg("Failed to expire direct message")
-	}
-}
-
-func (dm *DirectMessage) Update(contents *MessageContents) error {
+func (dm *DirectMessage) Update(ctx context.Context, tx *db.SafeWTX, contents *MessageContents) error {
 	if dm.ID == "" {
 		return errors.New("DM has not been sent yet. Use DirectMessage.Send() first")
 	}
 	msg := ""
+	var expiresAt time.Time
 	if dm.Expiry != 0 {
 		action := "lock"
 		if dm.deleteAfterExpiry {
 			action = "delete"
 		}
-		expiresAt := time.Now().Add(dm.Expiry)
-		dm.ExpiresAt = expiresAt
+		expiresAt = time.Now().Add(dm.Expiry)
 		msg = fmt.Sprintf("*Message will %s %s*", action, DiscordUntil(&expiresAt))
 	}
-	_, err := dm.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+	err := dm.b.Schedule(ctx, PriorityInteraction,
+		http.MethodPatch, discordgo.EndpointChannelMessage(dm.c.ID, dm.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
+	_, err = dm.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 		ID:         dm.ID,
 		Channel:    dm.c.ID,
 		Content:    &msg,
@@ -135,19 +136,34 @@ func (dm *DirectMessage) Update(contents *MessageContents) error {
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageEditComplex (%s, %s)", dm.Label, dm.UserID))
 	}
+	if dm.Expiry != 0 {
+		err = dm.b.setDirectMessageExpiry(tx, dm, expiresAt)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("bot.setDirectMessageExpiry (%s, %s)", dm.Label, dm.UserID))
+		}
+	}
 	return nil
 }
 
-func (dm *DirectMessage) Expire(contents *MessageContents) error {
+func (dm *DirectMessage) Expire(ctx context.Context, tx *db.SafeWTX, contents *MessageContents) error {
+	method := http.MethodPatch
+	if dm.deleteAfterExpiry {
+		method = http.MethodDelete
+	}
+	err := dm.b.Schedule(ctx, PriorityInteraction,
+		method, discordgo.EndpointChannelMessage(dm.c.ID, dm.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
 	if dm.deleteAfterExpiry {
-		err := dm.b.Session.ChannelMessageDelete(dm.c.ID, dm.ID)
+		err = dm.b.Session.ChannelMessageDelete(dm.c.ID, dm.ID)
 		if err != nil {
 			return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageDelete (%s, %s)", dm.Label, dm.UserID))
 		}
 	} else {
 		msg := "*Message is locked*"
 		disableComponents(&contents.Components)
-		_, err := dm.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		_, err = dm.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 			ID:         dm.ID,
 			Channel:    dm.c.ID,
 			Content:    &msg,
@@ -158,22 +174,98 @@ func (dm *DirectMessage) Expire(contents *MessageContents) error {
 			return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageEditComplex (%s, %s)", dm.Label, dm.UserID))
 		}
 	}
-	err := dm.b.removeDirectMessage(dm.ID)
+	err = dm.b.removeDirectMessage(tx, dm.ID)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("bot.RemoveDirectMessage (%s, %s)", dm.Label, dm.UserID))
+	}
+	return nil
+}
+
+// Expire the message when it is due, without the contents it was sent with.
+// The components of the message as it is in discord are locked instead
+func (dm *DirectMessage) sweep(ctx context.Context) error {
+	err := dm.b.Schedule(ctx, PriorityBulk,
+		http.MethodGet, discordgo.EndpointChannelMessage(dm.c.ID, dm.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
+	message, err := dm.b.Session.ChannelMessage(dm.c.ID, dm.ID)
+	if IsNotFound(err) {
+		// deleted by the user, nothing left to expire
+		return dm.b.removeDirectMessage(nil, dm.ID)
+	}
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("session.ChannelMessage (%s, %s)", dm.Label, dm.UserID))
+	}
+	method := http.MethodPatch
+	if dm.deleteAfterExpiry {
+		method = http.MethodDelete
+	}
+	err = dm.b.Schedule(ctx, PriorityBulk,
+		method, discordgo.EndpointChannelMessage(dm.c.ID, dm.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
+	if dm.deleteAfterExpiry {
+		err = dm.b.Session.ChannelMessageDelete(dm.c.ID, dm.ID)
+		if err != nil && !IsNotFound(err) {
+			return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageDelete (%s, %s)", dm.Label, dm.UserID))
+		}
+	} else {
+		msg := "*Message is locked*"
+		components := slices.Clone(message.Components)
+		disableComponents(&components)
+		_, err = dm.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         dm.ID,
+			Channel:    dm.c.ID,
+			Content:    &msg,
+			Embeds:     &message.Embeds,
+			Components: &components,
+		})
+		if err != nil && !IsNotFound(err) {
+			return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageEditComplex (%s, %s)", dm.Label, dm.UserID))
+		}
+	}
+	err = dm.b.removeDirectMessage(nil, dm.ID)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("bot.RemoveDirectMessage (%s, %s)", dm.Label, dm.UserID))
 	}
 	return nil
 }
 
+// Get the DM channel with the user, creating it if it doesn't exist yet
+func (b *Bot) userChannel(ctx context.Context, userID string) (*discordgo.Channel, error) {
+	err := b.Schedule(ctx, PriorityInteraction,
+		http.MethodPost, discordgo.EndpointUserChannels("@me"))
+	if err != nil {
+		return nil, errors.Wrap(err, "bot.Schedule")
+	}
+	channel, err := b.Session.UserChannelCreate(userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "session.UserChannelCreate")
+	}
+	return channel, nil
+}
+
 func disableComponents(comps *[]discordgo.MessageComponent) {
-	for _, comp := range *comps {
+	for idx, comp := range *comps {
 		switch v := comp.(type) {
 		case *discordgo.ActionsRow:
 			disableComponents(&v.Components)
+		case discordgo.ActionsRow:
+			v.Components = slices.Clone(v.Components)
+			disableComponents(&v.Components)
+			(*comps)[idx] = v
 		case *discordgo.Button:
 			v.Disabled = true
+		case discordgo.Button:
+			v.Disabled = true
+			(*comps)[idx] = v
 		case *discordgo.SelectMenu:
 			v.Disabled = true
+		case discordgo.SelectMenu:
+			v.Disabled = true
+			(*comps)[idx] = v
 		case *discordgo.TextInput:
 		}
 	}
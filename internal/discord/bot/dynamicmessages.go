@@ -1,7 +1,10 @@
 package bot
 
 import (
+	"context"
 	"fmt"
+	"gosl/pkg/db"
+	"net/http"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
@@ -22,19 +25,33 @@ func NewDynamicMessage(label, channelID string, b *Bot) *DynamicMessage {
 	}
 }
 
-func reAddDynamicMessage(label, messageID, channelID string, b *Bot) *DynamicMessage {
+func reAddDynamicMessage(
+	tx *db.SafeWTX,
+	label, messageID, channelID string,
+	b *Bot,
+) (*DynamicMessage, error) {
 	msg := &DynamicMessage{
 		ID:        messageID,
 		Label:     label,
 		ChannelID: channelID,
 		b:         b,
 	}
-	b.addDynamicMessage(msg)
-	return msg
+	err := b.addDynamicMessage(tx, msg)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("bot.addDynamicMessage (%s)", label))
+	}
+	return msg, nil
 }
 
-func (dy *DynamicMessage) Send(contents *MessageContents) error {
+// Send the message to the channel. It is tracked in the transaction
+// provided, or in one of its own if it is nil
+func (dy *DynamicMessage) Send(ctx context.Context, tx *db.SafeWTX, contents *MessageContents) error {
 	msg := ""
+	err := dy.b.Schedule(ctx, PriorityInteraction,
+		http.MethodPost, discordgo.EndpointChannelMessages(dy.ChannelID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
 	message, err := dy.b.Session.ChannelMessageSendComplex(dy.ChannelID, &discordgo.MessageSend{
 		Content:    msg,
 		Embeds:     []*discordgo.MessageEmbed{contents.Embed},
@@ -43,20 +60,26 @@ func (dy *DynamicMessage) Send(contents *MessageContents) error {
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageSendComplex (%s)", dy.Label))
 	}
+	messagesSent.Inc("dynamic")
 	dy.ID = message.ID
-	err = dy.b.addDynamicMessage(dy)
+	err = dy.b.addDynamicMessage(tx, dy)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("bot.AddDynamicMessage (%s)", dy.Label))
 	}
 	return nil
 }
 
-func (dy *DynamicMessage) Update(contents *MessageContents) error {
+func (dy *DynamicMessage) Update(ctx context.Context, contents *MessageContents) error {
 	if dy.ID == "" {
 		return errors.New("Message has not been sent yet. Use Send() first")
 	}
 	msg := ""
-	_, err := dy.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+	err := dy.b.Schedule(ctx, PriorityInteraction,
+		http.MethodPatch, discordgo.EndpointChannelMessage(dy.ChannelID, dy.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
+	_, err = dy.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 		ID:         dy.ID,
 		Channel:    dy.ChannelID,
 		Content:    &msg,
@@ -69,10 +92,15 @@ func (dy *DynamicMessage) Update(contents *MessageContents) error {
 	return nil
 }
 
-func (dy *DynamicMessage) Expire(contents *MessageContents) error {
+func (dy *DynamicMessage) Expire(ctx context.Context, tx *db.SafeWTX, contents *MessageContents) error {
 	msg := "*Message is locked*"
 	disableComponents(&contents.Components)
-	_, err := dy.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+	err := dy.b.Schedule(ctx, PriorityInteraction,
+		http.MethodPatch, discordgo.EndpointChannelMessage(dy.ChannelID, dy.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
+	_, err = dy.b.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 		ID:         dy.ID,
 		Channel:    dy.ChannelID,
 		Content:    &msg,
@@ -82,19 +110,24 @@ func (dy *DynamicMessage) Expire(contents *MessageContents) error {
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageEditComplex (%s)", dy.Label))
 	}
-	err = dy.b.removeDynamicMessage(dy.ID)
+	err = dy.b.removeDynamicMessage(tx, dy.ID)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("bot.RemoveDynamicMessage (%s)", dy.Label))
 	}
 	return nil
 }
 
-func (dy *DynamicMessage) Delete(contents *MessageContents) error {
-	err := dy.b.Session.ChannelMessageDelete(dy.ChannelID, dy.ID)
+func (dy *DynamicMessage) Delete(ctx context.Context, tx *db.SafeWTX, contents *MessageContents) error {
+	err := dy.b.Schedule(ctx, PriorityInteraction,
+		http.MethodDelete, discordgo.EndpointChannelMessage(dy.ChannelID, dy.ID))
+	if err != nil {
+		return errors.Wrap(err, "bot.Schedule")
+	}
+	err = dy.b.Session.ChannelMessageDelete(dy.ChannelID, dy.ID)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("session.ChannelMessageDelete (%s)", dy.Label))
 	}
-	err = dy.b.removeDynamicMessage(dy.ID)
+	err = dy.b.removeDynamicMessage(tx, dy.ID)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("bot.RemoveDynamicMessage (%s)", dy.Label))
 	}
@@ -1,7 +1,7 @@
 package bot
 
 import (
-	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -18,7 +18,7 @@ func (b *Bot) Error(
 	embed := &discordgo.MessageEmbed{
 		Color: 0xff1919,
 		Author: &discordgo.MessageEmbedAuthor{
-			Name:    "Error",
+			Name:    b.T(i, "error.author"),
 			IconURL: "attachment://error.png",
 		},
 		Fields: []*discordgo.MessageEmbedField{
@@ -36,17 +36,17 @@ func (b *Bot) Error(
 	deleteafter := 60 * time.Second
 	deleteat := time.Now().Add(deleteafter)
 	if ack {
-		_, err = b.Session.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("*This message will delete %s*", DiscordUntil(&deleteat)),
+		err = b.followupMessageCreate(i, &discordgo.WebhookParams{
+			Content: b.T(i, "message.deletes", DiscordUntil(&deleteat)),
 			Embeds:  []*discordgo.MessageEmbed{embed},
 			Files:   []*discordgo.File{errIco},
 			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 	} else {
-		err = b.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		err = b.interactionRespond(i, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("*This message will delete %s*", DiscordUntil(&deleteat)),
+				Content: b.T(i, "message.deletes", DiscordUntil(&deleteat)),
 				Embeds:  []*discordgo.MessageEmbed{embed},
 				Files:   []*discordgo.File{errIco},
 				Flags:   discordgo.MessageFlagsEphemeral,
@@ -54,13 +54,7 @@ func (b *Bot) Error(
 		})
 	}
 	// Wait for for the delay before deleting
-	go func() {
-		time.Sleep(deleteafter)
-		err := b.Session.InteractionResponseDelete(i.Interaction)
-		if err != nil {
-			b.Logger.Warn().Err(err).Msg("Failed to delete emphemeral message")
-		}
-	}()
+	go b.deleteResponseAfter(i, deleteafter)
 	return err
 }
 
@@ -70,8 +64,7 @@ func (b *Bot) Forbidden(
 	i *discordgo.InteractionCreate,
 	ack bool,
 ) {
-	msg := "You do not have permission for this action"
-	err := b.Error("Forbidden", msg, i, ack)
+	err := b.Error(b.T(i, "error.forbidden.title"), b.T(i, "error.forbidden.message"), i, ack)
 	if err != nil {
 		b.Logger.Warn().Err(err).Msg("Failed to reply to interaction")
 	}
@@ -81,8 +74,29 @@ func (b *Bot) SlowDown(
 	i *discordgo.InteractionCreate,
 	ack bool,
 ) {
-	err := b.Error("Slow down!", "An update is in progress, please try again", i, ack)
+	err := b.Error(b.T(i, "error.slowdown.title"), b.T(i, "error.slowdown.message"), i, ack)
 	if err != nil {
 		b.Logger.Warn().Err(err).Msg("Failed to reply to interaction")
 	}
 }
+
+// Helper function to send the standard response for an interaction on a
+// message that is out of date or no longer handled
+func (b *Bot) Stale(
+	i *discordgo.InteractionCreate,
+	ack bool,
+) {
+	err := b.Error(b.T(i, "error.stale.title"), b.T(i, "error.stale.message"), i, ack)
+	if err != nil {
+		b.Logger.Warn().Err(err).Msg("Failed to reply to interaction")
+	}
+}
+
+// Check if the error returned from the discord API is a 404
+func IsNotFound(err error) bool {
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil {
+		return restErr.Response.StatusCode == http.StatusNotFound
+	}
+	return false
+}
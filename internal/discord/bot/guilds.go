@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"sync"
+)
+
+// The bots serving each guild of a deployment. They share the discord
+// session, database connection and rate limits, but each has its own
+// channels and messages
+type guildGroup struct {
+	mu   sync.RWMutex
+	bots []*Bot // the primary guild's bot is first
+}
+
+// Get the bot serving the guild, creating it if needed. The new bot shares
+// the session and connections of this bot
+func (b *Bot) ForGuild(guildID string) *Bot {
+	b.guilds.mu.Lock()
+	defer b.guilds.mu.Unlock()
+	for _, peer := range b.guilds.bots {
+		if peer.GuildID == guildID {
+			return peer
+		}
+	}
+	logger := b.Logger.With().Str("guild_id", guildID).Logger()
+	peer := &Bot{
+		Session:         b.Session,
+		GuildID:         guildID,
+		Logger:          &logger,
+		Files:           b.Files,
+		Conn:            b.Conn,
+		Config:          b.Config,
+		Channels:        make(map[uint16]*Channel),
+		DirectMessages:  make(map[string]*DirectMessage),
+		DynamicMessages: make(map[string]*DynamicMessage),
+		Health:          b.Health,
+		Maintenance:     b.Maintenance,
+		Slapshot:        b.Slapshot,
+		scheduler:       b.scheduler,
+		guilds:          b.guilds,
+		languages:       b.languages,
+	}
+	b.guilds.bots = append(b.guilds.bots, peer)
+	return peer
+}
+
+// Get the bot serving the guild, or nil if no bot serves it
+func (b *Bot) Guild(guildID string) *Bot {
+	b.guilds.mu.RLock()
+	defer b.guilds.mu.RUnlock()
+	for _, peer := range b.guilds.bots {
+		if peer.GuildID == guildID {
+			return peer
+		}
+	}
+	return nil
+}
+
+// Get the bots serving every guild, starting with the primary guild
+func (b *Bot) Guilds() []*Bot {
+	b.guilds.mu.RLock()
+	defer b.guilds.mu.RUnlock()
+	return append([]*Bot(nil), b.guilds.bots...)
+}
+
+// Check if this bot serves the primary guild, which handles the work that is
+// shared by every guild
+func (b *Bot) IsPrimary() bool {
+	return b.Guilds()[0] == b
+}
+
+// Get the bot that sent the direct message, or the primary guild's bot if the
+// message isn't tracked by any of them
+func (b *Bot) directMessageOwner(messageID string) *Bot {
+	bots := b.Guilds()
+	for _, peer := range bots {
+		peer.messagesLock.Lock()
+		_, exists := peer.DirectMessages[messageID]
+		peer.messagesLock.Unlock()
+		if exists {
+			return peer
+		}
+	}
+	return bots[0]
+}
@@ -3,4 +3,12 @@ package bot
 import "github.com/bwmarrin/discordgo"
 
 // Function that handles a user interaction
-type Handler = func(s *discordgo.Session, i *discordgo.InteractionCreate)
+type Handler = func(i *discordgo.InteractionCreate)
+
+// Add a handler for interactions to the session. Handlers make their discord
+// calls through the bot, so they are not given the session
+func (b *Bot) AddInteractionHandler(handler Handler) func() {
+	return b.Session.AddHandler(func(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+		handler(i)
+	})
+}
@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"context"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// The languages chosen by guilds and users. Kept in memory as they are
+// needed for every reply, and shared by the bots for each guild
+type languageCache struct {
+	mu     sync.RWMutex
+	guilds map[string]string // guild ID -> default language
+	users  map[string]string // discord ID -> chosen language
+}
+
+func newLanguageCache() *languageCache {
+	return &languageCache{guilds: map[string]string{}, users: map[string]string{}}
+}
+
+// Load the languages chosen by guilds and users from the database
+func (b *Bot) LoadLanguages(ctx context.Context) error {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.RBegin(timeout, "bot.LoadLanguages")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.RBegin")
+	}
+	defer tx.Rollback()
+	guilds, err := models.GetGuildLanguages(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "models.GetGuildLanguages")
+	}
+	users, err := models.GetUserLanguages(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "models.GetUserLanguages")
+	}
+	b.languages.mu.Lock()
+	defer b.languages.mu.Unlock()
+	b.languages.guilds = guilds
+	b.languages.users = users
+	return nil
+}
+
+// Set the default language of the guild served by the bot. If language is
+// empty the default language of the bot is used
+func (b *Bot) SetGuildLanguage(ctx context.Context, tx *db.SafeWTX, language string) error {
+	err := models.SetGuildLanguage(ctx, tx, b.GuildID, language)
+	if err != nil {
+		return errors.Wrap(err, "models.SetGuildLanguage")
+	}
+	b.languages.mu.Lock()
+	defer b.languages.mu.Unlock()
+	if language == "" {
+		delete(b.languages.guilds, b.GuildID)
+	} else {
+		b.languages.guilds[b.GuildID] = language
+	}
+	return nil
+}
+
+// Set the language chosen by the user. If language is empty the user's
+// choice is removed
+func (b *Bot) SetUserLanguage(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	discordID string,
+	language string,
+) error {
+	err := models.SetUserLanguage(ctx, tx, discordID, language)
+	if err != nil {
+		return errors.Wrap(err, "models.SetUserLanguage")
+	}
+	b.languages.mu.Lock()
+	defer b.languages.mu.Unlock()
+	if language == "" {
+		delete(b.languages.users, discordID)
+	} else {
+		b.languages.users[discordID] = language
+	}
+	return nil
+}
+
+// Get the default language of the guild served by the bot
+func (b *Bot) GuildLanguage() string {
+	b.languages.mu.RLock()
+	defer b.languages.mu.RUnlock()
+	if language, exists := b.languages.guilds[b.GuildID]; exists && i18n.Supported(language) {
+		return language
+	}
+	return i18n.Default
+}
+
+// Get the language chosen by the user, or an empty string if they haven't
+// chosen one
+func (b *Bot) UserLanguage(discordID string) string {
+	b.languages.mu.RLock()
+	defer b.languages.mu.RUnlock()
+	language := b.languages.users[discordID]
+	if !i18n.Supported(language) {
+		return ""
+	}
+	return language
+}
+
+// Get the language to reply to the interaction in. Uses the language chosen
+// by the user, then the language of their discord client, then the default
+// language of the guild. Messages that aren't a reply to an interaction (i
+// is nil) use the default language of the guild
+func (b *Bot) Lang(i *discordgo.InteractionCreate) string {
+	if i == nil || i.Interaction == nil {
+		return b.GuildLanguage()
+	}
+	if language := b.UserLanguage(AuditActor(i).DiscordID); language != "" {
+		return language
+	}
+	if language := i18n.Match(string(i.Locale)); language != "" {
+		return language
+	}
+	return b.GuildLanguage()
+}
+
+// Get the language to message the user in outside of a reply to their
+// interaction, such as direct messages and panels that are updated later.
+// Uses the language chosen by the user, then the default language of the guild
+func (b *Bot) UserLang(discordID string) string {
+	if language := b.UserLanguage(discordID); language != "" {
+		return language
+	}
+	return b.GuildLanguage()
+}
+
+// Get the message in the language for the interaction. See Lang
+func (b *Bot) T(i *discordgo.InteractionCreate, key string, args ...any) string {
+	return i18n.T(b.Lang(i), key, args...)
+}
@@ -1,12 +1,18 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"gosl/internal/models"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
 
+// How long a message can wait to be sent to the log channel. Logging isn't
+// done on behalf of a caller with a context, so it is bounded by this instead
+const logSendTimeout = 30 * time.Second
+
 // Log message object for logging to discord channel
 type logmsg struct {
 	b       *Bot
@@ -28,6 +34,7 @@ func (b *Bot) TripleError(
 	i *discordgo.InteractionCreate,
 	ack bool,
 ) {
+	interactionErrors.Inc(InteractionName(i))
 	b.Logger.Error().Err(err).Msg(msg)
 	b.Log().Error(msg, err)
 	newerr := b.Error(msg, err.Error(), i, ack)
@@ -51,7 +58,7 @@ func (l *logmsg) Error(msg string, err error) {
 	l.level = "Error"
 	l.message = msg + "\n" + err.Error()
 	l.color = 0xff0000
-	l.b.Channels[models.ChannelLog].SendMessage(l.logMsgContents())
+	l.send()
 }
 
 // Send the logmsg as a user event
@@ -65,7 +72,7 @@ func (l *logmsg) UserEvent(user *discordgo.Member, msg string) {
 `
 	l.message = fmt.Sprintf(evtmsg, user.User.Username, msg)
 	l.color = 0x0096FF
-	l.b.Channels[models.ChannelLog].SendMessage(l.logMsgContents())
+	l.send()
 }
 
 // Send the logmsg as an info event
@@ -73,7 +80,7 @@ func (l *logmsg) Info(msg string) {
 	l.level = "Info"
 	l.message = msg
 	l.color = 0x00ff00
-	l.b.Channels[models.ChannelLog].SendMessage(l.logMsgContents())
+	l.send()
 }
 
 func (l *logmsg) logMsgContents() MessageContents {
@@ -86,3 +93,10 @@ func (l *logmsg) logMsgContents() MessageContents {
 		Components: nil,
 	}
 }
+
+// Send the logmsg to the log channel
+func (l *logmsg) send() {
+	ctx, cancel := context.WithTimeout(context.Background(), logSendTimeout)
+	defer cancel()
+	l.b.Channels[models.ChannelLog].SendMessage(ctx, l.logMsgContents())
+}
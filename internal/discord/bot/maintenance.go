@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+// Reply to the interaction with the maintenance message if maintenance mode
+// is active, so it isn't left waiting on the paused database.
+// Returns true if maintenance is active and the interaction should not be
+// handled
+func (b *Bot) UnderMaintenance(i *discordgo.InteractionCreate) bool {
+	if !b.Maintenance.Active() {
+		return false
+	}
+	err := b.interactionRespond(i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{{
+				Title:       b.T(i, "maintenance.title"),
+				Description: b.Maintenance.Info().Message(b.Lang(i), DiscordDateTimeUntil),
+				Color:       0xffa500, // Orange color
+			}},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		b.Logger.Warn().Err(err).Msg("Failed to notify user of maintenance")
+	}
+	return true
+}
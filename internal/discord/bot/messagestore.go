@@ -0,0 +1,228 @@
+package bot
+
+import (
+	"context"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+const messageSweepInterval = 30 * time.Second // time between expiring due messages
+
+// How long a message that never expires stays tracked after it was last sent
+// or found. Once pruned it is tracked again the next time it is interacted
+// with, as the message is found in discord
+const trackedMessageTTL = 30 * 24 * time.Hour
+
+// Start expiring direct messages once they are due. Messages tracked before
+// the last restart are restored first, and any that became due while the bot
+// was offline are expired straight away
+func (b *Bot) StartMessageSweeper(ctx context.Context) {
+	b.Logger.Info().Msg("Message sweeper has been started.")
+	ticker := time.NewTicker(messageSweepInterval)
+	go func() {
+		defer ticker.Stop()
+		err := b.restoreTrackedMessages(ctx)
+		if err != nil {
+			b.Logger.Error().Err(err).Msg("Error occured restoring tracked messages")
+		}
+		b.sweepMessages(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				b.Logger.Info().Msg("Stopping message sweeper due to shutdown.")
+				return
+			case <-ticker.C:
+				if b.Maintenance.Active() {
+					continue
+				}
+				b.sweepMessages(ctx)
+			}
+		}
+	}()
+}
+
+// Load the messages tracked before the bot was restarted
+func (b *Bot) restoreTrackedMessages(ctx context.Context) error {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.RBegin(timeout, "bot.restoreTrackedMessages")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.RBegin")
+	}
+	msgs, err := models.GetTrackedMessages(ctx, tx, b.GuildID)
+	tx.Rollback()
+	if err != nil {
+		return errors.Wrap(err, "models.GetTrackedMessages")
+	}
+	b.messagesLock.Lock()
+	defer b.messagesLock.Unlock()
+	for _, msg := range *msgs {
+		switch msg.Kind {
+		case models.TrackedDirect:
+			if _, exists := b.DirectMessages[msg.MessageID]; exists {
+				continue
+			}
+			dm := &DirectMessage{
+				ID:                msg.MessageID,
+				Label:             msg.Label,
+				UserID:            msg.UserID,
+				Expiry:            msg.Expiry,
+				deleteAfterExpiry: msg.DeleteAfterExpiry,
+				b:                 b,
+				c:                 &discordgo.Channel{ID: msg.ChannelID},
+			}
+			if msg.ExpiresAt != nil {
+				dm.ExpiresAt = *msg.ExpiresAt
+			}
+			b.DirectMessages[dm.ID] = dm
+		case models.TrackedDynamic:
+			if _, exists := b.DynamicMessages[msg.MessageID]; exists {
+				continue
+			}
+			b.DynamicMessages[msg.MessageID] = &DynamicMessage{
+				ID:        msg.MessageID,
+				ChannelID: msg.ChannelID,
+				Label:     msg.Label,
+				b:         b,
+			}
+		}
+	}
+	messagesTracked.Set(float64(len(b.DirectMessages)), "direct")
+	messagesTracked.Set(float64(len(b.DynamicMessages)), "dynamic")
+	b.Logger.Info().Int("messages", len(*msgs)).Msg("Restored tracked messages")
+	return nil
+}
+
+// Expire all the direct messages that are due, and stop tracking the messages
+// that never expire once they are older than the TTL
+func (b *Bot) sweepMessages(ctx context.Context) {
+	err := b.pruneTrackedMessages(ctx)
+	if err != nil {
+		b.Logger.Warn().Err(err).Msg("Failed to prune tracked messages")
+	}
+	now := time.Now()
+	due := []*DirectMessage{}
+	b.messagesLock.Lock()
+	for _, dm := range b.DirectMessages {
+		if dm.Expiry != 0 && !dm.ExpiresAt.IsZero() && !dm.ExpiresAt.After(now) {
+			due = append(due, dm)
+		}
+	}
+	b.messagesLock.Unlock()
+	for _, dm := range due {
+		err := dm.sweep(ctx)
+		if err != nil {
+			b.Logger.Warn().Err(err).
+				Str("msg", dm.Label).
+				Str("user", dm.UserID).
+				Msg("Failed to expire direct message")
+		}
+	}
+}
+
+// Stop tracking the messages that never expire and haven't been tracked
+// again within the TTL
+func (b *Bot) pruneTrackedMessages(ctx context.Context) error {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.Begin(timeout, "bot.pruneTrackedMessages")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	pruned, err := models.PruneTrackedMessages(timeout, tx, b.GuildID,
+		time.Now().Add(-trackedMessageTTL))
+	if err != nil {
+		return errors.Wrap(err, "models.PruneTrackedMessages")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "tx.Commit")
+	}
+	b.messagesLock.Lock()
+	defer b.messagesLock.Unlock()
+	for _, messageID := range *pruned {
+		delete(b.DirectMessages, messageID)
+		delete(b.DynamicMessages, messageID)
+	}
+	messagesTracked.Set(float64(len(b.DirectMessages)), "direct")
+	messagesTracked.Set(float64(len(b.DynamicMessages)), "dynamic")
+	return nil
+}
+
+// Run the change to the tracked messages in the transaction provided, or in
+// a transaction of its own if it is nil. Messages are usually tracked from
+// inside an interaction handler that already holds the write transaction, so
+// they need to be written in it or they would wait on it forever
+func (b *Bot) writeTrackedMessages(
+	tx *db.SafeWTX,
+	label string,
+	write func(ctx context.Context, tx *db.SafeWTX) error,
+) error {
+	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if tx != nil {
+		return write(timeout, tx)
+	}
+	tx, err := b.Conn.Begin(timeout, label)
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	err = write(timeout, tx)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "tx.Commit")
+	}
+	return nil
+}
+
+func (b *Bot) trackDirectMessage(tx *db.SafeWTX, dm *DirectMessage) error {
+	msg := &models.TrackedMessage{
+		MessageID:         dm.ID,
+		Kind:              models.TrackedDirect,
+		GuildID:           b.GuildID,
+		Label:             dm.Label,
+		ChannelID:         dm.c.ID,
+		UserID:            dm.UserID,
+		Expiry:            dm.Expiry,
+		DeleteAfterExpiry: dm.deleteAfterExpiry,
+	}
+	if !dm.ExpiresAt.IsZero() {
+		expiresAt := dm.ExpiresAt
+		msg.ExpiresAt = &expiresAt
+	}
+	return b.writeTrackedMessages(tx, "bot.trackDirectMessage",
+		func(ctx context.Context, tx *db.SafeWTX) error {
+			return errors.Wrap(models.SetTrackedMessage(ctx, tx, msg), "models.SetTrackedMessage")
+		})
+}
+
+func (b *Bot) trackDynamicMessage(tx *db.SafeWTX, dy *DynamicMessage) error {
+	msg := &models.TrackedMessage{
+		MessageID: dy.ID,
+		Kind:      models.TrackedDynamic,
+		GuildID:   b.GuildID,
+		Label:     dy.Label,
+		ChannelID: dy.ChannelID,
+	}
+	return b.writeTrackedMessages(tx, "bot.trackDynamicMessage",
+		func(ctx context.Context, tx *db.SafeWTX) error {
+			return errors.Wrap(models.SetTrackedMessage(ctx, tx, msg), "models.SetTrackedMessage")
+		})
+}
+
+func (b *Bot) untrackMessage(tx *db.SafeWTX, messageID string) error {
+	return b.writeTrackedMessages(tx, "bot.untrackMessage",
+		func(ctx context.Context, tx *db.SafeWTX) error {
+			return errors.Wrap(models.RemoveTrackedMessage(ctx, tx, messageID),
+				"models.RemoveTrackedMessage")
+		})
+}
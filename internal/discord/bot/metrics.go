@@ -0,0 +1,58 @@
+package bot
+
+import (
+	"gosl/pkg/metrics"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var (
+	interactionsHandled = metrics.NewCounterVec("gosl_discord_interactions_total",
+		"Discord interactions handled, by custom ID or command", "interaction")
+	interactionDuration = metrics.NewHistogramVec("gosl_discord_interaction_duration_seconds",
+		"Time taken to handle Discord interactions, by custom ID or command",
+		nil, "interaction")
+	interactionErrors = metrics.NewCounterVec("gosl_discord_interaction_errors_total",
+		"Discord interactions that failed with an unexpected error, by custom ID or command",
+		"interaction")
+	schedulerWaitTime = metrics.NewHistogramVec("gosl_discord_scheduler_wait_seconds",
+		"Time requests spent waiting for the Discord rate limits, by priority",
+		[]float64{.001, .01, .1, .25, .5, 1, 2, 5, 10, 30}, "priority")
+	schedulerQueueDepth = metrics.NewGaugeVec("gosl_discord_scheduler_queue_depth",
+		"Requests waiting for the Discord rate limits, by priority", "priority")
+	rateLimitsHit = metrics.NewCounterVec("gosl_discord_rate_limits_hit_total",
+		"Requests rejected by Discord for going over a rate limit, by scope", "scope")
+	messagesSent = metrics.NewCounterVec("gosl_discord_messages_sent_total",
+		"Dynamic and direct messages sent by the bot", "kind")
+	messagesTracked = metrics.NewGaugeVec("gosl_discord_messages_tracked",
+		"Dynamic and direct messages currently being tracked by the bot", "kind")
+)
+
+// Get the name of the interaction used in the metrics. Commands are the
+// command name prefixed with "/", components and modals are the custom ID
+// with any parts containing IDs replaced
+func InteractionName(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		return "/" + i.ApplicationCommandData().Name
+	case discordgo.InteractionMessageComponent:
+		return metrics.Normalize(i.MessageComponentData().CustomID, "_")
+	case discordgo.InteractionModalSubmit:
+		return metrics.Normalize(i.ModalSubmitData().CustomID, "_")
+	}
+	return "unknown"
+}
+
+// Start tracking the interaction in the metrics. Returns a func to call once
+// the interaction has been handled, i.e.
+//
+//	defer bot.TrackInteraction(i)()
+func TrackInteraction(i *discordgo.InteractionCreate) func() {
+	name := InteractionName(i)
+	start := time.Now()
+	interactionsHandled.Inc(name)
+	return func() {
+		interactionDuration.ObserveDuration(time.Since(start), name)
+	}
+}
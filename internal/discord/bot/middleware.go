@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/models"
+	"runtime/debug"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Turn a panic in a route into an error so the user gets a response and the
+// bot stays up. Always applied by the router
+func recovery(b *Bot) Middleware {
+	return func(next RouteFunc) RouteFunc {
+		return func(r *Request) (err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					b.Logger.Error().Str("route", r.pattern).Interface("panic", p).
+						Bytes("stack", debug.Stack()).Msg("Recovered from panic in interaction handler")
+					err = errors.New(fmt.Sprintf("panic: %v", p))
+				}
+			}()
+			return next(r)
+		}
+	}
+}
+
+// Reply with the maintenance message instead of handling the interaction if
+// maintenance mode is active
+func MaintenanceGate(b *Bot) Middleware {
+	return func(next RouteFunc) RouteFunc {
+		return func(r *Request) error {
+			if b.UnderMaintenance(r.I) {
+				return nil
+			}
+			return next(r)
+		}
+	}
+}
+
+// Acknowledge the interaction before handling it, for routes that reply with
+// a follow up
+func AcknowledgeFirst(b *Bot) Middleware {
+	return func(next RouteFunc) RouteFunc {
+		return func(r *Request) error {
+			err := b.Acknowledge(r.I, &r.Ack)
+			if err != nil {
+				return errors.Wrap(err, "b.Acknowledge")
+			}
+			return next(r)
+		}
+	}
+}
+
+// Open a transaction for the route, committing it if the route returns
+// without an error and then running any functions given to AfterCommit
+func Transaction(b *Bot) Middleware {
+	return func(next RouteFunc) RouteFunc {
+		return func(r *Request) error {
+			timeout, cancel := context.WithTimeout(r.Ctx, 10*time.Second)
+			defer cancel()
+			tx, err := b.Conn.Begin(timeout, r.label+" interaction handler")
+			if err != nil {
+				return errors.Wrap(err, "conn.Begin")
+			}
+			defer tx.Rollback()
+			r.Tx = tx
+			err = next(r)
+			if err != nil {
+				return err
+			}
+			err = tx.Commit()
+			r.Tx = nil
+			if err != nil {
+				// the route has finished, so the router logs this and tells
+				// the user their changes weren't saved
+				return errors.Wrap(err, "tx.Commit")
+			}
+			for _, fn := range r.afterCommit {
+				fn()
+			}
+			r.afterCommit = nil
+			return nil
+		}
+	}
+}
+
+// Reply with forbidden unless the member has the permission. Uses the
+// route's transaction if it has one, otherwise reads with a new one that is
+// finished before the route runs, so the route doesn't hold it while it waits
+// for its own transactions
+func RequirePermission(b *Bot, permid uint16) Middleware {
+	return func(next RouteFunc) RouteFunc {
+		return func(r *Request) error {
+			if r.I.Member == nil {
+				b.Forbidden(r.I, r.Ack)
+				return nil
+			}
+			allowed, err := memberHasPermission(b, r, permid)
+			if err != nil {
+				return errors.Wrap(err, "memberHasPermission")
+			}
+			if !allowed {
+				b.Forbidden(r.I, r.Ack)
+				return nil
+			}
+			return next(r)
+		}
+	}
+}
+
+func memberHasPermission(b *Bot, r *Request, permid uint16) (bool, error) {
+	if r.Tx != nil {
+		return models.MemberHasPermission(r.Ctx, r.Tx, b.GuildID, r.I.Member, permid)
+	}
+	timeout, cancel := context.WithTimeout(r.Ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.RBegin(timeout, r.label+" permission check")
+	if err != nil {
+		return false, errors.Wrap(err, "conn.RBegin")
+	}
+	defer tx.Rollback()
+	return models.MemberHasPermission(r.Ctx, tx, b.GuildID, r.I.Member, permid)
+}
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"context"
+	"gosl/internal/models"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	playerSyncInterval  = 10 * time.Minute // time between syncing batches of players
+	playerSyncMaxAge    = 6 * time.Hour    // players synced longer ago are synced again
+	playerSyncBatchSize = 20               // most players synced each interval
+)
+
+// Start periodically syncing the in-game name and rank of players from the
+// slapshot API
+func (b *Bot) StartSyncingPlayers(ctx context.Context) {
+	b.Logger.Info().Msg("Player sync has been started.")
+	ticker := time.NewTicker(playerSyncInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				b.Logger.Info().Msg("Stopping player sync due to shutdown.")
+				return
+			case <-ticker.C:
+				if b.Maintenance.Active() {
+					continue
+				}
+				err := b.syncPlayers(ctx)
+				if err != nil {
+					b.Logger.Error().Err(err).Msg("Error occured syncing players")
+				}
+			}
+		}
+	}()
+}
+
+// Sync a batch of the players that are due to be synced
+func (b *Bot) syncPlayers(ctx context.Context) error {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.RBegin(timeout, "bot.syncPlayers")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.RBegin")
+	}
+	players, err := models.GetPlayersToSync(ctx, tx,
+		time.Now().Add(-playerSyncMaxAge), playerSyncBatchSize)
+	tx.Rollback()
+	if err != nil {
+		return errors.Wrap(err, "models.GetPlayersToSync")
+	}
+	for _, player := range *players {
+		err = b.SyncPlayer(ctx, &player)
+		if err != nil {
+			b.Logger.Warn().Err(err).Uint16("player_id", player.ID).
+				Msg("Failed to sync player")
+		}
+	}
+	return nil
+}
+
+// Get the in-game name and rank of the player from the slapshot API and store
+// them. Must not be called while holding a write transaction
+func (b *Bot) SyncPlayer(ctx context.Context, player *models.Player) error {
+	timeout, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	details, err := b.Slapshot.GetPlayer(timeout, player.SlapID)
+	if err != nil {
+		return errors.Wrap(err, "b.Slapshot.GetPlayer")
+	}
+	rank, err := b.Slapshot.GetPlayerRank(timeout, player.SlapID)
+	if err != nil {
+		return errors.Wrap(err, "b.Slapshot.GetPlayerRank")
+	}
+	info := &models.SlapshotInfo{Username: details.Username}
+	if rank != nil {
+		info.Rank = rank.Rank
+		info.MMR = &rank.MMR
+	}
+	tx, err := b.Conn.Begin(timeout, "bot.SyncPlayer")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	err = models.SetSlapshotInfo(ctx, tx, player.ID, info)
+	if err != nil {
+		return errors.Wrap(err, "models.SetSlapshotInfo")
+	}
+	tx.Commit()
+	player.Slapshot = info
+	return nil
+}
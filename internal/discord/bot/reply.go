@@ -1,13 +1,67 @@
 package bot
 
 import (
-	"fmt"
+	"context"
+	"net/http"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
 )
 
+// How long the token for an interaction can be used to reply to it
+const interactionTokenLifetime = 15 * time.Minute
+
+// Wait until a reply to the interaction can be sent. Replies don't have the
+// handler's context, so they only wait while the interaction token is valid
+func (b *Bot) scheduleReply(i *discordgo.InteractionCreate, method, endpoint string) error {
+	created, err := discordgo.SnowflakeTimestamp(i.ID)
+	if err != nil {
+		created = time.Now()
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), created.Add(interactionTokenLifetime))
+	defer cancel()
+	return b.Schedule(ctx, PriorityInteraction, method, endpoint)
+}
+
+// Respond to the interaction once the response can be sent
+func (b *Bot) interactionRespond(
+	i *discordgo.InteractionCreate,
+	resp *discordgo.InteractionResponse,
+) error {
+	err := b.scheduleReply(i, http.MethodPost, discordgo.EndpointInteractionResponse(i.ID, i.Token))
+	if err != nil {
+		return errors.Wrap(err, "bot.scheduleReply")
+	}
+	return b.Session.InteractionRespond(i.Interaction, resp)
+}
+
+// Send a follow up message to the interaction once it can be sent
+func (b *Bot) followupMessageCreate(
+	i *discordgo.InteractionCreate,
+	params *discordgo.WebhookParams,
+) error {
+	err := b.scheduleReply(i, http.MethodPost, discordgo.EndpointFollowupMessage(i.AppID, i.Token))
+	if err != nil {
+		return errors.Wrap(err, "bot.scheduleReply")
+	}
+	_, err = b.Session.FollowupMessageCreate(i.Interaction, true, params)
+	return err
+}
+
+// Delete the response to the interaction after the delay
+func (b *Bot) deleteResponseAfter(i *discordgo.InteractionCreate, delay time.Duration) {
+	time.Sleep(delay)
+	err := b.scheduleReply(i, http.MethodDelete,
+		discordgo.EndpointInteractionResponseActions(i.AppID, i.Token))
+	if err == nil {
+		err = b.Session.InteractionResponseDelete(i.Interaction)
+	}
+	if err != nil {
+		b.Logger.Warn().Err(err).Msg("Failed to delete emphemeral message")
+	}
+}
+
 // Acknowledge the interaction and prepare for an ephemeral response when
 // interaction handling is complete. call FollowUP() to follow up
 func (b *Bot) Acknowledge(
@@ -15,7 +69,7 @@ func (b *Bot) Acknowledge(
 	ack *bool,
 ) error {
 	b.Logger.Debug().Msg("Acknowledging interaction")
-	err := b.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	err := b.interactionRespond(i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Flags: discordgo.MessageFlagsEphemeral,
@@ -36,7 +90,7 @@ func (b *Bot) SilentAcknowledge(
 	ack *bool,
 ) error {
 	b.Logger.Debug().Msg("Acknowledging interaction")
-	err := b.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	err := b.interactionRespond(i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredMessageUpdate,
 	})
 	if err != nil {
@@ -54,20 +108,14 @@ func (b *Bot) FollowUp(
 	i *discordgo.InteractionCreate,
 ) error {
 	b.Logger.Debug().Msg("Responding to interaction")
-	_, err := b.Session.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+	err := b.followupMessageCreate(i, &discordgo.WebhookParams{
 		Content: msg,
 	})
 	if err != nil {
 		return errors.Wrap(err, "s.FollowupMessageCreate")
 	}
 	// Wait for 5 seconds before deleting
-	go func() {
-		time.Sleep(5 * time.Second)
-		err := b.Session.InteractionResponseDelete(i.Interaction)
-		if err != nil {
-			b.Logger.Warn().Err(err).Msg("Failed to delete emphemeral message")
-		}
-	}()
+	go b.deleteResponseAfter(i, 5*time.Second)
 	return nil
 }
 
@@ -79,8 +127,8 @@ func (b *Bot) FollowUpComplex(
 ) error {
 	b.Logger.Debug().Msg("Responding to interaction")
 	deleteat := time.Now().Add(deleteafter)
-	_, err := b.Session.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-		Content:    fmt.Sprintf("*This message will delete %s*", DiscordUntil(&deleteat)),
+	err := b.followupMessageCreate(i, &discordgo.WebhookParams{
+		Content:    b.T(i, "message.deletes", DiscordUntil(&deleteat)),
 		Embeds:     []*discordgo.MessageEmbed{contents.Embed},
 		Components: contents.Components,
 	})
@@ -88,13 +136,7 @@ func (b *Bot) FollowUpComplex(
 		return errors.Wrap(err, "s.FollowupMessageCreate")
 	}
 	// Wait for the delay before deleting
-	go func() {
-		time.Sleep(deleteafter)
-		err := b.Session.InteractionResponseDelete(i.Interaction)
-		if err != nil {
-			b.Logger.Warn().Err(err).Msg("Failed to delete emphemeral message")
-		}
-	}()
+	go b.deleteResponseAfter(i, deleteafter)
 	return nil
 }
 
@@ -105,7 +147,7 @@ func (b *Bot) ReplyModal(
 	components []discordgo.MessageComponent,
 	i *discordgo.InteractionCreate,
 ) error {
-	err := b.Session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	err := b.interactionRespond(i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
 			Title:      title,
@@ -0,0 +1,323 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"gosl/pkg/db"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Returned by a route to give the user the standard response for an
+// interaction on a message that is out of date, e.g. an application that has
+// since been deleted
+var ErrStaleInteraction = errors.New("stale interaction")
+
+// Handles an interaction matched to a route
+type RouteFunc func(r *Request) error
+
+// Wraps a route with common behaviour such as opening a transaction
+type Middleware func(next RouteFunc) RouteFunc
+
+// The state of an interaction as it passes through the middleware to the
+// route handler
+type Request struct {
+	Ctx    context.Context
+	I      *discordgo.InteractionCreate
+	Params Params
+	// Set once the interaction has been acknowledged
+	Ack bool
+	// Only set if the route uses the Transaction middleware
+	Tx          *db.SafeWTX
+	afterCommit []func()
+	label       string
+	pattern     string
+}
+
+// Run fn once the route's transaction has been committed, e.g. to start work
+// that needs to see the changes made by the route. fn is never run if the
+// transaction is rolled back. Runs straight away if the route has no
+// transaction
+func (r *Request) AfterCommit(fn func()) {
+	if r.Tx == nil {
+		fn()
+		return
+	}
+	r.afterCommit = append(r.afterCommit, fn)
+}
+
+// The parameters parsed from a custom ID
+type Params struct {
+	strs map[string]string
+	ids  map[string]uint32
+}
+
+// Get a string parameter, or the raw value of a typed parameter
+func (p Params) String(name string) string {
+	return p.strs[name]
+}
+
+// Get an ID parameter, declared in the pattern as {name:id}
+func (p Params) ID(name string) uint32 {
+	return p.ids[name]
+}
+
+// Routes component and modal interactions to handlers by custom ID pattern.
+// Patterns are split into parts by "_". Each part is either a literal, a
+// string parameter {name} or a typed parameter {name:id}. A custom ID
+// matches if it has the same number of parts, the literals are equal and the
+// typed parameters parse. Literal custom IDs are matched before patterns,
+// then patterns in the order they were declared
+type Router struct {
+	b          *Bot
+	label      string
+	match      func(i *discordgo.InteractionCreate) bool
+	routes     *routeTable
+	middleware []Middleware
+}
+
+type routeTable struct {
+	literal map[discordgo.InteractionType]map[string]*route
+	pattern map[discordgo.InteractionType][]*route
+}
+
+type route struct {
+	pattern string
+	parts   []routePart
+	handler RouteFunc
+}
+
+type routePart struct {
+	literal string
+	param   string
+	typ     string
+}
+
+// Create a new router. match is used to pick out the interactions the router
+// is responsible for, such as those in a particular channel. The label is
+// used in logging and transaction names
+func NewRouter(
+	b *Bot,
+	label string,
+	match func(i *discordgo.InteractionCreate) bool,
+) *Router {
+	return &Router{
+		b:     b,
+		label: label,
+		match: match,
+		routes: &routeTable{
+			literal: make(map[discordgo.InteractionType]map[string]*route),
+			pattern: make(map[discordgo.InteractionType][]*route),
+		},
+	}
+}
+
+// Get a router that shares the routes of this router, with extra
+// middleware applied to any routes declared on it. Middleware runs in the
+// order given, after any middleware already on the router
+func (rt *Router) With(mw ...Middleware) *Router {
+	child := *rt
+	child.middleware = append(append([]Middleware{}, rt.middleware...), mw...)
+	return &child
+}
+
+// Declare a route for a button or select menu. Panics if the pattern is
+// invalid or already declared, as routes are fixed when the bot is built
+func (rt *Router) Component(pattern string, handler RouteFunc) {
+	rt.add(discordgo.InteractionMessageComponent, pattern, handler)
+}
+
+// Declare a route for a modal submission. Panics if the pattern is invalid
+// or already declared, as routes are fixed when the bot is built
+func (rt *Router) Modal(pattern string, handler RouteFunc) {
+	rt.add(discordgo.InteractionModalSubmit, pattern, handler)
+}
+
+// Get the handler to add to the discord session for the router
+func (rt *Router) Handler(ctx context.Context) Handler {
+	rt.b.Logger.Debug().Str("router", rt.label).Msg("Adding handler for interactions")
+	return func(i *discordgo.InteractionCreate) {
+		if i.Type != discordgo.InteractionMessageComponent &&
+			i.Type != discordgo.InteractionModalSubmit {
+			return
+		}
+		if !rt.match(i) {
+			return
+		}
+		defer TrackInteraction(i)()
+		customID := interactionCustomID(i)
+		rt.b.Logger.Debug().Str("router", rt.label).Str("custom_id", customID).
+			Msg("Handling interaction")
+		rte, params, found := rt.routes.find(i.Type, customID)
+		if !found {
+			rt.b.Logger.Debug().Str("router", rt.label).Str("custom_id", customID).
+				Msg("No route for interaction")
+			rt.b.Stale(i, false)
+			return
+		}
+		r := &Request{
+			Ctx:     ctx,
+			I:       i,
+			Params:  params,
+			label:   rt.label,
+			pattern: rte.pattern,
+		}
+		err := rte.handler(r)
+		if errors.Is(err, ErrStaleInteraction) {
+			rt.b.Stale(i, r.Ack)
+			return
+		}
+		if err != nil {
+			rt.b.TripleError("Failed to handle interaction", err, i, r.Ack)
+		}
+	}
+}
+
+// Match interactions on messages in the channel with the given purpose
+func InChannel(b *Bot, purpose uint16) func(i *discordgo.InteractionCreate) bool {
+	return func(i *discordgo.InteractionCreate) bool {
+		channel, exists := b.Channels[purpose]
+		return exists && i.Message != nil && i.Message.ChannelID == channel.ID
+	}
+}
+
+// Match interactions in direct messages sent by the bot. Each direct message
+// is handled by the guild's bot that sent it, and by the primary guild's bot
+// if it isn't tracked
+func InDirectMessage(b *Bot) func(i *discordgo.InteractionCreate) bool {
+	return func(i *discordgo.InteractionCreate) bool {
+		if i.User == nil {
+			return false
+		}
+		if i.Message == nil {
+			return b.IsPrimary()
+		}
+		return b.directMessageOwner(i.Message.ID) == b
+	}
+}
+
+// ===========================================================================
+// PRIVATE FUNCTIONS
+// ===========================================================================
+
+func (rt *Router) add(itype discordgo.InteractionType, pattern string, handler RouteFunc) {
+	parts, err := parsePattern(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("bot.Router (%s): %s", rt.label, err))
+	}
+	// wrap in reverse so the first middleware runs first, with panic recovery
+	// outermost so it covers the middleware too
+	for idx := len(rt.middleware) - 1; idx >= 0; idx-- {
+		handler = rt.middleware[idx](handler)
+	}
+	handler = recovery(rt.b)(handler)
+	rte := &route{pattern: pattern, parts: parts, handler: handler}
+
+	literal := true
+	for _, part := range parts {
+		if part.param != "" {
+			literal = false
+		}
+	}
+	if literal {
+		if rt.routes.literal[itype] == nil {
+			rt.routes.literal[itype] = make(map[string]*route)
+		}
+		if _, exists := rt.routes.literal[itype][pattern]; exists {
+			panic(fmt.Sprintf("bot.Router (%s): route already declared: %s", rt.label, pattern))
+		}
+		rt.routes.literal[itype][pattern] = rte
+		return
+	}
+	for _, existing := range rt.routes.pattern[itype] {
+		if existing.pattern == pattern {
+			panic(fmt.Sprintf("bot.Router (%s): route already declared: %s", rt.label, pattern))
+		}
+	}
+	rt.routes.pattern[itype] = append(rt.routes.pattern[itype], rte)
+}
+
+func parsePattern(pattern string) ([]routePart, error) {
+	if pattern == "" {
+		return nil, errors.New("empty pattern")
+	}
+	split := strings.Split(pattern, "_")
+	parts := make([]routePart, len(split))
+	seen := make(map[string]bool)
+	for idx, s := range split {
+		if !strings.HasPrefix(s, "{") {
+			if strings.ContainsAny(s, "{}") || s == "" {
+				return nil, errors.New("invalid pattern: " + pattern)
+			}
+			parts[idx] = routePart{literal: s}
+			continue
+		}
+		if !strings.HasSuffix(s, "}") {
+			return nil, errors.New("invalid pattern: " + pattern)
+		}
+		name, typ, _ := strings.Cut(strings.Trim(s, "{}"), ":")
+		if name == "" || seen[name] {
+			return nil, errors.New("invalid parameter name in pattern: " + pattern)
+		}
+		if typ != "" && typ != "id" {
+			return nil, errors.New("unknown parameter type " + typ + " in pattern: " + pattern)
+		}
+		seen[name] = true
+		parts[idx] = routePart{param: name, typ: typ}
+	}
+	return parts, nil
+}
+
+func (t *routeTable) find(
+	itype discordgo.InteractionType,
+	customID string,
+) (*route, Params, bool) {
+	if rte, exists := t.literal[itype][customID]; exists {
+		return rte, Params{}, true
+	}
+	split := strings.Split(customID, "_")
+	for _, rte := range t.pattern[itype] {
+		if params, ok := rte.match(split); ok {
+			return rte, params, true
+		}
+	}
+	return nil, Params{}, false
+}
+
+func (rte *route) match(split []string) (Params, bool) {
+	if len(split) != len(rte.parts) {
+		return Params{}, false
+	}
+	params := Params{strs: make(map[string]string), ids: make(map[string]uint32)}
+	for idx, part := range rte.parts {
+		value := split[idx]
+		if part.param == "" {
+			if value != part.literal {
+				return Params{}, false
+			}
+			continue
+		}
+		if part.typ == "id" {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return Params{}, false
+			}
+			params.ids[part.param] = uint32(id)
+		}
+		params.strs[part.param] = value
+	}
+	return params, true
+}
+
+func interactionCustomID(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		return i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		return i.ModalSubmitData().CustomID
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package bot_test
+
+import (
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter(t *testing.T) {
+	env := setupTestEnv(t)
+	session := env.session
+	b := env.newBot()
+	// errors are logged to the log channel
+	logChannel := &bot.Channel{Purpose: models.ChannelLog, Name: "log", Label: "Log channel"}
+	require.NoError(t, b.AddChannel(logChannel))
+	require.NoError(t, logChannel.Setup(t.Context(), true))
+	channel, err := session.GuildChannelCreate(session.GuildID, "router", discordgo.ChannelTypeGuildText)
+	require.NoError(t, err)
+	member := session.AddMember("300", "player")
+	admin := session.AddMember("301", "admin")
+	admin.Permissions = discordgo.PermissionAdministrator
+
+	var called string
+	var params bot.Params
+	router := bot.NewRouter(b, "Test router", func(i *discordgo.InteractionCreate) bool {
+		return i.Message != nil && i.Message.ChannelID == channel.ID
+	})
+	router.Component("test_button", func(r *bot.Request) error {
+		called = "test_button"
+		return nil
+	})
+	router.Component("test_{name}", func(r *bot.Request) error {
+		called, params = "test_{name}", r.Params
+		return nil
+	})
+	router.Component("test_{item:id}_{panel}", func(r *bot.Request) error {
+		called, params = "test_{item:id}_{panel}", r.Params
+		return nil
+	})
+	router.Modal("test_modal_{item:id}", func(r *bot.Request) error {
+		called, params = "test_modal_{item:id}", r.Params
+		return nil
+	})
+	router.Component("panic_button", func(r *bot.Request) error {
+		panic("oops")
+	})
+	router.With(bot.AcknowledgeFirst(b)).Component("stale_button", func(r *bot.Request) error {
+		return bot.ErrStaleInteraction
+	})
+	var checkHeld int
+	router.With(bot.RequirePermission(b, models.PermAdmin)).Component("admin_button",
+		func(r *bot.Request) error {
+			called = "admin_button"
+			checkHeld = b.Conn.LockStats().Holders["Test router permission check"]
+			return nil
+		})
+	committed := []string{}
+	router.With(bot.Transaction(b)).Component("write_{ok}", func(r *bot.Request) error {
+		err := models.SetChannel(r.Ctx, r.Tx, b.GuildID, "channel-"+r.Params.String("ok"), 950)
+		require.NoError(t, err)
+		r.AfterCommit(func() {
+			// the write lock is released before the hook runs
+			tx, err := b.Conn.Begin(r.Ctx, "TestRouter after commit")
+			require.NoError(t, err)
+			tx.Rollback()
+			committed = append(committed, r.Params.String("ok"))
+		})
+		if r.Params.String("ok") != "yes" {
+			return assert.AnError
+		}
+		return nil
+	})
+	b.AddInteractionHandler(router.Handler(t.Context()))
+
+	press := func(userID, customID string) *discordgo.InteractionCreate {
+		called, params = "", bot.Params{}
+		i := session.ComponentInteraction(channel.ID, "1", userID, customID)
+		session.Interact(i)
+		return i
+	}
+	// Get the title of the error response sent for the interaction
+	errorTitle := func(i *discordgo.InteractionCreate) string {
+		embeds := []*discordgo.MessageEmbed{}
+		if followups := session.Followups(i); len(followups) > 0 {
+			embeds = followups[0].Embeds
+		} else if resp := session.Response(i); resp != nil && resp.Data != nil {
+			embeds = resp.Data.Embeds
+		}
+		if len(embeds) == 0 || len(embeds[0].Fields) == 0 {
+			return ""
+		}
+		return embeds[0].Fields[0].Name
+	}
+
+	t.Run("Literal custom IDs are matched before patterns", func(t *testing.T) {
+		press("300", "test_button")
+		assert.Equal(t, "test_button", called)
+		press("300", "test_other")
+		assert.Equal(t, "test_{name}", called)
+		assert.Equal(t, "other", params.String("name"))
+	})
+
+	t.Run("Typed parameters are parsed", func(t *testing.T) {
+		press("300", "test_42_1234567890123456789")
+		assert.Equal(t, "test_{item:id}_{panel}", called)
+		assert.Equal(t, uint32(42), params.ID("item"))
+		assert.Equal(t, "42", params.String("item"))
+		assert.Equal(t, "1234567890123456789", params.String("panel"))
+
+		called = ""
+		i := session.ModalInteraction(channel.ID, "1", "300", "test_modal_7")
+		session.Interact(i)
+		assert.Equal(t, "test_modal_{item:id}", called)
+		assert.Equal(t, uint32(7), params.ID("item"))
+	})
+
+	t.Run("Unknown and malformed custom IDs get the stale response", func(t *testing.T) {
+		for _, customID := range []string{"unknown", "test_abc_123", "test_modal_7"} {
+			i := press("300", customID)
+			assert.Empty(t, called, customID)
+			assert.Equal(t, "Interaction expired", errorTitle(i), customID)
+		}
+	})
+
+	t.Run("Routes can return a stale interaction", func(t *testing.T) {
+		i := press("300", "stale_button")
+		assert.Equal(t, "Interaction expired", errorTitle(i))
+	})
+
+	t.Run("Panics are recovered", func(t *testing.T) {
+		i := press("300", "panic_button")
+		assert.Equal(t, "Failed to handle interaction", errorTitle(i))
+		press("300", "test_button")
+		assert.Equal(t, "test_button", called)
+	})
+
+	t.Run("Members need the permission for the route", func(t *testing.T) {
+		i := press(member.User.ID, "admin_button")
+		assert.Empty(t, called)
+		assert.Equal(t, "Forbidden", errorTitle(i))
+		press(admin.User.ID, "admin_button")
+		assert.Equal(t, "admin_button", called)
+		assert.Zero(t, checkHeld, "permission check transaction held by the route")
+	})
+
+	t.Run("Transactions are only committed if the route succeeds", func(t *testing.T) {
+		press("300", "write_no")
+		press("300", "write_yes")
+		tx, err := b.Conn.RBegin(t.Context(), "TestRouter")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		channels, err := models.GetChannels(t.Context(), tx, b.GuildID, 950)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"channel-yes"}, channels)
+		assert.Equal(t, []string{"yes"}, committed)
+	})
+}
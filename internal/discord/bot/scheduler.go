@@ -0,0 +1,297 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Priority of a request to the discord API. Waiting requests are granted in
+// priority order, so bulk work never holds up replies to users
+type Priority int
+
+const (
+	PriorityInteraction Priority = iota // made while handling a user's interaction
+	PriorityNormal                      // background work such as logging
+	PriorityBulk                        // refreshing many messages at once
+	priorityCount
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityInteraction:
+		return "interaction"
+	case PriorityNormal:
+		return "normal"
+	case PriorityBulk:
+		return "bulk"
+	}
+	return "unknown"
+}
+
+const (
+	globalRequestLimit  = 50          // requests allowed by discord per window
+	globalRequestWindow = time.Second // length of the global rate limit window
+)
+
+// Schedules requests to the discord API around the rate limits. Each route
+// is limited by the bucket discord reports for it in the X-RateLimit headers,
+// and all routes share the global limit. Routes that haven't had a response
+// yet are only held back by the global limit
+type scheduler struct {
+	mu          sync.Mutex
+	lanes       [priorityCount][]*waiter
+	routes      map[string]string      // route -> bucket, learnt from responses
+	buckets     map[string]*rateBucket // bucket -> remaining requests
+	globalLimit int
+	window      time.Duration
+	windowEnd   time.Time
+	windowCount int
+	globalReset time.Time // set when discord reports the global limit was hit
+	timer       *time.Timer
+}
+
+type rateBucket struct {
+	remaining int
+	reset     time.Time
+}
+
+type waiter struct {
+	route   string
+	granted chan struct{}
+}
+
+func newScheduler(globalLimit int, window time.Duration) *scheduler {
+	return &scheduler{
+		routes:      make(map[string]string),
+		buckets:     make(map[string]*rateBucket),
+		globalLimit: globalLimit,
+		window:      window,
+	}
+}
+
+// Wait until a request can be made to the discord API endpoint without going
+// over the rate limit. Returns the context error if the context is done
+// before the request is allowed
+func (b *Bot) Schedule(ctx context.Context, p Priority, method, endpoint string) error {
+	return b.scheduler.wait(ctx, p, method, endpoint)
+}
+
+// Get the number of requests waiting to be made with the priority
+func (b *Bot) QueueDepth(p Priority) int {
+	return b.scheduler.depth(p)
+}
+
+func (s *scheduler) depth(p Priority) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.lanes[p])
+}
+
+func (s *scheduler) wait(ctx context.Context, p Priority, method, endpoint string) error {
+	start := time.Now()
+	w := &waiter{route: routeKey(method, endpoint), granted: make(chan struct{})}
+	s.mu.Lock()
+	s.lanes[p] = append(s.lanes[p], w)
+	s.dispatch()
+	s.mu.Unlock()
+	select {
+	case <-w.granted:
+		schedulerWaitTime.ObserveDuration(time.Since(start), p.String())
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		select {
+		case <-w.granted:
+			// granted while cancelling, the request can still go ahead
+			schedulerWaitTime.ObserveDuration(time.Since(start), p.String())
+			return nil
+		default:
+		}
+		s.lanes[p] = slices.DeleteFunc(s.lanes[p], func(q *waiter) bool { return q == w })
+		schedulerQueueDepth.Set(float64(len(s.lanes[p])), p.String())
+		return ctx.Err()
+	}
+}
+
+// Grant every waiting request that the rate limits allow, highest priority
+// first, and set a timer for when the next request will be allowed. Must be
+// called while holding the lock
+func (s *scheduler) dispatch() {
+	now := time.Now()
+	if !now.Before(s.windowEnd) {
+		s.windowEnd = now.Add(s.window)
+		s.windowCount = 0
+	}
+	var wake time.Time
+	for p := range s.lanes {
+		waiting := s.lanes[p][:0]
+		for _, w := range s.lanes[p] {
+			until := s.blockedUntil(w.route, now)
+			if until.IsZero() {
+				s.grant(w.route)
+				close(w.granted)
+				continue
+			}
+			if wake.IsZero() || until.Before(wake) {
+				wake = until
+			}
+			waiting = append(waiting, w)
+		}
+		clear(s.lanes[p][len(waiting):])
+		s.lanes[p] = waiting
+		schedulerQueueDepth.Set(float64(len(waiting)), Priority(p).String())
+	}
+	if wake.IsZero() {
+		return
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(wake.Sub(now), func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.dispatch()
+	})
+}
+
+// Get the time a request to the route will be allowed, or the zero time if it
+// is allowed now
+func (s *scheduler) blockedUntil(route string, now time.Time) time.Time {
+	if !globalExempt(route) {
+		if now.Before(s.globalReset) {
+			return s.globalReset
+		}
+		if s.windowCount >= s.globalLimit {
+			return s.windowEnd
+		}
+	}
+	bucket, exists := s.buckets[s.routes[route]]
+	if exists && bucket.remaining <= 0 && now.Before(bucket.reset) {
+		return bucket.reset
+	}
+	return time.Time{}
+}
+
+func (s *scheduler) grant(route string) {
+	if !globalExempt(route) {
+		s.windowCount++
+	}
+	if bucket, exists := s.buckets[s.routes[route]]; exists {
+		bucket.remaining--
+	}
+}
+
+// Update the rate limits from the headers of a response from the discord API
+func (s *scheduler) update(method, endpoint string, status int, header http.Header) {
+	route := routeKey(method, endpoint)
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if status == http.StatusTooManyRequests {
+		scope := "bucket"
+		if header.Get("X-RateLimit-Global") == "true" || header.Get("X-RateLimit-Scope") == "global" {
+			scope = "global"
+			s.globalReset = now.Add(parseSeconds(header.Get("Retry-After")))
+		}
+		rateLimitsHit.Inc(scope)
+	}
+	hash := header.Get("X-RateLimit-Bucket")
+	if hash == "" {
+		return
+	}
+	key := hash + ":" + majorParameter(route)
+	s.routes[route] = key
+	bucket, exists := s.buckets[key]
+	if !exists {
+		bucket = &rateBucket{remaining: 1}
+		s.buckets[key] = bucket
+	}
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		bucket.remaining = remaining
+	}
+	if resetAfter := header.Get("X-RateLimit-Reset-After"); resetAfter != "" {
+		bucket.reset = now.Add(parseSeconds(resetAfter))
+	}
+	s.dispatch()
+}
+
+// Reads the rate limit headers from responses to keep the scheduler up to
+// date. Discordgo still handles retrying requests that are rate limited
+type rateLimitTransport struct {
+	next      http.RoundTripper
+	scheduler *scheduler
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	t.scheduler.update(req.Method, req.URL.String(), resp.StatusCode, resp.Header)
+	return resp, nil
+}
+
+// Get the route used to look up the rate limit bucket for a request, e.g.
+// "PATCH channels/123/messages/{id}". IDs other than the major parameter are
+// replaced, as they share the bucket
+func routeKey(method, endpoint string) string {
+	path, _, _ := strings.Cut(strings.TrimPrefix(endpoint, discordgo.EndpointAPI), "?")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for idx := 1; idx < len(parts); idx++ {
+		switch {
+		case idx == 1 && isMajorResource(parts[0]):
+		case idx == 2 && parts[0] == "webhooks":
+			// webhook token is part of the major parameter
+		case idx == 2 && parts[0] == "interactions":
+			parts[idx] = "{token}"
+		case isSnowflake(parts[idx]):
+			parts[idx] = "{id}"
+		}
+	}
+	return method + " " + strings.Join(parts, "/")
+}
+
+// Get the major parameter of the route, which discord rate limits separately
+// even when routes share a bucket
+func majorParameter(route string) string {
+	_, path, _ := strings.Cut(route, " ")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || !isMajorResource(parts[0]) {
+		return ""
+	}
+	if parts[0] == "webhooks" && len(parts) > 2 {
+		return strings.Join(parts[:3], "/")
+	}
+	return strings.Join(parts[:2], "/")
+}
+
+func isMajorResource(resource string) bool {
+	return resource == "channels" || resource == "guilds" || resource == "webhooks"
+}
+
+// Interaction responses and follow ups are not bound by the global limit
+func globalExempt(route string) bool {
+	_, path, _ := strings.Cut(route, " ")
+	return strings.HasPrefix(path, "interactions/") || strings.HasPrefix(path, "webhooks/")
+}
+
+func isSnowflake(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+func parseSeconds(s string) time.Duration {
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteKey(t *testing.T) {
+	tests := []struct {
+		method   string
+		endpoint string
+		expected string
+	}{
+		{http.MethodPost, discordgo.EndpointChannelMessages("123"), "POST channels/123/messages"},
+		{http.MethodPatch, discordgo.EndpointChannelMessage("123", "456"),
+			"PATCH channels/123/messages/{id}"},
+		{http.MethodPut, discordgo.EndpointGuildMemberRole("1", "2", "3"),
+			"PUT guilds/1/members/{id}/roles/{id}"},
+		{http.MethodPost, discordgo.EndpointInteractionResponse("789", "token"),
+			"POST interactions/{id}/{token}/callback"},
+		{http.MethodPost, discordgo.EndpointWebhookToken("10", "token") + "?wait=true",
+			"POST webhooks/10/token"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, routeKey(tt.method, tt.endpoint), tt.endpoint)
+	}
+	assert.Equal(t, "channels/123", majorParameter("PATCH channels/123/messages/{id}"))
+	assert.Equal(t, "webhooks/10/token", majorParameter("POST webhooks/10/token"))
+	assert.Equal(t, "", majorParameter("POST users/@me/channels"))
+}
+
+func TestScheduler(t *testing.T) {
+	messages := discordgo.EndpointChannelMessages("123")
+	// Wait in the background, sending the priority on the channel once granted
+	waitAsync := func(s *scheduler, p Priority, endpoint string, granted chan Priority) {
+		go func() {
+			err := s.wait(t.Context(), p, http.MethodPost, endpoint)
+			assert.NoError(t, err)
+			granted <- p
+		}()
+		require.Eventually(t, func() bool { return s.depth(p) > 0 },
+			time.Second, time.Millisecond)
+	}
+
+	t.Run("Higher priority requests are granted first", func(t *testing.T) {
+		s := newScheduler(1, 100*time.Millisecond)
+		require.NoError(t, s.wait(t.Context(), PriorityNormal, http.MethodPost, messages))
+		granted := make(chan Priority, 3)
+		waitAsync(s, PriorityBulk, messages, granted)
+		waitAsync(s, PriorityNormal, messages, granted)
+		waitAsync(s, PriorityInteraction, messages, granted)
+		assert.Equal(t, PriorityInteraction, <-granted)
+		assert.Equal(t, PriorityNormal, <-granted)
+		assert.Equal(t, PriorityBulk, <-granted)
+	})
+
+	t.Run("Requests wait for their bucket to reset", func(t *testing.T) {
+		s := newScheduler(100, time.Second)
+		header := http.Header{}
+		header.Set("X-RateLimit-Bucket", "abc")
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Reset-After", "0.2")
+		s.update(http.MethodPost, messages, http.StatusOK, header)
+
+		start := time.Now()
+		// other channels are rate limited separately
+		other := discordgo.EndpointChannelMessages("456")
+		require.NoError(t, s.wait(t.Context(), PriorityNormal, http.MethodPost, other))
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+		require.NoError(t, s.wait(t.Context(), PriorityNormal, http.MethodPost, messages))
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	})
+
+	t.Run("Global rate limits hold back every route", func(t *testing.T) {
+		s := newScheduler(100, time.Second)
+		header := http.Header{}
+		header.Set("X-RateLimit-Global", "true")
+		header.Set("Retry-After", "0.2")
+		s.update(http.MethodPost, messages, http.StatusTooManyRequests, header)
+
+		start := time.Now()
+		// interaction responses aren't bound by the global limit
+		callback := discordgo.EndpointInteractionResponse("1", "token")
+		require.NoError(t, s.wait(t.Context(), PriorityInteraction, http.MethodPost, callback))
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+		other := discordgo.EndpointChannelMessages("456")
+		require.NoError(t, s.wait(t.Context(), PriorityNormal, http.MethodPost, other))
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	})
+
+	t.Run("Waiting requests can be cancelled", func(t *testing.T) {
+		s := newScheduler(1, time.Minute)
+		require.NoError(t, s.wait(t.Context(), PriorityNormal, http.MethodPost, messages))
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		err := s.wait(ctx, PriorityBulk, http.MethodPost, messages)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 0, s.depth(PriorityBulk))
+	})
+}
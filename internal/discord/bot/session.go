@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"io"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// The calls the bot makes to the discord API. Implemented by a live
+// discordgo session, or by the in-memory fake in discordtest for tests
+type Session interface {
+	// ID of the bot's user, only set once the session is open
+	UserID() string
+
+	AddHandler(handler any) func()
+	Open() error
+	Close() error
+	UpdateCustomStatus(state string) error
+
+	// Interactions
+	InteractionRespond(
+		interaction *discordgo.Interaction,
+		resp *discordgo.InteractionResponse,
+		options ...discordgo.RequestOption,
+	) error
+	InteractionResponseDelete(
+		interaction *discordgo.Interaction,
+		options ...discordgo.RequestOption,
+	) error
+	FollowupMessageCreate(
+		interaction *discordgo.Interaction,
+		wait bool,
+		data *discordgo.WebhookParams,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Message, error)
+	ApplicationCommands(
+		appID string,
+		guildID string,
+		options ...discordgo.RequestOption,
+	) ([]*discordgo.ApplicationCommand, error)
+	ApplicationCommandBulkOverwrite(
+		appID string,
+		guildID string,
+		commands []*discordgo.ApplicationCommand,
+		options ...discordgo.RequestOption,
+	) ([]*discordgo.ApplicationCommand, error)
+
+	// Messages
+	ChannelMessage(
+		channelID string,
+		messageID string,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Message, error)
+	ChannelMessageSendComplex(
+		channelID string,
+		data *discordgo.MessageSend,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Message, error)
+	ChannelMessageEditComplex(
+		m *discordgo.MessageEdit,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Message, error)
+	ChannelMessageDelete(
+		channelID string,
+		messageID string,
+		options ...discordgo.RequestOption,
+	) error
+	ChannelFileSendWithMessage(
+		channelID string,
+		content string,
+		name string,
+		r io.Reader,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Message, error)
+
+	// Channels and DMs
+	Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	ChannelEdit(
+		channelID string,
+		data *discordgo.ChannelEdit,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Channel, error)
+	ChannelDelete(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	GuildChannelCreate(
+		guildID string,
+		name string,
+		ctype discordgo.ChannelType,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Channel, error)
+	GuildChannelCreateComplex(
+		guildID string,
+		data discordgo.GuildChannelCreateData,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Channel, error)
+	UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+
+	// Guild members and roles
+	GuildMember(
+		guildID string,
+		userID string,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Member, error)
+	GuildMemberRoleAdd(
+		guildID string,
+		userID string,
+		roleID string,
+		options ...discordgo.RequestOption,
+	) error
+	GuildMemberRoleRemove(
+		guildID string,
+		userID string,
+		roleID string,
+		options ...discordgo.RequestOption,
+	) error
+	GuildRoles(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Role, error)
+	GuildRoleCreate(
+		guildID string,
+		data *discordgo.RoleParams,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Role, error)
+	GuildRoleEdit(
+		guildID string,
+		roleID string,
+		data *discordgo.RoleParams,
+		options ...discordgo.RequestOption,
+	) (*discordgo.Role, error)
+	GuildRoleDelete(guildID string, roleID string, options ...discordgo.RequestOption) error
+}
+
+// A live session with the discord API
+type liveSession struct {
+	*discordgo.Session
+}
+
+func (s *liveSession) UserID() string {
+	if s.State == nil || s.State.User == nil {
+		return ""
+	}
+	return s.State.User.ID
+}
+
+var _ Session = (*liveSession)(nil)
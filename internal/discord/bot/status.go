@@ -9,11 +9,14 @@ import (
 	"github.com/pkg/errors"
 )
 
-func (b *Bot) updateStatus() error {
-	queue, err := b.getPubsQueue()
+func (b *Bot) updateStatus(ctx context.Context) error {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	queue, err := b.getPubsQueue(timeout)
 	if err != nil {
 		return errors.Wrap(err, "b.getPubsQueue")
 	}
+	b.Health.QueuePolled()
 
 	msg := "In Queue: %v | In Match: %v"
 	msg = fmt.Sprintf(msg, queue.InQueue, queue.InMatch)
@@ -30,10 +33,10 @@ func (b *Bot) updateStatus() error {
 	return nil
 }
 
-func (b *Bot) getPubsQueue() (*slapshotapi.PubsQueue, error) {
-	queue, err := slapshotapi.GetQueueStatus(b.Config.SlapshotAPIKey, b.Config.SlapshotAPIEnv)
+func (b *Bot) getPubsQueue(ctx context.Context) (*slapshotapi.PubsQueue, error) {
+	queue, err := b.Slapshot.GetQueueStatus(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "slapshotapi.GetQueueStatus")
+		return nil, errors.Wrap(err, "b.Slapshot.GetQueueStatus")
 	}
 	return queue, nil
 }
@@ -56,7 +59,7 @@ func (b *Bot) StartWatchingQueue(ctx context.Context) {
 				b.Logger.Info().Msg("Stopping queue watch due to shutdown.")
 				return
 			case <-ticker.C:
-				err := b.updateStatus()
+				err := b.updateStatus(ctx)
 				if err != nil {
 					b.Logger.Error().Err(err).Msg("Error occured updating the queue status")
 				}
@@ -1,10 +1,31 @@
 package bot
 
 import (
+	"context"
 	"fmt"
+	"gosl/internal/models"
+	"gosl/pkg/db"
 	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
 )
 
+// Get the location of the timezone of the user that made the interaction,
+// used to read the dates they enter. Users that haven't set a timezone in
+// their player profile use the configured locale
+func (b *Bot) UserLocation(
+	ctx context.Context,
+	tx db.SafeTX,
+	i *discordgo.InteractionCreate,
+) (*time.Location, error) {
+	loc, err := models.GetPlayerLocation(ctx, tx, AuditActor(i).DiscordID, b.Config.Locale)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetPlayerLocation")
+	}
+	return loc, nil
+}
+
 // Format the time as a discord time stamp with type F (Full Date & Time)
 //
 // Friday, March 14, 2025 7:35 PM
@@ -0,0 +1,58 @@
+package adminchannel
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/backup"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"path/filepath"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Handle an interaction with the create backup button
+func handleCreateBackupInteraction(
+	ctx context.Context,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+) error {
+	b.Acknowledge(i, ack)
+	msgBackups, err := b.GetMessage(models.ChannelAdmin, models.MsgBackups)
+	if err != nil {
+		return errors.Wrap(err, "b.GetMessage")
+	}
+	if !msgBackups.StartUpdate(false) {
+		b.SlowDown(i, *ack)
+		return nil
+	}
+	err = b.FollowUp(b.T(i, "admin.backup.creating"), i)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUp")
+	}
+	// Spin off the backup so it doesnt hold up the interaction transaction,
+	// then update the message to show the new backup
+	go func() {
+		timeout, cancel := context.WithTimeout(ctx, b.Config.DBLockTimeout*time.Second)
+		defer cancel()
+		snapshot, err := backup.Create(timeout, b.Conn, b.Config, b.Logger)
+		if err != nil {
+			b.DoubleError("Failed to create database backup", err)
+		} else {
+			b.Log().UserEvent(i.Member,
+				fmt.Sprintf("Database backup created: %s", filepath.Base(snapshot.Path)))
+		}
+		errch := make(chan error)
+		go msgBackups.Update(ctx, errch)
+		for err := range errch {
+			if err != nil {
+				msg := "Failed to update message after interaction"
+				b.DoubleError(msg, err)
+			}
+		}
+	}()
+	return nil
+}
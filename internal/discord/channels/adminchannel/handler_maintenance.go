@@ -0,0 +1,139 @@
+package adminchannel
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/components"
+	"gosl/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Longest ETA that can be given for maintenance, in minutes (1 week)
+const maxMaintenanceETA = 10080
+
+// Handle an interaction with the start maintenance button
+func handleStartMaintenanceButtonInteraction(
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+) error {
+	modalComps := []discordgo.MessageComponent{
+		components.TextInput("maintenance_reason", b.T(i, "admin.maintenance.reason"),
+			false, "", 0, 200),
+		components.TextInput("maintenance_eta", b.T(i, "admin.maintenance.eta"),
+			false, "", 0, 5),
+	}
+	err := b.ReplyModal(b.T(i, "admin.maintenance.modal"), "start_maintenance_modal", modalComps, i)
+	if err != nil {
+		return errors.Wrap(err, "b.ReplyModal")
+	}
+	return nil
+}
+
+// Handle the start maintenance modal being submitted
+func handleStartMaintenanceModalInteraction(
+	ctx context.Context,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+) error {
+	b.Acknowledge(i, ack)
+	reason := strings.TrimSpace(i.ModalSubmitData().Components[0].(*discordgo.ActionsRow).
+		Components[0].(*discordgo.TextInput).Value)
+	etaInput := strings.TrimSpace(i.ModalSubmitData().Components[1].(*discordgo.ActionsRow).
+		Components[0].(*discordgo.TextInput).Value)
+	var eta *time.Time
+	if etaInput != "" {
+		minutes, err := strconv.Atoi(etaInput)
+		if err != nil || minutes < 1 || minutes > maxMaintenanceETA {
+			return b.Error(b.T(i, "admin.maintenance.invalid.title"),
+				b.T(i, "admin.maintenance.invalid.message", maxMaintenanceETA),
+				i, *ack)
+		}
+		end := time.Now().Add(time.Duration(minutes) * time.Minute)
+		eta = &end
+	}
+	msgMaintenance, err := b.GetMessage(models.ChannelAdmin, models.MsgMaintenance)
+	if err != nil {
+		return errors.Wrap(err, "b.GetMessage")
+	}
+	if !msgMaintenance.StartUpdate(false) {
+		b.SlowDown(i, *ack)
+		return nil
+	}
+	err = b.FollowUp(b.T(i, "admin.maintenance.starting"), i)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUp")
+	}
+	// Spin off starting maintenance as it waits for all open transactions,
+	// including the one for this interaction, to finish
+	go func() {
+		err := b.Maintenance.Start(reason, eta, i.Member.User.Username)
+		if err != nil {
+			b.DoubleError("Failed to start maintenance", err)
+		} else {
+			msg := "Maintenance started"
+			if reason != "" {
+				msg = msg + ": " + reason
+			}
+			b.Log().UserEvent(i.Member, msg)
+		}
+		updateMaintenanceMessage(ctx, b, msgMaintenance)
+	}()
+	return nil
+}
+
+// Handle an interaction with the end maintenance button. Called without a
+// transaction as the database is paused while in maintenance
+func handleEndMaintenanceInteraction(
+	ctx context.Context,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+) error {
+	if !b.Maintenance.CanEnd(i.Member) {
+		b.Forbidden(i, *ack)
+		return nil
+	}
+	b.Acknowledge(i, ack)
+	msgMaintenance, err := b.GetMessage(models.ChannelAdmin, models.MsgMaintenance)
+	if err != nil {
+		return errors.Wrap(err, "b.GetMessage")
+	}
+	if !msgMaintenance.StartUpdate(false) {
+		b.SlowDown(i, *ack)
+		return nil
+	}
+	err = b.Maintenance.End(i.Member.User.Username)
+	if err != nil {
+		go updateMaintenanceMessage(ctx, b, msgMaintenance)
+		if strings.HasPrefix(err.Error(), "VE:") {
+			return b.Error(b.T(i, "admin.maintenance.notactive"),
+				strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "b.Maintenance.End")
+	}
+	b.Log().UserEvent(i.Member, "Maintenance ended")
+	err = b.FollowUp(b.T(i, "admin.maintenance.ended"), i)
+	if err != nil {
+		b.Logger.Warn().Err(err).Msg("Failed to reply to interaction")
+	}
+	go updateMaintenanceMessage(ctx, b, msgMaintenance)
+	return nil
+}
+
+// Update the maintenance message. StartUpdate must be called first
+func updateMaintenanceMessage(ctx context.Context, b *bot.Bot, msg *bot.Message) {
+	errch := make(chan error)
+	go msg.Update(ctx, errch)
+	for err := range errch {
+		if err != nil {
+			msg := "Failed to update message after interaction"
+			b.DoubleError(msg, err)
+		}
+	}
+}
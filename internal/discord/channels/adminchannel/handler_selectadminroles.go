@@ -3,6 +3,7 @@ package adminchannel
 import (
 	"context"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/commands"
 	"gosl/internal/models"
 	"gosl/pkg/db"
 
@@ -28,7 +29,11 @@ func handleSelectAdminRolesInteraction(
 		return nil
 	}
 	roles := i.MessageComponentData().Values
-	err = models.SetRoles(ctx, tx, roles, models.PermAdmin)
+	previousRoles, err := models.GetRoles(ctx, tx, b.GuildID, models.PermAdmin)
+	if err != nil {
+		return errors.Wrap(err, "models.GetRoles")
+	}
+	err = models.SetRoles(ctx, tx, b.GuildID, roles, models.PermAdmin)
 	if err != nil {
 		return errors.Wrap(err, "setRolesForPermission (admin)")
 	}
@@ -37,7 +42,18 @@ func handleSelectAdminRolesInteraction(
 	for _, role := range roles {
 		msg = msg + " - " + droles[role].Name + "\n"
 	}
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditRolesSet,
+		Entity:   "roles",
+		EntityID: "admin",
+		Summary:  msg,
+	}, previousRoles, roles)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 	b.Log().UserEvent(i.Member, msg)
+	commands.SyncPermissions(ctx, b)
 	err = b.FollowUp(msg, i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
@@ -6,6 +6,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"strconv"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
@@ -31,7 +32,11 @@ func handleSelectChannelInteraction(
 		return nil
 	}
 	selectedChannel := i.MessageComponentData().Values[0]
-	err = models.SetChannel(ctx, tx, selectedChannel, purpose)
+	previousChannel, err := models.GetChannel(ctx, tx, b.GuildID, purpose)
+	if err != nil {
+		return errors.Wrap(err, "models.GetChannel")
+	}
+	err = models.SetChannel(ctx, tx, b.GuildID, selectedChannel, purpose)
 	if err != nil {
 		return errors.Wrap(err, "models.SetChannel")
 	}
@@ -42,6 +47,17 @@ func handleSelectChannelInteraction(
 	}
 	channelDiscord := i.MessageComponentData().Resolved.Channels[selectedChannel]
 	msg := fmt.Sprintf("%s updated to: %s", channel.Label, channelDiscord.Name)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditChannelSet,
+		Entity:   "channel",
+		EntityID: strconv.FormatUint(uint64(purpose), 10),
+		Summary:  msg,
+	}, map[string]string{"channel_id": previousChannel},
+		map[string]string{"channel_id": selectedChannel})
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 	b.Log().UserEvent(i.Member, msg)
 	err = b.FollowUp(msg, i)
 	if err != nil {
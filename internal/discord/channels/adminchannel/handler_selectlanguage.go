@@ -0,0 +1,74 @@
+package adminchannel
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Handle an interaction with the select default language component
+func handleSelectLanguageInteraction(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+) error {
+	b.Acknowledge(i, ack)
+	values := i.MessageComponentData().Values
+	if len(values) == 0 || !i18n.Supported(values[0]) {
+		return bot.ErrStaleInteraction
+	}
+	language := values[0]
+	previous := b.GuildLanguage()
+	msg := "**Default language updated to:** " + i18n.Name(language)
+	err := models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditLanguageSet,
+		Entity:   "guild",
+		EntityID: b.GuildID,
+		Summary:  msg,
+	}, map[string]string{"language": previous},
+		map[string]string{"language": language})
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	err = b.SetGuildLanguage(ctx, tx, language)
+	if err != nil {
+		return errors.Wrap(err, "b.SetGuildLanguage")
+	}
+	b.Log().UserEvent(i.Member, msg)
+	err = b.FollowUp(msg, i)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUp")
+	}
+	// Spin off updating the messages so it doesnt block/get blocked by the
+	// transaction and runs as soon as the interaction is completed. Every
+	// message is updated as they are shown in the default language
+	messages := []*bot.Message{}
+	for _, channel := range b.Channels {
+		for _, message := range channel.Messages {
+			if message.StartUpdate(true) {
+				messages = append(messages, message)
+			}
+		}
+	}
+	go func() {
+		errch := make(chan error)
+		for _, message := range messages {
+			go message.Update(ctx, errch)
+		}
+		for err := range errch {
+			if err != nil {
+				msg := "Failed to update message after interaction"
+				b.DoubleError(msg, err)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,78 @@
+package adminchannel
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Handle an interaction with one of the select league role components
+func handleSelectLeagueRoleInteraction(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+	league string,
+) error {
+	b.Acknowledge(i, ack)
+	msgSelectLeagueRoles, err := b.GetMessage(models.ChannelAdmin, models.MsgSelectLeagueRoles)
+	if err != nil {
+		return errors.Wrap(err, "b.GetMessage")
+	}
+	if !msgSelectLeagueRoles.StartUpdate(false) {
+		b.SlowDown(i, *ack)
+		return nil
+	}
+	roleID := ""
+	roleName := "None"
+	if values := i.MessageComponentData().Values; len(values) > 0 {
+		roleID = values[0]
+		roleName = i.MessageComponentData().Resolved.Roles[roleID].Name
+	}
+	previousRoles, err := models.GetLeagueRoles(ctx, tx, b.GuildID)
+	if err != nil {
+		return errors.Wrap(err, "models.GetLeagueRoles")
+	}
+	err = models.SetLeagueRole(ctx, tx, b.GuildID, league, roleID)
+	if err != nil {
+		return errors.Wrap(err, "models.SetLeagueRole")
+	}
+	msg := "**" + league + " role updated to:** " + roleName
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditLeagueRoleSet,
+		Entity:   "league_role",
+		EntityID: league,
+		Summary:  msg,
+	}, map[string]string{"role_id": previousRoles[league]},
+		map[string]string{"role_id": roleID})
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	b.Log().UserEvent(i.Member, msg)
+	err = b.FollowUp(msg, i)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUp")
+	}
+	leagueroles.Sync(ctx, b)
+	// Spin off updating the message so it doesnt block/get blocked by the transaction
+	// and runs as soon as the interaction is completed
+	go func() {
+		errch := make(chan error)
+		b.Logger.Debug().Msg("Updating league roles select")
+		go msgSelectLeagueRoles.Update(ctx, errch)
+		for err := range errch {
+			if err != nil {
+				msg := "Failed to update message after interaction"
+				b.DoubleError(msg, err)
+			}
+		}
+	}()
+	return nil
+}
@@ -4,69 +4,58 @@ import (
 	"context"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
-	"time"
-
-	"github.com/bwmarrin/discordgo"
-	"github.com/pkg/errors"
 )
 
 func handleInteractions(ctx context.Context, b *bot.Bot) bot.Handler {
-	b.Logger.Debug().Msg("Adding handler for admin channel interactions")
-	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if i.Type == discordgo.InteractionApplicationCommand {
-			return
-		}
-		if i.Message.ChannelID != b.Channels[models.ChannelAdmin].ID {
-			return
-		}
-		ack := false
-		if i.Type == discordgo.InteractionMessageComponent {
-			timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
-			defer cancel()
-			tx, err := b.Conn.Begin(timeout, "Admin channel interaction handler")
-			msg := "Failed to handle interaction in admin channel"
-			if err != nil {
-				b.TripleError(msg, err, i, ack)
-				return
-			}
-			defer tx.Rollback()
-			b.Logger.Debug().Msg("Handling admin channel interaction")
-			isAdmin, err := models.MemberHasPermission(
-				ctx, tx, s, b.Config.DiscordGuildID, i.Member, models.PermAdmin)
-			if !isAdmin {
-				b.Forbidden(i, ack)
-				return
-			}
+	router := bot.NewRouter(b, "Admin channel", bot.InChannel(b, models.ChannelAdmin))
+	// Ending maintenance can't use the database as it's paused, so it checks
+	// the member can end it itself
+	router.Component("end_maintenance_button", func(r *bot.Request) error {
+		return handleEndMaintenanceInteraction(r.Ctx, b, r.I, &r.Ack)
+	})
 
-			// Handle select menu interactions
-			customID := i.MessageComponentData().CustomID
-			b.Logger.Debug().Str("custom_id", customID).Msg("Handling interaction")
-			switch customID {
-			case "log_channel_select":
-				err = handleSelectChannelInteraction(ctx, tx, b, i, &ack, models.ChannelLog)
-			case "admin_role_select":
-				err = handleSelectAdminRolesInteraction(ctx, tx, b, i, &ack)
-			case "manager_role_select":
-				err = handleSelectManagerRolesInteraction(ctx, tx, b, i, &ack)
-			case "registration_channel_select":
-				err = handleSelectChannelInteraction(ctx, tx, b, i, &ack, models.ChannelRegistration)
-			case "team_application_channel_select":
-				err = handleSelectChannelInteraction(ctx, tx, b, i, &ack, models.ChannelTeamApplications)
-			case "team_rosters_channel_select":
-				err = handleSelectChannelInteraction(ctx, tx, b, i, &ack, models.ChannelTeamRosters)
-			case "freeagent_application_channel_select":
-				err = handleSelectChannelInteraction(ctx, tx, b, i, &ack, models.ChannelFreeAgentApplications)
-			case "transfer_approval_channel_select":
-				err = handleSelectChannelInteraction(ctx, tx, b, i, &ack, models.ChannelTransferApprovals)
-			default:
-				err = errors.New("No handler for interaction")
-			}
-			if err != nil {
-				msg := "Failed to handle interaction"
-				b.TripleError(msg, err, i, ack)
-				return
-			}
-			tx.Commit()
+	r := router.With(
+		bot.MaintenanceGate(b),
+		bot.Transaction(b),
+		bot.RequirePermission(b, models.PermAdmin),
+	)
+	selectChannel := func(purpose uint16) bot.RouteFunc {
+		return func(r *bot.Request) error {
+			return handleSelectChannelInteraction(r.Ctx, r.Tx, b, r.I, &r.Ack, purpose)
 		}
 	}
+	r.Component("log_channel_select", selectChannel(models.ChannelLog))
+	r.Component("registration_channel_select", selectChannel(models.ChannelRegistration))
+	r.Component("team_application_channel_select", selectChannel(models.ChannelTeamApplications))
+	r.Component("team_rosters_channel_select", selectChannel(models.ChannelTeamRosters))
+	r.Component("freeagent_application_channel_select",
+		selectChannel(models.ChannelFreeAgentApplications))
+	r.Component("transfer_approval_channel_select", selectChannel(models.ChannelTransferApprovals))
+	r.Component("admin_role_select", func(r *bot.Request) error {
+		return handleSelectAdminRolesInteraction(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("manager_role_select", func(r *bot.Request) error {
+		return handleSelectManagerRolesInteraction(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("league_role_select_{league}", func(r *bot.Request) error {
+		league := r.Params.String("league")
+		switch league {
+		case "Pro", "IM", "Open", models.LeagueRoleFreeAgent:
+			return handleSelectLeagueRoleInteraction(r.Ctx, r.Tx, b, r.I, &r.Ack, league)
+		}
+		return bot.ErrStaleInteraction
+	})
+	r.Component("language_select", func(r *bot.Request) error {
+		return handleSelectLanguageInteraction(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("create_backup_button", func(r *bot.Request) error {
+		return handleCreateBackupInteraction(r.Ctx, b, r.I, &r.Ack)
+	})
+	r.Component("start_maintenance_button", func(r *bot.Request) error {
+		return handleStartMaintenanceButtonInteraction(b, r.I)
+	})
+	r.Modal("start_maintenance_modal", func(r *bot.Request) error {
+		return handleStartMaintenanceModalInteraction(r.Ctx, b, r.I, &r.Ack)
+	})
+	return router.Handler(ctx)
 }
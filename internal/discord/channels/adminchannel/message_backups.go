@@ -0,0 +1,77 @@
+package adminchannel
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/backup"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"path/filepath"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Max number of snapshots listed in the backups message
+const maxBackupsListed = 5
+
+var backups = &bot.Message{
+	Label:       "Database Backups",
+	Purpose:     models.MsgBackups,
+	GetContents: backupsContents,
+}
+
+// Get the message contents for the database backups message
+func backupsContents(
+	ctx context.Context,
+	b *bot.Bot,
+) (*bot.MessageContents, error) {
+	b.Logger.Debug().Msg("Setting up database backups message")
+	snapshots, err := backup.List(b.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, "backup.List")
+	}
+	schedule := "Scheduled backups are disabled"
+	if b.Config.BackupInterval > 0 {
+		schedule = fmt.Sprintf("A backup is taken every %v hours, keeping the latest %v",
+			int(b.Config.BackupInterval), b.Config.BackupRetention)
+	}
+	recent := ""
+	for idx, snapshot := range snapshots {
+		if idx == maxBackupsListed {
+			break
+		}
+		recent = recent + fmt.Sprintf("`%s` - %s\n",
+			filepath.Base(snapshot.Path), bot.DiscordDateTime(&snapshot.Taken))
+	}
+	if recent == "" {
+		recent = "No backups yet"
+	}
+	contents := &bot.MessageContents{
+		Embed: &discordgo.MessageEmbed{
+			Title: "Database backups",
+			Description: fmt.Sprintf(`%s.
+Backups can be restored by running gosl with the **-restore** flag while the bot is stopped`,
+				schedule),
+			Fields: []*discordgo.MessageEmbedField{
+				{
+					Name:   fmt.Sprintf("Recent backups (%v total)", len(snapshots)),
+					Value:  recent,
+					Inline: false,
+				},
+			},
+			Color: 0x00ff00, // Green color
+		},
+		Components: []discordgo.MessageComponent{
+			&discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					&discordgo.Button{
+						CustomID: "create_backup_button",
+						Label:    "Create Backup",
+					},
+				},
+			},
+		},
+	}
+	return contents, nil
+}
@@ -0,0 +1,81 @@
+package adminchannel
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var maintenance = &bot.Message{
+	Label:       "Maintenance Mode",
+	Purpose:     models.MsgMaintenance,
+	GetContents: maintenanceContents,
+}
+
+// Get the message contents for the maintenance mode message. Doesn't use the
+// database so it can be updated while in maintenance
+func maintenanceContents(
+	ctx context.Context,
+	b *bot.Bot,
+) (*bot.MessageContents, error) {
+	b.Logger.Debug().Msg("Setting up maintenance mode message")
+	info := b.Maintenance.Info()
+	status := "Inactive"
+	color := 0x00ff00 // Green color
+	if info.Active {
+		status = "Active"
+		color = 0xffa500 // Orange color
+	}
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Status", Value: status, Inline: true},
+	}
+	if info.Active {
+		reason := info.Reason
+		if reason == "" {
+			reason = "No reason given"
+		}
+		fields = append(fields,
+			&discordgo.MessageEmbedField{
+				Name:   "Started",
+				Value:  bot.DiscordDateTime(info.Started),
+				Inline: true,
+			},
+			&discordgo.MessageEmbedField{
+				Name:   "Expected back",
+				Value:  bot.DiscordDateTimeUntil(info.ETA),
+				Inline: true,
+			},
+			&discordgo.MessageEmbedField{Name: "Reason", Value: reason},
+		)
+	}
+	contents := &bot.MessageContents{
+		Embed: &discordgo.MessageEmbed{
+			Title: "Maintenance mode",
+			Description: `While active the database is locked, the website shows a maintenance notice and the bot replies to users that the league system is under maintenance.
+Starting waits for any open transactions to finish`,
+			Fields: fields,
+			Color:  color,
+		},
+		Components: []discordgo.MessageComponent{
+			&discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					&discordgo.Button{
+						CustomID: "start_maintenance_button",
+						Label:    "Start Maintenance",
+						Style:    discordgo.DangerButton,
+						Disabled: info.Active,
+					},
+					&discordgo.Button{
+						CustomID: "end_maintenance_button",
+						Label:    "End Maintenance",
+						Style:    discordgo.SuccessButton,
+						Disabled: !info.Active,
+					},
+				},
+			},
+		},
+	}
+	return contents, nil
+}
@@ -31,23 +31,23 @@ func selectChannelsContents(
 	}
 	defer tx.Rollback()
 	b.Logger.Debug().Msg("Getting default values for select channel components")
-	regChannelID, err := models.GetChannel(ctx, tx, models.ChannelRegistration)
+	regChannelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelRegistration)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetChannel")
 	}
-	teamAppChannelID, err := models.GetChannel(ctx, tx, models.ChannelTeamApplications)
+	teamAppChannelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelTeamApplications)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetChannel")
 	}
-	freeAgentAppChannelID, err := models.GetChannel(ctx, tx, models.ChannelFreeAgentApplications)
+	freeAgentAppChannelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelFreeAgentApplications)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetChannel")
 	}
-	teamRostersChannelID, err := models.GetChannel(ctx, tx, models.ChannelTeamRosters)
+	teamRostersChannelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelTeamRosters)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetChannel")
 	}
-	transferApprovalsChannelID, err := models.GetChannel(ctx, tx, models.ChannelTransferApprovals)
+	transferApprovalsChannelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelTransferApprovals)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetChannel")
 	}
@@ -0,0 +1,52 @@
+package adminchannel
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/components"
+	"gosl/internal/models"
+	"gosl/pkg/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var selectLanguage = &bot.Message{
+	Label:       "Select Language",
+	Purpose:     models.MsgSelectLanguage,
+	GetContents: selectLanguageContents,
+}
+
+// Get the message contents for the select default language component
+func selectLanguageContents(
+	ctx context.Context,
+	b *bot.Bot,
+) (*bot.MessageContents, error) {
+	b.Logger.Debug().Msg("Setting up select language components")
+	current := b.GuildLanguage()
+	options := []discordgo.SelectMenuOption{}
+	for _, lang := range i18n.Languages() {
+		options = append(options, discordgo.SelectMenuOption{
+			Label:   i18n.Name(lang),
+			Value:   lang,
+			Default: lang == current,
+		})
+	}
+	contents := &bot.MessageContents{
+		Embed: &discordgo.MessageEmbed{
+			Title: "Default language",
+			Description: `Select the language used for messages in the server's channels, ` +
+				`and for replies to users whose discord client is set to a language ` +
+				`the bot doesn't support. Users can choose their own language with /language`,
+			Color: 0x00ff00, // Green color
+		},
+		Components: components.StringSelect(
+			"language_select",
+			"Select the default language",
+			options,
+			1,
+			1,
+			false,
+		),
+	}
+	return contents, nil
+}
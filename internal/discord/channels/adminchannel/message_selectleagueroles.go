@@ -0,0 +1,84 @@
+package adminchannel
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/components"
+	"gosl/internal/models"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+var selectLeagueRoles = &bot.Message{
+	Label:       "Select League Roles",
+	Purpose:     models.MsgSelectLeagueRoles,
+	GetContents: selectLeagueRolesContents,
+}
+
+// Get the message contents for the select league roles message
+func selectLeagueRolesContents(
+	ctx context.Context,
+	b *bot.Bot,
+) (*bot.MessageContents, error) {
+	b.Logger.Debug().Msg("Setting up select league roles message")
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.Begin(timeout, "selectLeagueRolesContents()")
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	leagueRoles, err := models.GetLeagueRoles(ctx, tx, b.GuildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetLeagueRoles")
+	}
+	tx.Commit()
+
+	selects := []struct {
+		league      string
+		placeholder string
+	}{
+		{"Pro", "Select Pro league role"},
+		{"IM", "Select IM league role"},
+		{"Open", "Select Open league role"},
+		{models.LeagueRoleFreeAgent, "Select Free Agent role"},
+	}
+	msgcomps := []discordgo.MessageComponent{}
+	for _, sel := range selects {
+		var defaults []discordgo.SelectMenuDefaultValue
+		if roleID, exists := leagueRoles[sel.league]; exists {
+			defaults = append(defaults, discordgo.SelectMenuDefaultValue{
+				ID:   roleID,
+				Type: discordgo.SelectMenuDefaultValueRole,
+			})
+		}
+		msgcomps = append(msgcomps, components.RoleSelect(
+			"league_role_select_"+sel.league,
+			sel.placeholder,
+			defaults,
+			0,
+			1,
+		)...)
+	}
+	contents := &bot.MessageContents{
+		Embed: &discordgo.MessageEmbed{
+			Title: "League role select",
+			Description: `
+**League Roles**
+Select the role given to players in each league.
+Players on a placed team or placed as a free agent will be given the role for their league.
+
+**Free Agent Role**
+Select the role given to players placed as a free agent.
+
+*Roles are removed when the season ends or the player leaves their team*
+`,
+
+			Color: 0x00ff00, // Green color
+		},
+		Components: msgcomps,
+	}
+	return contents, nil
+}
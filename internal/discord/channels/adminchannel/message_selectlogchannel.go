@@ -31,7 +31,7 @@ func selectLogChannelContents(
 	}
 	defer tx.Rollback()
 	b.Logger.Debug().Msg("Getting default values for select log channel components")
-	logChannelID, err := models.GetChannel(ctx, tx, models.ChannelLog)
+	logChannelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelLog)
 	if err != nil {
 		return nil, errors.Wrap(err, "getChannelForPurpose")
 	}
@@ -31,11 +31,11 @@ func selectRolesContents(
 	}
 	defer tx.Rollback()
 	b.Logger.Debug().Msg("Getting default values for select roles components")
-	adminroles, err := models.GetRoles(ctx, tx, models.PermAdmin)
+	adminroles, err := models.GetRoles(ctx, tx, b.GuildID, models.PermAdmin)
 	if err != nil {
 		return nil, errors.Wrap(err, "getRolesWithPermission")
 	}
-	managerroles, err := models.GetRoles(ctx, tx, models.PermLeagueManager)
+	managerroles, err := models.GetRoles(ctx, tx, b.GuildID, models.PermLeagueManager)
 	if err != nil {
 		return nil, errors.Wrap(err, "getRolesWithPermission")
 	}
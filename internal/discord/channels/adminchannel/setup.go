@@ -39,6 +39,10 @@ func Setup(
 	errs = append(errs, channel.RegisterMessage(selectLogChannel))
 	errs = append(errs, channel.RegisterMessage(selectRoles))
 	errs = append(errs, channel.RegisterMessage(selectChannels))
+	errs = append(errs, channel.RegisterMessage(selectLeagueRoles))
+	errs = append(errs, channel.RegisterMessage(selectLanguage))
+	errs = append(errs, channel.RegisterMessage(backups))
+	errs = append(errs, channel.RegisterMessage(maintenance))
 
 	// check for any errors setting up messages and return if any occured
 	hadErr := false
@@ -19,26 +19,34 @@ func handleApproveFreeAgentApplication(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	appIDstr string,
+	appID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	appID, err := strconv.ParseUint(appIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
-	app, err := models.GetFreeAgentRegistration(ctx, tx, uint32(appID))
+	app, err := models.GetFreeAgentRegistration(ctx, tx, appID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetFreeAgentRegistration")
 	}
+	before := *app
 	err = app.Approve(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "app.Approve")
 	}
+	msg := fmt.Sprintf("Application from %s approved", app.PlayerName)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditFreeAgentApproved,
+		Entity:   "free_agent_registration",
+		EntityID: strconv.FormatUint(uint64(appID), 10),
+		PlayerID: &app.PlayerID,
+		Summary:  msg,
+	}, before, app)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 	player, err := models.GetPlayerByID(ctx, tx, app.PlayerID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByID")
 	}
-	err = b.SendDirectMessage("Free Agent Application Approved",
+	err = b.SendDirectMessage(ctx, "Free Agent Application Approved",
 		fmt.Sprintf("Your application to play in %s as a Free Agent has been approved",
 			app.SeasonName),
 		player.DiscordID,
@@ -55,7 +63,6 @@ func handleApproveFreeAgentApplication(
 		return errors.Wrap(err, "teamrosters.UpdateTeamRosters")
 	}
 
-	msg := fmt.Sprintf("Application from %s approved", app.PlayerName)
 	b.Log().UserEvent(i.Member, msg)
 	return b.FollowUp(msg, i)
 }
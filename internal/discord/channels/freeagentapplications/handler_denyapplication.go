@@ -18,26 +18,34 @@ func handleRejectFreeAgentApplication(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	appIDstr string,
+	appID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	appID, err := strconv.ParseUint(appIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
-	app, err := models.GetFreeAgentRegistration(ctx, tx, uint32(appID))
+	app, err := models.GetFreeAgentRegistration(ctx, tx, appID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetFreeAgentRegistration")
 	}
+	before := *app
 	err = app.Reject(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "app.Reject")
 	}
+	msg := fmt.Sprintf("Application from %s rejected", app.PlayerName)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditFreeAgentDenied,
+		Entity:   "free_agent_registration",
+		EntityID: strconv.FormatUint(uint64(appID), 10),
+		PlayerID: &app.PlayerID,
+		Summary:  msg,
+	}, before, app)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 	player, err := models.GetPlayerByID(ctx, tx, app.PlayerID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByID")
 	}
-	err = b.SendDirectMessage("Free Agent Application Rejected",
+	err = b.SendDirectMessage(ctx, "Free Agent Application Rejected",
 		fmt.Sprintf("Your application to play in %s as a Free Agent has been rejected",
 			app.SeasonName),
 		player.DiscordID,
@@ -50,7 +58,6 @@ func handleRejectFreeAgentApplication(
 	if err != nil {
 		return errors.Wrap(err, "updateAppMsg")
 	}
-	msg := fmt.Sprintf("Application from %s rejected", app.PlayerName)
 	b.Log().UserEvent(i.Member, msg)
 	return b.FollowUp(msg, i)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/channels/teamrosters"
+	"gosl/internal/discord/leagueroles"
 	"gosl/internal/models"
 	"gosl/pkg/db"
 	"strconv"
@@ -20,14 +21,9 @@ func handlePlaceFreeAgentLeagueSelect(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	appIDstr string,
+	appID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	appID, err := strconv.ParseUint(appIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
-	app, err := models.GetFreeAgentRegistration(ctx, tx, uint32(appID))
+	app, err := models.GetFreeAgentRegistration(ctx, tx, appID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetFreeAgentRegistration")
 	}
@@ -40,6 +36,7 @@ func handlePlaceFreeAgentLeagueSelect(
 		return errors.Wrap(err, "strconv.ParseUint")
 	}
 
+	before := *app
 	err = app.Place(ctx, tx, uint16(leagueID))
 	if err != nil {
 		if strings.Contains(err.Error(), "VE:") {
@@ -50,12 +47,24 @@ func handlePlaceFreeAgentLeagueSelect(
 	}
 	msg := fmt.Sprintf("%s has been placed in %s for %s",
 		app.PlayerName, app.PlacedLeagueName, app.SeasonName)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditFreeAgentPlaced,
+		Entity:   "free_agent_registration",
+		EntityID: strconv.FormatUint(uint64(appID), 10),
+		PlayerID: &app.PlayerID,
+		Summary:  msg,
+	}, before, app)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	leagueroles.Sync(ctx, b)
 
 	player, err := models.GetPlayerByID(ctx, tx, app.PlayerID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByID")
 	}
-	err = b.SendDirectMessage("Free Agent Application Approved", msg, player.DiscordID)
+	err = b.SendDirectMessage(ctx, "Free Agent Application Approved", msg, player.DiscordID)
 	if err != nil {
 		return errors.Wrap(err, "b.SendDirectMessage")
 	}
@@ -21,7 +21,7 @@ func NewFreeAgentApplicationMsg(ctx context.Context, b *bot.Bot) (*bot.DynamicMe
 		return nil, errors.Wrap(err, "b.Conn.RBegin")
 	}
 	defer tx.Rollback()
-	channelID, err := models.GetChannel(ctx, tx, models.ChannelFreeAgentApplications)
+	channelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelFreeAgentApplications)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetChannel")
 	}
@@ -115,7 +115,7 @@ func updateAppMsg(
 	app *models.FreeAgentRegistration,
 	locked bool,
 ) error {
-	appMsg, err := b.GetDynamicMessage("Free Agent application", i.Message.ID, i.ChannelID)
+	appMsg, err := b.GetDynamicMessage(ctx, tx, "Free Agent application", i.Message.ID, i.ChannelID)
 	if err != nil {
 		return errors.Wrap(err, "b.GetDynamicMessage")
 	}
@@ -124,12 +124,12 @@ func updateAppMsg(
 		return errors.Wrap(err, "FreeAgentApplicationContents")
 	}
 	if locked {
-		err = appMsg.Expire(contents)
+		err = appMsg.Expire(ctx, tx, contents)
 		if err != nil {
 			return errors.Wrap(err, "appMsg.Expire")
 		}
 	} else {
-		err = appMsg.Update(contents)
+		err = appMsg.Update(ctx, contents)
 		if err != nil {
 			return errors.Wrap(err, "appMsg.Update")
 		}
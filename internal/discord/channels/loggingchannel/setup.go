@@ -5,8 +5,11 @@ import (
 	"gosl/internal/models"
 )
 
-var LogChannel = &bot.Channel{
-	Purpose: models.ChannelLog,
-	Name:    "gosl-bot-log",
-	Label:   "Log channel",
+// Create the log channel. Each guild's bot needs its own channel
+func LogChannel() *bot.Channel {
+	return &bot.Channel{
+		Purpose: models.ChannelLog,
+		Name:    "gosl-bot-log",
+		Label:   "Log channel",
+	}
 }
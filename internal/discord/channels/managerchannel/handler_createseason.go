@@ -17,10 +17,10 @@ func handleCreateSeasonButtonInteraction(
 	i *discordgo.InteractionCreate,
 ) error {
 	modalComps := []discordgo.MessageComponent{
-		components.TextInput("season_id", "Season ID", true, "", 1, 5),
-		components.TextInput("season_name", "Season Name", true, "", 1, 32),
+		components.TextInput("season_id", b.T(i, "seasons.create.id"), true, "", 1, 5),
+		components.TextInput("season_name", b.T(i, "seasons.create.name"), true, "", 1, 32),
 	}
-	err := b.ReplyModal("Create Season", "create_season_modal", modalComps, i)
+	err := b.ReplyModal(b.T(i, "seasons.create.title"), "create_season_modal", modalComps, i)
 	if err != nil {
 		return errors.Wrap(err, "bot.ReplyModal")
 	}
@@ -45,16 +45,26 @@ func handleCreateSeasonModalInteraction(
 	seasonName := i.ModalSubmitData().Components[1].(*discordgo.ActionsRow).
 		Components[0].(*discordgo.TextInput).Value
 
-	season, err := models.CreateSeason(ctx, tx, seasonID, seasonName)
+	season, err := models.CreateSeason(ctx, tx, b.GuildID, seasonID, seasonName)
 	if err != nil {
 		if strings.Contains(err.Error(), "must be unique") {
-			return b.Error("Error creating season", err.Error(), i, true)
+			return b.Error(b.T(i, "seasons.create.failed"), err.Error(), i, true)
 		}
 		return errors.Wrap(err, "models.CreateSeason")
 	}
 	msg := "New Season created: " + season.Name
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditSeasonCreated,
+		Entity:   "season",
+		EntityID: season.ID,
+		Summary:  msg,
+	}, nil, season)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 	b.Log().UserEvent(i.Member, msg)
-	err = b.FollowUp(msg, i)
+	err = b.FollowUp(b.T(i, "seasons.created", season.Name), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
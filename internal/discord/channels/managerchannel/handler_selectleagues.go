@@ -6,6 +6,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
@@ -28,23 +29,40 @@ func handleSelectLeaguesInteraction(
 		return nil
 	}
 	b.Logger.Debug().Msg("Getting active season")
-	season, err := models.GetActiveSeason(ctx, tx)
+	season, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetActiveSeason")
 	}
 	leagues := i.MessageComponentData().Values
+	previous, err := models.GetLeagues(ctx, tx, season.ID, true)
+	if err != nil {
+		return errors.Wrap(err, "models.GetLeagues")
+	}
+	before := []string{}
+	for _, league := range *previous {
+		before = append(before, league.Division)
+	}
 	err = models.SetLeagues(ctx, tx, season.ID, leagues)
 	if err != nil {
 		return errors.Wrap(err, "models.SetLeagues")
 	}
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditSeasonLeaguesSet,
+		Entity:   "season",
+		EntityID: season.ID,
+		Summary:  "Leagues updated for " + season.Name,
+	}, map[string][]string{"leagues": before}, map[string][]string{"leagues": leagues})
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 
-	msg := "Leagues updated for %s:\n"
+	list := ""
 	for _, league := range leagues {
-		msg = msg + " - " + league + "\n"
+		list = list + " - " + league + "\n"
 	}
-	msg = fmt.Sprintf(msg, season.Name)
-	b.Log().UserEvent(i.Member, msg)
-	err = b.FollowUp(msg, i)
+	b.Log().UserEvent(i.Member, fmt.Sprintf("Leagues updated for %s:\n%s", season.Name, list))
+	err = b.FollowUp(b.T(i, "seasons.leagues.updated", season.Name)+"\n"+list, i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
@@ -66,20 +84,21 @@ func handleSelectLeaguesInteraction(
 }
 
 func getLeagueOptions(
+	lang string,
 	leagues *[]models.League,
 ) ([]discordgo.SelectMenuOption, error) {
 	allLeagues := map[string]*discordgo.SelectMenuOption{
 
 		"Open": {
-			Label: "Open",
+			Label: i18n.T(lang, "league.open"),
 			Value: "Open",
 		},
 		"IM": {
-			Label: "Intermediate",
+			Label: i18n.T(lang, "league.im"),
 			Value: "IM",
 		},
 		"Pro": {
-			Label: "Pro",
+			Label: i18n.T(lang, "league.pro"),
 			Value: "Pro",
 		},
 	}
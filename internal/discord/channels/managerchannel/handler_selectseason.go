@@ -3,6 +3,8 @@ package managerchannel
 import (
 	"context"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/models"
 	"gosl/pkg/db"
 
@@ -46,14 +48,33 @@ func handleSelectSeasonInteraction(
 		return nil
 	}
 	season := i.MessageComponentData().Values[0]
-	err = models.SetActiveSeason(ctx, tx, season)
+	previous, err := models.GetActiveSeason(ctx, tx, b.GuildID)
+	if err != nil {
+		return errors.Wrap(err, "models.GetActiveSeason")
+	}
+	err = models.SetActiveSeason(ctx, tx, b.GuildID, season)
 	if err != nil {
 		return errors.Wrap(err, "models.SetActiveSeason")
 	}
+	var before any
+	if previous != nil {
+		before = map[string]string{"active_season": previous.ID}
+	}
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditSeasonActivated,
+		Entity:   "season",
+		EntityID: season,
+		Summary:  "Active season set to: " + season,
+	}, before, map[string]string{"active_season": season})
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	leagueroles.Sync(ctx, b)
+	teamdiscord.SyncAll(ctx, b)
 
-	msg := "Active season set to: " + season
-	b.Log().UserEvent(i.Member, msg)
-	err = b.FollowUp(msg, i)
+	b.Log().UserEvent(i.Member, "Active season set to: "+season)
+	err = b.FollowUp(b.T(i, "seasons.activated", season), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
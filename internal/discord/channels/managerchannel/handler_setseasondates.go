@@ -17,19 +17,24 @@ func handleSetSeasonDatesButtonInteraction(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 ) error {
-	season, err := models.GetActiveSeason(ctx, tx)
+	season, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetActiveSeason")
 	}
-	startDate := models.DateStr(season.Start)
-	endDate := models.DateStr(season.RegSeasonEnd)
-	finalsDate := models.DateStr(season.FinalsEnd)
+	// Dates are shown and entered in the manager's timezone
+	loc, err := b.UserLocation(ctx, tx, i)
+	if err != nil {
+		return errors.Wrap(err, "b.UserLocation")
+	}
+	startDate := models.DateStrIn(season.Start, loc)
+	endDate := models.DateStrIn(season.RegSeasonEnd, loc)
+	finalsDate := models.DateStrIn(season.FinalsEnd, loc)
 	components := []discordgo.MessageComponent{
 		&discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				&discordgo.TextInput{
 					CustomID:    "season_start_date",
-					Label:       "Start Date (DD/MM/YYYY)",
+					Label:       b.T(i, "seasons.dates.start"),
 					Style:       discordgo.TextInputShort,
 					Placeholder: "DD/MM/YYYY",
 					Required:    false,
@@ -41,7 +46,7 @@ func handleSetSeasonDatesButtonInteraction(
 			Components: []discordgo.MessageComponent{
 				&discordgo.TextInput{
 					CustomID:    "season_regend_date",
-					Label:       "Regulation End Date (DD/MM/YYYY)",
+					Label:       b.T(i, "seasons.dates.regend"),
 					Style:       discordgo.TextInputShort,
 					Placeholder: "DD/MM/YYYY",
 					Required:    false,
@@ -53,7 +58,7 @@ func handleSetSeasonDatesButtonInteraction(
 			Components: []discordgo.MessageComponent{
 				&discordgo.TextInput{
 					CustomID:    "season_finalsend_date",
-					Label:       "Finals End Date (DD/MM/YYYY)",
+					Label:       b.T(i, "seasons.dates.finalsend"),
 					Style:       discordgo.TextInputShort,
 					Placeholder: "DD/MM/YYYY",
 					Required:    false,
@@ -62,7 +67,12 @@ func handleSetSeasonDatesButtonInteraction(
 			},
 		},
 	}
-	err = b.ReplyModal("Set Season Dates", "set_season_dates_modal", components, i)
+	title := b.T(i, "seasons.dates.title")
+	// modal titles are limited to 45 characters
+	if withTZ := fmt.Sprintf("%s (%s)", title, loc); len(withTZ) <= 45 {
+		title = withTZ
+	}
+	err = b.ReplyModal(title, "set_season_dates_modal", components, i)
 	if err != nil {
 		return errors.Wrap(err, "messages.ReplyModal")
 	}
@@ -91,11 +101,16 @@ func handleSetSeasonDatesModalInteraction(
 		Components[0].(*discordgo.TextInput).Value
 	finalsEndDate := i.ModalSubmitData().Components[2].(*discordgo.ActionsRow).
 		Components[0].(*discordgo.TextInput).Value
-	season, err := models.GetActiveSeason(ctx, tx)
+	season, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetActiveSeason")
 	}
-	err = season.SetDates(ctx, tx, startDate, regEndDate, finalsEndDate, b.Config.Locale)
+	before := *season
+	loc, err := b.UserLocation(ctx, tx, i)
+	if err != nil {
+		return errors.Wrap(err, "b.UserLocation")
+	}
+	err = season.SetDates(ctx, tx, startDate, regEndDate, finalsEndDate, loc)
 	if err != nil {
 		return errors.Wrap(err, "season.SetDates")
 	}
@@ -105,9 +120,22 @@ func handleSetSeasonDatesModalInteraction(
 Start: %s
 Regular Season End: %s
 Finals End: %s`
-	msg = fmt.Sprintf(msg, season.Name, season.Start, season.RegSeasonEnd, season.FinalsEnd)
+	msg = fmt.Sprintf(msg, season.Name, bot.DiscordDate(season.Start),
+		bot.DiscordDate(season.RegSeasonEnd), bot.DiscordDate(season.FinalsEnd))
+	reply := "\n" + b.T(i, "seasons.dates.updated", season.Name, bot.DiscordDate(season.Start),
+		bot.DiscordDate(season.RegSeasonEnd), bot.DiscordDate(season.FinalsEnd))
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditSeasonDatesSet,
+		Entity:   "season",
+		EntityID: season.ID,
+		Summary:  "Dates updated for " + season.Name,
+	}, before, season)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 	b.Log().UserEvent(i.Member, msg)
-	err = b.FollowUp(msg, i)
+	err = b.FollowUp(reply, i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
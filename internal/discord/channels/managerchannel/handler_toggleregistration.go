@@ -32,11 +32,12 @@ func handleToggleRegistrationInteraction(
 		return nil
 	}
 	b.Logger.Debug().Msg("Getting active season")
-	season, err := models.GetActiveSeason(ctx, tx)
+	season, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetActiveSeason")
 	}
 	b.Logger.Debug().Msg("Toggling active season registration")
+	before := *season
 	err = season.ToggleRegistration(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "season.ToggleRegistration")
@@ -44,8 +45,19 @@ func handleToggleRegistrationInteraction(
 
 	msg := "Registration status for %s set to %s"
 	msg = fmt.Sprintf(msg, season.Name, season.RegistrationStatusString())
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditRegistrationToggled,
+		Entity:   "season",
+		EntityID: season.ID,
+		Summary:  msg,
+	}, before, season)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 	b.Log().UserEvent(i.Member, msg)
-	err = b.FollowUp(msg, i)
+	err = b.FollowUp(b.T(i, "seasons.registration.set", season.Name,
+		registrationStatusText(b.Lang(i), season)), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
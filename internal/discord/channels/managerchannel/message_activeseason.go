@@ -2,10 +2,10 @@ package managerchannel
 
 import (
 	"context"
-	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/components"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -33,25 +33,26 @@ func activeSeasonComponents(
 	}
 	defer tx.Rollback()
 
-	season, err := models.GetActiveSeason(ctx, tx)
+	season, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetActiveSeason")
 	}
+	lang := b.GuildLanguage()
 	if season == nil {
 		season = &models.Season{
 			ID:               "NOACTIVESEASON",
-			Name:             "No active season",
+			Name:             i18n.T(lang, "season.none.title"),
 			Active:           false,
 			RegistrationOpen: false,
 		}
 	}
 	registrationButton := &discordgo.Button{
-		Label:    "Open Registration",
+		Label:    i18n.T(lang, "seasons.registration.open"),
 		CustomID: "toggle_registration",
 		Style:    discordgo.SuccessButton,
 	}
 	if season.RegistrationOpen {
-		registrationButton.Label = "Close Registration"
+		registrationButton.Label = i18n.T(lang, "seasons.registration.close")
 		registrationButton.Style = discordgo.DangerButton
 	}
 	comps := []discordgo.MessageComponent{}
@@ -66,19 +67,19 @@ func activeSeasonComponents(
 				Components: []discordgo.MessageComponent{
 					registrationButton,
 					&discordgo.Button{
-						Label:    "Set dates",
+						Label:    i18n.T(lang, "seasons.button.dates"),
 						CustomID: "set_dates_button",
 					},
 				},
 			},
 		}
-		options, err := getLeagueOptions(leagues)
+		options, err := getLeagueOptions(lang, leagues)
 		if err != nil {
 			return nil, errors.Wrap(err, "getLeagueOptions")
 		}
 		leagueSelect := components.StringSelect(
 			"select_season_leagues",
-			"Select Leagues",
+			i18n.T(lang, "seasons.leagues.placeholder"),
 			options,
 			0,
 			3,
@@ -88,21 +89,9 @@ func activeSeasonComponents(
 	}
 	tx.Commit()
 	embed := &discordgo.MessageEmbed{
-		Title: "Active Season",
-		Description: fmt.Sprintf(`
-**%s (%s)**
-
-**Registration:** %s
-
-**Leagues:** %s
-
-Start Date: %s
-Regular Season End: %s
-Finals End: %s
-
-Transfer windows:
-`,
-			season.Name, season.ID, season.RegistrationStatusString(),
+		Title: i18n.T(lang, "seasons.active.title"),
+		Description: "\n" + i18n.T(lang, "seasons.active.description",
+			season.Name, season.ID, registrationStatusText(lang, season),
 			func() string {
 				msg := ""
 				for i, league := range *leagues {
@@ -116,7 +105,7 @@ Transfer windows:
 			bot.DiscordDateUntil(season.Start),
 			bot.DiscordDateUntil(season.RegSeasonEnd),
 			bot.DiscordDateUntil(season.FinalsEnd),
-		),
+		) + "\n",
 		Color: 0x00ff00, // Green color
 	}
 	contents := &bot.MessageContents{
@@ -125,3 +114,11 @@ Transfer windows:
 	}
 	return contents, nil
 }
+
+// Get the registration status of the season in the language
+func registrationStatusText(lang string, season *models.Season) string {
+	if season.RegistrationOpen {
+		return i18n.T(lang, "seasons.registration.status.open")
+	}
+	return i18n.T(lang, "seasons.registration.status.closed")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -19,23 +20,22 @@ func createSeasonComponents(
 	ctx context.Context,
 	b *bot.Bot,
 ) (*bot.MessageContents, error) {
+	lang := b.GuildLanguage()
 	components := []discordgo.MessageComponent{
 		&discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				&discordgo.Button{
 					CustomID: "create_season_button",
-					Label:    "Create Season",
+					Label:    i18n.T(lang, "seasons.create.title"),
 				},
 			},
 		},
 	}
 	contents := &bot.MessageContents{
 		Embed: &discordgo.MessageEmbed{
-			Title: "Create Season",
-			Description: `
-            Create a new season.
-            Season ID and Name must be unique.`,
-			Color: 0x00ff00, // Green color
+			Title:       i18n.T(lang, "seasons.create.title"),
+			Description: "\n" + i18n.T(lang, "seasons.create.description"),
+			Color:       0x00ff00, // Green color
 		},
 		Components: components,
 	}
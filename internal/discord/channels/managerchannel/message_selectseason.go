@@ -5,6 +5,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/components"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -31,17 +32,18 @@ func selectSeasonComponents(
 	}
 	defer tx.Rollback()
 	b.Logger.Debug().Msg("Getting default values for select season components")
-	seasons, err := models.GetSeasons(ctx, tx)
+	seasons, err := models.GetSeasons(ctx, tx, b.GuildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetSeasons")
 	}
-	activeSeason, err := models.GetActiveSeason(ctx, tx)
+	activeSeason, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetActiveSeason")
 	}
+	lang := b.GuildLanguage()
 	options := []discordgo.SelectMenuOption{
 		{
-			Label:   "No active season",
+			Label:   i18n.T(lang, "season.none.title"),
 			Value:   "NOACTIVESEASON",
 			Default: activeSeason == nil,
 		},
@@ -56,18 +58,13 @@ func selectSeasonComponents(
 	tx.Commit()
 	contents := &bot.MessageContents{
 		Embed: &discordgo.MessageEmbed{
-			Title: "Current Season",
-			Description: `
-Select the season to be set as the active season.
-
-**NOTE**
-This will update all related messages to show data for the selected season.
-(i.e. team rosters, fixtures).`,
-			Color: 0x00ff00, // Green color
+			Title:       i18n.T(lang, "seasons.select.title"),
+			Description: "\n" + i18n.T(lang, "seasons.select.description"),
+			Color:       0x00ff00, // Green color
 		},
 		Components: components.StringSelect(
 			"season_select",
-			"Select active season",
+			i18n.T(lang, "seasons.select.placeholder"),
 			options,
 			1,
 			1,
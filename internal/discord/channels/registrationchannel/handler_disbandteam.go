@@ -2,7 +2,11 @@ package registrationchannel
 
 import (
 	"context"
+	"fmt"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/transfers"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/models"
 	"gosl/pkg/db"
 	"strconv"
@@ -18,13 +22,9 @@ func handleDisbandTeamInteraction(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	teamidstr string,
+	teamid uint32,
 ) error {
 	b.Acknowledge(i, ack)
-	teamid, err := strconv.ParseUint(teamidstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
 	player, err := models.GetPlayerByDiscordID(ctx, tx, i.Member.User.ID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
@@ -35,22 +35,41 @@ func handleDisbandTeamInteraction(
 	}
 	msg := ""
 	if player == nil {
-		msg = "Not registered as a player"
+		msg = b.T(i, "registration.notplayer")
 	}
 	if team == nil {
-		msg = "Team not found"
+		msg = b.T(i, "registration.teamnotfound")
 	}
 	if team.ManagerID != player.ID {
-		msg = "You are not the manager of this team!"
+		msg = b.T(i, "registration.notmanager")
 	}
 	if msg != "" {
-		return b.Error("Error disbanding team", msg, i, true)
+		return b.Error(b.T(i, "registration.disband.failed"), msg, i, true)
+	}
+	now := time.Now()
+	roster, err := team.Players(ctx, tx, &now, &now)
+	if err != nil {
+		return errors.Wrap(err, "team.Players")
 	}
 	err = team.Disband(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "team.Disband")
 	}
-	contents, err := teamSelectComponents(ctx, tx, player)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTeamDisbanded,
+		Entity:   "team",
+		EntityID: strconv.FormatUint(uint64(teamid), 10),
+		TeamID:   &team.ID,
+		Summary:  fmt.Sprintf("%s was disbanded by its manager", team.Name),
+	}, roster, nil)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	teamdiscord.SyncTeam(ctx, b, team.ID)
+	leagueroles.Sync(ctx, b)
+	transfers.Update(ctx, b, team.GuildID)
+	contents, err := teamSelectComponents(ctx, tx, b.Lang(i), player)
 	if err != nil {
 		return errors.Wrap(err, "teamSelectComponents")
 	}
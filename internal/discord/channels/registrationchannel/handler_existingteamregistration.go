@@ -24,7 +24,8 @@ func handleExistingTeamRegistrationButtonInteraction(
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
 	}
 	if player == nil {
-		return b.Error("Unregistered Player", "You must register as a player to register a team", i, false)
+		return b.Error(b.T(i, "registration.unregistered.title"),
+			b.T(i, "registration.unregistered.team"), i, false)
 	}
 	currentTeam, err := player.CurrentTeam(ctx, tx)
 	if err != nil {
@@ -37,17 +38,16 @@ func handleExistingTeamRegistrationButtonInteraction(
 			return errors.Wrap(err, "models.GetTeamByID")
 		}
 		if team.ManagerID != player.ID {
-
-			msg := "You are already on a team. " +
-				"Leave your current team or choose 'Register Existing Team'"
-			return b.Error("Already on a team", msg, i, false)
+			return b.Error(b.T(i, "registration.onteam.title"),
+				b.T(i, "registration.onteam.message"), i, false)
 		}
-		contents = reregisterTeamComponents(team)
+		contents = reregisterTeamComponents(b.Lang(i), team)
 	} else {
-		contents, err = teamSelectComponents(ctx, tx, player)
+		contents, err = teamSelectComponents(ctx, tx, b.Lang(i), player)
 		if err != nil {
 			if err.Error() == "No managed teams" {
-				return b.Error("Unable to re-register team", "You have not managed any teams previously. Please create a new team.", i, *ack)
+				return b.Error(b.T(i, "registration.reregister.none.title"),
+					b.T(i, "registration.reregister.none.message"), i, *ack)
 			}
 			return errors.Wrap(err, "teamSelectComponents")
 		}
@@ -2,7 +2,6 @@ package registrationchannel
 
 import (
 	"context"
-	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/channels/freeagentapplications"
 	"gosl/internal/models"
@@ -21,14 +20,14 @@ func handleFreeAgentRegisterButton(
 	ack *bool,
 ) error {
 	b.Acknowledge(i, ack)
-	currentSeason, _, usererrmsg, err := checkFreeAgentCanRegister(ctx, tx, i.Member)
+	currentSeason, player, usererrkey, err := checkFreeAgentCanRegister(ctx, tx, b.GuildID, i.Member)
 	if err != nil {
 		return errors.Wrap(err, "checkFreeAgentCanRegister")
 	}
-	if usererrmsg != "" {
-		return b.Error("Failed to register", usererrmsg, i, *ack)
+	if usererrkey != "" {
+		return b.Error(b.T(i, "registration.failed"), b.T(i, usererrkey), i, *ack)
 	}
-	contents, err := registerFreeAgentSelectLeagueComponents(ctx, tx, currentSeason)
+	contents, err := registerFreeAgentSelectLeagueComponents(ctx, tx, b.Lang(i), currentSeason, player)
 	if err != nil {
 		return errors.Wrap(err, "registerFreeAgentSelectLeagueComponents")
 	}
@@ -47,12 +46,12 @@ func handleFreeAgentRegisterSelectLeague(
 	ack *bool,
 ) error {
 	b.Acknowledge(i, ack)
-	currentSeason, player, usererrmsg, err := checkFreeAgentCanRegister(ctx, tx, i.Member)
+	currentSeason, player, usererrkey, err := checkFreeAgentCanRegister(ctx, tx, b.GuildID, i.Member)
 	if err != nil {
 		return errors.Wrap(err, "checkFreeAgentCanRegister")
 	}
-	if usererrmsg != "" {
-		return b.Error("Failed to register", usererrmsg, i, *ack)
+	if usererrkey != "" {
+		return b.Error(b.T(i, "registration.failed"), b.T(i, usererrkey), i, *ack)
 	}
 	preferredLeague := i.MessageComponentData().Values[0]
 	app, err := currentSeason.RegisterFreeAgent(ctx, tx, player.ID, preferredLeague)
@@ -67,13 +66,11 @@ func handleFreeAgentRegisterSelectLeague(
 	if err != nil {
 		return errors.Wrap(err, "FreeAgentApplicationContents")
 	}
-	err = regMsg.Send(contents)
+	err = regMsg.Send(ctx, tx, contents)
 	if err != nil {
 		return errors.Wrap(err, "regMsg.Send")
 	}
-	err = b.FollowUp(
-		fmt.Sprintf("You have successfully applied to be a Free Agent for %s",
-			currentSeason.Name), i)
+	err = b.FollowUp(b.T(i, "registration.freeagent.applied", currentSeason.Name), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
@@ -81,10 +78,11 @@ func handleFreeAgentRegisterSelectLeague(
 }
 
 // checks if the player can register as a free agent. returns an empty string if
-// they are allowed to register, and an error message if they are not.
+// they are allowed to register, and the key of the error message if they are not.
 func checkFreeAgentCanRegister(
 	ctx context.Context,
 	tx db.SafeTX,
+	guildID string,
 	member *discordgo.Member,
 ) (*models.Season, *models.Player, string, error) {
 	player, err := models.GetPlayerByDiscordID(ctx, tx, member.User.ID)
@@ -92,28 +90,28 @@ func checkFreeAgentCanRegister(
 		return nil, nil, "", errors.Wrap(err, "models.GetPlayerByDiscordID")
 	}
 	if player == nil {
-		return nil, nil, "You must register as a player first", nil
+		return nil, nil, "registration.mustregister", nil
 	}
 	currentTeam, err := player.CurrentTeam(ctx, tx)
 	if err != nil {
 		return nil, nil, "", errors.Wrap(err, "player.CurrentTeam")
 	}
 	if currentTeam != nil {
-		return nil, nil, "You are already on a team", nil
+		return nil, nil, "registration.freeagent.onteam", nil
 	}
-	currentSeason, err := models.GetActiveSeason(ctx, tx)
+	currentSeason, err := models.GetActiveSeason(ctx, tx, guildID)
 	if err != nil {
 		return nil, nil, "", errors.Wrap(err, "models.GetActiveSeason")
 	}
 	if currentSeason == nil {
-		return nil, nil, "There is no active season right now", nil
+		return nil, nil, "season.none.message", nil
 	}
 	isRegistered, err := models.CheckPlayerFreeAgentRegistration(ctx, tx, player.ID, currentSeason.ID)
 	if err != nil {
 		return nil, nil, "", errors.Wrap(err, "models.CheckPlayerFreeAgentRegistration")
 	}
 	if isRegistered {
-		return nil, nil, "You are already registered as a Free Agent this season", nil
+		return nil, nil, "registration.freeagent.registered", nil
 	}
 
 	// we dont check if registration is open because free agents can always register
@@ -2,8 +2,8 @@ package registrationchannel
 
 import (
 	"context"
-	"fmt"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/transfers"
 	"gosl/internal/discord/components"
 	"gosl/internal/discord/directmessages"
 	"gosl/internal/models"
@@ -25,23 +25,23 @@ func handleNewTeamRegistrationButtonInteraction(
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
 	}
 	if player == nil {
-		return b.Error("Unregistered Player", "You must register as a player to register a team", i, false)
+		return b.Error(b.T(i, "registration.unregistered.title"),
+			b.T(i, "registration.unregistered.team"), i, false)
 	}
 	currentTeam, err := player.CurrentTeam(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "player.CurrentTeam")
 	}
 	if currentTeam != nil {
-		msg := "You are already on a team. " +
-			"Leave your current team or choose 'Register Existing Team'"
-		return b.Error("Already on a team", msg, i, false)
+		return b.Error(b.T(i, "registration.onteam.title"),
+			b.T(i, "registration.onteam.message"), i, false)
 	}
 	steamcmp := []discordgo.MessageComponent{
-		components.TextInput("team_name", "Team Name", true, "", 1, 64),
-		components.TextInput("team_abbr", "Team Acronym", true, "", 3, 5),
+		components.TextInput("team_name", b.T(i, "registration.team.name"), true, "", 1, 64),
+		components.TextInput("team_abbr", b.T(i, "registration.team.abbr"), true, "", 3, 5),
 	}
 
-	err = b.ReplyModal("New Team Registration", "new_team_registration_details", steamcmp, i)
+	err = b.ReplyModal(b.T(i, "registration.team.modal"), "new_team_registration_details", steamcmp, i)
 	if err != nil {
 		return errors.Wrap(err, "b.ReplyModal")
 	}
@@ -61,39 +61,40 @@ func handleNewTeamDetailsSubmit(
 	teamAbbr := i.ModalSubmitData().Components[1].(*discordgo.ActionsRow).
 		Components[0].(*discordgo.TextInput).Value
 
-	nameTaken, err := models.CheckTeamNameExists(ctx, tx, teamName)
+	nameTaken, err := models.CheckTeamNameExists(ctx, tx, b.GuildID, teamName)
 	if err != nil {
 		return errors.Wrap(err, "models.GetTeamByName")
 	}
-	abbrTaken, err := models.CheckTeamAbbrExists(ctx, tx, teamAbbr)
+	abbrTaken, err := models.CheckTeamAbbrExists(ctx, tx, b.GuildID, teamAbbr)
 	if err != nil {
 		return errors.Wrap(err, "models.GetTeamByAbbr")
 	}
 	msg := ""
 	if nameTaken {
-		msg = fmt.Sprintf("Team name '%s' is taken\n", teamName)
+		msg = b.T(i, "registration.team.nametaken", teamName) + "\n"
 	}
 	if abbrTaken {
-		msg = msg + fmt.Sprintf("Team abbreviation '%s' is taken", teamAbbr)
+		msg = msg + b.T(i, "registration.team.abbrtaken", teamAbbr)
 	}
 	if msg != "" {
-		return b.Error("Cannot create team", msg, i, true)
+		return b.Error(b.T(i, "registration.team.failed"), msg, i, true)
 	}
 
 	player, err := models.GetPlayerByDiscordID(ctx, tx, i.Member.User.ID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
 	}
-	team, err := models.CreateTeam(ctx, tx, teamName, teamAbbr, player.ID)
+	team, err := models.CreateTeam(ctx, tx, b.GuildID, teamName, teamAbbr, player.ID)
 	if err != nil {
 		return errors.Wrap(err, "models.CreateTeam")
 	}
-	err = player.JoinTeam(ctx, tx, team.ID)
+	err = player.JoinTeam(ctx, tx, team.ID, models.MoveCreated, nil)
 	if err != nil {
 		return errors.Wrap(err, "player.JoinTeam")
 	}
+	transfers.Update(ctx, b, team.GuildID)
 
-	contents, err := directmessages.TeamManagerComponents(ctx, tx, b, team)
+	contents, err := directmessages.TeamManagerComponents(ctx, tx, b, b.UserLang(player.DiscordID), team)
 	if err != nil {
 		return errors.Wrap(err, "components.TeamManagerComponents")
 	}
@@ -104,11 +105,11 @@ func handleNewTeamDetailsSubmit(
 		false,
 		b,
 	)
-	err = dm.Send(contents)
+	err = dm.Send(ctx, tx, contents)
 	if err != nil {
 		return errors.Wrap(err, "dm.Send")
 	}
-	err = b.FollowUp("Team registration started, check your DM's to continue", i)
+	err = b.FollowUp(b.T(i, "registration.team.started"), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
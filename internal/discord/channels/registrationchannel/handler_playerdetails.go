@@ -5,7 +5,6 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
-	"strconv"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
@@ -18,16 +17,13 @@ func handleDisplayNameSubmit(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	slapidstr string,
+	afterCommit func(fn func()),
+	slapid uint32,
 ) error {
 	b.Acknowledge(i, ack)
 	displayname := i.ModalSubmitData().Components[0].(*discordgo.ActionsRow).
 		Components[0].(*discordgo.TextInput).Value
-	slapid, err := strconv.ParseUint(slapidstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
-	player, err := models.GetPlayerBySlapID(ctx, tx, uint32(slapid))
+	player, err := models.GetPlayerBySlapID(ctx, tx, slapid)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerBySlapID")
 	}
@@ -37,15 +33,29 @@ func handleDisplayNameSubmit(
 		}
 		player.UpdateDiscordID(ctx, tx, i.Member.User.ID)
 	} else {
-		err = models.CreatePlayer(ctx, tx, uint32(slapid), i.Member.User.ID, displayname)
+		err = models.CreatePlayer(ctx, tx, slapid, i.Member.User.ID, displayname)
 		if err != nil {
 			if strings.Contains(err.Error(), "Display name must be unique") {
-				return b.Error("Registration failed", err.Error(), i, true)
+				return b.Error(b.T(i, "registration.player.failed"), err.Error(), i, true)
 			}
 			return errors.Wrap(err, "models.CreatePlayer")
 		}
+		player, err = models.GetPlayerBySlapID(ctx, tx, slapid)
+		if err != nil {
+			return errors.Wrap(err, "models.GetPlayerBySlapID")
+		}
 	}
-	err = b.FollowUp("Player registration successful!", i)
+	// Sync their in-game details once this transaction is committed so they
+	// can be used straight away
+	afterCommit(func() {
+		go func() {
+			err := b.SyncPlayer(ctx, player)
+			if err != nil {
+				b.Logger.Warn().Err(err).Msg("Failed to sync new player")
+			}
+		}()
+	})
+	err = b.FollowUp(b.T(i, "registration.player.success"), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
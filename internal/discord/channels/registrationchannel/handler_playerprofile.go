@@ -0,0 +1,51 @@
+package registrationchannel
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/directmessages"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+func handlePlayerProfileButton(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+) error {
+	b.Acknowledge(i, ack)
+	player, err := models.GetPlayerByDiscordID(ctx, tx, i.Member.User.ID)
+	if err != nil {
+		return errors.Wrap(err, "models.GetPlayerByDiscordID")
+	}
+	if player == nil {
+		return b.Error(b.T(i, "registration.interaction.failed"),
+			b.T(i, "registration.mustregister"), i, *ack)
+	}
+	profile, err := models.GetPlayerProfile(ctx, tx, player.ID)
+	if err != nil {
+		return errors.Wrap(err, "models.GetPlayerProfile")
+	}
+	dm := bot.NewDirectMessage(
+		"Player Profile Panel",
+		i.Member.User.ID,
+		5*time.Minute,
+		false,
+		b,
+	)
+	err = dm.Send(ctx, tx, directmessages.PlayerProfileComponents(player, profile))
+	if err != nil {
+		return errors.Wrap(err, "dm.Send")
+	}
+	err = b.FollowUp(b.T(i, "team.checkdms"), i)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUp")
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package registrationchannel
 
 import (
 	"context"
-	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/components"
 	"gosl/internal/models"
@@ -23,14 +22,14 @@ func handlePlayerRegistrationButtonInteraction(
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
 	}
 	if player != nil {
-		msg := fmt.Sprintf("__Player Name:__ %s\n__Slap ID:__ %v", player.Name, player.SlapID)
-		return b.Error("You are already registered", msg, i, false)
+		return b.Error(b.T(i, "registration.player.already.title"),
+			b.T(i, "registration.player.already.message", player.Name, player.SlapID), i, false)
 	}
 	steamcmp := []discordgo.MessageComponent{
-		components.TextInput("steam_id", "Steam ID", true, "", 1, 256),
+		components.TextInput("steam_id", b.T(i, "registration.player.steamid"), true, "", 1, 256),
 	}
 
-	err = b.ReplyModal("Player Registration", "player_reg_steam_id", steamcmp, i)
+	err = b.ReplyModal(b.T(i, "registration.player.title"), "player_reg_steam_id", steamcmp, i)
 	if err != nil {
 		return errors.Wrap(err, "b.ReplyModal")
 	}
@@ -6,7 +6,6 @@ import (
 	"gosl/internal/discord/directmessages"
 	"gosl/internal/models"
 	"gosl/pkg/db"
-	"strconv"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -19,13 +18,9 @@ func handleReregisterExistingTeamInteraction(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	teamidstr string,
+	teamid uint32,
 ) error {
 	b.Acknowledge(i, ack)
-	teamid, err := strconv.ParseUint(teamidstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
 	player, err := models.GetPlayerByDiscordID(ctx, tx, i.Member.User.ID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
@@ -36,18 +31,18 @@ func handleReregisterExistingTeamInteraction(
 	}
 	msg := ""
 	if player == nil {
-		msg = "Not registered as a player"
+		msg = b.T(i, "registration.notplayer")
 	}
 	if team == nil {
-		msg = "Team not found"
+		msg = b.T(i, "registration.teamnotfound")
 	}
 	if team.ManagerID != player.ID {
-		msg = "You are not the manager of this team!"
+		msg = b.T(i, "registration.notmanager")
 	}
 	if msg != "" {
-		return b.Error("Error re-registering team", msg, i, true)
+		return b.Error(b.T(i, "registration.reregister.failed"), msg, i, true)
 	}
-	contents, err := directmessages.TeamManagerComponents(ctx, tx, b, team)
+	contents, err := directmessages.TeamManagerComponents(ctx, tx, b, b.UserLang(player.DiscordID), team)
 	if err != nil {
 		return errors.Wrap(err, "components.TeamManagerComponents")
 	}
@@ -58,11 +53,11 @@ func handleReregisterExistingTeamInteraction(
 		false,
 		b,
 	)
-	err = dm.Send(contents)
+	err = dm.Send(ctx, tx, contents)
 	if err != nil {
 		return errors.Wrap(err, "dm.Send")
 	}
-	err = b.FollowUp("Team registration started, check your DM's to continue", i)
+	err = b.FollowUp(b.T(i, "registration.team.started"), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
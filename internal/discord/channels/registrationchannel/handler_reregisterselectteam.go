@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/transfers"
 	"gosl/internal/discord/components"
 	"gosl/internal/discord/directmessages"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"strconv"
 	"time"
 
@@ -38,29 +42,32 @@ func handleReregisterTeamSelect(
 	}
 	msg := ""
 	if player == nil {
-		msg = "Not registered as a player"
+		msg = b.T(i, "registration.notplayer")
 	}
 	if team == nil {
-		msg = "Team not found"
+		msg = b.T(i, "registration.teamnotfound")
 	}
 	if team.ManagerID != player.ID {
-		msg = "You are not the manager of this team!"
+		msg = b.T(i, "registration.notmanager")
 	}
 	currentTeam, err := player.CurrentTeam(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "player.CurrentTeam")
 	}
 	if currentTeam != nil {
-		msg = "You are already on a team!"
+		msg = b.T(i, "registration.alreadyonteam")
 	}
 	if msg != "" {
-		return b.Error("Error re-registering team", msg, i, true)
+		return b.Error(b.T(i, "registration.reregister.failed"), msg, i, true)
 	}
-	err = player.JoinTeam(ctx, tx, team.ID)
+	err = player.JoinTeam(ctx, tx, team.ID, models.MoveRejoined, nil)
 	if err != nil {
 		return errors.Wrap(err, "player.JoinTeam")
 	}
-	contents, err := directmessages.TeamManagerComponents(ctx, tx, b, team)
+	teamdiscord.SyncTeam(ctx, b, team.ID)
+	leagueroles.Sync(ctx, b)
+	transfers.Update(ctx, b, team.GuildID)
+	contents, err := directmessages.TeamManagerComponents(ctx, tx, b, b.UserLang(player.DiscordID), team)
 	if err != nil {
 		return errors.Wrap(err, "components.TeamManagerComponents")
 	}
@@ -71,11 +78,11 @@ func handleReregisterTeamSelect(
 		false,
 		b,
 	)
-	err = dm.Send(contents)
+	err = dm.Send(ctx, tx, contents)
 	if err != nil {
 		return errors.Wrap(err, "dm.Send")
 	}
-	err = b.FollowUp("Team registration started, check your DM's to continue", i)
+	err = b.FollowUp(b.T(i, "registration.team.started"), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
@@ -85,14 +92,15 @@ func handleReregisterTeamSelect(
 func teamSelectComponents(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	player *models.Player,
 ) (*bot.MessageContents, error) {
 	embed := &discordgo.MessageEmbed{
 		Color: 0xeb7d34,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Re-register a team",
-				Value:  "Select a team from the list below to re-register",
+				Name:   i18n.T(lang, "registration.reregister.title"),
+				Value:  i18n.T(lang, "registration.reregister.select"),
 				Inline: false,
 			},
 		},
@@ -114,7 +122,7 @@ func teamSelectComponents(
 
 	msgcomps := components.StringSelect(
 		"reregister_select_team",
-		"Select Team",
+		i18n.T(lang, "registration.reregister.placeholder"),
 		opts, 1, 1, false)
 	contents := &bot.MessageContents{
 		Embed:      embed,
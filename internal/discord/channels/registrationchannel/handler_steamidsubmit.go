@@ -2,7 +2,6 @@ package registrationchannel
 
 import (
 	"context"
-	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/components"
 	"gosl/internal/models"
@@ -31,27 +30,26 @@ func handleSteamIDModalSubmit(
 		return errors.Wrap(err, "steamapi.GetUser")
 	}
 	if steamuser == nil {
-		return b.Error("Invalid Steam ID", "No steam user was found", i, true)
+		return b.Error(b.T(i, "registration.steamid.invalid"), b.T(i, "registration.steamid.nouser"), i, true)
 	}
-	slapid, err := slapshotapi.GetSlapID(
-		steamuser.SteamID,
-		b.Config.SlapshotAPIKey,
-		b.Config.SlapshotAPIEnv,
-	)
-	if err != nil {
-		return errors.Wrap(err, "slapshotapi.GetSlapID")
+	slapid, err := b.Slapshot.GetSlapID(ctx, steamuser.SteamID)
+	if errors.Is(err, slapshotapi.ErrNotFound) {
+		return b.Error(b.T(i, "registration.steamid.invalid"),
+			b.T(i, "registration.steamid.notplayed"), i, true)
 	}
-	if slapid == 0 {
-		return b.Error("Invalid Steam ID", "Steam account hasn't played slapshot", i, true)
+	if err != nil {
+		b.Logger.Warn().Err(err).Msg("Failed to get SlapID")
+		return b.Error(b.T(i, "registration.slapshot.unavailable.title"),
+			b.T(i, "registration.slapshot.unavailable.message"), i, true)
 	}
 	existingPlayer, err := models.GetPlayerBySlapID(ctx, tx, slapid)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerBySlapID")
 	}
 	if existingPlayer != nil {
-		return b.Error("Invalid Steam ID", "Account already linked to a player", i, true)
+		return b.Error(b.T(i, "registration.steamid.invalid"), b.T(i, "registration.steamid.linked"), i, true)
 	}
-	contents := confirmSlapIDContents(steamuser, slapid)
+	contents := confirmSlapIDContents(b.Lang(i), steamuser, slapid)
 	err = b.FollowUpComplex(contents, i, 60*time.Second)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUpComplex")
@@ -71,13 +69,13 @@ func handleSteamIDConfirm(
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
 	}
 	if player != nil {
-		msg := fmt.Sprintf("__Player Name:__ %s\n__Slap ID:__ %v", player.Name, player.SlapID)
-		return b.Error("You are already registered", msg, i, false)
+		return b.Error(b.T(i, "registration.player.already.title"),
+			b.T(i, "registration.player.already.message", player.Name, player.SlapID), i, false)
 	}
 	regcmp := []discordgo.MessageComponent{
-		components.TextInput("player_name", "Display Name", true, "", 1, 32),
+		components.TextInput("player_name", b.T(i, "registration.player.displayname"), true, "", 1, 32),
 	}
-	err = b.ReplyModal("Player Registration", "player_reg_display_name_"+slapid, regcmp, i)
+	err = b.ReplyModal(b.T(i, "registration.player.title"), "player_reg_display_name_"+slapid, regcmp, i)
 	if err != nil {
 		return errors.Wrap(err, "b.ReplyModal")
 	}
@@ -3,12 +3,13 @@ package registrationchannel
 import (
 	"fmt"
 	"gosl/internal/discord/bot"
+	"gosl/pkg/i18n"
 	"gosl/pkg/steamapi"
 
 	"github.com/bwmarrin/discordgo"
 )
 
-func confirmSlapIDContents(steamuser *steamapi.User, slapid uint32) *bot.MessageContents {
+func confirmSlapIDContents(lang string, steamuser *steamapi.User, slapid uint32) *bot.MessageContents {
 	embed := &discordgo.MessageEmbed{
 		Color: 0xeb7d34,
 		Author: &discordgo.MessageEmbedAuthor{
@@ -17,8 +18,8 @@ func confirmSlapIDContents(steamuser *steamapi.User, slapid uint32) *bot.Message
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Steam User Found",
-				Value:  fmt.Sprintf("__SlapID:__ %v", slapid),
+				Name:   i18n.T(lang, "registration.steamid.found"),
+				Value:  i18n.T(lang, "registration.steamid.slapid", slapid),
 				Inline: false,
 			},
 		},
@@ -28,7 +29,7 @@ func confirmSlapIDContents(steamuser *steamapi.User, slapid uint32) *bot.Message
 			Components: []discordgo.MessageComponent{
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("confirm_slapid_%v", slapid),
-					Label:    "Confirm",
+					Label:    i18n.T(lang, "button.confirm"),
 					Style:    discordgo.SuccessButton,
 				},
 			},
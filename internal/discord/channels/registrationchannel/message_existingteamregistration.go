@@ -4,11 +4,12 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 
 	"github.com/bwmarrin/discordgo"
 )
 
-func reregisterTeamComponents(team *models.Team) *bot.MessageContents {
+func reregisterTeamComponents(lang string, team *models.Team) *bot.MessageContents {
 	embed := &discordgo.MessageEmbed{
 		Color: team.Color,
 		Author: &discordgo.MessageEmbedAuthor{
@@ -17,14 +18,8 @@ func reregisterTeamComponents(team *models.Team) *bot.MessageContents {
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name: fmt.Sprintf("Currently manager of %s (%s)", team.Name, team.Abbreviation),
-				Value: `
-Do you want to re-register this team, or disband the team?
-
-Re-registering will retain all current players and start the registration process.
-
-Disbanding will remove all players (including you) and allow you to pick from any teams you have previously been a manager of.
-`,
+				Name:   i18n.T(lang, "registration.reregister.current", team.Name, team.Abbreviation),
+				Value:  "\n" + i18n.T(lang, "registration.reregister.prompt") + "\n",
 				Inline: false,
 			},
 		},
@@ -34,12 +29,12 @@ Disbanding will remove all players (including you) and allow you to pick from an
 			Components: []discordgo.MessageComponent{
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("reregister_team_%v", team.ID),
-					Label:    "Re-Register current team",
+					Label:    i18n.T(lang, "registration.reregister.button.reregister"),
 					Style:    discordgo.SuccessButton,
 				},
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("disband_team_%v", team.ID),
-					Label:    "Disband current team",
+					Label:    i18n.T(lang, "registration.reregister.button.disband"),
 					Style:    discordgo.DangerButton,
 				},
 			},
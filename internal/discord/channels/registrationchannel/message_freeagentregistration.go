@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -29,7 +30,7 @@ func freeAgentRegistrationContents(
 	}
 	defer tx.Rollback()
 
-	activeSeason, err := models.GetActiveSeason(ctx, tx)
+	activeSeason, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetActiveSeason")
 	}
@@ -37,29 +38,31 @@ func freeAgentRegistrationContents(
 	if activeSeason == nil {
 		disabled = true
 	}
-	regmsg := "%s - Registration %s!"
+	lang := b.GuildLanguage()
+	regmsg := ""
 	if activeSeason == nil {
-		regmsg = fmt.Sprintf(regmsg, "No active season", "closed")
+		regmsg = i18n.T(lang, "registration.closed", i18n.T(lang, "season.none.title"))
 	} else {
-		regmsg = fmt.Sprintf(regmsg, activeSeason.Name, "open")
+		regmsg = i18n.T(lang, "registration.open", activeSeason.Name)
 	}
 	contents := &bot.MessageContents{
 		Embed: &discordgo.MessageEmbed{
-			Title: "Free Agent Registration",
-			Description: fmt.Sprintf(`
-**%s**
-Register as a Free Agent in the Oceanic Slapshot League!
-`, regmsg),
-			Color: 0x00ff00, // Green color
+			Title:       i18n.T(lang, "registration.freeagent.title"),
+			Description: fmt.Sprintf("\n**%s**\n%s\n", regmsg, i18n.T(lang, "registration.freeagent.description")),
+			Color:       0x00ff00, // Green color
 		},
 		Components: []discordgo.MessageComponent{
 			&discordgo.ActionsRow{
 				Components: []discordgo.MessageComponent{
 					&discordgo.Button{
-						Label:    "Free Agent Registration",
+						Label:    i18n.T(lang, "registration.freeagent.title"),
 						CustomID: "freeagent_registration_button",
 						Disabled: disabled,
 					},
+					&discordgo.Button{
+						Label:    i18n.T(lang, "registration.button.profile"),
+						CustomID: "player_profile_button",
+					},
 				},
 			},
 		},
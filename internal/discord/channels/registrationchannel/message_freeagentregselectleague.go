@@ -2,11 +2,11 @@ package registrationchannel
 
 import (
 	"context"
-	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/components"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
@@ -15,35 +15,46 @@ import (
 func registerFreeAgentSelectLeagueComponents(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	season *models.Season,
+	player *models.Player,
 ) (*bot.MessageContents, error) {
 	leagues, err := models.GetLeagues(ctx, tx, season.ID, false)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetLeagues")
 	}
+	slapshot, err := models.GetSlapshotInfo(ctx, tx, player.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetSlapshotInfo")
+	}
+	suggested := slapshot.SuggestedDivision()
+	suggestedMsg := ""
 	opts := []discordgo.SelectMenuOption{}
 	for _, league := range *leagues {
-		opts = append(opts, discordgo.SelectMenuOption{
+		opt := discordgo.SelectMenuOption{
 			Label: league.Division,
 			Value: league.Division,
-		})
+		}
+		if league.Division == suggested {
+			opt.Description = i18n.T(lang, "registration.freeagent.suggested")
+			suggestedMsg = "\n" + i18n.T(lang, "registration.freeagent.suggestion",
+				slapshot.Summary(), suggested)
+		}
+		opts = append(opts, opt)
 	}
 	embed := &discordgo.MessageEmbed{
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name: "Register as Free Agent",
-				Value: fmt.Sprintf(`
-**Register as a Free Agent to play in %s**
-Select your preferred league from the select box to apply.
-**WARNING**: Clicking off the select box will send the application.
-`, season.Name),
+				Name: i18n.T(lang, "registration.freeagent.register"),
+				Value: "\n" + i18n.T(lang, "registration.freeagent.select",
+					season.Name, suggestedMsg) + "\n",
 				Inline: false,
 			},
 		},
 	}
 	msgcomps := components.StringSelect(
 		"freeagent_registration_select_league",
-		"Select Preferred League",
+		i18n.T(lang, "registration.freeagent.placeholder"),
 		opts,
 		1,
 		1,
@@ -2,9 +2,9 @@ package registrationchannel
 
 import (
 	"context"
-	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -19,23 +19,19 @@ func playerRegistrationContents(
 	ctx context.Context,
 	b *bot.Bot,
 ) (*bot.MessageContents, error) {
+	lang := b.GuildLanguage()
 	contents := &bot.MessageContents{
 		Embed: &discordgo.MessageEmbed{
-			Title: "Player Registration",
-			Description: fmt.Sprintf(`
-**Register as a player in the Oceanic Slapshot League!**
-
-To register, you will need to provide the Steam ID of the account you play slapshot on. 
-
-[Click here](%s/registration-help) for instructions on how to find your Steam ID and why it is required.
-`, b.Config.TrustedHost),
+			Title: i18n.T(lang, "registration.player.title"),
+			Description: "\n" + i18n.T(lang, "registration.player.description",
+				b.Config.TrustedHost) + "\n",
 			Color: 0x00ff00, // Green color
 		},
 		Components: []discordgo.MessageComponent{
 			&discordgo.ActionsRow{
 				Components: []discordgo.MessageComponent{
 					&discordgo.Button{
-						Label:    "Player Registration",
+						Label:    i18n.T(lang, "registration.player.title"),
 						CustomID: "player_registration_button",
 					},
 				},
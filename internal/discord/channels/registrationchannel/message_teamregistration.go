@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -29,7 +30,7 @@ func teamRegistrationContents(
 	}
 	defer tx.Rollback()
 
-	activeSeason, err := models.GetActiveSeason(ctx, tx)
+	activeSeason, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetActiveSeason")
 	}
@@ -37,33 +38,31 @@ func teamRegistrationContents(
 	if activeSeason == nil || !activeSeason.RegistrationOpen {
 		disabled = true
 	}
-	regmsg := "%s - Registration %s!"
+	lang := b.GuildLanguage()
+	regmsg := ""
 	if activeSeason == nil {
-		regmsg = fmt.Sprintf(regmsg, "No active season", "closed")
+		regmsg = i18n.T(lang, "registration.closed", i18n.T(lang, "season.none.title"))
 	} else if activeSeason.RegistrationOpen {
-		regmsg = fmt.Sprintf(regmsg, activeSeason.Name, "open")
+		regmsg = i18n.T(lang, "registration.open", activeSeason.Name)
 	} else {
-		regmsg = fmt.Sprintf(regmsg, activeSeason.Name, "closed")
+		regmsg = i18n.T(lang, "registration.closed", activeSeason.Name)
 	}
 	contents := &bot.MessageContents{
 		Embed: &discordgo.MessageEmbed{
-			Title: "Team Registration",
-			Description: fmt.Sprintf(`
-**%s**
-Register a team to play in the Oceanic Slapshot League!
-`, regmsg),
-			Color: 0x00ff00, // Green color
+			Title:       i18n.T(lang, "registration.team.title"),
+			Description: fmt.Sprintf("\n**%s**\n%s\n", regmsg, i18n.T(lang, "registration.team.description")),
+			Color:       0x00ff00, // Green color
 		},
 		Components: []discordgo.MessageComponent{
 			&discordgo.ActionsRow{
 				Components: []discordgo.MessageComponent{
 					&discordgo.Button{
-						Label:    "Register New Team",
+						Label:    i18n.T(lang, "registration.team.button.new"),
 						CustomID: "new_team_registration_button",
 						Disabled: disabled,
 					},
 					&discordgo.Button{
-						Label:    "Register Existing Team",
+						Label:    i18n.T(lang, "registration.team.button.existing"),
 						CustomID: "existing_team_registration_button",
 						Disabled: disabled,
 					},
@@ -7,6 +7,7 @@ import (
 	"gosl/internal/discord/channels/teamrosters"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"strconv"
 
 	"github.com/bwmarrin/discordgo"
@@ -19,25 +20,33 @@ func handleApproveTeamApplication(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	appIDstr string,
+	appID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	appID, err := strconv.ParseUint(appIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
 	app, err := models.GetTeamRegistration(ctx, tx, uint16(appID))
 	if err != nil {
 		return errors.Wrap(err, "models.GetTeamRegistration")
 	}
 
+	before := *app
 	err = app.Approve(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "app.Approve")
 	}
-	err = b.SendDirectMessage("Team Application Approved",
-		fmt.Sprintf("Your application for %s to play in %s has been approved",
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTeamApplicationApproved,
+		Entity:   "team_registration",
+		EntityID: strconv.FormatUint(uint64(appID), 10),
+		TeamID:   &app.TeamID,
+		Summary: fmt.Sprintf("Application from %s for %s approved",
 			app.TeamName, app.SeasonName),
+	}, before, app)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	lang := b.UserLang(app.ManagerID)
+	err = b.SendDirectMessage(ctx, i18n.T(lang, "teamapps.approved.title"),
+		i18n.T(lang, "teamapps.approved.message", app.TeamName, app.SeasonName),
 		app.ManagerID,
 	)
 	if err != nil {
@@ -55,5 +64,5 @@ func handleApproveTeamApplication(
 
 	msg := fmt.Sprintf("Application from %s approved", app.TeamName)
 	b.Log().UserEvent(i.Member, msg)
-	return b.FollowUp(msg, i)
+	return b.FollowUp(b.T(i, "teamapps.approved.reply", app.TeamName), i)
 }
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/channels/teamrosters"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"strconv"
 	"strings"
 
@@ -20,19 +23,14 @@ func handlePlaceTeamLeagueSelect(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	appIDstr string,
+	appID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	appID, err := strconv.ParseUint(appIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
 	app, err := models.GetTeamRegistration(ctx, tx, uint16(appID))
 	if err != nil {
 		return errors.Wrap(err, "models.GetTeamRegistration")
 	}
 	if app.Approved == nil || *app.Approved == 0 {
-		return b.Error("Failed to place team", "Application is not approved", i, *ack)
+		return b.Error(b.T(i, "teamapps.place.failed"), b.T(i, "teamapps.place.notapproved"), i, *ack)
 	}
 
 	leagueIDstr := i.MessageComponentData().Values[0]
@@ -41,19 +39,35 @@ func handlePlaceTeamLeagueSelect(
 		return errors.Wrap(err, "strconv.ParseUint")
 	}
 
+	before := *app
 	err = app.Place(ctx, tx, uint16(leagueID))
 	if err != nil {
 		if strings.Contains(err.Error(), "VE:") {
-			return b.Error("Failed to place team",
+			return b.Error(b.T(i, "teamapps.place.failed"),
 				strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
 		}
 		return errors.Wrap(err, "app.Place")
 	}
-
 	msg := fmt.Sprintf("%s has been placed in %s for %s",
 		app.TeamName, app.PlacedLeagueName, app.SeasonName)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTeamPlaced,
+		Entity:   "team_registration",
+		EntityID: strconv.FormatUint(uint64(appID), 10),
+		TeamID:   &app.TeamID,
+		Summary:  msg,
+	}, before, app)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	teamdiscord.SyncTeam(ctx, b, app.TeamID)
+	leagueroles.Sync(ctx, b)
 
-	err = b.SendDirectMessage("Team Application Approved", msg, app.ManagerID)
+	lang := b.UserLang(app.ManagerID)
+	err = b.SendDirectMessage(ctx, i18n.T(lang, "teamapps.approved.title"),
+		i18n.T(lang, "teamapps.placed", app.TeamName, app.PlacedLeagueName, app.SeasonName),
+		app.ManagerID)
 	if err != nil {
 		return errors.Wrap(err, "b.SendDirectMessage")
 	}
@@ -67,5 +81,6 @@ func handlePlaceTeamLeagueSelect(
 		return errors.Wrap(err, "updateAppMsg")
 	}
 	b.Log().UserEvent(i.Member, msg)
-	return b.FollowUp(msg, i)
+	return b.FollowUp(b.T(i, "teamapps.placed",
+		app.TeamName, app.PlacedLeagueName, app.SeasonName), i)
 }
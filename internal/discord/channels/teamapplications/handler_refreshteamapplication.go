@@ -5,7 +5,6 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
-	"strconv"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
@@ -17,13 +16,8 @@ func handleRefreshTeamApplication(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	appIDstr string,
+	appID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	appID, err := strconv.ParseUint(appIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
 	app, err := models.GetTeamRegistration(ctx, tx, uint16(appID))
 	if err != nil {
 		return errors.Wrap(err, "models.GetTeamRegistration")
@@ -32,5 +26,5 @@ func handleRefreshTeamApplication(
 	if err != nil {
 		return errors.Wrap(err, "updateAppMsg")
 	}
-	return b.FollowUp("Refreshed", i)
+	return b.FollowUp(b.T(i, "refreshed"), i)
 }
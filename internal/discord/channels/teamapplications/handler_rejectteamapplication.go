@@ -6,6 +6,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"strconv"
 
 	"github.com/bwmarrin/discordgo"
@@ -18,25 +19,33 @@ func handleRejectTeamApplication(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	appIDstr string,
+	appID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	appID, err := strconv.ParseUint(appIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
 	app, err := models.GetTeamRegistration(ctx, tx, uint16(appID))
 	if err != nil {
 		return errors.Wrap(err, "models.GetTeamRegistration")
 	}
 
+	before := *app
 	err = app.Reject(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "app.Reject")
 	}
-	err = b.SendDirectMessage("Team Application Rejected",
-		fmt.Sprintf("Your application for %s to play in %s has been rejected",
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTeamApplicationRejected,
+		Entity:   "team_registration",
+		EntityID: strconv.FormatUint(uint64(appID), 10),
+		TeamID:   &app.TeamID,
+		Summary: fmt.Sprintf("Application from %s for %s rejected",
 			app.TeamName, app.SeasonName),
+	}, before, app)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	lang := b.UserLang(app.ManagerID)
+	err = b.SendDirectMessage(ctx, i18n.T(lang, "teamapps.rejected.title"),
+		i18n.T(lang, "teamapps.rejected.message", app.TeamName, app.SeasonName),
 		app.ManagerID,
 	)
 	if err != nil {
@@ -49,5 +58,5 @@ func handleRejectTeamApplication(
 	}
 	msg := fmt.Sprintf("Application from %s rejected", app.TeamName)
 	b.Log().UserEvent(i.Member, msg)
-	return b.FollowUp(msg, i)
+	return b.FollowUp(b.T(i, "teamapps.rejected.reply", app.TeamName), i)
 }
@@ -0,0 +1,139 @@
+package teamapplications
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/teamrosters"
+	"gosl/internal/discord/teamdiscord"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+func handleApproveTeamRename(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+	reqID uint32,
+) error {
+	req, err := getRenameRequest(ctx, tx, reqID)
+	if err != nil {
+		return errors.Wrap(err, "getRenameRequest")
+	}
+	before := *req
+	err = req.Approve(ctx, tx)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "teamapps.rename.approve.failed"),
+				strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "req.Approve")
+	}
+	msg := fmt.Sprintf("%s has been renamed to %s (%s)",
+		req.TeamName, req.Name, req.Abbreviation)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTeamRenameApproved,
+		Entity:   "team_rename_request",
+		EntityID: strconv.FormatUint(uint64(reqID), 10),
+		TeamID:   &req.TeamID,
+		Summary:  msg,
+	}, before, req)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	teamdiscord.SyncTeam(ctx, b, req.TeamID)
+	lang := b.UserLang(req.ManagerID)
+	err = b.SendDirectMessage(ctx, i18n.T(lang, "teamapps.rename.approved.title"),
+		i18n.T(lang, "teamapps.rename.approved.message",
+			req.TeamName, req.TeamAbbr, req.Name, req.Abbreviation),
+		req.ManagerID,
+	)
+	if err != nil {
+		return errors.Wrap(err, "b.SendDirectMessage")
+	}
+	err = updateRenameMsg(ctx, tx, b, i, req)
+	if err != nil {
+		return errors.Wrap(err, "updateRenameMsg")
+	}
+	err = teamrosters.UpdateTeamRosters(ctx, b)
+	if err != nil {
+		return errors.Wrap(err, "teamrosters.UpdateTeamRosters")
+	}
+	b.Log().UserEvent(i.Member, msg)
+	return b.FollowUp(b.T(i, "teamapps.rename.approved.reply",
+		req.TeamName, req.Name, req.Abbreviation), i)
+}
+
+func handleRejectTeamRename(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+	reqID uint32,
+) error {
+	req, err := getRenameRequest(ctx, tx, reqID)
+	if err != nil {
+		return errors.Wrap(err, "getRenameRequest")
+	}
+	before := *req
+	err = req.Reject(ctx, tx)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "teamapps.rename.reject.failed"),
+				strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "req.Reject")
+	}
+	msg := fmt.Sprintf("Rename request from %s rejected", req.TeamName)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTeamRenameRejected,
+		Entity:   "team_rename_request",
+		EntityID: strconv.FormatUint(uint64(reqID), 10),
+		TeamID:   &req.TeamID,
+		Summary:  msg,
+	}, before, req)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	lang := b.UserLang(req.ManagerID)
+	err = b.SendDirectMessage(ctx, i18n.T(lang, "teamapps.rename.rejected.title"),
+		i18n.T(lang, "teamapps.rename.rejected.message",
+			req.TeamName, req.TeamAbbr, req.Name, req.Abbreviation),
+		req.ManagerID,
+	)
+	if err != nil {
+		return errors.Wrap(err, "b.SendDirectMessage")
+	}
+	err = updateRenameMsg(ctx, tx, b, i, req)
+	if err != nil {
+		return errors.Wrap(err, "updateRenameMsg")
+	}
+	b.Log().UserEvent(i.Member, msg)
+	return b.FollowUp(b.T(i, "teamapps.rename.rejected.reply", req.TeamName), i)
+}
+
+func getRenameRequest(
+	ctx context.Context,
+	tx db.SafeTX,
+	reqID uint32,
+) (*models.TeamRenameRequest, error) {
+	req, err := models.GetTeamRenameRequest(ctx, tx, reqID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetTeamRenameRequest")
+	}
+	if req == nil {
+		return nil, bot.ErrStaleInteraction
+	}
+	return req, nil
+}
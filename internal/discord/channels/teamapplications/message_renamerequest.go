@@ -0,0 +1,120 @@
+package teamapplications
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+func NewTeamRenameRequestMsg(ctx context.Context, b *bot.Bot) (*bot.DynamicMessage, error) {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.RBegin(timeout, "NewTeamRenameRequestMsg")
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Conn.RBegin")
+	}
+	defer tx.Rollback()
+	channelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelTeamApplications)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetChannel")
+	}
+	msg := bot.NewDynamicMessage("Team Rename Request", channelID, b)
+	return msg, nil
+}
+
+// Get the contents of the message for the rename request, in the language
+func TeamRenameRequestContents(
+	ctx context.Context,
+	tx db.SafeTX,
+	lang string,
+	req *models.TeamRenameRequest,
+) (*bot.MessageContents, error) {
+	team, err := models.GetTeamByID(ctx, tx, req.TeamID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetTeamByID")
+	}
+	statusMsg := i18n.T(lang, "status.pending")
+	if req.Approved != nil {
+		if *req.Approved == 1 {
+			statusMsg = i18n.T(lang, "status.approved")
+		} else {
+			statusMsg = i18n.T(lang, "status.rejected")
+		}
+	}
+	lastRenamed := i18n.T(lang, "teamapps.rename.never")
+	renamed, err := team.LastRenamed(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "team.LastRenamed")
+	}
+	if renamed != nil {
+		lastRenamed = bot.DiscordDate(renamed)
+	}
+	embed := &discordgo.MessageEmbed{
+		Color: team.Color,
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    fmt.Sprintf("%s (%s)", team.Name, team.Abbreviation),
+			IconURL: team.Logo,
+		},
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name: i18n.T(lang, "teamapps.rename.title"),
+				Value: "\n" + i18n.T(lang, "teamapps.rename.details",
+					req.TeamName, req.TeamName, req.TeamAbbr,
+					req.Name, req.Abbreviation, lastRenamed, statusMsg) + "\n",
+				Inline: false,
+			},
+		},
+	}
+	msgcomps := []discordgo.MessageComponent{
+		&discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				&discordgo.Button{
+					CustomID: fmt.Sprintf("approve_team_rename_%v", req.ID),
+					Label:    i18n.T(lang, "teamapps.rename.button.approve"),
+					Style:    discordgo.SuccessButton,
+					Disabled: req.Approved != nil,
+				},
+				&discordgo.Button{
+					CustomID: fmt.Sprintf("reject_team_rename_%v", req.ID),
+					Label:    i18n.T(lang, "teamapps.rename.button.reject"),
+					Style:    discordgo.DangerButton,
+					Disabled: req.Approved != nil,
+				},
+			},
+		},
+	}
+	contents := &bot.MessageContents{
+		Embed:      embed,
+		Components: msgcomps,
+	}
+	return contents, nil
+}
+
+func updateRenameMsg(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	req *models.TeamRenameRequest,
+) error {
+	reqMsg, err := b.GetDynamicMessage(ctx, tx, "Team Rename Request", i.Message.ID, i.ChannelID)
+	if err != nil {
+		return errors.Wrap(err, "b.GetDynamicMessage")
+	}
+	contents, err := TeamRenameRequestContents(ctx, tx, b.GuildLanguage(), req)
+	if err != nil {
+		return errors.Wrap(err, "TeamRenameRequestContents")
+	}
+	err = reqMsg.Expire(ctx, tx, contents)
+	if err != nil {
+		return errors.Wrap(err, "reqMsg.Expire")
+	}
+	return nil
+}
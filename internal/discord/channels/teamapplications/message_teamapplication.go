@@ -7,6 +7,7 @@ import (
 	"gosl/internal/discord/components"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"sort"
 	"time"
 
@@ -22,7 +23,7 @@ func NewTeamApplicationMsg(ctx context.Context, b *bot.Bot) (*bot.DynamicMessage
 		return nil, errors.Wrap(err, "b.Conn.RBegin")
 	}
 	defer tx.Rollback()
-	channelID, err := models.GetChannel(ctx, tx, models.ChannelTeamApplications)
+	channelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelTeamApplications)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetChannel")
 	}
@@ -30,9 +31,11 @@ func NewTeamApplicationMsg(ctx context.Context, b *bot.Bot) (*bot.DynamicMessage
 	return msg, nil
 }
 
+// Get the contents of the message for the application, in the language
 func TeamApplicationContents(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	app *models.TeamRegistration,
 ) (*bot.MessageContents, error) {
 	team, err := models.GetTeamByID(ctx, tx, app.TeamID)
@@ -44,7 +47,7 @@ func TeamApplicationContents(
 	if err != nil {
 		return nil, errors.Wrap(err, "team.Players")
 	}
-	playersmsg := "**Players:**"
+	playersmsg := i18n.T(lang, "teamapps.players")
 	sort.SliceStable(*currentPlayers, func(i, j int) bool {
 		if (*currentPlayers)[i].ID == team.ManagerID {
 			return true
@@ -56,22 +59,21 @@ func TeamApplicationContents(
 	})
 	for _, player := range *currentPlayers {
 		if player.ID == team.ManagerID {
-			playersmsg = playersmsg + "\n%s (Manager)"
+			playersmsg = playersmsg + "\n" + i18n.T(lang, "team.manager", player.Name)
 		} else {
-			playersmsg = playersmsg + "\n%s"
+			playersmsg = playersmsg + "\n" + player.Name
 		}
-		playersmsg = fmt.Sprintf(playersmsg, player.Name)
 	}
-	statusMsg := "Pending"
+	statusMsg := i18n.T(lang, "status.pending")
 	canPlace := false
 	if app.Approved != nil {
 		if *app.Approved == 1 && app.Placed == 0 {
-			statusMsg = "Approved"
+			statusMsg = i18n.T(lang, "status.approved")
 			canPlace = true
 		} else if *app.Approved == 1 && app.Placed != 0 {
-			statusMsg = "Placed"
+			statusMsg = i18n.T(lang, "status.placed")
 		} else {
-			statusMsg = "Rejected"
+			statusMsg = i18n.T(lang, "status.rejected")
 		}
 	}
 	leagues, err := models.GetLeagues(ctx, tx, app.SeasonID, false)
@@ -87,7 +89,7 @@ func TeamApplicationContents(
 	}
 	selectLeague := components.StringSelect(
 		fmt.Sprintf("place_team_league_select_%v", app.ID),
-		"Select League Placement",
+		i18n.T(lang, "teamapps.select.league"),
 		leagueOpts,
 		1,
 		1,
@@ -101,17 +103,10 @@ func TeamApplicationContents(
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name: "Team Application",
-				Value: fmt.Sprintf(`
-**%s has applied to join %s!**
-__Preferred League:__ %s
-__Status:__ %s
-__Placed In:__ %s
-
-%s
-`,
+				Name: i18n.T(lang, "teamapps.application.title"),
+				Value: "\n" + i18n.T(lang, "teamapps.application.details",
 					team.Name, app.SeasonName, app.PreferredLeague,
-					statusMsg, app.PlacedLeagueName, playersmsg),
+					statusMsg, app.PlacedLeagueName) + "\n\n" + playersmsg + "\n",
 				Inline: false,
 			},
 		},
@@ -121,19 +116,19 @@ __Placed In:__ %s
 			Components: []discordgo.MessageComponent{
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("approve_team_application_%v", app.ID),
-					Label:    "Approve application",
+					Label:    i18n.T(lang, "teamapps.button.approve"),
 					Style:    discordgo.SuccessButton,
 					Disabled: app.Approved != nil,
 				},
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("reject_team_application_%v", app.ID),
-					Label:    "Reject the application",
+					Label:    i18n.T(lang, "teamapps.button.reject"),
 					Style:    discordgo.DangerButton,
 					Disabled: app.Placed != 0,
 				},
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("refresh_team_application_%v", app.ID),
-					Label:    "Refresh",
+					Label:    i18n.T(lang, "button.refresh"),
 					Disabled: app.Placed != 0,
 				},
 				// TODO: add deleting team for rule breaking submissions
@@ -161,21 +156,21 @@ func updateAppMsg(
 	app *models.TeamRegistration,
 	locked bool,
 ) error {
-	appMsg, err := b.GetDynamicMessage("Team application", i.Message.ID, i.ChannelID)
+	appMsg, err := b.GetDynamicMessage(ctx, tx, "Team application", i.Message.ID, i.ChannelID)
 	if err != nil {
 		return errors.Wrap(err, "b.GetDynamicMessage")
 	}
-	contents, err := TeamApplicationContents(ctx, tx, app)
+	contents, err := TeamApplicationContents(ctx, tx, b.GuildLanguage(), app)
 	if err != nil {
 		return errors.Wrap(err, "TeamApplicationContents")
 	}
 	if locked {
-		err = appMsg.Expire(contents)
+		err = appMsg.Expire(ctx, tx, contents)
 		if err != nil {
 			return errors.Wrap(err, "appMsg.Expire")
 		}
 	} else {
-		err = appMsg.Update(contents)
+		err = appMsg.Update(ctx, contents)
 		if err != nil {
 			return errors.Wrap(err, "appMsg.Update")
 		}
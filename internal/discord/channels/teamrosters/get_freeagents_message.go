@@ -4,6 +4,7 @@ import (
 	"context"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 
 	"github.com/pkg/errors"
 )
@@ -11,6 +12,7 @@ import (
 func updateFreeAgentListsMessages(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	currentSeason *models.Season,
 	proFAsmsg *string,
 	imFAsmsg *string,
@@ -37,13 +39,13 @@ func updateFreeAgentListsMessages(
 		var msg *string
 		switch league.Division {
 		case "Pro":
-			*proFAsmsg = "__Pro Free Agents:__"
+			*proFAsmsg = i18n.T(lang, "rosters.freeagents.division", league.Division)
 			msg = proFAsmsg
 		case "IM":
-			*imFAsmsg = "\n__IM Free Agents:__"
+			*imFAsmsg = "\n" + i18n.T(lang, "rosters.freeagents.division", league.Division)
 			msg = imFAsmsg
 		case "Open":
-			*openFAsmsg = "\n__Open Free Agents:__"
+			*openFAsmsg = "\n" + i18n.T(lang, "rosters.freeagents.division", league.Division)
 			msg = openFAsmsg
 		}
 		for _, FA := range *FAs {
@@ -56,6 +58,7 @@ func updateFreeAgentListsMessages(
 func updateUnplacedFreeAgentsListMessage(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	currentSeason *models.Season,
 	unplacedFAsMsg *string,
 ) error {
@@ -69,7 +72,7 @@ func updateUnplacedFreeAgentsListMessage(
 	if len(*FAs) == 0 {
 		return nil
 	}
-	*unplacedFAsMsg = "__Approved Free Agents:__"
+	*unplacedFAsMsg = i18n.T(lang, "rosters.freeagents.approved")
 	for _, FA := range *FAs {
 		*unplacedFAsMsg = *unplacedFAsMsg + "\n - " + FA.Name
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@ import (
 func updateTeamListsMessages(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	currentSeason *models.Season,
 	proteamsmsg *string,
 	imteamsmsg *string,
@@ -39,13 +41,13 @@ func updateTeamListsMessages(
 		var msg *string
 		switch league.Division {
 		case "Pro":
-			*proteamsmsg = "__Pro Teams:__"
+			*proteamsmsg = i18n.T(lang, "rosters.teams.division", league.Division)
 			msg = proteamsmsg
 		case "IM":
-			*imteamsmsg = "\n__IM Teams:__"
+			*imteamsmsg = "\n" + i18n.T(lang, "rosters.teams.division", league.Division)
 			msg = imteamsmsg
 		case "Open":
-			*openteamsmsg = "\n__Open Teams:__"
+			*openteamsmsg = "\n" + i18n.T(lang, "rosters.teams.division", league.Division)
 			msg = openteamsmsg
 		}
 		for _, team := range *teams {
@@ -54,18 +56,16 @@ func updateTeamListsMessages(
 			if err != nil {
 				return errors.Wrap(err, "team.Players")
 			}
-			playerslist := "Players:"
+			playerslist := i18n.T(lang, "rosters.players")
 			for i, player := range *players {
-				playerslist = playerslist + player.Name
+				playerslist = playerslist + player.NameWithRank()
 				if i < len(*players)-1 {
 					playerslist = playerslist + ", "
 				}
 			}
-			*msg = fmt.Sprintf(`
-%s
-%s (%s) - managed by %s
-%s
-`, *msg, team.Name, team.Abbreviation, team.ManagerName, playerslist)
+			*msg = fmt.Sprintf("\n%s\n%s\n%s\n", *msg,
+				i18n.T(lang, "rosters.team", team.Name, team.Abbreviation, team.ManagerName),
+				playerslist)
 		}
 	}
 	return nil
@@ -74,6 +74,7 @@ func updateTeamListsMessages(
 func updateUnplacedTeamListMessage(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	currentSeason *models.Season,
 	unplacedTeamsMsg *string,
 ) error {
@@ -90,18 +91,17 @@ func updateUnplacedTeamListMessage(
 		if err != nil {
 			return errors.Wrap(err, "team.Players")
 		}
-		playerslist := "Players:"
+		playerslist := i18n.T(lang, "rosters.players")
 		for i, player := range *players {
-			playerslist = playerslist + player.Name
+			playerslist = playerslist + player.NameWithRank()
 			if i < len(*players)-1 {
 				playerslist = playerslist + ", "
 			}
 		}
-		*unplacedTeamsMsg = fmt.Sprintf(`
-__Approved Teams:__
-%s (%s) - managed by %s
-%s
-`, team.Name, team.Abbreviation, team.ManagerName, playerslist)
+		*unplacedTeamsMsg = fmt.Sprintf("\n%s\n%s\n%s\n",
+			i18n.T(lang, "rosters.teams.approved"),
+			i18n.T(lang, "rosters.team", team.Name, team.Abbreviation, team.ManagerName),
+			playerslist)
 	}
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -35,7 +36,7 @@ func teamRostersContents(
 	}
 	defer tx.Rollback()
 
-	contents, err := getTeamRostersContents(ctx, tx)
+	contents, err := getTeamRostersContents(ctx, tx, b.GuildID, b.GuildLanguage())
 	if err != nil {
 		return nil, errors.Wrap(err, "getTeamRostersContents")
 	}
@@ -46,6 +47,8 @@ func teamRostersContents(
 func getTeamRostersContents(
 	ctx context.Context,
 	tx db.SafeTX,
+	guildID string,
+	lang string,
 ) (*bot.MessageContents, error) {
 	proteamsmsg := ""
 	imteamsmsg := ""
@@ -55,37 +58,34 @@ func getTeamRostersContents(
 	openFAsmsg := ""
 	unplacedteamsmsg := ""
 	unplacedFAsmsg := ""
-	currentSeason, err := models.GetActiveSeason(ctx, tx)
+	currentSeason, err := models.GetActiveSeason(ctx, tx, guildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetActiveSeason")
 	}
-	err = updateTeamListsMessages(ctx, tx, currentSeason, &proteamsmsg, &imteamsmsg, &openteamsmsg)
+	err = updateTeamListsMessages(ctx, tx, lang, currentSeason, &proteamsmsg, &imteamsmsg, &openteamsmsg)
 	if err != nil {
 		return nil, errors.Wrap(err, "updateTeamListsMessages")
 	}
-	err = updateUnplacedTeamListMessage(ctx, tx, currentSeason, &unplacedteamsmsg)
+	err = updateUnplacedTeamListMessage(ctx, tx, lang, currentSeason, &unplacedteamsmsg)
 	if err != nil {
 		return nil, errors.Wrap(err, "updateUnplacedTeamListMessage")
 	}
-	err = updateFreeAgentListsMessages(ctx, tx, currentSeason, &proFAsmsg, &imFAsmsg, &openFAsmsg)
+	err = updateFreeAgentListsMessages(ctx, tx, lang, currentSeason, &proFAsmsg, &imFAsmsg, &openFAsmsg)
 	if err != nil {
 		return nil, errors.Wrap(err, "updateFreeAgentListsMessages")
 	}
-	err = updateUnplacedFreeAgentsListMessage(ctx, tx, currentSeason, &unplacedFAsmsg)
+	err = updateUnplacedFreeAgentsListMessage(ctx, tx, lang, currentSeason, &unplacedFAsmsg)
 	if err != nil {
 		return nil, errors.Wrap(err, "updateUnplacedFreeAgentsListMessage")
 	}
 
 	contents := &bot.MessageContents{
 		Embed: &discordgo.MessageEmbed{
-			Title: "Team/Free Agent Rosters!",
-			Description: fmt.Sprintf(`
-**Teams:**
-%s%s%s%s
-
-**Free Agents:**
-%s%s%s%s
-`, proteamsmsg, imteamsmsg, openteamsmsg, unplacedteamsmsg,
+			Title: i18n.T(lang, "rosters.title"),
+			Description: fmt.Sprintf("\n%s\n%s%s%s%s\n\n%s\n%s%s%s%s\n",
+				i18n.T(lang, "rosters.teams"),
+				proteamsmsg, imteamsmsg, openteamsmsg, unplacedteamsmsg,
+				i18n.T(lang, "rosters.freeagents"),
 				proFAsmsg, imFAsmsg, openFAsmsg, unplacedFAsmsg),
 		},
 		Components: []discordgo.MessageComponent{
@@ -93,7 +93,7 @@ func getTeamRostersContents(
 				Components: []discordgo.MessageComponent{
 					&discordgo.Button{
 						CustomID: "refresh_team_rosters",
-						Label:    "Refresh",
+						Label:    i18n.T(lang, "button.refresh"),
 					},
 				},
 			},
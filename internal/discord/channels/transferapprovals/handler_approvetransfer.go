@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/channels/teamrosters"
+	"gosl/internal/discord/channels/transfers"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"strconv"
 	"time"
 
@@ -20,24 +24,19 @@ func handleApproveTransfer(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	ptiIDstr string,
+	ptiID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	ptiID, err := strconv.ParseUint(ptiIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
-	pti, err := models.GetPlayerTeamInvite(ctx, tx, uint32(ptiID))
+	pti, err := models.GetPlayerTeamInvite(ctx, tx, ptiID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerTeamInvite")
 	}
 	if pti.Approved != nil {
 		updateRequestMsg(ctx, tx, b, i, pti, true)
-		return b.Error("Failed to approve transfer", "Transfer is not pending", i, *ack)
+		return b.Error(b.T(i, "transfers.approve.failed"), b.T(i, "transfers.notpending"), i, *ack)
 	}
 	if pti.Status != nil && *pti.Status == 0 {
 		updateRequestMsg(ctx, tx, b, i, pti, true)
-		return b.FollowUp("Transfer was rejected by the player", i)
+		return b.FollowUp(b.T(i, "transfers.playerrejected"), i)
 	}
 	team, err := models.GetTeamByID(ctx, tx, pti.TeamID)
 	if err != nil {
@@ -49,7 +48,7 @@ func handleApproveTransfer(
 		return errors.Wrap(err, "team.Players")
 	}
 	if len(*players) == 5 {
-		return b.Error("Failed to approve transfer", "Team has 5 players already", i, *ack)
+		return b.Error(b.T(i, "transfers.approve.failed"), b.T(i, "transfers.teamfull"), i, *ack)
 	}
 
 	player, err := models.GetPlayerByID(ctx, tx, pti.PlayerID)
@@ -57,13 +56,12 @@ func handleApproveTransfer(
 		return errors.Wrap(err, "models.GetPlayerByID")
 	}
 
+	before := *pti
 	err = pti.Approve(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "pti.Approve")
 	}
-	playermsg := fmt.Sprintf(
-		"Your invite to join %s has been approved. You have not yet accepted",
-		pti.TeamName)
+	outcome := "pending"
 	managermsg := fmt.Sprintf(
 		"The invite for %s to join %s has been approved. The player has not yet accepted",
 		pti.PlayerName, pti.TeamName)
@@ -76,20 +74,35 @@ func handleApproveTransfer(
 			// We manually rollback here to force the approval to revert
 			// without returning a system error
 			tx.Rollback()
-			return b.Error("Failed to approve transfer", "Player is already in a team", i, *ack)
+			return b.Error(b.T(i, "transfers.approve.failed"), b.T(i, "transfers.alreadyinteam"), i, *ack)
 		}
-		err = player.JoinTeam(ctx, tx, pti.TeamID)
+		err = player.JoinTeam(ctx, tx, pti.TeamID, models.MoveInvite, &pti.ID)
 		if err != nil {
 			return errors.Wrap(err, "player.JoinTeam")
 		}
-		playermsg = fmt.Sprintf(
-			"Your invite to join %s has been approved. You have now joined the team",
-			pti.TeamName)
+		teamdiscord.SyncTeam(ctx, b, pti.TeamID)
+		leagueroles.Sync(ctx, b)
+		transfers.Update(ctx, b, team.GuildID)
+		outcome = "joined"
 		managermsg = fmt.Sprintf(
 			"The invite for %s to join %s has been approved. The player has joined the team",
 			pti.PlayerName, pti.TeamName)
 	}
-	err = b.SendDirectMessage("Team Invite Approved", playermsg, player.DiscordID)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTransferApproved,
+		Entity:   "player_team_invite",
+		EntityID: strconv.FormatUint(uint64(ptiID), 10),
+		TeamID:   &pti.TeamID,
+		PlayerID: &pti.PlayerID,
+		Summary:  managermsg,
+	}, before, pti)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	lang := b.UserLang(player.DiscordID)
+	err = b.SendDirectMessage(ctx, i18n.T(lang, "transfers.approved.title"),
+		i18n.T(lang, "transfers.approved.player."+outcome, pti.TeamName), player.DiscordID)
 	if err != nil {
 		return errors.Wrap(err, "b.SendDirectMessage")
 	}
@@ -97,7 +110,10 @@ func handleApproveTransfer(
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByID")
 	}
-	err = b.SendDirectMessage("Team Invite Approved", managermsg, manager.DiscordID)
+	lang = b.UserLang(manager.DiscordID)
+	err = b.SendDirectMessage(ctx, i18n.T(lang, "transfers.approved.title"),
+		i18n.T(lang, "transfers.approved.manager."+outcome, pti.PlayerName, pti.TeamName),
+		manager.DiscordID)
 	if err != nil {
 		return errors.Wrap(err, "b.SendDirectMessage")
 	}
@@ -110,7 +126,7 @@ func handleApproveTransfer(
 	}
 
 	// TODO: add transfer window handling
-	err = b.FollowUp(managermsg, i)
+	err = b.FollowUp(b.T(i, "transfers.approved.manager."+outcome, pti.PlayerName, pti.TeamName), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
@@ -6,6 +6,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"strconv"
 
 	"github.com/bwmarrin/discordgo"
@@ -18,45 +19,54 @@ func handleRejectTransfer(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	ptiIDstr string,
+	ptiID uint32,
 ) error {
-	b.Acknowledge(i, ack)
-	ptiID, err := strconv.ParseUint(ptiIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
-	pti, err := models.GetPlayerTeamInvite(ctx, tx, uint32(ptiID))
+	pti, err := models.GetPlayerTeamInvite(ctx, tx, ptiID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerTeamInvite")
 	}
 	if pti.Approved != nil {
 		updateRequestMsg(ctx, tx, b, i, pti, true)
-		return b.Error("Failed to approve transfer", "Transfer is not pending", i, *ack)
+		return b.Error(b.T(i, "transfers.approve.failed"), b.T(i, "transfers.notpending"), i, *ack)
 	}
 	if pti.Status != nil && *pti.Status == 0 {
 		updateRequestMsg(ctx, tx, b, i, pti, true)
-		return b.FollowUp("Transfer was rejected by the player", i)
+		return b.FollowUp(b.T(i, "transfers.playerrejected"), i)
 	}
 	team, err := models.GetTeamByID(ctx, tx, pti.TeamID)
 	if err != nil {
 		return errors.Wrap(err, "models.GetTeamByID")
 	}
 
+	before := *pti
 	err = pti.Deny(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "pti.Deny")
 	}
-	playermsg := fmt.Sprintf("Your invite to join %s has been denied.", pti.TeamName)
 	managermsg := fmt.Sprintf(
 		"The invite for %s to join %s has been denied.",
 		pti.PlayerName, pti.TeamName)
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTransferDenied,
+		Entity:   "player_team_invite",
+		EntityID: strconv.FormatUint(uint64(ptiID), 10),
+		TeamID:   &pti.TeamID,
+		PlayerID: &pti.PlayerID,
+		Summary:  managermsg,
+	}, before, pti)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
 
 	if pti.Status == nil || *pti.Status == 1 {
 		player, err := models.GetPlayerByID(ctx, tx, pti.PlayerID)
 		if err != nil {
 			return errors.Wrap(err, "models.GetPlayerByID")
 		}
-		err = b.SendDirectMessage("Team Invite Denied", playermsg, player.DiscordID)
+		lang := b.UserLang(player.DiscordID)
+		err = b.SendDirectMessage(ctx, i18n.T(lang, "transfers.denied.title"),
+			i18n.T(lang, "transfers.denied.player", pti.TeamName), player.DiscordID)
 		if err != nil {
 			return errors.Wrap(err, "b.SendDirectMessage")
 		}
@@ -64,7 +74,10 @@ func handleRejectTransfer(
 		if err != nil {
 			return errors.Wrap(err, "models.GetPlayerByID")
 		}
-		err = b.SendDirectMessage("Team Invite Denied", managermsg, manager.DiscordID)
+		lang = b.UserLang(manager.DiscordID)
+		err = b.SendDirectMessage(ctx, i18n.T(lang, "transfers.denied.title"),
+			i18n.T(lang, "transfers.denied.manager", pti.PlayerName, pti.TeamName),
+			manager.DiscordID)
 		if err != nil {
 			return errors.Wrap(err, "b.SendDirectMessage")
 		}
@@ -74,7 +87,7 @@ func handleRejectTransfer(
 	updateRequestMsg(ctx, tx, b, i, pti, true)
 
 	// TODO: add transfer window handling
-	err = b.FollowUp(managermsg, i)
+	err = b.FollowUp(b.T(i, "transfers.denied.manager", pti.PlayerName, pti.TeamName), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -18,13 +19,11 @@ func transferapprovalsinfoContents(
 	ctx context.Context,
 	b *bot.Bot,
 ) (*bot.MessageContents, error) {
+	lang := b.GuildLanguage()
 	contents := &bot.MessageContents{
 		Embed: &discordgo.MessageEmbed{
-			Title: "Transfer Approvals",
-			Description: `
-When players are invited to join a team after their application has been approved, they will require approval.
-These will appear in this channel as transfer requests that require staff approval.
-`,
+			Title:       i18n.T(lang, "transfers.info.title"),
+			Description: "\n" + i18n.T(lang, "transfers.info.description") + "\n",
 		},
 		Components: []discordgo.MessageComponent{},
 	}
@@ -6,6 +6,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -20,7 +21,7 @@ func NewTransferRequestMsg(ctx context.Context, b *bot.Bot) (*bot.DynamicMessage
 		return nil, errors.Wrap(err, "b.Conn.RBegin")
 	}
 	defer tx.Rollback()
-	channelID, err := models.GetChannel(ctx, tx, models.ChannelTransferApprovals)
+	channelID, err := models.GetChannel(ctx, tx, b.GuildID, models.ChannelTransferApprovals)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetChannel")
 	}
@@ -31,14 +32,15 @@ func NewTransferRequestMsg(ctx context.Context, b *bot.Bot) (*bot.DynamicMessage
 func TransferRequestContents(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	pti *models.PlayerTeamInvite,
 ) (*bot.MessageContents, error) {
+	msg := i18n.T(lang, "transfers.request."+pti.Offer, pti.PlayerName, pti.TeamName)
 	embed := &discordgo.MessageEmbed{
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name: "Transfer Request",
-				Value: fmt.Sprintf(`**%s has been invited to join %s!**`,
-					pti.PlayerName, pti.TeamName),
+				Name:   i18n.T(lang, "transfers.request.title"),
+				Value:  msg,
 				Inline: false,
 			},
 		},
@@ -48,12 +50,12 @@ func TransferRequestContents(
 			Components: []discordgo.MessageComponent{
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("approve_transfer_%v", pti.ID),
-					Label:    "Approve application",
+					Label:    i18n.T(lang, "transfers.button.approve"),
 					Style:    discordgo.SuccessButton,
 				},
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("reject_transfer_%v", pti.ID),
-					Label:    "Reject the application",
+					Label:    i18n.T(lang, "transfers.button.reject"),
 					Style:    discordgo.DangerButton,
 				},
 			},
@@ -74,21 +76,21 @@ func updateRequestMsg(
 	pti *models.PlayerTeamInvite,
 	remove bool,
 ) error {
-	reqMsg, err := b.GetDynamicMessage("Transfer request", i.Message.ID, i.ChannelID)
+	reqMsg, err := b.GetDynamicMessage(ctx, tx, "Transfer request", i.Message.ID, i.ChannelID)
 	if err != nil {
 		return errors.Wrap(err, "b.GetDynamicMessage")
 	}
-	contents, err := TransferRequestContents(ctx, tx, pti)
+	contents, err := TransferRequestContents(ctx, tx, b.GuildLanguage(), pti)
 	if err != nil {
 		return errors.Wrap(err, "TransferRequestContents")
 	}
 	if remove {
-		err = reqMsg.Delete(contents)
+		err = reqMsg.Delete(ctx, tx, contents)
 		if err != nil {
 			return errors.Wrap(err, "reqMsg.Delete")
 		}
 	} else {
-		err = reqMsg.Update(contents)
+		err = reqMsg.Update(ctx, contents)
 		if err != nil {
 			return errors.Wrap(err, "reqMsg.Update")
 		}
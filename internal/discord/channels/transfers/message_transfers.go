@@ -0,0 +1,123 @@
+package transfers
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/util"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Most transfers listed in the feed, older ones are counted instead so the
+// embed stays within discord's size limits
+const maxFeedLines = 30
+
+var transfersFeed = &bot.Message{
+	Label:       "Transfers",
+	Purpose:     models.MsgTransfers,
+	GetContents: transfersContents,
+}
+
+func transfersContents(
+	ctx context.Context,
+	b *bot.Bot,
+) (*bot.MessageContents, error) {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// we use a WTX here to force it to block until commit of the transaction
+	// that moved the players, same as the team rosters
+	tx, err := b.Conn.Begin(timeout, "transfersContents()")
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	embed, err := TransfersEmbed(ctx, tx, b.GuildLanguage(), b.GuildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "TransfersEmbed")
+	}
+	return &bot.MessageContents{Embed: embed}, nil
+}
+
+// Build the embed listing the players that have joined or left teams in the
+// guild during the current transfer window. If the active season has no
+// transfer windows the moves since the season started are listed instead
+func TransfersEmbed(
+	ctx context.Context,
+	tx db.SafeTX,
+	lang string,
+	guildID string,
+) (*discordgo.MessageEmbed, error) {
+	title := i18n.T(lang, "transfers.feed.title")
+	since := time.Time{}
+	season, err := models.GetActiveSeason(ctx, tx, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetActiveSeason")
+	}
+	if season != nil {
+		window, err := models.GetCurrentTransferWindow(ctx, tx, season.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "models.GetCurrentTransferWindow")
+		}
+		if window != nil {
+			since = window.Start
+			title = i18n.T(lang, "transfers.feed.window", season.Name)
+		} else if season.Start != nil {
+			since = *season.Start
+			title = i18n.T(lang, "transfers.feed.season", season.Name)
+		}
+	}
+	transfers, err := models.GetTransfersSince(ctx, tx, guildID, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetTransfersSince")
+	}
+	description := ""
+	for idx, transfer := range *transfers {
+		if idx == maxFeedLines {
+			description = description + i18n.T(lang, "transfers.feed.more", len(*transfers)-idx)
+			break
+		}
+		description = description + util.TransferLine(&transfer) + "\n"
+	}
+	if description == "" {
+		description = i18n.T(lang, "transfers.feed.none")
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       0xeb7d34,
+	}
+	return embed, nil
+}
+
+// Update the transfers feed of the guild with the latest moves. Does nothing
+// if the guild isn't served by the bot.
+//
+// Runs in the background so it doesnt block/get blocked by the transaction
+// that moved the players, and runs as soon as that transaction is committed
+func Update(ctx context.Context, b *bot.Bot, guildID string) {
+	b = b.Guild(guildID)
+	if b == nil {
+		return
+	}
+	msg, err := b.GetMessage(models.ChannelTransfers, models.MsgTransfers)
+	if err != nil {
+		b.DoubleError("Failed to update transfers", errors.Wrap(err, "b.GetMessage"))
+		return
+	}
+	msg.StartUpdate(true)
+	go func() {
+		b.Logger.Debug().Msg("Updating transfers")
+		errch := make(chan error)
+		go msg.Update(ctx, errch)
+		for err := range errch {
+			if err != nil {
+				b.DoubleError("Failed to update transfers", err)
+			}
+		}
+	}()
+}
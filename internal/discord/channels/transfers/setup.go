@@ -0,0 +1,42 @@
+package transfers
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+func Setup(
+	wg *sync.WaitGroup,
+	errch chan error,
+	ctx context.Context,
+	b *bot.Bot,
+) {
+	defer wg.Done()
+	channel := &bot.Channel{
+		Purpose: models.ChannelTransfers,
+		Name:    "transfers",
+		Label:   "Transfers channel",
+	}
+	err := b.AddChannel(channel)
+	if err != nil {
+		errch <- errors.Wrap(err, "b.AddChannel")
+		return
+	}
+	err = channel.Setup(ctx, true)
+	if err != nil {
+		errch <- errors.Wrap(err, "channel.Setup")
+		return
+	}
+	err = channel.RegisterMessage(transfersFeed)
+	if err != nil {
+		errch <- errors.Wrap(err, "channel.RegisterMessage")
+		return
+	}
+	var mwg sync.WaitGroup
+	mwg.Add(1)
+	channel.SetupMessages(ctx, &mwg, errch)
+}
@@ -0,0 +1,867 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/teamrosters"
+	"gosl/internal/discord/channels/transfers"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Result of an admin action, used to sync discord once the change is committed
+type adminResult struct {
+	msg       string   // summary of the change shown to the admin and logged
+	teams     []uint16 // teams whose roles and channels need syncing
+	rosters   bool     // the team rosters need updating
+	transfers bool     // players joined or left teams so the transfers feed needs updating
+}
+
+type adminOptions map[string]*discordgo.ApplicationCommandInteractionDataOption
+
+// Handles a /admin subcommand. Returns an error starting with "VE:" if the
+// change is not valid
+type adminAction func(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+) (*adminResult, error)
+
+func cmdAdmin(ctx context.Context, b *bot.Bot) *Command {
+	playerOpt := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "player",
+		Description: "Name or slap ID of the player",
+		Required:    true,
+	}
+	teamOpt := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "team",
+		Description: "Name or abbreviation of the team",
+		Required:    true,
+	}
+	dateOpt := func(name, description string) *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        name,
+			Description: description + " (DD/MM/YYYY), defaults to now",
+		}
+	}
+	registrationOpts := []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "type",
+			Description: "Type of the registration",
+			Required:    true,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Team", Value: "team"},
+				{Name: "Free Agent", Value: "freeagent"},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "id",
+			Description: "ID of the registration",
+			Required:    true,
+			MinValue:    &[]float64{1}[0],
+		},
+	}
+	actions := map[string]adminAction{
+		"rename-player":        adminRenamePlayer,
+		"set-slapid":           adminSetSlapID,
+		"relink-discord":       adminRelinkDiscord,
+		"add-to-team":          adminAddToTeam(b.Config.Locale),
+		"remove-from-team":     adminRemoveFromTeam(b.Config.Locale),
+		"set-manager":          adminSetManager,
+		"unplace-registration": adminUnplaceRegistration,
+		"place-registration":   adminPlaceRegistration,
+		"undo-invite":          adminUndoInvite,
+	}
+	return &Command{
+		Name:        "admin",
+		Description: "Correct league data",
+		Handler:     handleAdmin(ctx, b, actions),
+		Permission:  models.PermAdmin,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "rename-player",
+				Description: "Change the display name of a player",
+				Options: []*discordgo.ApplicationCommandOption{
+					playerOpt,
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name",
+						Description: "New display name",
+						Required:    true,
+						MaxLength:   64,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "set-slapid",
+				Description: "Change the slap ID of a player",
+				Options: []*discordgo.ApplicationCommandOption{
+					playerOpt,
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "slapid",
+						Description: "New slap ID",
+						Required:    true,
+						MinValue:    &[]float64{1}[0],
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "relink-discord",
+				Description: "Link a player to a different discord account",
+				Options: []*discordgo.ApplicationCommandOption{
+					playerOpt,
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "user",
+						Description: "Discord account to link",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "add-to-team",
+				Description: "Add a player to a team",
+				Options: []*discordgo.ApplicationCommandOption{
+					playerOpt,
+					teamOpt,
+					dateOpt("joined", "Date the player joined"),
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "remove-from-team",
+				Description: "Remove a player from their current team",
+				Options: []*discordgo.ApplicationCommandOption{
+					playerOpt,
+					dateOpt("left", "Date the player left"),
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "set-manager",
+				Description: "Change the manager of a team",
+				Options: []*discordgo.ApplicationCommandOption{
+					teamOpt,
+					playerOpt,
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "unplace-registration",
+				Description: "Remove a registration from the league it was placed in",
+				Options:     registrationOpts,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "place-registration",
+				Description: "Place a registration into a league, replacing any placement",
+				Options: append(registrationOpts, &discordgo.ApplicationCommandOption{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "league",
+					Description: "League to place the registration in",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Pro", Value: "Pro"},
+						{Name: "IM", Value: "IM"},
+						{Name: "Open", Value: "Open"},
+					},
+				}),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "undo-invite",
+				Description: "Return an invite to pending by undoing its latest decision",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "id",
+						Description: "ID of the invite",
+						Required:    true,
+						MinValue:    &[]float64{1}[0],
+					},
+				},
+			},
+		},
+	}
+}
+
+func handleAdmin(
+	ctx context.Context,
+	b *bot.Bot,
+	actions map[string]adminAction,
+) bot.Handler {
+	return func(i *discordgo.InteractionCreate) {
+		b.Acknowledge(i, nil)
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		tx, err := b.Conn.Begin(timeout, "Handle /admin command")
+		if err != nil {
+			b.TripleError("Unexpected error", err, i, true)
+			return
+		}
+		defer tx.Rollback()
+		member := i.Member
+		if member == nil {
+			err = b.Schedule(timeout, bot.PriorityInteraction, http.MethodGet,
+				discordgo.EndpointGuildMember(b.GuildID, i.User.ID))
+			if err != nil {
+				b.TripleError("Unexpected error", errors.Wrap(err, "b.Schedule"), i, true)
+				return
+			}
+			member, err = b.Session.GuildMember(b.GuildID, i.User.ID)
+			if err != nil {
+				b.TripleError("Unexpected error", err, i, true)
+				return
+			}
+		}
+		isAdmin, err := models.MemberHasPermission(ctx, tx,
+			b.GuildID, member, models.PermAdmin)
+		if err != nil {
+			b.TripleError("Unexpected error", err, i, true)
+			return
+		}
+		if !isAdmin {
+			b.Forbidden(i, true)
+			return
+		}
+
+		sub := i.ApplicationCommandData().Options[0]
+		action, exists := actions[sub.Name]
+		if !exists {
+			b.TripleError("Unexpected error",
+				errors.New("Unknown /admin subcommand: "+sub.Name), i, true)
+			return
+		}
+		opts := adminOptions{}
+		for _, opt := range sub.Options {
+			opts[opt.Name] = opt
+		}
+		result, err := action(ctx, tx, i, opts)
+		if err != nil {
+			if strings.Contains(err.Error(), "VE:") {
+				err = b.Error("Failed to "+strings.ReplaceAll(sub.Name, "-", " "),
+					strings.TrimPrefix(err.Error(), "VE:"), i, true)
+				if err != nil {
+					b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+				}
+				return
+			}
+			b.TripleError("Unexpected error", errors.Wrap(err, sub.Name), i, true)
+			return
+		}
+		tx.Commit()
+
+		for _, teamID := range result.teams {
+			teamdiscord.SyncTeam(ctx, b, teamID)
+		}
+		if len(result.teams) > 0 {
+			leagueroles.Sync(ctx, b)
+		}
+		if result.rosters {
+			err = teamrosters.UpdateTeamRosters(ctx, b)
+			if err != nil {
+				b.Logger.Error().Err(err).Msg("Failed to update team rosters")
+			}
+		}
+		if result.transfers {
+			transfers.Update(ctx, b, b.GuildID)
+		}
+		b.Log().UserEvent(member, result.msg)
+		err = b.FollowUp(result.msg, i)
+		if err != nil {
+			b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
+		}
+	}
+}
+
+// Find the player by slap ID, or by name if no player has that slap ID
+func adminPlayer(
+	ctx context.Context,
+	tx db.SafeTX,
+	opts adminOptions,
+) (*models.Player, error) {
+	search := strings.TrimSpace(opts["player"].StringValue())
+	if slapID, err := strconv.ParseUint(search, 10, 32); err == nil {
+		player, err := models.GetPlayerBySlapID(ctx, tx, uint32(slapID))
+		if err != nil {
+			return nil, errors.Wrap(err, "models.GetPlayerBySlapID")
+		}
+		if player != nil {
+			return player, nil
+		}
+	}
+	player, err := models.GetPlayerByName(ctx, tx, search)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetPlayerByName")
+	}
+	if player == nil {
+		return nil, errors.New("VE:No player has the name or slap ID '" + search + "'")
+	}
+	return player, nil
+}
+
+func adminTeam(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+	opts adminOptions,
+) (*models.Team, error) {
+	search := strings.TrimSpace(opts["team"].StringValue())
+	team, err := models.GetTeamByName(ctx, tx, guildID, search)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetTeamByName")
+	}
+	if team == nil {
+		return nil, errors.New("VE:No team has the name or abbreviation '" + search + "'")
+	}
+	return team, nil
+}
+
+// Get the date from the option in the admin's timezone, or now if not given.
+// Admins that haven't set a timezone use the league's locale. Dates can't be
+// in the future
+func adminDate(
+	ctx context.Context,
+	tx db.SafeTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+	name string,
+	locale string,
+) (time.Time, error) {
+	now := time.Now()
+	opt, exists := opts[name]
+	if !exists {
+		return now, nil
+	}
+	loc, err := models.GetPlayerLocation(ctx, tx, bot.AuditActor(i).DiscordID, locale)
+	if err != nil {
+		return now, errors.Wrap(err, "models.GetPlayerLocation")
+	}
+	date, err := time.ParseInLocation("02/01/2006", strings.TrimSpace(opt.StringValue()), loc)
+	if err != nil {
+		return now, errors.New("VE:Dates must be in the format DD/MM/YYYY")
+	}
+	if date.After(now) {
+		return now, errors.New("VE:Date can't be in the future")
+	}
+	return date, nil
+}
+
+func auditPlayerEntry(
+	i *discordgo.InteractionCreate,
+	action string,
+	player *models.Player,
+	teamID *uint16,
+	msg string,
+) *models.AuditEntry {
+	return &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   action,
+		Entity:   "player",
+		EntityID: strconv.FormatUint(uint64(player.ID), 10),
+		TeamID:   teamID,
+		PlayerID: &player.ID,
+		Summary:  msg,
+	}
+}
+
+func adminRenamePlayer(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+) (*adminResult, error) {
+	player, err := adminPlayer(ctx, tx, opts)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(opts["name"].StringValue())
+	if name == "" {
+		return nil, errors.New("VE:Display name can't be empty")
+	}
+	before := *player
+	err = player.UpdateName(ctx, tx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "player.UpdateName")
+	}
+	msg := fmt.Sprintf("%s has been renamed to %s", before.Name, player.Name)
+	err = models.RecordAudit(ctx, tx, i.GuildID,
+		auditPlayerEntry(i, models.AuditPlayerEdited, player, nil, msg), before, player)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.RecordAudit")
+	}
+	team, err := player.CurrentTeam(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "player.CurrentTeam")
+	}
+	return &adminResult{msg: msg, rosters: team != nil}, nil
+}
+
+func adminSetSlapID(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+) (*adminResult, error) {
+	player, err := adminPlayer(ctx, tx, opts)
+	if err != nil {
+		return nil, err
+	}
+	before := *player
+	err = player.UpdateSlapID(ctx, tx, uint32(opts["slapid"].IntValue()))
+	if err != nil {
+		return nil, errors.Wrap(err, "player.UpdateSlapID")
+	}
+	msg := fmt.Sprintf("Slap ID of %s has been changed from %v to %v",
+		player.Name, before.SlapID, player.SlapID)
+	err = models.RecordAudit(ctx, tx, i.GuildID,
+		auditPlayerEntry(i, models.AuditPlayerEdited, player, nil, msg), before, player)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.RecordAudit")
+	}
+	return &adminResult{msg: msg}, nil
+}
+
+func adminRelinkDiscord(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+) (*adminResult, error) {
+	player, err := adminPlayer(ctx, tx, opts)
+	if err != nil {
+		return nil, err
+	}
+	user := opts["user"].UserValue(nil)
+	if user.ID == player.DiscordID {
+		return nil, errors.New("VE:" + player.Name + " is already linked to that account")
+	}
+	before := *player
+	err = player.UpdateDiscordID(ctx, tx, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "player.UpdateDiscordID")
+	}
+	msg := fmt.Sprintf("%s has been linked to <@%s> (previously <@%s>)",
+		player.Name, player.DiscordID, before.DiscordID)
+	result := &adminResult{msg: msg}
+	team, err := player.CurrentTeam(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "player.CurrentTeam")
+	}
+	var teamID *uint16
+	if team != nil {
+		teamID = &team.TeamID
+		result.teams = []uint16{team.TeamID}
+		result.rosters = true
+	}
+	err = models.RecordAudit(ctx, tx, i.GuildID,
+		auditPlayerEntry(i, models.AuditPlayerRelinked, player, teamID, msg), before, player)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.RecordAudit")
+	}
+	return result, nil
+}
+
+func adminAddToTeam(locale string) adminAction {
+	return func(
+		ctx context.Context,
+		tx *db.SafeWTX,
+		i *discordgo.InteractionCreate,
+		opts adminOptions,
+	) (*adminResult, error) {
+		player, err := adminPlayer(ctx, tx, opts)
+		if err != nil {
+			return nil, err
+		}
+		team, err := adminTeam(ctx, tx, i.GuildID, opts)
+		if err != nil {
+			return nil, err
+		}
+		joined, err := adminDate(ctx, tx, i, opts, "joined", locale)
+		if err != nil {
+			return nil, err
+		}
+		current, err := player.CurrentTeam(ctx, tx)
+		if err != nil {
+			return nil, errors.Wrap(err, "player.CurrentTeam")
+		}
+		if current != nil {
+			return nil, errors.New("VE:" + player.Name + " is already on " + current.TeamName)
+		}
+		err = player.JoinTeamAt(ctx, tx, team.ID, joined, models.MoveAdmin, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "player.JoinTeamAt")
+		}
+		msg := fmt.Sprintf("%s has been added to %s as of %s",
+			player.Name, team.Name, models.DateStr(&joined))
+		err = models.RecordAudit(ctx, tx, i.GuildID, &models.AuditEntry{
+			Actor:    bot.AuditActor(i),
+			Action:   models.AuditPlayerAddedToTeam,
+			Entity:   "player_team",
+			EntityID: strconv.FormatUint(uint64(player.ID), 10),
+			TeamID:   &team.ID,
+			PlayerID: &player.ID,
+			Summary:  msg,
+		}, nil, map[string]any{"team_id": team.ID, "joined": joined})
+		if err != nil {
+			return nil, errors.Wrap(err, "models.RecordAudit")
+		}
+		return &adminResult{
+			msg: msg, teams: []uint16{team.ID}, rosters: true, transfers: true,
+		}, nil
+	}
+}
+
+func adminRemoveFromTeam(locale string) adminAction {
+	return func(
+		ctx context.Context,
+		tx *db.SafeWTX,
+		i *discordgo.InteractionCreate,
+		opts adminOptions,
+	) (*adminResult, error) {
+		player, err := adminPlayer(ctx, tx, opts)
+		if err != nil {
+			return nil, err
+		}
+		left, err := adminDate(ctx, tx, i, opts, "left", locale)
+		if err != nil {
+			return nil, err
+		}
+		current, err := player.CurrentTeam(ctx, tx)
+		if err != nil {
+			return nil, errors.Wrap(err, "player.CurrentTeam")
+		}
+		if current == nil {
+			return nil, errors.New("VE:" + player.Name + " is not on a team")
+		}
+		if current.ManagerID == player.ID {
+			return nil, errors.New("VE:" + player.Name + " is the manager of " +
+				current.TeamName + ", change the manager first")
+		}
+		err = player.LeaveTeamAt(ctx, tx, current.TeamID, left, models.MoveAdmin)
+		if err != nil {
+			return nil, errors.Wrap(err, "player.LeaveTeamAt")
+		}
+		msg := fmt.Sprintf("%s has been removed from %s as of %s",
+			player.Name, current.TeamName, models.DateStr(&left))
+		err = models.RecordAudit(ctx, tx, i.GuildID, &models.AuditEntry{
+			Actor:    bot.AuditActor(i),
+			Action:   models.AuditPlayerRemovedFromTeam,
+			Entity:   "player_team",
+			EntityID: strconv.FormatUint(uint64(player.ID), 10),
+			TeamID:   &current.TeamID,
+			PlayerID: &player.ID,
+			Summary:  msg,
+		}, current, map[string]any{"team_id": current.TeamID, "left": left})
+		if err != nil {
+			return nil, errors.Wrap(err, "models.RecordAudit")
+		}
+		return &adminResult{
+			msg: msg, teams: []uint16{current.TeamID}, rosters: true, transfers: true,
+		}, nil
+	}
+}
+
+func adminSetManager(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+) (*adminResult, error) {
+	team, err := adminTeam(ctx, tx, i.GuildID, opts)
+	if err != nil {
+		return nil, err
+	}
+	player, err := adminPlayer(ctx, tx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if team.ManagerID == player.ID {
+		return nil, errors.New("VE:" + player.Name + " is already the manager of " + team.Name)
+	}
+	before := *team
+	err = team.SetManager(ctx, tx, player)
+	if err != nil {
+		return nil, errors.Wrap(err, "team.SetManager")
+	}
+	msg := fmt.Sprintf("%s is now the manager of %s (previously %s)",
+		player.Name, team.Name, before.ManagerName)
+	err = models.RecordAudit(ctx, tx, i.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTeamManagerChanged,
+		Entity:   "team",
+		EntityID: strconv.FormatUint(uint64(team.ID), 10),
+		TeamID:   &team.ID,
+		PlayerID: &player.ID,
+		Summary:  msg,
+	}, before, team)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.RecordAudit")
+	}
+	return &adminResult{msg: msg, teams: []uint16{team.ID}, rosters: true}, nil
+}
+
+// Either a team or free agent registration, selected by the type option
+type adminRegistration struct {
+	team      *models.TeamRegistration
+	freeAgent *models.FreeAgentRegistration
+}
+
+func getAdminRegistration(
+	ctx context.Context,
+	tx db.SafeTX,
+	opts adminOptions,
+) (*adminRegistration, error) {
+	id := opts["id"].IntValue()
+	if opts["type"].StringValue() == "team" {
+		reg, err := models.GetTeamRegistration(ctx, tx, uint16(id))
+		if err != nil {
+			if errors.Cause(err) == sql.ErrNoRows {
+				return nil, errors.New(fmt.Sprintf("VE:No team registration has the ID %v", id))
+			}
+			return nil, errors.Wrap(err, "models.GetTeamRegistration")
+		}
+		return &adminRegistration{team: reg}, nil
+	}
+	reg, err := models.GetFreeAgentRegistration(ctx, tx, uint32(id))
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, errors.New(fmt.Sprintf("VE:No free agent registration has the ID %v", id))
+		}
+		return nil, errors.Wrap(err, "models.GetFreeAgentRegistration")
+	}
+	return &adminRegistration{freeAgent: reg}, nil
+}
+
+// Remove the registration from its league and record it in the audit log.
+// Returns the summary of the change
+func (r *adminRegistration) unplace(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+) (string, error) {
+	if r.team != nil {
+		before := *r.team
+		err := r.team.Unplace(ctx, tx)
+		if err != nil {
+			return "", errors.Wrap(err, "registration.Unplace")
+		}
+		msg := fmt.Sprintf("%s has been removed from %s for %s",
+			r.team.TeamName, before.PlacedLeagueName, r.team.SeasonName)
+		err = models.RecordAudit(ctx, tx, i.GuildID, &models.AuditEntry{
+			Actor:    bot.AuditActor(i),
+			Action:   models.AuditTeamUnplaced,
+			Entity:   "team_registration",
+			EntityID: strconv.FormatUint(uint64(r.team.ID), 10),
+			TeamID:   &r.team.TeamID,
+			Summary:  msg,
+		}, before, r.team)
+		if err != nil {
+			return "", errors.Wrap(err, "models.RecordAudit")
+		}
+		return msg, nil
+	}
+	before := *r.freeAgent
+	err := r.freeAgent.Unplace(ctx, tx)
+	if err != nil {
+		return "", errors.Wrap(err, "registration.Unplace")
+	}
+	msg := fmt.Sprintf("%s has been removed from %s for %s",
+		r.freeAgent.PlayerName, before.PlacedLeagueName, r.freeAgent.SeasonName)
+	err = models.RecordAudit(ctx, tx, i.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditFreeAgentUnplaced,
+		Entity:   "free_agent_registration",
+		EntityID: strconv.FormatUint(uint64(r.freeAgent.ID), 10),
+		PlayerID: &r.freeAgent.PlayerID,
+		Summary:  msg,
+	}, before, r.freeAgent)
+	if err != nil {
+		return "", errors.Wrap(err, "models.RecordAudit")
+	}
+	return msg, nil
+}
+
+// Teams affected by a change to the registration
+func (r *adminRegistration) teams() []uint16 {
+	if r.team != nil {
+		return []uint16{r.team.TeamID}
+	}
+	return nil
+}
+
+func adminUnplaceRegistration(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+) (*adminResult, error) {
+	reg, err := getAdminRegistration(ctx, tx, opts)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := reg.unplace(ctx, tx, i)
+	if err != nil {
+		return nil, err
+	}
+	return &adminResult{msg: msg, teams: reg.teams(), rosters: reg.team != nil}, nil
+}
+
+func adminPlaceRegistration(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+) (*adminResult, error) {
+	reg, err := getAdminRegistration(ctx, tx, opts)
+	if err != nil {
+		return nil, err
+	}
+	seasonID, approved, placed := reg.status()
+	if approved == nil || *approved == 0 {
+		return nil, errors.New("VE:Registration is not approved")
+	}
+	division := opts["league"].StringValue()
+	leagues, err := models.GetLeagues(ctx, tx, seasonID, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetLeagues")
+	}
+	var leagueID uint16
+	for _, league := range *leagues {
+		if league.Division == division {
+			leagueID = league.ID
+		}
+	}
+	if leagueID == 0 {
+		return nil, errors.New("VE:The " + division + " league is not enabled for the season")
+	}
+	if placed == leagueID {
+		return nil, errors.New("VE:Registration is already placed in " + division)
+	}
+	if placed != 0 {
+		_, err = reg.unplace(ctx, tx, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	name, season := reg.describe()
+	msg := fmt.Sprintf("%s has been placed in %s for %s", name, division, season)
+	if reg.team != nil {
+		before := *reg.team
+		err = reg.team.Place(ctx, tx, leagueID)
+		if err != nil {
+			return nil, errors.Wrap(err, "registration.Place")
+		}
+		err = models.RecordAudit(ctx, tx, i.GuildID, &models.AuditEntry{
+			Actor:    bot.AuditActor(i),
+			Action:   models.AuditTeamPlaced,
+			Entity:   "team_registration",
+			EntityID: strconv.FormatUint(uint64(reg.team.ID), 10),
+			TeamID:   &reg.team.TeamID,
+			Summary:  msg,
+		}, before, reg.team)
+	} else {
+		before := *reg.freeAgent
+		err = reg.freeAgent.Place(ctx, tx, leagueID)
+		if err != nil {
+			return nil, errors.Wrap(err, "registration.Place")
+		}
+		err = models.RecordAudit(ctx, tx, i.GuildID, &models.AuditEntry{
+			Actor:    bot.AuditActor(i),
+			Action:   models.AuditFreeAgentPlaced,
+			Entity:   "free_agent_registration",
+			EntityID: strconv.FormatUint(uint64(reg.freeAgent.ID), 10),
+			PlayerID: &reg.freeAgent.PlayerID,
+			Summary:  msg,
+		}, before, reg.freeAgent)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "models.RecordAudit")
+	}
+	return &adminResult{msg: msg, teams: reg.teams(), rosters: reg.team != nil}, nil
+}
+
+// Get the name of the team or player registered and the season name
+func (r *adminRegistration) describe() (string, string) {
+	if r.team != nil {
+		return r.team.TeamName, r.team.SeasonName
+	}
+	return r.freeAgent.PlayerName, r.freeAgent.SeasonName
+}
+
+// Get the season, approval and placement of the registration
+func (r *adminRegistration) status() (string, *uint16, uint16) {
+	if r.team != nil {
+		return r.team.SeasonID, r.team.Approved, r.team.Placed
+	}
+	return r.freeAgent.SeasonID, r.freeAgent.Approved, r.freeAgent.Placed
+}
+
+func adminUndoInvite(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	i *discordgo.InteractionCreate,
+	opts adminOptions,
+) (*adminResult, error) {
+	id := opts["id"].IntValue()
+	invite, err := models.GetPlayerTeamInvite(ctx, tx, uint32(id))
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetPlayerTeamInvite")
+	}
+	if invite == nil {
+		return nil, errors.New(fmt.Sprintf("VE:No invite has the ID %v", id))
+	}
+	if invite.Approved != nil && *invite.Approved == 1 {
+		player := &models.Player{ID: invite.PlayerID}
+		current, err := player.CurrentTeam(ctx, tx)
+		if err != nil {
+			return nil, errors.Wrap(err, "player.CurrentTeam")
+		}
+		if current != nil && current.TeamID == invite.TeamID {
+			return nil, errors.New("VE:" + invite.PlayerName + " joined " + invite.TeamName +
+				" from this invite, remove them from the team first")
+		}
+	}
+	before := *invite
+	decision, err := invite.UndoDecision(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "invite.UndoDecision")
+	}
+	msg := fmt.Sprintf("The %s of the %s for %s to join %s has been undone",
+		decision, strings.ToLower(invite.OfferName()), invite.PlayerName, invite.TeamName)
+	err = models.RecordAudit(ctx, tx, i.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditInviteDecisionUndone,
+		Entity:   "player_team_invite",
+		EntityID: strconv.FormatUint(uint64(invite.ID), 10),
+		TeamID:   &invite.TeamID,
+		PlayerID: &invite.PlayerID,
+		Summary:  msg,
+	}, before, invite)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.RecordAudit")
+	}
+	return &adminResult{msg: msg}, nil
+}
@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/tests"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminActions(t *testing.T) {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	logger := tests.NilLogger()
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	wconn, rconn, err := tests.SetupTestDB(ver)
+	require.NoError(t, err)
+	conn := db.MakeSafe(wconn, rconn, logger)
+	t.Cleanup(func() { conn.Close() })
+	ctx := t.Context()
+
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		GuildID: cfg.DiscordGuildID,
+		Member:  &discordgo.Member{User: &discordgo.User{ID: "900", Username: "admin"}},
+	}}
+	str := func(name, value string) *discordgo.ApplicationCommandInteractionDataOption {
+		return &discordgo.ApplicationCommandInteractionDataOption{
+			Name: name, Type: discordgo.ApplicationCommandOptionString, Value: value}
+	}
+	num := func(name string, value float64) *discordgo.ApplicationCommandInteractionDataOption {
+		return &discordgo.ApplicationCommandInteractionDataOption{
+			Name: name, Type: discordgo.ApplicationCommandOptionInteger, Value: value}
+	}
+	run := func(action adminAction, opts ...*discordgo.ApplicationCommandInteractionDataOption) (*adminResult, error) {
+		tx, err := conn.Begin(ctx, "TestAdminActions")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		o := adminOptions{}
+		for _, opt := range opts {
+			o[opt.Name] = opt
+		}
+		result, err := action(ctx, tx, i, o)
+		if err == nil {
+			tx.Commit()
+		}
+		return result, err
+	}
+
+	tx, err := conn.Begin(ctx, "TestAdminActions setup")
+	require.NoError(t, err)
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1001, "1", "Manager"))
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1002, "2", "Player"))
+	manager, err := models.GetPlayerBySlapID(ctx, tx, 1001)
+	require.NoError(t, err)
+	team, err := models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Admins", "ADM", manager.ID)
+	require.NoError(t, err)
+	require.NoError(t, manager.JoinTeamAt(ctx, tx, team.ID, time.Now().AddDate(0, -1, 0),
+		models.MoveCreated, nil))
+	tx.Commit()
+	locale := cfg.Locale
+
+	t.Run("Player is renamed and can be found by name or slap ID", func(t *testing.T) {
+		result, err := run(adminRenamePlayer, str("player", "1002"), str("name", "Renamed"))
+		require.NoError(t, err)
+		assert.Equal(t, "Player has been renamed to Renamed", result.msg)
+		_, err = run(adminRenamePlayer, str("player", "renamed"), str("name", "Manager"))
+		assert.ErrorContains(t, err, "VE:Display name must be unique")
+		_, err = run(adminRenamePlayer, str("player", "Nobody"), str("name", "Other"))
+		assert.ErrorContains(t, err, "VE:No player")
+	})
+
+	t.Run("Player is added to a team with a backdated join", func(t *testing.T) {
+		result, err := run(adminAddToTeam(locale), str("player", "Renamed"),
+			str("team", "ADM"), str("joined", "01/01/2020"))
+		require.NoError(t, err)
+		assert.Equal(t, []uint16{team.ID}, result.teams)
+		_, err = run(adminAddToTeam(locale), str("player", "Renamed"), str("team", "ADM"))
+		assert.ErrorContains(t, err, "VE:Renamed is already on Admins")
+		_, err = run(adminAddToTeam(locale), str("player", "Renamed"),
+			str("team", "ADM"), str("joined", "2020-01-01"))
+		assert.ErrorContains(t, err, "VE:Dates must be in the format")
+	})
+
+	t.Run("Manager is changed to a player on the team", func(t *testing.T) {
+		_, err := run(adminRemoveFromTeam(locale), str("player", "Manager"))
+		assert.ErrorContains(t, err, "change the manager first")
+		result, err := run(adminSetManager, str("team", "Admins"), str("player", "Renamed"))
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed is now the manager of Admins (previously Manager)", result.msg)
+	})
+
+	t.Run("Player is removed from the team but not before they joined", func(t *testing.T) {
+		_, err := run(adminRemoveFromTeam(locale), str("player", "Manager"),
+			str("left", "01/01/2020"))
+		assert.ErrorContains(t, err, "VE:Player can't leave the team before they joined it")
+		_, err = run(adminRemoveFromTeam(locale), str("player", "Manager"))
+		require.NoError(t, err)
+		_, err = run(adminSetManager, str("team", "Admins"), str("player", "Manager"))
+		assert.ErrorContains(t, err, "VE:Manager is not on Admins")
+	})
+
+	t.Run("Every change is audited", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestAdminActions audit")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		entries, err := models.GetAuditLog(ctx, rtx, cfg.DiscordGuildID, models.AuditFilter{ActorID: "900"})
+		require.NoError(t, err)
+		actions := []string{}
+		for _, entry := range *entries {
+			actions = append(actions, entry.Action)
+		}
+		assert.Equal(t, []string{
+			models.AuditPlayerRemovedFromTeam,
+			models.AuditTeamManagerChanged,
+			models.AuditPlayerAddedToTeam,
+			models.AuditPlayerEdited,
+		}, actions)
+		_, err = run(adminUndoInvite, num("id", 1))
+		assert.ErrorContains(t, err, "VE:No invite has the ID 1")
+	})
+
+	t.Run("Moves are recorded in the transfer ledger", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestAdminActions ledger")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		history, err := models.GetTeamHistory(ctx, rtx, team.ID)
+		require.NoError(t, err)
+		moves := []string{}
+		for _, transfer := range *history {
+			moves = append(moves, transfer.PlayerName+" "+transfer.Reason)
+		}
+		assert.Equal(t, []string{
+			"Manager " + models.MoveAdmin,
+			"Manager " + models.MoveCreated,
+			"Renamed " + models.MoveAdmin,
+		}, moves)
+		assert.False(t, (*history)[0].Joined)
+
+		career, err := models.GetPlayerCareer(ctx, rtx, cfg.DiscordGuildID, manager.ID)
+		require.NoError(t, err)
+		require.Len(t, *career, 1)
+		assert.Equal(t, models.MoveCreated, (*career)[0].JoinReason)
+		assert.Equal(t, models.MoveAdmin, (*career)[0].LeftReason)
+		assert.NotNil(t, (*career)[0].Left)
+
+		transfers, err := models.GetTransfersSince(ctx, rtx, cfg.DiscordGuildID,
+			time.Now().AddDate(0, 0, -1))
+		require.NoError(t, err)
+		assert.Len(t, *transfers, 1)
+	})
+
+	t.Run("Dates are read in the admin's timezone", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestAdminActions timezone")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		opts := adminOptions{"joined": str("joined", "01/01/2020")}
+		date, err := adminDate(ctx, tx, i, opts, "joined", "Australia/Sydney")
+		require.NoError(t, err)
+		sydney, err := time.LoadLocation("Australia/Sydney")
+		require.NoError(t, err)
+		assert.True(t, time.Date(2020, 1, 1, 0, 0, 0, 0, sydney).Equal(date))
+
+		require.NoError(t, models.CreatePlayer(ctx, tx, 1900, "900", "Admin"))
+		admin, err := models.GetPlayerByDiscordID(ctx, tx, "900")
+		require.NoError(t, err)
+		profile, err := models.GetPlayerProfile(ctx, tx, admin.ID)
+		require.NoError(t, err)
+		require.NoError(t, profile.SetDetails("Pacific/Auckland", "", "", ""))
+		require.NoError(t, profile.Save(ctx, tx))
+		date, err = adminDate(ctx, tx, i, opts, "joined", "Australia/Sydney")
+		require.NoError(t, err)
+		auckland, err := time.LoadLocation("Pacific/Auckland")
+		require.NoError(t, err)
+		assert.True(t, time.Date(2020, 1, 1, 0, 0, 0, 0, auckland).Equal(date))
+	})
+}
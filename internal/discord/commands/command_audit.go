@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Number of entries shown by the /audit command
+const auditCommandEntries = 15
+
+func cmdAudit(ctx context.Context, b *bot.Bot) *Command {
+	return &Command{
+		Name:        "audit",
+		Description: "View the audit log of league administration actions",
+		Handler:     handleAudit(ctx, b),
+		Permission:  models.PermLeagueManager,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "team",
+				Description: "Only show actions affecting the team (name or abbreviation)",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "player",
+				Description: "Only show actions affecting the player",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "actor",
+				Description: "Only show actions performed by the user",
+			},
+		},
+	}
+}
+
+func handleAudit(
+	ctx context.Context,
+	b *bot.Bot,
+) bot.Handler {
+	return func(i *discordgo.InteractionCreate) {
+		b.Acknowledge(i, nil)
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		tx, err := b.Conn.RBegin(timeout, "Handle /audit command")
+		if err != nil {
+			b.TripleError("Unexpected error", err, i, true)
+			return
+		}
+		defer tx.Rollback()
+		member := i.Member
+		if member == nil {
+			err = b.Schedule(timeout, bot.PriorityInteraction, http.MethodGet,
+				discordgo.EndpointGuildMember(b.GuildID, i.User.ID))
+			if err != nil {
+				b.TripleError("Unexpected error", errors.Wrap(err, "b.Schedule"), i, true)
+				return
+			}
+			member, err = b.Session.GuildMember(b.GuildID, i.User.ID)
+			if err != nil {
+				b.TripleError("Unexpected error", err, i, true)
+				return
+			}
+		}
+		isLeagueMgr, err := models.MemberHasPermission(ctx, tx,
+			b.GuildID, member, models.PermLeagueManager)
+		if err != nil {
+			b.TripleError("Unexpected error", err, i, true)
+			return
+		}
+		if !isLeagueMgr {
+			b.Forbidden(i, true)
+			return
+		}
+
+		filter := models.AuditFilter{Limit: auditCommandEntries}
+		filters := []string{}
+		for _, opt := range i.ApplicationCommandData().Options {
+			switch opt.Name {
+			case "team":
+				team, err := models.GetTeamByName(ctx, tx, b.GuildID, opt.StringValue())
+				if err != nil {
+					b.TripleError("Unexpected error", errors.Wrap(err, "models.GetTeamByName"), i, true)
+					return
+				}
+				if team == nil {
+					err = b.Error("Team not found", "No team has that name or abbreviation", i, true)
+					if err != nil {
+						b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+					}
+					return
+				}
+				filter.TeamID = &team.ID
+				filters = append(filters, "Team: "+team.Name)
+			case "player":
+				user := opt.UserValue(nil)
+				player, err := models.GetPlayerByDiscordID(ctx, tx, user.ID)
+				if err != nil {
+					b.TripleError("Unexpected error", errors.Wrap(err, "models.GetPlayerByDiscordID"), i, true)
+					return
+				}
+				if player == nil {
+					err = b.Error("Unregistered player", "That user is not registered as a player", i, true)
+					if err != nil {
+						b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+					}
+					return
+				}
+				filter.PlayerID = &player.ID
+				filters = append(filters, "Player: "+player.Name)
+			case "actor":
+				user := opt.UserValue(nil)
+				filter.ActorID = user.ID
+				filters = append(filters, "Actor: <@"+user.ID+">")
+			}
+		}
+		entries, err := models.GetAuditLog(ctx, tx, b.GuildID, filter)
+		if err != nil {
+			b.TripleError("Unexpected error", errors.Wrap(err, "models.GetAuditLog"), i, true)
+			return
+		}
+		contents := &bot.MessageContents{Embed: auditLogEmbed(entries, filters)}
+		err = b.FollowUpComplex(contents, i, 5*time.Minute)
+		if err != nil {
+			b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
+		}
+	}
+}
+
+// Get the embed listing the audit log entries
+func auditLogEmbed(entries *[]models.AuditEntry, filters []string) *discordgo.MessageEmbed {
+	description := "Showing all actions"
+	if len(filters) > 0 {
+		description = "Filtered by " + strings.Join(filters, ", ")
+	}
+	if len(*entries) == 0 {
+		description = description + "\n\n*No actions found*"
+	}
+	fields := []*discordgo.MessageEmbedField{}
+	for _, entry := range *entries {
+		summary := entry.Summary
+		if len(summary) > 900 {
+			summary = summary[:900] + "..."
+		}
+		performed := fmt.Sprintf("by <@%s>", entry.Actor.DiscordID)
+		if entry.Created != nil {
+			performed = fmt.Sprintf("<t:%d:f> %s", entry.Created.Unix(), performed)
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("#%d %s", entry.ID, entry.Action),
+			Value: summary + "\n-# " + performed,
+		})
+	}
+	return &discordgo.MessageEmbed{
+		Title:       "Audit Log",
+		Description: description,
+		Fields:      fields,
+		Color:       0x00ff00, // Green color
+	}
+}
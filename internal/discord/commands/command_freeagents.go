@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/util"
+	"gosl/internal/models"
+	"gosl/pkg/i18n"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Max number of free agents shown in the embed, and the max length of their
+// notes, to keep the embed under discords size limit
+const (
+	maxFreeAgentsListed = 8
+	maxFreeAgentNotes   = 100
+)
+
+func cmdFreeAgents(ctx context.Context, b *bot.Bot) *Command {
+	return &Command{
+		Name:        "freeagents",
+		Description: "Browse the free agents available this season",
+		Handler:     handleFreeAgents(ctx, b),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "league",
+				Description: "Only show free agents in this league",
+				Choices:     stringChoices([]string{"Pro", "IM", "Open"}),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "position",
+				Description: "Only show free agents that play this position",
+				Choices:     stringChoices(models.PlayerPositions),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "available",
+				Description: "Only show free agents available on this day",
+				Choices:     stringChoices(models.AvailabilityDays),
+			},
+		},
+	}
+}
+
+func handleFreeAgents(
+	ctx context.Context,
+	b *bot.Bot,
+) bot.Handler {
+	return func(i *discordgo.InteractionCreate) {
+		b.Acknowledge(i, nil)
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		tx, err := b.Conn.RBegin(timeout, "Handle /freeagents command")
+		if err != nil {
+			b.TripleError("Unexpected error", err, i, true)
+			return
+		}
+		defer tx.Rollback()
+		filters := map[string]string{}
+		for _, opt := range i.ApplicationCommandData().Options {
+			filters[opt.Name] = opt.StringValue()
+		}
+		season, err := models.GetActiveSeason(ctx, tx, b.GuildID)
+		if err != nil {
+			b.TripleError("Unexpected error", errors.Wrap(err, "models.GetActiveSeason"), i, true)
+			return
+		}
+		if season == nil {
+			err = b.Error(b.T(i, "season.none.title"), b.T(i, "season.none.message"), i, true)
+			if err != nil {
+				b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+			}
+			return
+		}
+		freeAgents, err := models.GetFreeAgentListings(ctx, tx, b.GuildID,
+			filters["league"], filters["position"], filters["available"])
+		if err != nil {
+			b.TripleError("Unexpected error", errors.Wrap(err, "models.GetFreeAgentListings"), i, true)
+			return
+		}
+		contents := &bot.MessageContents{
+			Embed: freeAgentsEmbed(b.Lang(i), season, freeAgents, filters),
+		}
+		err = b.FollowUpComplex(contents, i, 5*time.Minute)
+		if err != nil {
+			b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
+		}
+	}
+}
+
+func freeAgentsEmbed(
+	lang string,
+	season *models.Season,
+	freeAgents *[]models.FreeAgentListing,
+	filters map[string]string,
+) *discordgo.MessageEmbed {
+	filterText := []string{}
+	for _, name := range []string{"league", "position", "available"} {
+		if filters[name] != "" {
+			filterText = append(filterText, fmt.Sprintf("%s: %s",
+				i18n.T(lang, "freeagents.filter."+name), filters[name]))
+		}
+	}
+	description := i18n.T(lang, "freeagents.found", len(*freeAgents))
+	if len(filterText) > 0 {
+		description = description + "\n" +
+			i18n.T(lang, "freeagents.filters", strings.Join(filterText, ", "))
+	}
+	if len(*freeAgents) > maxFreeAgentsListed {
+		description = description + "\n" + i18n.T(lang, "freeagents.truncated", maxFreeAgentsListed)
+	}
+	fields := []*discordgo.MessageEmbedField{}
+	for idx, fa := range *freeAgents {
+		if idx == maxFreeAgentsListed {
+			break
+		}
+		league := i18n.T(lang, "freeagents.preferred", fa.League)
+		if fa.Placed {
+			league = fa.League
+		}
+		value := fmt.Sprintf("<@%s>\n", fa.DiscordID) + i18n.T(lang, "freeagents.league", league)
+		if fa.Profile.Updated == nil {
+			value = value + "\n" + i18n.T(lang, "freeagents.noprofile")
+		} else {
+			value = value + "\n" + i18n.T(lang, "freeagents.positions",
+				listOrNone(lang, fa.Profile.Positions))
+			value = value + "\n" + i18n.T(lang, "freeagents.available",
+				util.AvailabilitySummary(&fa.Profile))
+			if fa.Profile.Region != "" {
+				value = value + "\n" + i18n.T(lang, "freeagents.region", fa.Profile.Region)
+			}
+			if fa.Profile.Timezone != "" {
+				value = value + "\n" + i18n.T(lang, "freeagents.timezone", fa.Profile.Timezone)
+			}
+			if fa.Profile.Playstyle != "" {
+				value = value + "\n" + i18n.T(lang, "freeagents.playstyle", fa.Profile.Playstyle)
+			}
+			if fa.Profile.Notes != "" {
+				notes := fa.Profile.Notes
+				if len([]rune(notes)) > maxFreeAgentNotes {
+					notes = string([]rune(notes)[:maxFreeAgentNotes]) + "..."
+				}
+				value = value + "\n" + i18n.T(lang, "freeagents.notes", notes)
+			}
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   fa.PlayerName,
+			Value:  value,
+			Inline: false,
+		})
+	}
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "freeagents.title", season.Name),
+		Description: description,
+		Fields:      fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(lang, "freeagents.footer"),
+		},
+		Color: 0x00ff00, // Green color
+	}
+}
+
+func listOrNone(lang string, values []string) string {
+	if len(values) == 0 {
+		return i18n.T(lang, "notset")
+	}
+	return strings.Join(values, ", ")
+}
+
+func stringChoices(values []string) []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, value := range values {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  value,
+			Value: value,
+		})
+	}
+	return choices
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/pkg/i18n"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Value of the language option that removes the user's choice, so replies
+// follow their discord client's language
+const languageAuto = "auto"
+
+func cmdLanguage(ctx context.Context, b *bot.Bot) *Command {
+	choices := []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "Automatic (use my Discord language)", Value: languageAuto},
+	}
+	for _, lang := range i18n.Languages() {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name: i18n.Name(lang), Value: lang,
+		})
+	}
+	return &Command{
+		Name:        "language",
+		Description: "Choose the language the bot uses when replying to you",
+		Handler:     handleLanguage(ctx, b),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "language",
+				Description: "Language to use",
+				Required:    true,
+				Choices:     choices,
+			},
+		},
+	}
+}
+
+func handleLanguage(
+	ctx context.Context,
+	b *bot.Bot,
+) bot.Handler {
+	return func(i *discordgo.InteractionCreate) {
+		b.Acknowledge(i, nil)
+		language := i.ApplicationCommandData().Options[0].StringValue()
+		if language == languageAuto {
+			language = ""
+		} else if !i18n.Supported(language) {
+			b.Stale(i, true)
+			return
+		}
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		tx, err := b.Conn.Begin(timeout, "Handle /language command")
+		if err != nil {
+			b.TripleError("Unexpected error", err, i, true)
+			return
+		}
+		defer tx.Rollback()
+		err = b.SetUserLanguage(ctx, tx, bot.AuditActor(i).DiscordID, language)
+		if err != nil {
+			b.TripleError("Unexpected error", errors.Wrap(err, "b.SetUserLanguage"), i, true)
+			return
+		}
+		tx.Commit()
+		msg := b.T(i, "language.auto")
+		if language != "" {
+			msg = b.T(i, "language.set", i18n.Name(language))
+		}
+		err = b.FollowUp(msg, i)
+		if err != nil {
+			b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/gamelogs"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/slapshotapi"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// League settings for the lobbies created for fixtures. Lobbies are played in
+// periods, with a log for each period like those uploaded with /uploadlogs
+const (
+	lobbyPeriods     = 3
+	lobbyMatchLength = 300 // length of each period in seconds
+	lobbyMercyRule   = 0   // no mercy rule
+	lobbyArena       = "Slapstadium"
+	lobbyGameMode    = "hockey"
+	lobbyCreatorName = "GOSL"
+)
+
+// How often a lobby is checked for finished periods, and how long after it
+// was created the bot stops waiting for them and closes it
+const (
+	lobbyPollInterval = time.Minute
+	lobbyTimeout      = 3 * time.Hour
+)
+
+func cmdLobby(ctx context.Context, b *bot.Bot) *Command {
+	return &Command{
+		Name:        "lobby",
+		Description: "Create a private lobby for a match and send the details to both teams",
+		Handler:     handleLobby(ctx, b),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "home",
+				Description: "Home team (name or abbreviation)",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "away",
+				Description: "Away team (name or abbreviation)",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "region",
+				Description: "Region to host the lobby in",
+				Choices:     stringChoices(b.Config.SlapshotRegions),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "arena",
+				Description: "Arena to play in, " + lobbyArena + " if not set",
+			},
+		},
+	}
+}
+
+func handleLobby(
+	ctx context.Context,
+	b *bot.Bot,
+) bot.Handler {
+	return func(i *discordgo.InteractionCreate) {
+		b.Acknowledge(i, nil)
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		tx, err := b.Conn.RBegin(timeout, "Handle /lobby command")
+		if err != nil {
+			b.TripleError("Lobby creation failed", err, i, true)
+			return
+		}
+		defer tx.Rollback()
+		if i.Member == nil {
+			b.Forbidden(i, true)
+			return
+		}
+
+		region := b.Config.SlapshotRegions[0]
+		arena := lobbyArena
+		teams := map[string]*models.Team{}
+		for _, opt := range i.ApplicationCommandData().Options {
+			switch opt.Name {
+			case "home", "away":
+				team, err := models.GetTeamByName(ctx, tx, b.GuildID, opt.StringValue())
+				if err != nil {
+					b.TripleError("Lobby creation failed",
+						errors.Wrap(err, "models.GetTeamByName"), i, true)
+					return
+				}
+				if team == nil {
+					err = b.Error("Team not found",
+						fmt.Sprintf("No team has the name or abbreviation '%s'", opt.StringValue()),
+						i, true)
+					if err != nil {
+						b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+					}
+					return
+				}
+				teams[opt.Name] = team
+			case "region":
+				region = opt.StringValue()
+			case "arena":
+				arena = opt.StringValue()
+			}
+		}
+		home, away := teams["home"], teams["away"]
+		allowed, err := canCreateLobby(ctx, tx, b, i.Member, home)
+		if err != nil {
+			b.TripleError("Lobby creation failed", errors.Wrap(err, "canCreateLobby"), i, true)
+			return
+		}
+		if !allowed {
+			b.Forbidden(i, true)
+			return
+		}
+		if home.ID == away.ID {
+			err = b.Error("Invalid teams", "A team can't play against itself", i, true)
+			if err != nil {
+				b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+			}
+			return
+		}
+		now := time.Now()
+		players := []models.Player{}
+		for _, team := range []*models.Team{home, away} {
+			roster, err := team.Players(ctx, tx, &now, &now)
+			if err != nil {
+				b.TripleError("Lobby creation failed", errors.Wrap(err, "team.Players"), i, true)
+				return
+			}
+			players = append(players, *roster...)
+		}
+		// the transaction isn't needed while waiting on the APIs
+		tx.Rollback()
+
+		password, err := lobbyPassword()
+		if err != nil {
+			b.TripleError("Lobby creation failed", errors.Wrap(err, "lobbyPassword"), i, true)
+			return
+		}
+		settings := lobbySettings(home, away, region, arena, password)
+		lobby, err := b.Slapshot.CreateLobby(ctx, settings)
+		if err != nil {
+			b.Logger.Warn().Err(err).Msg("Failed to create lobby")
+			err = b.Error("Slapshot unavailable",
+				"Couldn't create the lobby, please try again later", i, true)
+			if err != nil {
+				b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+			}
+			return
+		}
+
+		details := fmt.Sprintf(
+			"__Lobby:__ %s\n__Password:__ %s\n__Region:__ %s\n__Arena:__ %s",
+			settings.Name, settings.Password, settings.Region, settings.Arena)
+		failed := []string{}
+		for _, player := range players {
+			err = b.SendDirectMessage(ctx, "Lobby for "+settings.Name, details, player.DiscordID)
+			if err != nil {
+				b.Logger.Warn().Err(err).Str("player", player.Name).
+					Msg("Failed to send lobby details")
+				failed = append(failed, player.Name)
+			}
+		}
+		go watchLobby(ctx, b, lobby.ID, settings.Name, i.Member.User.ID,
+			lobbyPollInterval, lobbyTimeout)
+
+		description := details + fmt.Sprintf(
+			"\n\nThe details have been sent to the %v players on both teams. "+
+				"The logs will be collected once all %v periods have been played",
+			len(players)-len(failed), lobbyPeriods)
+		if len(failed) > 0 {
+			description = description + "\n\nCouldn't send the details to:\n" +
+				strings.Join(failed, "\n")
+		}
+		contents := &bot.MessageContents{Embed: &discordgo.MessageEmbed{
+			Title:       "Lobby created",
+			Description: description,
+			Color:       0x00ff00, // Green color
+		}}
+		err = b.FollowUpComplex(contents, i, 5*time.Minute)
+		if err != nil {
+			b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
+		}
+	}
+}
+
+// Check if the member can create a lobby for the home team. League managers
+// can create one for any match, team managers only for their home matches
+func canCreateLobby(
+	ctx context.Context,
+	tx db.SafeTX,
+	b *bot.Bot,
+	member *discordgo.Member,
+	home *models.Team,
+) (bool, error) {
+	isLeagueMgr, err := models.MemberHasPermission(ctx, tx,
+		b.GuildID, member, models.PermLeagueManager)
+	if err != nil {
+		return false, errors.Wrap(err, "models.MemberHasPermission")
+	}
+	if isLeagueMgr {
+		return true, nil
+	}
+	player, err := models.GetPlayerByDiscordID(ctx, tx, member.User.ID)
+	if err != nil {
+		return false, errors.Wrap(err, "models.GetPlayerByDiscordID")
+	}
+	return player != nil && player.ID == home.ManagerID, nil
+}
+
+// Get the league settings for a lobby for the teams
+func lobbySettings(
+	home, away *models.Team,
+	region, arena, password string,
+) slapshotapi.LobbySettings {
+	return slapshotapi.LobbySettings{
+		Region:        region,
+		Name:          fmt.Sprintf("%s vs %s", home.Abbreviation, away.Abbreviation),
+		Password:      password,
+		CreatorName:   lobbyCreatorName,
+		Periods:       true,
+		CurrentPeriod: 1,
+		Arena:         arena,
+		MercyRule:     lobbyMercyRule,
+		MatchLength:   lobbyMatchLength,
+		GameMode:      lobbyGameMode,
+	}
+}
+
+// Get a random password for a lobby
+func lobbyPassword() (string, error) {
+	buf := make([]byte, 4)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", errors.Wrap(err, "rand.Read")
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Wait for the periods to be played in the lobby, then collect their logs the
+// same way as /uploadlogs and send the scores to the manager that created it.
+// The lobby is closed once the logs are collected, or when the bot gives up
+// waiting. Lobbies aren't watched across restarts, so the logs of a match
+// still being played when the bot restarts need to be uploaded
+func watchLobby(
+	ctx context.Context,
+	b *bot.Bot,
+	lobbyID string,
+	name string,
+	creatorID string,
+	interval time.Duration,
+	timeout time.Duration,
+) {
+	defer func() {
+		// the watch context may have ended, but the lobby should still close
+		err := b.Slapshot.DeleteLobby(context.Background(), lobbyID)
+		if err != nil {
+			b.Logger.Warn().Err(err).Str("lobby", lobbyID).Msg("Failed to close lobby")
+		}
+	}()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	giveUp := time.After(timeout)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-giveUp:
+			err := b.SendDirectMessage(ctx, "Lobby logs not collected", fmt.Sprintf(
+				"Not all the periods were played in the lobby for %s before it closed. "+
+					"Upload the logs with /uploadlogs", name), creatorID)
+			if err != nil {
+				b.Logger.Warn().Err(err).Msg("Failed to notify lobby creator of lobby timeout")
+			}
+			return
+		case <-ticker.C:
+			matches, err := b.Slapshot.GetLobbyMatches(ctx, lobbyID)
+			if err != nil {
+				b.Logger.Warn().Err(err).Str("lobby", lobbyID).Msg("Failed to get lobby matches")
+				continue
+			}
+			if len(matches) < lobbyPeriods {
+				continue
+			}
+			err = collectLobbyLogs(ctx, b, matches, name, creatorID)
+			if err != nil {
+				b.DoubleError("Failed to collect lobby logs for "+name, err)
+			}
+			return
+		}
+	}
+}
+
+// Get the logs of the matches played in the lobby and send the score of each
+// period, and any players in them that aren't registered, to the manager that
+// created the lobby
+func collectLobbyLogs(
+	ctx context.Context,
+	b *bot.Bot,
+	matches []string,
+	name string,
+	creatorID string,
+) error {
+	logs := []*gamelogs.Gamelog{}
+	for _, matchID := range matches {
+		data, err := b.Slapshot.GetMatch(ctx, matchID)
+		if err != nil {
+			return errors.Wrap(err, "b.Slapshot.GetMatch")
+		}
+		var log gamelogs.Gamelog
+		err = json.Unmarshal(data, &log)
+		if err != nil {
+			return errors.Wrap(err, "json.Unmarshal")
+		}
+		logs = append(logs, &log)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.RBegin(timeout, "commands.collectLobbyLogs()")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.RBegin")
+	}
+	defer tx.Rollback()
+	unregistered, err := unregisteredLogPlayers(ctx, tx, logs)
+	if err != nil {
+		return errors.Wrap(err, "unregisteredLogPlayers")
+	}
+	message := fmt.Sprintf("The logs of the %v periods played in the lobby for %s "+
+		"have been collected\n", len(logs), name)
+	for period, log := range logs {
+		message = message + fmt.Sprintf("\n__Period %v:__ %v - %v",
+			period+1, log.Score.Home, log.Score.Away)
+	}
+	if len(unregistered) > 0 {
+		message = message + "\n\n" + unregisteredPlayersText(unregistered)
+	}
+	err = b.SendDirectMessage(ctx, "Lobby logs collected", message, creatorID)
+	if err != nil {
+		return errors.Wrap(err, "b.SendDirectMessage")
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/discordtest"
+	"gosl/internal/health"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchLobby(t *testing.T) {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	// lobby "played" has all its periods played on the second check, lobby
+	// "empty" never has any
+	var checks, deleted atomic.Int32
+	slapshot := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete:
+			deleted.Add(1)
+			w.Write([]byte(`{"success":true}`))
+		case r.URL.Path == "/api/public/lobbies/played/matches":
+			if checks.Add(1) == 1 {
+				w.Write([]byte(`["m1"]`))
+				return
+			}
+			w.Write([]byte(`["m1","m2","m3"]`))
+		case r.URL.Path == "/api/public/lobbies/empty/matches":
+			w.Write([]byte(`[]`))
+		default:
+			fmt.Fprintf(w, `{"match_id":"%s","score":{"home":2,"away":1},"players":[`+
+				`{"game_user_id":"1001","username":"Registered"},`+
+				`{"game_user_id":"1002","username":"Unregistered"},`+
+				`{"game_user_id":"bot","username":"Bot"}]}`, r.URL.Path)
+		}
+	}))
+	t.Cleanup(slapshot.Close)
+	cfg.SlapshotAPIURL = slapshot.URL
+	logger := tests.NilLogger()
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	wconn, rconn, err := tests.SetupTestDB(ver)
+	require.NoError(t, err)
+	conn := db.MakeSafe(wconn, rconn, logger)
+	t.Cleanup(func() { conn.Close() })
+	ctx := t.Context()
+
+	tx, err := conn.Begin(ctx, "TestWatchLobby")
+	require.NoError(t, err)
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1001, "1", "Registered"))
+	tx.Commit()
+
+	session := discordtest.NewSession(cfg.DiscordGuildID)
+	b, err := bot.NewBotWithSession(
+		session, logger, nil, conn, cfg, health.NewStatus(true), nil)
+	require.NoError(t, err)
+
+	t.Run("Logs are collected once every period is played", func(t *testing.T) {
+		watchLobby(ctx, b, "played", "AAA vs BBB", "900", time.Millisecond, time.Minute)
+		assert.Equal(t, int32(2), checks.Load())
+		assert.Equal(t, int32(1), deleted.Load())
+		dms := session.DirectMessages("900")
+		require.Len(t, dms, 1)
+		embed := dms[0].Embeds[0]
+		assert.Equal(t, "Lobby logs collected", embed.Title)
+		assert.Contains(t, embed.Description, "3 periods played in the lobby for AAA vs BBB")
+		assert.Contains(t, embed.Description, "__Period 3:__ 2 - 1")
+		assert.Contains(t, embed.Description, "Unregistered (SlapID 1002)")
+		assert.Contains(t, embed.Description, `Bot (invalid SlapID "bot")`)
+		assert.NotContains(t, embed.Description, "Registered (")
+	})
+
+	t.Run("Lobby is closed if the periods aren't played in time", func(t *testing.T) {
+		watchLobby(ctx, b, "empty", "CCC vs DDD", "901", time.Millisecond, 20*time.Millisecond)
+		assert.Equal(t, int32(2), deleted.Load())
+		dms := session.DirectMessages("901")
+		require.Len(t, dms, 1)
+		assert.Equal(t, "Lobby logs not collected", dms[0].Embeds[0].Title)
+	})
+}
+
+func TestCanCreateLobby(t *testing.T) {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	logger := tests.NilLogger()
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	wconn, rconn, err := tests.SetupTestDB(ver)
+	require.NoError(t, err)
+	conn := db.MakeSafe(wconn, rconn, logger)
+	t.Cleanup(func() { conn.Close() })
+	ctx := t.Context()
+	b, err := bot.NewBotWithSession(discordtest.NewSession(cfg.DiscordGuildID),
+		logger, nil, conn, cfg, health.NewStatus(true), nil)
+	require.NoError(t, err)
+
+	tx, err := conn.Begin(ctx, "TestCanCreateLobby")
+	require.NoError(t, err)
+	require.NoError(t, models.AddPermission(ctx, tx, cfg.DiscordGuildID,
+		"league-managers", models.PermLeagueManager))
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1001, "1", "Home"))
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1002, "2", "Away"))
+	homeMgr, err := models.GetPlayerBySlapID(ctx, tx, 1001)
+	require.NoError(t, err)
+	awayMgr, err := models.GetPlayerBySlapID(ctx, tx, 1002)
+	require.NoError(t, err)
+	home, err := models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Home", "HOM", homeMgr.ID)
+	require.NoError(t, err)
+	_, err = models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Away", "AWY", awayMgr.ID)
+	require.NoError(t, err)
+	tx.Commit()
+
+	rtx, err := conn.RBegin(ctx, "TestCanCreateLobby check")
+	require.NoError(t, err)
+	defer rtx.Rollback()
+	for name, expected := range map[string]struct {
+		member  *discordgo.Member
+		allowed bool
+	}{
+		"League managers can create any lobby": {&discordgo.Member{
+			User: &discordgo.User{ID: "900"}, Roles: []string{"league-managers"}}, true},
+		"The home team manager can create the lobby": {&discordgo.Member{
+			User: &discordgo.User{ID: "1"}}, true},
+		"The away team manager can't create the lobby": {&discordgo.Member{
+			User: &discordgo.User{ID: "2"}}, false},
+		"Unregistered members can't create the lobby": {&discordgo.Member{
+			User: &discordgo.User{ID: "901"}}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			allowed, err := canCreateLobby(ctx, rtx, b, expected.member, home)
+			require.NoError(t, err)
+			assert.Equal(t, expected.allowed, allowed)
+		})
+	}
+}
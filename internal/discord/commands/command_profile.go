@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/directmessages"
+	"gosl/internal/models"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+func cmdProfile(ctx context.Context, b *bot.Bot) *Command {
+	return &Command{
+		Name:        "profile",
+		Description: "Edit your player profile, or view another players profile",
+		Handler:     handleProfile(ctx, b),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "player",
+				Description: "The player to view the profile of",
+			},
+		},
+	}
+}
+
+func handleProfile(
+	ctx context.Context,
+	b *bot.Bot,
+) bot.Handler {
+	return func(i *discordgo.InteractionCreate) {
+		b.Acknowledge(i, nil)
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		tx, err := b.Conn.RBegin(timeout, "Handle /profile command")
+		if err != nil {
+			b.TripleError("Unexpected error", err, i, true)
+			return
+		}
+		defer tx.Rollback()
+		inDMs := false
+		var discordID string
+		if i.User == nil {
+			discordID = i.Member.User.ID
+		} else {
+			discordID = i.User.ID
+			inDMs = true
+		}
+		viewing := false
+		if opts := i.ApplicationCommandData().Options; len(opts) > 0 {
+			user := opts[0].UserValue(nil)
+			viewing = user.ID != discordID
+			discordID = user.ID
+		}
+		player, err := models.GetPlayerByDiscordID(ctx, tx, discordID)
+		if err != nil {
+			b.TripleError("Unexpected error", errors.Wrap(err, "models.GetPlayerByDiscordID"), i, true)
+			return
+		}
+		if player == nil {
+			msg := "You are not registered as a player. Please register to use this command"
+			if viewing {
+				msg = "That user is not registered as a player"
+			}
+			err = b.Error("Unregistered player", msg, i, true)
+			if err != nil {
+				b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+			}
+			return
+		}
+		profile, err := models.GetPlayerProfile(ctx, tx, player.ID)
+		if err != nil {
+			b.TripleError("Unexpected error", errors.Wrap(err, "models.GetPlayerProfile"), i, true)
+			return
+		}
+		if viewing {
+			contents := &bot.MessageContents{
+				Embed: directmessages.PlayerProfileEmbed(player, profile),
+			}
+			err = b.FollowUpComplex(contents, i, 5*time.Minute)
+			if err != nil {
+				b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
+			}
+			return
+		}
+		dm := bot.NewDirectMessage(
+			"Player Profile Panel",
+			discordID,
+			5*time.Minute,
+			false,
+			b,
+		)
+		// only a read transaction is held, so the message is tracked in its own
+		err = dm.Send(ctx, nil, directmessages.PlayerProfileComponents(player, profile))
+		if err != nil {
+			b.TripleError("Unexpected error", errors.Wrap(err, "dm.Send"), i, true)
+			return
+		}
+		msg := "Check your DM's"
+		if inDMs {
+			msg = "Editing player profile"
+		}
+		err = b.FollowUp(msg, i)
+		if err != nil {
+			b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
+		}
+	}
+}
@@ -23,7 +23,7 @@ func handleTeam(
 	ctx context.Context,
 	b *bot.Bot,
 ) bot.Handler {
-	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	return func(i *discordgo.InteractionCreate) {
 		b.Acknowledge(i, nil)
 		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
@@ -48,8 +48,8 @@ func handleTeam(
 		}
 		if player == nil {
 			err = b.Error(
-				"Unregistered player",
-				"You are not registered as a player. Please register to use this command",
+				b.T(i, "team.unregistered.title"),
+				b.T(i, "team.unregistered.message"),
 				i, true,
 			)
 			if err != nil {
@@ -64,8 +64,8 @@ func handleTeam(
 		}
 		if pt == nil {
 			err = b.Error(
-				"Not on a team",
-				"You are not currently a member of a team. Join or create one to use this command",
+				b.T(i, "team.noteam.title"),
+				b.T(i, "team.noteam.message"),
 				i, true,
 			)
 			if err != nil {
@@ -80,13 +80,13 @@ func handleTeam(
 		}
 		var contents *bot.MessageContents
 		if team.ManagerID == player.ID {
-			contents, err = directmessages.TeamManagerComponents(ctx, tx, b, team)
+			contents, err = directmessages.TeamManagerComponents(ctx, tx, b, b.UserLang(discordID), team)
 			if err != nil {
 				b.TripleError("Unexpected error", errors.Wrap(err, "TeamManagerComponents"), i, true)
 				return
 			}
 		} else {
-			contents, err = directmessages.TeamPlayerComponents(ctx, tx, team)
+			contents, err = directmessages.TeamPlayerComponents(ctx, tx, b.UserLang(discordID), team)
 			if err != nil {
 				b.TripleError("Unexpected error", errors.Wrap(err, "teamPlayerComponents"), i, true)
 				return
@@ -99,14 +99,15 @@ func handleTeam(
 			false,
 			b,
 		)
-		err = dm.Send(contents)
+		// only a read transaction is held, so the message is tracked in its own
+		err = dm.Send(ctx, nil, contents)
 		if err != nil {
 			b.TripleError("Unexpected error", errors.Wrap(err, "dm.Send"), i, true)
 			return
 		}
-		msg := "Check your DM's"
+		msg := b.T(i, "team.checkdms")
 		if inDMs {
-			msg = "Viewing current team"
+			msg = b.T(i, "team.viewing")
 		}
 		err = b.FollowUp(msg, i)
 		if err != nil {
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/transfers"
+	"gosl/internal/discord/util"
+	"gosl/internal/models"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Most lines shown in a team's history or a player's career, older ones are
+// counted instead so the embed stays within discord's size limits
+const transfersCommandLines = 30
+
+func cmdTransfers(ctx context.Context, b *bot.Bot) *Command {
+	return &Command{
+		Name:        "transfers",
+		Description: "View the players that have joined or left teams",
+		Handler:     handleTransfers(ctx, b),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "team",
+				Description: "Show the roster history of the team (name or abbreviation)",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "player",
+				Description: "Show the teams the player has been on",
+			},
+		},
+	}
+}
+
+func handleTransfers(
+	ctx context.Context,
+	b *bot.Bot,
+) bot.Handler {
+	return func(i *discordgo.InteractionCreate) {
+		b.Acknowledge(i, nil)
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		tx, err := b.Conn.RBegin(timeout, "Handle /transfers command")
+		if err != nil {
+			b.TripleError("Unexpected error", err, i, true)
+			return
+		}
+		defer tx.Rollback()
+
+		var team *models.Team
+		var player *models.Player
+		for _, opt := range i.ApplicationCommandData().Options {
+			switch opt.Name {
+			case "team":
+				team, err = models.GetTeamByName(ctx, tx, b.GuildID, opt.StringValue())
+				if err != nil {
+					b.TripleError("Unexpected error", errors.Wrap(err, "models.GetTeamByName"), i, true)
+					return
+				}
+				if team == nil {
+					err = b.Error("Team not found", "No team has that name or abbreviation", i, true)
+					if err != nil {
+						b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+					}
+					return
+				}
+			case "player":
+				user := opt.UserValue(nil)
+				player, err = models.GetPlayerByDiscordID(ctx, tx, user.ID)
+				if err != nil {
+					b.TripleError("Unexpected error", errors.Wrap(err, "models.GetPlayerByDiscordID"), i, true)
+					return
+				}
+				if player == nil {
+					err = b.Error("Unregistered player", "That user is not registered as a player", i, true)
+					if err != nil {
+						b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+					}
+					return
+				}
+			}
+		}
+
+		var embed *discordgo.MessageEmbed
+		switch {
+		case team != nil && player != nil:
+			err = b.Error("Invalid options", "Choose either a team or a player, not both", i, true)
+			if err != nil {
+				b.Logger.Warn().Err(err).Msg("Failed to notify user of the error")
+			}
+			return
+		case team != nil:
+			history, err := models.GetTeamHistory(ctx, tx, team.ID)
+			if err != nil {
+				b.TripleError("Unexpected error", errors.Wrap(err, "models.GetTeamHistory"), i, true)
+				return
+			}
+			lines := []string{}
+			for _, transfer := range *history {
+				lines = append(lines, util.TransferLine(&transfer))
+			}
+			embed = transfersListEmbed("Roster history of "+team.Name,
+				lines, "No players have joined or left the team")
+		case player != nil:
+			career, err := models.GetPlayerCareer(ctx, tx, b.GuildID, player.ID)
+			if err != nil {
+				b.TripleError("Unexpected error", errors.Wrap(err, "models.GetPlayerCareer"), i, true)
+				return
+			}
+			lines := []string{}
+			for _, pt := range *career {
+				lines = append(lines, util.CareerLine(&pt))
+			}
+			embed = transfersListEmbed("Career of "+player.Name,
+				lines, "The player has not been on a team")
+		default:
+			embed, err = transfers.TransfersEmbed(ctx, tx, b.Lang(i), b.GuildID)
+			if err != nil {
+				b.TripleError("Unexpected error", errors.Wrap(err, "transfers.TransfersEmbed"), i, true)
+				return
+			}
+		}
+		err = b.FollowUpComplex(&bot.MessageContents{Embed: embed}, i, 5*time.Minute)
+		if err != nil {
+			b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
+		}
+	}
+}
+
+// Get the embed listing the lines, most recent first
+func transfersListEmbed(title string, lines []string, empty string) *discordgo.MessageEmbed {
+	description := ""
+	for idx, line := range lines {
+		if idx == transfersCommandLines {
+			description = description + fmt.Sprintf("...and %d more", len(lines)-idx)
+			break
+		}
+		description = description + line + "\n"
+	}
+	if description == "" {
+		description = "*" + empty + "*"
+	}
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       0x00ff00, // Green color
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
 )
 
 func cmdUploadLogo(ctx context.Context, b *bot.Bot) *Command {
@@ -34,7 +35,7 @@ func handleUploadLogo(
 	ctx context.Context,
 	b *bot.Bot,
 ) bot.Handler {
-	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	return func(i *discordgo.InteractionCreate) {
 		b.Acknowledge(i, nil)
 		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
@@ -46,7 +47,13 @@ func handleUploadLogo(
 		defer tx.Rollback()
 		member := i.Member
 		if member == nil {
-			member, err = s.GuildMember(b.Config.DiscordGuildID, i.User.ID)
+			err = b.Schedule(timeout, bot.PriorityInteraction, http.MethodGet,
+				discordgo.EndpointGuildMember(b.GuildID, i.User.ID))
+			if err != nil {
+				b.TripleError("Logo upload failed", errors.Wrap(err, "b.Schedule"), i, true)
+				return
+			}
+			member, err = b.Session.GuildMember(b.GuildID, i.User.ID)
 			if err != nil {
 				b.TripleError("Logo upload failed", err, i, true)
 				return
@@ -138,7 +145,7 @@ func handleUploadLogo(
 		msg := fmt.Sprintf("Team Logo for %s", team.TeamName)
 		now := time.Now().Unix()
 		filename := fmt.Sprintf("%s_logo_%v.%s", team.TeamName, now, fileext)
-		logoMsg, err := logoChan.SendFile(msg, filename, logo)
+		logoMsg, err := logoChan.SendFile(ctx, msg, filename, logo)
 		if err != nil {
 			b.TripleError("Logo upload failed", err, i, true)
 			return
@@ -3,15 +3,19 @@ package commands
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/gamelogs"
 	"gosl/internal/models"
+	"gosl/pkg/db"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
 )
 
 func cmdUploadLogs(ctx context.Context, b *bot.Bot) *Command {
@@ -19,6 +23,7 @@ func cmdUploadLogs(ctx context.Context, b *bot.Bot) *Command {
 		Name:        "uploadlogs",
 		Description: "Upload match logs",
 		Handler:     handleUploadLogs(ctx, b),
+		Permission:  models.PermLeagueManager,
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionAttachment,
@@ -46,7 +51,7 @@ func handleUploadLogs(
 	ctx context.Context,
 	b *bot.Bot,
 ) bot.Handler {
-	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	return func(i *discordgo.InteractionCreate) {
 		b.Acknowledge(i, nil)
 		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
@@ -58,14 +63,20 @@ func handleUploadLogs(
 		defer tx.Rollback()
 		member := i.Member
 		if member == nil {
-			member, err = s.GuildMember(b.Config.DiscordGuildID, i.User.ID)
+			err = b.Schedule(timeout, bot.PriorityInteraction, http.MethodGet,
+				discordgo.EndpointGuildMember(b.GuildID, i.User.ID))
+			if err != nil {
+				b.TripleError("Log upload failed", errors.Wrap(err, "b.Schedule"), i, true)
+				return
+			}
+			member, err = b.Session.GuildMember(b.GuildID, i.User.ID)
 			if err != nil {
 				b.TripleError("Log upload failed", err, i, true)
 				return
 			}
 		}
-		isLeagueMgr, err := models.MemberHasPermission(ctx, tx, s,
-			b.Config.DiscordGuildID, member, models.PermLeagueManager)
+		isLeagueMgr, err := models.MemberHasPermission(ctx, tx,
+			b.GuildID, member, models.PermLeagueManager)
 		if err != nil {
 			b.TripleError("Log upload failed", err, i, true)
 			return
@@ -113,12 +124,67 @@ func handleUploadLogs(
 		}
 
 		// TODO: actually do something with the log data
-		_ = logs
+		unregistered, err := unregisteredLogPlayers(ctx, tx, logs)
+		if err != nil {
+			b.TripleError("Log upload failed", err, i, true)
+			return
+		}
 
 		tx.Commit()
-		err = b.FollowUp("Log files uploaded", i)
+		if len(unregistered) == 0 {
+			err = b.FollowUp("Log files uploaded", i)
+		} else {
+			contents := &bot.MessageContents{Embed: &discordgo.MessageEmbed{
+				Title:       "Log files uploaded",
+				Description: unregisteredPlayersText(unregistered),
+				Color:       0xffa500, // Orange color
+			}}
+			err = b.FollowUpComplex(contents, i, 5*time.Minute)
+		}
 		if err != nil {
 			b.Logger.Error().Err(err).Msg("Failed to reply to interaction")
 		}
 	}
 }
+
+// Get the in-game names and SlapIDs of players in the logs that aren't
+// registered as a player, including any whose game user ID isn't a SlapID
+func unregisteredLogPlayers(
+	ctx context.Context,
+	tx db.SafeTX,
+	logs []*gamelogs.Gamelog,
+) ([]string, error) {
+	seen := map[string]bool{}
+	unregistered := []string{}
+	for _, log := range logs {
+		for _, player := range log.Players {
+			if seen[player.GameUserID] {
+				continue
+			}
+			seen[player.GameUserID] = true
+			slapid, err := strconv.ParseUint(player.GameUserID, 10, 32)
+			if err != nil {
+				// not a SlapID so they can't be registered, but the rest of
+				// the logs are still usable
+				unregistered = append(unregistered,
+					fmt.Sprintf("%s (invalid SlapID %q)", player.Username, player.GameUserID))
+				continue
+			}
+			registered, err := models.GetPlayerBySlapID(ctx, tx, uint32(slapid))
+			if err != nil {
+				return nil, errors.Wrap(err, "models.GetPlayerBySlapID")
+			}
+			if registered == nil {
+				unregistered = append(unregistered,
+					fmt.Sprintf("%s (SlapID %v)", player.Username, slapid))
+			}
+		}
+	}
+	return unregistered, nil
+}
+
+// Get the text listing the players in uploaded logs that aren't registered
+func unregisteredPlayersText(unregistered []string) string {
+	return "These players in the logs are not registered:\n" +
+		strings.Join(unregistered, "\n")
+}
@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"gosl/pkg/i18n"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Edits the permissions of the commands. Bots can't edit command permissions,
+// so this is a session using the bearer token of a user that can manage the
+// server
+type permissionsSession interface {
+	ApplicationCommandPermissionsEdit(
+		appID string,
+		guildID string,
+		cmdID string,
+		permissions *discordgo.ApplicationCommandPermissionsList,
+		options ...discordgo.RequestOption,
+	) error
+}
+
+// Get a session authorised with the user bearer token
+var newPermissionsSession = func(token string) (permissionsSession, error) {
+	return discordgo.New("Bearer " + token)
+}
+
+// The roles that need access to a command restricted to a permission
+type commandAccess struct {
+	name  string
+	id    string
+	roles []string
+}
+
+// Give the roles with the permission each command needs access to it once
+// the transaction that changed the roles is committed. Runs in the background
+// so it doesnt block/get blocked by that transaction
+func SyncPermissions(ctx context.Context, b *bot.Bot) {
+	go func() {
+		err := syncPermissions(ctx, b, getCommands(ctx, b), true)
+		if err != nil {
+			b.DoubleError("Failed to sync command permissions", err)
+		}
+	}()
+}
+
+// Give the roles with the permission each command needs access to it. With
+// no user token, or if editing the permissions fails, the admin channel is
+// told which roles to give access to in the server's integration settings
+// instead if notify is true
+func syncPermissions(
+	ctx context.Context,
+	b *bot.Bot,
+	commands []*Command,
+	notify bool,
+) error {
+	access, adminChannelID, err := getCommandAccess(ctx, b, commands)
+	if err != nil {
+		return errors.Wrap(err, "getCommandAccess")
+	}
+	if len(access) == 0 {
+		return nil
+	}
+	if b.Config.DiscordUserToken != "" {
+		err = editCommandPermissions(ctx, b, access)
+		if err == nil {
+			b.Logger.Info().Int("commands", len(access)).Msg("Synced command permissions")
+			return nil
+		}
+		b.Logger.Warn().Err(err).Msg("Failed to edit command permissions")
+		notify = true
+	}
+	if !notify {
+		return nil
+	}
+	if adminChannelID == "" {
+		b.Logger.Warn().Msg("Admin channel not configured, roles not told about command access")
+		return nil
+	}
+	err = b.Schedule(ctx, bot.PriorityNormal, http.MethodPost,
+		discordgo.EndpointChannelMessages(adminChannelID))
+	if err != nil {
+		return errors.Wrap(err, "b.Schedule")
+	}
+	_, err = b.Session.ChannelMessageSendComplex(adminChannelID, &discordgo.MessageSend{
+		Embed: commandAccessEmbed(b.GuildLanguage(), access),
+	})
+	if err != nil {
+		return errors.Wrap(err, "b.Session.ChannelMessageSendComplex")
+	}
+	return nil
+}
+
+// Get the restricted commands registered with the guild and the roles that
+// need access to them, and the ID of the admin channel
+func getCommandAccess(
+	ctx context.Context,
+	b *bot.Bot,
+	commands []*Command,
+) ([]commandAccess, string, error) {
+	appID := b.Session.UserID()
+	err := b.Schedule(ctx, bot.PriorityBulk, http.MethodGet,
+		discordgo.EndpointApplicationGuildCommands(appID, b.GuildID))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "b.Schedule")
+	}
+	registered, err := b.Session.ApplicationCommands(appID, b.GuildID)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "b.Session.ApplicationCommands")
+	}
+	ids := map[string]string{}
+	for _, cmd := range registered {
+		ids[cmd.Name] = cmd.ID
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// we use a WTX here to force it to block until commit of the transaction
+	// that changed the roles
+	tx, err := b.Conn.Begin(timeout, "commands.getCommandAccess()")
+	if err != nil {
+		return nil, "", errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	roles := map[uint16][]string{}
+	access := []commandAccess{}
+	for _, cmd := range commands {
+		if cmd.Permission == 0 || ids[cmd.Name] == "" {
+			continue
+		}
+		if _, exists := roles[cmd.Permission]; !exists {
+			roles[cmd.Permission], err = models.GetRoles(timeout, tx, b.GuildID, cmd.Permission)
+			if err != nil {
+				return nil, "", errors.Wrap(err, "models.GetRoles")
+			}
+		}
+		access = append(access, commandAccess{
+			name:  cmd.Name,
+			id:    ids[cmd.Name],
+			roles: roles[cmd.Permission],
+		})
+	}
+	adminChannelID, err := models.GetChannel(timeout, tx, b.GuildID, models.ChannelAdmin)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "models.GetChannel")
+	}
+	return access, adminChannelID, nil
+}
+
+// Replace the permissions of each command so only the roles that need access
+// to it, and server admins, can use it
+func editCommandPermissions(
+	ctx context.Context,
+	b *bot.Bot,
+	access []commandAccess,
+) error {
+	session, err := newPermissionsSession(b.Config.DiscordUserToken)
+	if err != nil {
+		return errors.Wrap(err, "newPermissionsSession")
+	}
+	appID := b.Session.UserID()
+	for _, cmd := range access {
+		permissions := &discordgo.ApplicationCommandPermissionsList{
+			Permissions: []*discordgo.ApplicationCommandPermissions{},
+		}
+		for _, roleID := range cmd.roles {
+			permissions.Permissions = append(permissions.Permissions,
+				&discordgo.ApplicationCommandPermissions{
+					ID:         roleID,
+					Type:       discordgo.ApplicationCommandPermissionTypeRole,
+					Permission: true,
+				})
+		}
+		err = b.Schedule(ctx, bot.PriorityBulk, http.MethodPut,
+			discordgo.EndpointApplicationCommandPermissions(appID, b.GuildID, cmd.id))
+		if err != nil {
+			return errors.Wrap(err, "b.Schedule")
+		}
+		err = session.ApplicationCommandPermissionsEdit(appID, b.GuildID, cmd.id, permissions)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("session.ApplicationCommandPermissionsEdit (%s)", cmd.name))
+		}
+	}
+	return nil
+}
+
+// Get the embed telling the admins which roles to give access to each command
+func commandAccessEmbed(lang string, access []commandAccess) *discordgo.MessageEmbed {
+	description := i18n.T(lang, "commands.access.description") + "\n"
+	for _, cmd := range access {
+		roles := i18n.T(lang, "commands.access.noroles")
+		if len(cmd.roles) > 0 {
+			mentions := make([]string, len(cmd.roles))
+			for idx, roleID := range cmd.roles {
+				mentions[idx] = "<@&" + roleID + ">"
+			}
+			roles = strings.Join(mentions, ", ")
+		}
+		description = description + fmt.Sprintf("\n**/%s**: %s", cmd.name, roles)
+	}
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(lang, "commands.access.title"),
+		Description: description,
+		Color:       0xffa500, // Orange color
+	}
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/discordtest"
+	"gosl/internal/health"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/tests"
+	"strconv"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Records the command permissions edited with the user token
+type fakePermissionsSession struct {
+	edits map[string][]string // command ID -> role IDs given access
+	err   error
+}
+
+func (s *fakePermissionsSession) ApplicationCommandPermissionsEdit(
+	appID string,
+	guildID string,
+	cmdID string,
+	permissions *discordgo.ApplicationCommandPermissionsList,
+	options ...discordgo.RequestOption,
+) error {
+	if s.err != nil {
+		return s.err
+	}
+	roles := []string{}
+	for _, perm := range permissions.Permissions {
+		if perm.Type == discordgo.ApplicationCommandPermissionTypeRole && perm.Permission {
+			roles = append(roles, perm.ID)
+		}
+	}
+	s.edits[cmdID] = roles
+	return nil
+}
+
+func TestSyncPermissions(t *testing.T) {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	logger := tests.NilLogger()
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	wconn, rconn, err := tests.SetupTestDB(ver)
+	require.NoError(t, err)
+	conn := db.MakeSafe(wconn, rconn, logger)
+	t.Cleanup(func() { conn.Close() })
+	ctx := t.Context()
+
+	session := discordtest.NewSession(cfg.DiscordGuildID)
+	managerRole := session.AddRole("League Manager")
+	adminChannel, err := session.GuildChannelCreate(session.GuildID, "admin", discordgo.ChannelTypeGuildText)
+	require.NoError(t, err)
+	tx, err := conn.Begin(ctx, "TestSyncPermissions setup")
+	require.NoError(t, err)
+	require.NoError(t, models.SetChannel(ctx, tx, cfg.DiscordGuildID, adminChannel.ID, models.ChannelAdmin))
+	require.NoError(t, models.AddPermission(ctx, tx, cfg.DiscordGuildID, managerRole,
+		models.PermLeagueManager))
+	tx.Commit()
+
+	b, err := bot.NewBotWithSession(session, logger, nil, conn, cfg, health.NewStatus(true), nil)
+	require.NoError(t, err)
+	commands := getCommands(ctx, b)
+	_, err = syncCommands(t.Context(), b, commands)
+	require.NoError(t, err)
+	ids := map[string]string{}
+	for _, cmd := range session.Commands() {
+		ids[cmd.Name] = cmd.ID
+	}
+	fake := &fakePermissionsSession{edits: map[string][]string{}}
+	newPermissionsSession = func(token string) (permissionsSession, error) {
+		return fake, nil
+	}
+	t.Cleanup(func() {
+		newPermissionsSession = func(token string) (permissionsSession, error) {
+			return discordgo.New("Bearer " + token)
+		}
+	})
+
+	t.Run("Admin channel is told which roles need access without a user token", func(t *testing.T) {
+		require.NoError(t, syncPermissions(ctx, b, commands, false))
+		assert.Empty(t, session.Messages(adminChannel.ID))
+
+		require.NoError(t, syncPermissions(ctx, b, commands, true))
+		messages := session.Messages(adminChannel.ID)
+		require.Len(t, messages, 1)
+		description := messages[0].Embeds[0].Description
+		assert.Contains(t, description, "**/uploadlogs**: <@&"+managerRole+">")
+		assert.Contains(t, description, "**/audit**: <@&"+managerRole+">")
+		assert.Contains(t, description, "**/admin**: *no roles have the permission*")
+		assert.NotContains(t, description, "/profile")
+		assert.NotContains(t, description, "/lobby")
+		assert.Empty(t, fake.edits)
+	})
+
+	b.Config.DiscordUserToken = "user-token"
+	t.Run("Roles are given access with the user token", func(t *testing.T) {
+		require.NoError(t, syncPermissions(ctx, b, commands, true))
+		assert.Equal(t, map[string][]string{
+			ids["uploadlogs"]: {managerRole},
+			ids["audit"]:      {managerRole},
+			ids["admin"]:      {},
+		}, fake.edits)
+		assert.Len(t, session.Messages(adminChannel.ID), 1)
+	})
+
+	t.Run("Admin channel is told if the permissions can't be edited", func(t *testing.T) {
+		fake.err = errors.New("401: Unauthorized")
+		require.NoError(t, syncPermissions(ctx, b, commands, false))
+		assert.Len(t, session.Messages(adminChannel.ID), 2)
+	})
+}
@@ -3,11 +3,11 @@ package commands
 import (
 	"context"
 	"gosl/internal/discord/bot"
+	"slices"
 	"sync"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
-	"github.com/rs/zerolog"
 )
 
 type Command struct {
@@ -15,6 +15,7 @@ type Command struct {
 	Description string
 	Handler     bot.Handler
 	Options     []*discordgo.ApplicationCommandOption
+	Permission  uint16 // permission needed to see the command, 0 for everyone
 }
 
 // Get all the commands registered
@@ -23,6 +24,13 @@ func getCommands(ctx context.Context, b *bot.Bot) []*Command {
 		cmdUploadLogs(ctx, b),
 		cmdTeam(ctx, b),
 		cmdUploadLogo(ctx, b),
+		cmdFreeAgents(ctx, b),
+		cmdProfile(ctx, b),
+		cmdAudit(ctx, b),
+		cmdAdmin(ctx, b),
+		cmdLanguage(ctx, b),
+		cmdTransfers(ctx, b),
+		cmdLobby(ctx, b),
 	}
 }
 
@@ -35,39 +43,46 @@ func Setup(
 ) {
 	defer wg.Done()
 	commands := getCommands(ctx, b)
+	added, err := syncCommands(ctx, b, commands)
+	if err != nil {
+		errch <- errors.Wrap(err, "syncCommands")
+	}
+	// the admins only need telling which roles to give access to when a
+	// restricted command is added
+	notify := false
 	for _, cmd := range commands {
-		_, err := b.Session.ApplicationCommandCreate(
-			b.Session.State.User.ID,
-			"",
-			&discordgo.ApplicationCommand{
-				Name:        cmd.Name,
-				Description: cmd.Description,
-				Options:     cmd.Options,
-			})
-		if err != nil {
-			b.Logger.Error().Err(err).Str("command", cmd.Name).
-				Msg("Failed to register command")
-			errch <- errors.Wrapf(err, "b.session.ApplicationCommandCreate: %s", cmd.Name)
-			continue
+		if cmd.Permission != 0 && slices.Contains(added, cmd.Name) {
+			notify = true
 		}
-		b.Logger.Debug().Str("command", cmd.Name).Msg("Registering command")
+	}
+	err = syncPermissions(ctx, b, commands, notify)
+	if err != nil {
+		errch <- errors.Wrap(err, "syncPermissions")
 	}
 
-	b.Session.AddHandler(handleCommandInteractions(b.Logger, commands))
+	b.AddInteractionHandler(handleCommandInteractions(b, commands))
 	b.Logger.Info().Msg("Finished registering commands")
 }
 
 // Handle the command interactions
 func handleCommandInteractions(
-	logger *zerolog.Logger,
+	b *bot.Bot,
 	commands []*Command,
 ) bot.Handler {
-	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	return func(i *discordgo.InteractionCreate) {
+		// each guild's bot handles the commands used in its guild
+		if i.GuildID != b.GuildID {
+			return
+		}
 		if i.Type == discordgo.InteractionApplicationCommand {
 			for _, cmd := range commands {
 				if i.ApplicationCommandData().Name == cmd.Name {
-					cmd.Handler(s, i)
-					logger.Debug().Str("command", cmd.Name).Msg("Handled command")
+					track := bot.TrackInteraction(i)
+					if !b.UnderMaintenance(i) {
+						cmd.Handler(i)
+					}
+					track()
+					b.Logger.Debug().Str("command", cmd.Name).Msg("Handled command")
 					return
 				}
 			}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/pkg/i18n"
+	"net/http"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Get the application command to register for the command. Commands that
+// need a permission are hidden from everyone but server admins by default,
+// and the roles with the permission are given access by syncPermissions
+func (cmd *Command) applicationCommand() *discordgo.ApplicationCommand {
+	key := "command." + cmd.Name
+	appCmd := &discordgo.ApplicationCommand{
+		Type:        discordgo.ChatApplicationCommand,
+		Name:        cmd.Name,
+		Description: cmd.Description,
+		Options:     localiseOptions(key, cmd.Options),
+	}
+	if names := localisations(key + ".name"); names != nil {
+		appCmd.NameLocalizations = &names
+	}
+	if descriptions := localisations(key + ".description"); descriptions != nil {
+		appCmd.DescriptionLocalizations = &descriptions
+	}
+	if cmd.Permission != 0 {
+		var noPermissions int64 = 0
+		appCmd.DefaultMemberPermissions = &noPermissions
+	}
+	return appCmd
+}
+
+// Get copies of the options with the name, description and choice
+// localisations from the message catalogues. Options are looked up by their
+// path under the command, e.g. "command.admin.rename-player.name.description"
+// for the name option of /admin rename-player. Copies are made as the same
+// option can be used by more than one command
+func localiseOptions(
+	key string,
+	options []*discordgo.ApplicationCommandOption,
+) []*discordgo.ApplicationCommandOption {
+	if options == nil {
+		return nil
+	}
+	localised := make([]*discordgo.ApplicationCommandOption, len(options))
+	for idx, opt := range options {
+		optKey := key + "." + opt.Name
+		copied := *opt
+		copied.NameLocalizations = localisations(optKey + ".name")
+		copied.DescriptionLocalizations = localisations(optKey + ".description")
+		copied.Options = localiseOptions(optKey, opt.Options)
+		if opt.Choices != nil {
+			copied.Choices = make([]*discordgo.ApplicationCommandOptionChoice, len(opt.Choices))
+			for cidx, choice := range opt.Choices {
+				copiedChoice := *choice
+				copiedChoice.NameLocalizations = localisations(
+					fmt.Sprintf("%s.choice.%v", optKey, choice.Value))
+				copied.Choices[cidx] = &copiedChoice
+			}
+		}
+		localised[idx] = &copied
+	}
+	return localised
+}
+
+// Get the translations of the message for each discord locale that has one,
+// or nil if it hasn't been translated
+func localisations(key string) map[discordgo.Locale]string {
+	var translations map[discordgo.Locale]string
+	for locale := range discordgo.Locales {
+		lang := i18n.Match(string(locale))
+		if lang == "" || lang == i18n.Default {
+			continue
+		}
+		text, exists := i18n.Lookup(lang, key)
+		if !exists {
+			continue
+		}
+		if translations == nil {
+			translations = map[discordgo.Locale]string{}
+		}
+		translations[locale] = text
+	}
+	return translations
+}
+
+// Register the commands with the guild, replacing any that are registered
+// but no longer wanted. The commands are only overwritten if they differ
+// from those registered. Any global commands registered by older versions
+// of the bot are removed. Returns the names of the commands added
+func syncCommands(ctx context.Context, b *bot.Bot, commands []*Command) ([]string, error) {
+	appID := b.Session.UserID()
+	guildID := b.GuildID
+	desired := make([]*discordgo.ApplicationCommand, len(commands))
+	for idx, cmd := range commands {
+		desired[idx] = cmd.applicationCommand()
+	}
+	err := b.Schedule(ctx, bot.PriorityNormal, http.MethodGet,
+		discordgo.EndpointApplicationGuildCommands(appID, guildID))
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Schedule")
+	}
+	registered, err := b.Session.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Session.ApplicationCommands")
+	}
+	added, changed, removed := diffCommands(desired, registered)
+	if len(added)+len(changed)+len(removed) == 0 {
+		b.Logger.Debug().Int("commands", len(desired)).Msg("Commands are up to date")
+	} else {
+		err = b.Schedule(ctx, bot.PriorityNormal, http.MethodPut,
+			discordgo.EndpointApplicationGuildCommands(appID, guildID))
+		if err != nil {
+			return nil, errors.Wrap(err, "b.Schedule")
+		}
+		_, err = b.Session.ApplicationCommandBulkOverwrite(appID, guildID, desired)
+		if err != nil {
+			return nil, errors.Wrap(err, "b.Session.ApplicationCommandBulkOverwrite")
+		}
+		b.Logger.Info().
+			Strs("added", added).
+			Strs("changed", changed).
+			Strs("removed", removed).
+			Msg("Synced commands")
+	}
+
+	err = b.Schedule(ctx, bot.PriorityNormal, http.MethodGet,
+		discordgo.EndpointApplicationGlobalCommands(appID))
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Schedule")
+	}
+	global, err := b.Session.ApplicationCommands(appID, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Session.ApplicationCommands (global)")
+	}
+	if len(global) > 0 {
+		err = b.Schedule(ctx, bot.PriorityNormal, http.MethodPut,
+			discordgo.EndpointApplicationGlobalCommands(appID))
+		if err != nil {
+			return nil, errors.Wrap(err, "b.Schedule")
+		}
+		_, err = b.Session.ApplicationCommandBulkOverwrite(
+			appID, "", []*discordgo.ApplicationCommand{})
+		if err != nil {
+			return nil, errors.Wrap(err, "b.Session.ApplicationCommandBulkOverwrite (global)")
+		}
+		b.Logger.Info().Int("commands", len(global)).Msg("Removed global commands")
+	}
+	return added, nil
+}
+
+// Get the names of the commands that need to be added, changed or removed
+// for the registered commands to match the desired commands
+func diffCommands(
+	desired []*discordgo.ApplicationCommand,
+	registered []*discordgo.ApplicationCommand,
+) (added, changed, removed []string) {
+	current := make(map[string]*discordgo.ApplicationCommand, len(registered))
+	for _, cmd := range registered {
+		current[cmd.Name] = cmd
+	}
+	for _, cmd := range desired {
+		existing, exists := current[cmd.Name]
+		if !exists {
+			added = append(added, cmd.Name)
+		} else if commandDefinition(existing) != commandDefinition(cmd) {
+			changed = append(changed, cmd.Name)
+		}
+		delete(current, cmd.Name)
+	}
+	for name := range current {
+		removed = append(removed, name)
+	}
+	slices.Sort(removed)
+	return added, changed, removed
+}
+
+// Get the parts of the command definition set by the bot, ignoring the
+// fields discord assigns such as the ID and version
+func commandDefinition(cmd *discordgo.ApplicationCommand) string {
+	definition, _ := json.Marshal(&discordgo.ApplicationCommand{
+		Type:                     discordgo.ChatApplicationCommand,
+		Name:                     cmd.Name,
+		NameLocalizations:        cmd.NameLocalizations,
+		Description:              cmd.Description,
+		DescriptionLocalizations: cmd.DescriptionLocalizations,
+		DefaultMemberPermissions: cmd.DefaultMemberPermissions,
+		Options:                  cmd.Options,
+	})
+	return string(definition)
+}
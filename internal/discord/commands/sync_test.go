@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/discordtest"
+	"gosl/internal/health"
+	"gosl/pkg/i18n"
+	"gosl/pkg/tests"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCommands(t *testing.T) {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	session := discordtest.NewSession(cfg.DiscordGuildID)
+	b, err := bot.NewBotWithSession(
+		session, tests.NilLogger(), nil, nil, cfg, health.NewStatus(true), nil)
+	require.NoError(t, err)
+	commands := getCommands(t.Context(), b)
+
+	// commands registered by an older version of the bot
+	_, err = session.ApplicationCommandBulkOverwrite(session.UserID(), "",
+		[]*discordgo.ApplicationCommand{{Name: "team", Description: "View team info"}})
+	require.NoError(t, err)
+	_, err = session.ApplicationCommandBulkOverwrite(session.UserID(), cfg.DiscordGuildID,
+		[]*discordgo.ApplicationCommand{{Name: "stale", Description: "No longer used"}})
+	require.NoError(t, err)
+	registered := func(guildID string) map[string]*discordgo.ApplicationCommand {
+		cmds, err := session.ApplicationCommands(session.UserID(), guildID)
+		require.NoError(t, err)
+		byName := map[string]*discordgo.ApplicationCommand{}
+		for _, cmd := range cmds {
+			byName[cmd.Name] = cmd
+		}
+		return byName
+	}
+
+	t.Run("Commands are registered with the guild", func(t *testing.T) {
+		added, err := syncCommands(t.Context(), b, commands)
+		require.NoError(t, err)
+		assert.Len(t, added, len(commands))
+		guild := registered(cfg.DiscordGuildID)
+		assert.Len(t, guild, len(commands))
+		assert.NotContains(t, guild, "stale")
+		assert.Empty(t, registered(""))
+
+		require.Contains(t, guild, "uploadlogs")
+		require.NotNil(t, guild["uploadlogs"].DefaultMemberPermissions)
+		assert.Equal(t, int64(0), *guild["uploadlogs"].DefaultMemberPermissions)
+		require.Contains(t, guild, "profile")
+		assert.Nil(t, guild["profile"].DefaultMemberPermissions)
+	})
+
+	t.Run("Commands are registered with their translations", func(t *testing.T) {
+		freeagents := registered(cfg.DiscordGuildID)["freeagents"]
+		require.NotNil(t, freeagents.DescriptionLocalizations)
+		assert.Equal(t, i18n.T("fr", "command.freeagents.description"),
+			(*freeagents.DescriptionLocalizations)[discordgo.French])
+		assert.NotContains(t, *freeagents.DescriptionLocalizations, discordgo.EnglishUS)
+		position := freeagents.Options[1]
+		assert.Equal(t, i18n.T("de", "command.freeagents.position.description"),
+			position.DescriptionLocalizations[discordgo.German])
+		assert.Equal(t, i18n.T("es", "command.freeagents.position.choice.Goalie"),
+			position.Choices[2].NameLocalizations[discordgo.SpanishES])
+	})
+
+	t.Run("Commands are only overwritten when they change", func(t *testing.T) {
+		overwrites := session.CommandOverwrites()
+		added, err := syncCommands(t.Context(), b, commands)
+		require.NoError(t, err)
+		assert.Empty(t, added)
+		assert.Equal(t, overwrites, session.CommandOverwrites())
+
+		commands[0].Description = "Changed description"
+		_, err = syncCommands(t.Context(), b, commands)
+		require.NoError(t, err)
+		assert.Equal(t, overwrites+1, session.CommandOverwrites())
+		assert.Equal(t, "Changed description",
+			registered(cfg.DiscordGuildID)[commands[0].Name].Description)
+	})
+}
+
+func TestDiffCommands(t *testing.T) {
+	var zero int64
+	desired := []*discordgo.ApplicationCommand{
+		{Name: "a", Description: "A"},
+		{Name: "b", Description: "B", DefaultMemberPermissions: &zero},
+		{Name: "c", Description: "C"},
+	}
+	registered := []*discordgo.ApplicationCommand{
+		{ID: "1", Version: "1", Type: discordgo.ChatApplicationCommand, Name: "a", Description: "A"},
+		{ID: "2", Name: "b", Description: "B"},
+		{ID: "3", Name: "d", Description: "D"},
+	}
+	added, changed, removed := diffCommands(desired, registered)
+	assert.Equal(t, []string{"c"}, added)
+	assert.Equal(t, []string{"b"}, changed)
+	assert.Equal(t, []string{"d"}, removed)
+}
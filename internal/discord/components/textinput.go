@@ -25,3 +25,17 @@ func TextInput(
 		},
 	}
 }
+
+// Multi-line version of TextInput
+func TextArea(
+	customID string,
+	label string,
+	required bool,
+	value string,
+	minlen int,
+	maxlen int,
+) *discordgo.ActionsRow {
+	row := TextInput(customID, label, required, value, minlen, maxlen)
+	row.Components[0].(*discordgo.TextInput).Style = discordgo.TextInputParagraph
+	return row
+}
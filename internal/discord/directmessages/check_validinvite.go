@@ -4,35 +4,31 @@ import (
 	"context"
 	"gosl/internal/models"
 	"gosl/pkg/db"
-	"strconv"
 
 	"github.com/pkg/errors"
 )
 
-// For a given player, and inviteID as a string, check if the invite is valid and
-// can be actioned
+// For a given player and inviteID, check if the invite is valid and
+// can be actioned. Invalid invites return an error with the prefix
+// "Invalid invite:" followed by the message key of the reason
 func getValidInvite(
 	ctx context.Context,
 	tx db.SafeTX,
-	inviteIDstr string,
+	inviteID uint32,
 	player *models.Player,
 ) (*models.PlayerTeamInvite, error) {
-	inviteID, err := strconv.ParseUint(inviteIDstr, 10, 0)
-	if err != nil {
-		return nil, errors.Wrap(err, "strconv.ParseUint")
-	}
-	invite, err := models.GetPlayerTeamInvite(ctx, tx, uint32(inviteID))
+	invite, err := models.GetPlayerTeamInvite(ctx, tx, inviteID)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetPlayerTeamInvite")
 	}
 	if invite == nil {
-		return nil, errors.New("Invalid invite:This invite is no longer valid")
+		return nil, errors.New("Invalid invite:invite.invalid")
 	}
 	if invite.PlayerID != player.ID {
-		return nil, errors.New("Invalid invite:This invite is not for you")
+		return nil, errors.New("Invalid invite:invite.notforyou")
 	}
 	if invite.Status != nil {
-		return nil, errors.New("Invalid invite:This invite is not pending")
+		return nil, errors.New("Invalid invite:invite.notpending")
 	}
 	return invite, nil
 }
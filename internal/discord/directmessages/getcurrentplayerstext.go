@@ -3,11 +3,13 @@ package directmessages
 import (
 	"fmt"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 	"sort"
 )
 
 // Generates a string with the list of players
 func teamCurrentPlayersMsg(
+	lang string,
 	team *models.Team,
 	currentPlayers *[]models.Player,
 	invitedPlayers *[]models.PlayerTeamInvite,
@@ -24,18 +26,21 @@ func teamCurrentPlayersMsg(
 	})
 	for _, player := range *currentPlayers {
 		if player.ID == team.ManagerID {
-			playersmsg = playersmsg + "\n%s (Manager)"
+			playersmsg = playersmsg + "\n" + i18n.T(lang, "team.manager", player.NameWithRank())
 		} else {
-			playersmsg = playersmsg + "\n%s"
+			playersmsg = playersmsg + "\n" + player.NameWithRank()
 		}
-		playersmsg = fmt.Sprintf(playersmsg, player.Name)
 	}
 	for _, player := range *invitedPlayers {
-		playersmsg = playersmsg + "\n%s (%s)"
 		if player.Status == nil {
-			playersmsg = fmt.Sprintf(playersmsg, player.PlayerName, "Invited")
+			label := i18n.T(lang, "panel.invited")
+			if player.Offer != models.OfferInvite {
+				label = i18n.T(lang, "offer."+player.Offer)
+			}
+			playersmsg = playersmsg + fmt.Sprintf("\n%s (%s)", player.PlayerName, label)
 		} else if player.Approved == nil {
-			playersmsg = fmt.Sprintf(playersmsg, player.PlayerName, "Pending approval")
+			playersmsg = playersmsg + fmt.Sprintf("\n%s (%s)",
+				player.PlayerName, i18n.T(lang, "panel.pendingapproval"))
 		}
 	}
 	return playersmsg
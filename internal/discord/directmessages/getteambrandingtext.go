@@ -0,0 +1,34 @@
+package directmessages
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+
+	"github.com/pkg/errors"
+)
+
+// Generates a string listing the name and abbreviation the team played under
+// in each season, so the team's history is kept after a rename
+func teamBrandingMsg(
+	ctx context.Context,
+	tx db.SafeTX,
+	lang string,
+	team *models.Team,
+) (string, error) {
+	history, err := team.BrandingHistory(ctx, tx)
+	if err != nil {
+		return "", errors.Wrap(err, "team.BrandingHistory")
+	}
+	if len(*history) == 0 {
+		return i18n.T(lang, "panel.nopastseasons"), nil
+	}
+	brandingmsg := ""
+	for _, branding := range *history {
+		brandingmsg = brandingmsg + fmt.Sprintf("\n**%s** - %s (%s)",
+			branding.SeasonName, branding.Name, branding.Abbreviation)
+	}
+	return brandingmsg, nil
+}
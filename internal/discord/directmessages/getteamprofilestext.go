@@ -0,0 +1,69 @@
+package directmessages
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/util"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Generates a string summarising the profiles of the current players, the
+// positions the team has covered and the times most players are available
+func teamProfilesMsg(
+	ctx context.Context,
+	tx db.SafeTX,
+	lang string,
+	currentPlayers *[]models.Player,
+) (string, error) {
+	profilesmsg := ""
+	positions := map[string]int{}
+	slots := map[string]int{}
+	for _, player := range *currentPlayers {
+		profile, err := models.GetPlayerProfile(ctx, tx, player.ID)
+		if err != nil {
+			return "", errors.Wrap(err, "models.GetPlayerProfile")
+		}
+		profilesmsg = profilesmsg + fmt.Sprintf("\n**%s** - %s",
+			player.Name, util.ProfileSummary(lang, profile))
+		for _, position := range profile.Positions {
+			positions[position]++
+		}
+		for _, slot := range profile.Availability {
+			slots[slot]++
+		}
+	}
+	covered := []string{}
+	for _, position := range models.PlayerPositions {
+		covered = append(covered, fmt.Sprintf("%s %v", position, positions[position]))
+	}
+	profilesmsg = profilesmsg + "\n" + i18n.T(lang, "panel.positions", strings.Join(covered, ", "))
+
+	// list the slots with at least 2 players available, most players first
+	bestSlots := []string{}
+	for _, slot := range models.AvailabilitySlots() {
+		if slots[slot] >= 2 {
+			bestSlots = append(bestSlots, slot)
+		}
+	}
+	sort.SliceStable(bestSlots, func(i, j int) bool {
+		return slots[bestSlots[i]] > slots[bestSlots[j]]
+	})
+	if len(bestSlots) > 5 {
+		bestSlots = bestSlots[:5]
+	}
+	best := i18n.T(lang, "panel.nobesttimes")
+	if len(bestSlots) > 0 {
+		for idx, slot := range bestSlots {
+			bestSlots[idx] = fmt.Sprintf("%s (%v)", slot, slots[slot])
+		}
+		best = strings.Join(bestSlots, ", ")
+	}
+	profilesmsg = profilesmsg + "\n" + i18n.T(lang, "panel.besttimes", best)
+	return profilesmsg, nil
+}
@@ -0,0 +1,21 @@
+package directmessages
+
+import (
+	"gosl/internal/models"
+	"gosl/pkg/i18n"
+)
+
+// Generates a string with the status of the team's registration for the
+// season and their preferred league
+func teamRegistrationMsg(lang string, teamReg *models.TeamRegistration) string {
+	status := ""
+	if teamReg.Approved == nil {
+		status = i18n.T(lang, "panel.status.pending", teamReg.SeasonName)
+	} else if teamReg.Placed == 0 {
+		status = i18n.T(lang, "panel.status.approved", teamReg.SeasonName)
+	} else {
+		status = i18n.T(lang, "panel.status.placed",
+			teamReg.PlacedLeagueName, teamReg.SeasonName)
+	}
+	return "\n" + status + "\n" + i18n.T(lang, "panel.preferred", teamReg.PreferredLeague)
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/transfers"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -20,7 +25,7 @@ func handleAcceptInvite(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	inviteIDstr string,
+	inviteID uint32,
 	panelMsgID string,
 ) error {
 	b.Acknowledge(i, ack)
@@ -28,25 +33,25 @@ func handleAcceptInvite(
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
 	}
-	invite, err := getValidInvite(ctx, tx, inviteIDstr, player)
+	invite, err := getValidInvite(ctx, tx, inviteID, player)
 	if err != nil {
 		if strings.Contains(err.Error(), "Invalid invite:") {
 			errmsg := strings.TrimPrefix(err.Error(), "Invalid invite:")
-			expireInvite(b, i.Message.ID, i.User.ID, invite)
-			return b.Error("Failed to accept invite", errmsg, i, *ack)
+			expireInvite(ctx, tx, b, i.Message.ID, i.User.ID, invite)
+			return b.Error(b.T(i, "invite.accept.failed"), b.T(i, errmsg), i, *ack)
 		}
 		return errors.Wrap(err, "getValidInvite")
 	}
 	if invite.Approved != nil && *invite.Approved == 0 {
-		expireInvite(b, i.Message.ID, i.User.ID, invite)
-		return b.Error("Failed to accept invite", "This invite has been denied by staff", i, *ack)
+		expireInvite(ctx, tx, b, i.Message.ID, i.User.ID, invite)
+		return b.Error(b.T(i, "invite.accept.failed"), b.T(i, "invite.denied"), i, *ack)
 	}
 	currentTeam, err := player.CurrentTeam(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "player.CurrentTeam")
 	}
 	if currentTeam != nil {
-		return b.Error("Failed to accept invite", "You are already on a team", i, *ack)
+		return b.Error(b.T(i, "invite.accept.failed"), b.T(i, "invite.onteam"), i, *ack)
 	}
 	team, err := models.GetTeamByID(ctx, tx, invite.TeamID)
 	if err != nil {
@@ -58,30 +63,38 @@ func handleAcceptInvite(
 		return errors.Wrap(err, "team.Players")
 	}
 	if len(*currentPlayers) == 5 {
-		return b.Error("Failed to accept invite", "Team is at max player count", i, *ack)
+		return b.Error(b.T(i, "invite.accept.failed"), b.T(i, "invite.teamfull"), i, *ack)
 	}
 	err = invite.Accept(ctx, tx)
 	if err != nil {
 		return errors.Wrap(err, "invite.Accept")
 	}
-	resultMsg := ""
-	managerMsg := ""
+	resultKey := "invite.pending"
+	managerKey := "invite.pending.manager"
 	if invite.Approved != nil && *invite.Approved == 1 {
-		err = player.JoinTeam(ctx, tx, team.ID)
+		err = player.JoinTeam(ctx, tx, team.ID, models.MoveInvite, &invite.ID)
 		if err != nil {
 			return errors.Wrap(err, "player.JoinTeam")
 		}
-		resultMsg = fmt.Sprintf("You have joined %s!", team.Name)
-		managerMsg = fmt.Sprintf("%s has joined %s!", player.Name, team.Name)
-	} else {
-		resultMsg = fmt.Sprintf(
-			"You have accepted the invite to join %s and are awaiting staff approval",
-			team.Name)
-		managerMsg = fmt.Sprintf(
-			"%s has accepted the invite to join %s and is awaiting staff approval",
-			player.Name, team.Name)
+		err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+			Actor:    bot.AuditActor(i),
+			Action:   models.AuditPlayerJoinedTeam,
+			Entity:   "player_team",
+			EntityID: strconv.FormatUint(uint64(player.ID), 10),
+			TeamID:   &team.ID,
+			PlayerID: &player.ID,
+			Summary:  fmt.Sprintf("%s joined %s from an invite", player.Name, team.Name),
+		}, nil, map[string]uint16{"team_id": team.ID})
+		if err != nil {
+			return errors.Wrap(err, "models.RecordAudit")
+		}
+		teamdiscord.SyncTeam(ctx, b, team.ID)
+		leagueroles.Sync(ctx, b)
+		transfers.Update(ctx, b, team.GuildID)
+		resultKey = "invite.joined"
+		managerKey = "invite.joined.manager"
 	}
-	expireInvite(b, i.Message.ID, i.User.ID, invite)
+	expireInvite(ctx, tx, b, i.Message.ID, i.User.ID, invite)
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -91,7 +104,12 @@ func handleAcceptInvite(
 			b.Logger.Warn().Err(err).Msg("Failed to get team manager")
 			return
 		}
-		err = b.SendDirectMessage("Invite accepted", managerMsg, manager.DiscordID)
+		lang := b.UserLang(manager.DiscordID)
+		err = b.SendDirectMessage(ctx,
+			i18n.T(lang, "invite.accepted.title"),
+			i18n.T(lang, managerKey, player.Name, team.Name),
+			manager.DiscordID,
+		)
 		if err != nil {
 			b.Logger.Warn().Err(err).Msg("Failed to notify team manager of invite acceptance")
 			return
@@ -99,7 +117,7 @@ func handleAcceptInvite(
 		updateTeamManagerPanel(ctx, tx, b, team, panelMsgID, manager.DiscordID)
 	}()
 	wg.Wait()
-	err = b.FollowUp(resultMsg, i)
+	err = b.FollowUp(b.T(i, resultKey, team.Name), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/transfers"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/discord/util"
+	"gosl/internal/models"
 	"gosl/pkg/db"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,11 +29,11 @@ func handleDisbandTeam(
 	_, team, err := util.CheckPlayerIsManager(ctx, tx, i.User.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "VE:") {
-			return b.Error("Interaction failed", err.Error(), i, *ack)
+			return b.Error(b.T(i, "error.interaction"), err.Error(), i, *ack)
 		}
 		return errors.Wrap(err, "util.CheckPlayerIsManager")
 	}
-	contents := disbandTeamComponents(team, i.Message.ID)
+	contents := disbandTeamComponents(b.Lang(i), team, i.Message.ID)
 	err = b.FollowUpComplex(contents, i, 20*time.Second)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUpComplex")
@@ -48,18 +53,39 @@ func handleDisbandTeamConfirm(
 	_, team, err := util.CheckPlayerIsManager(ctx, tx, i.User.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "VE:") {
-			return b.Error("Interaction failed", err.Error(), i, *ack)
+			return b.Error(b.T(i, "error.interaction"), err.Error(), i, *ack)
 		}
 		return errors.Wrap(err, "util.CheckPlayerIsManager")
 	}
+	now := time.Now()
+	roster, err := team.Players(ctx, tx, &now, &now)
+	if err != nil {
+		return errors.Wrap(err, "team.Players")
+	}
 	err = team.Disband(ctx, tx)
 	if err != nil {
 		if err.Error() == "Team cannot be disbanded as they are in an active league" {
-			return b.Error("Failed to disband team", err.Error(), i, *ack)
+			return b.Error(b.T(i, "panel.disband.failed"), b.T(i, "panel.disband.active"), i, *ack)
 		}
 		return errors.Wrap(err, "team.Disband")
 	}
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditTeamDisbanded,
+		Entity:   "team",
+		EntityID: strconv.FormatUint(uint64(team.ID), 10),
+		TeamID:   &team.ID,
+		Summary:  fmt.Sprintf("%s was disbanded by its manager", team.Name),
+	}, roster, nil)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	teamdiscord.SyncTeam(ctx, b, team.ID)
+	leagueroles.Sync(ctx, b)
+	transfers.Update(ctx, b, team.GuildID)
 	panelMsg, err := b.GetDirectMessage(
+		ctx,
+		tx,
 		panelMsgID,
 		i.User.ID,
 		"Team Manager Panel",
@@ -69,15 +95,15 @@ func handleDisbandTeamConfirm(
 	if err != nil {
 		return errors.Wrap(err, "b.GetDirectMessage")
 	}
-	contents, err := TeamManagerComponents(ctx, tx, b, team)
+	contents, err := TeamManagerComponents(ctx, tx, b, b.UserLang(i.User.ID), team)
 	if err != nil {
 		return errors.Wrap(err, "TeamManagerComponents")
 	}
-	err = panelMsg.Expire(contents)
+	err = panelMsg.Expire(ctx, tx, contents)
 	if err != nil {
 		return errors.Wrap(err, "panelMsg.Expire")
 	}
-	err = b.FollowUp(fmt.Sprintf("%s has been disbanded", team.Name), i)
+	err = b.FollowUp(b.T(i, "panel.disband.disbanded", team.Name), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
@@ -0,0 +1,93 @@
+package directmessages
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/util"
+	"gosl/pkg/db"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+func handleFreeAgentOfferButton(
+	ctx context.Context,
+	tx db.SafeTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+) error {
+	b.Acknowledge(i, ack)
+	_, team, err := util.CheckPlayerIsManager(ctx, tx, i.User.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "error.interaction"), err.Error(), i, *ack)
+		}
+		return errors.Wrap(err, "util.CheckPlayerIsManager")
+	}
+	now := time.Now()
+	currentPlayers, err := team.Players(ctx, tx, &now, &now)
+	if err != nil {
+		return errors.Wrap(err, "team.Players")
+	}
+	if len(*currentPlayers) == 5 {
+		return b.Error(b.T(i, "offer.failed"), b.T(i, "offer.teamfull"), i, *ack)
+	}
+
+	contents, err := freeAgentOfferComponents(ctx, tx, b.Lang(i), team, i.Message.ID)
+	if err != nil {
+		if err.Error() == "No eligible players" {
+			err := b.FollowUp(b.T(i, "offer.none"), i)
+			if err != nil {
+				return errors.Wrap(err, "b.FollowUp")
+			}
+			return nil
+		}
+		return errors.Wrap(err, "freeAgentOfferComponents")
+	}
+	err = b.FollowUpComplex(contents, i, 60*time.Second)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUpComplex")
+	}
+	return nil
+}
+
+func handleFreeAgentOfferSelect(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+	offer string,
+	panelMsgID string,
+) error {
+	b.Acknowledge(i, ack)
+	_, team, err := util.CheckPlayerIsManager(ctx, tx, i.User.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "error.interaction"), err.Error(), i, *ack)
+		}
+		return errors.Wrap(err, "util.CheckPlayerIsManager")
+	}
+	playerIDs := i.MessageComponentData().Values
+	if len(playerIDs) == 0 {
+		err = b.FollowUp(b.T(i, "offer.noselection"), i)
+		if err != nil {
+			return errors.Wrap(err, "b.FollowUp")
+		}
+		return nil
+	}
+	err = sendTeamInvites(ctx, tx, b, team, playerIDs, offer, panelMsgID)
+	if err != nil {
+		return errors.Wrap(err, "sendTeamInvites")
+	}
+	updateTeamManagerPanel(ctx, tx, b, team, panelMsgID, i.User.ID)
+
+	err = b.FollowUp(b.T(i, "offer.sent", b.T(i, "offer."+offer)), i)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUp")
+	}
+	return nil
+}
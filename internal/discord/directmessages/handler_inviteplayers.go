@@ -73,7 +73,32 @@ func handleInviteSelectedPlayersInteraction(
 		return errors.Wrap(err, "util.CheckPlayerIsManager")
 	}
 
-	playerIDs := i.MessageComponentData().Values
+	err = sendTeamInvites(ctx, tx, b, team, i.MessageComponentData().Values,
+		models.OfferInvite, panelMsgID)
+	if err != nil {
+		return errors.Wrap(err, "sendTeamInvites")
+	}
+	updateTeamManagerPanel(ctx, tx, b, team, panelMsgID, i.User.ID)
+
+	err = b.FollowUp("Players invited", i)
+	if err != nil {
+		return errors.Wrap(err, "")
+	}
+
+	return nil
+}
+
+// Send an invite or offer to join the team to each of the players, and a
+// transfer request to staff if the invite needs to be approved
+func sendTeamInvites(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	team *models.Team,
+	playerIDs []string,
+	offer string,
+	panelMsgID string,
+) error {
 	for _, playerID := range playerIDs {
 		player, err := models.GetPlayerByDiscordID(ctx, tx, playerID)
 		if err != nil {
@@ -82,7 +107,7 @@ func handleInviteSelectedPlayersInteraction(
 		if player == nil {
 			return errors.New("Player doesn't exist")
 		}
-		invite, err := team.InvitePlayer(ctx, tx, player.ID)
+		invite, err := team.InvitePlayer(ctx, tx, player.ID, offer)
 		if err != nil {
 			return errors.Wrap(err, "team.InvitePlayer")
 		}
@@ -100,26 +125,19 @@ func handleInviteSelectedPlayersInteraction(
 			if err != nil {
 				return errors.Wrap(err, "transferapprovals.NewTransferRequestMsg")
 			}
-			contents, err := transferapprovals.TransferRequestContents(ctx, tx, invite)
+			contents, err := transferapprovals.TransferRequestContents(ctx, tx, b.GuildLanguage(), invite)
 			if err != nil {
 				return errors.Wrap(err, "transferapprovals.TransferRequestContents")
 			}
-			err = transferMsg.Send(contents)
+			err = transferMsg.Send(ctx, tx, contents)
 			if err != nil {
 				return errors.Wrap(err, "transferMsg.Send")
 			}
 		}
-		err = invMsg.Send(contents)
+		err = invMsg.Send(ctx, tx, contents)
 		if err != nil {
 			return errors.Wrap(err, "invMsg.Send")
 		}
 	}
-	updateTeamManagerPanel(ctx, tx, b, team, panelMsgID, i.User.ID)
-
-	err = b.FollowUp("Players invited", i)
-	if err != nil {
-		return errors.Wrap(err, "")
-	}
-
 	return nil
 }
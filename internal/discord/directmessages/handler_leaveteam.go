@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/transfers"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"strconv"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -68,10 +72,25 @@ func handleLeaveTeamConfirm(
 	if err != nil {
 		return errors.Wrap(err, "models.GetTeamByID")
 	}
-	err = player.LeaveTeam(ctx, tx, team.ID)
+	err = player.LeaveTeam(ctx, tx, team.ID, models.MoveLeft)
 	if err != nil {
 		return errors.Wrap(err, "player.LeaveTeam")
 	}
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditPlayerLeftTeam,
+		Entity:   "player_team",
+		EntityID: strconv.FormatUint(uint64(player.ID), 10),
+		TeamID:   &team.ID,
+		PlayerID: &player.ID,
+		Summary:  fmt.Sprintf("%s left %s", player.Name, team.Name),
+	}, map[string]uint16{"team_id": team.ID}, nil)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	teamdiscord.SyncTeam(ctx, b, team.ID)
+	leagueroles.Sync(ctx, b)
+	transfers.Update(ctx, b, team.GuildID)
 	updateTeamPlayerPanel(ctx, tx, b, team, panelMsgID, i.User.ID, true)
 	err = b.FollowUp(fmt.Sprintf("You have left %s", team.Name), i)
 	if err != nil {
@@ -0,0 +1,183 @@
+package directmessages
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/components"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Handle the positions, region and availability selects on the player profile panel
+func handleProfileSelect(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+	field string,
+) error {
+	b.SilentAcknowledge(i, ack)
+	player, profile, err := getPlayerProfile(ctx, tx, i.User.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "error.interaction"), strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "getPlayerProfile")
+	}
+	values := i.MessageComponentData().Values
+	switch field {
+	case "positions":
+		err = profile.SetPositions(values)
+	case "region":
+		region := ""
+		if len(values) > 0 {
+			region = values[0]
+		}
+		err = profile.SetRegion(region)
+	case "availability":
+		err = profile.SetAvailability(values)
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "profile.update.failed"),
+				strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "profile.Set")
+	}
+	err = profile.Save(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "profile.Save")
+	}
+	updatePlayerProfilePanel(ctx, tx, b, player, profile, i.Message.ID, i.User.ID)
+	return nil
+}
+
+func handleProfileDetailsButton(
+	ctx context.Context,
+	tx db.SafeTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+) error {
+	_, profile, err := getPlayerProfile(ctx, tx, i.User.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "error.interaction"), strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "getPlayerProfile")
+	}
+	modalComps := []discordgo.MessageComponent{
+		components.TextInput("profile_timezone", b.T(i, "profile.modal.timezone"),
+			false, profile.Timezone, 0, 64),
+		components.TextInput("profile_playstyle", b.T(i, "profile.playstyle"),
+			false, profile.Playstyle, 0, 100),
+		components.TextArea("profile_bio", b.T(i, "profile.bio"), false, profile.Bio, 0, 300),
+		components.TextArea("profile_notes", b.T(i, "profile.notes"), false, profile.Notes, 0, 500),
+	}
+	err = b.ReplyModal(
+		b.T(i, "profile.modal.title"),
+		fmt.Sprintf("profile_details_modal_%s", i.Message.ID),
+		modalComps, i)
+	if err != nil {
+		return errors.Wrap(err, "b.ReplyModal")
+	}
+	return nil
+}
+
+func handleProfileDetailsSubmit(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+	panelMsgID string,
+) error {
+	b.Acknowledge(i, ack)
+	player, profile, err := getPlayerProfile(ctx, tx, i.User.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "error.interaction"), strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "getPlayerProfile")
+	}
+	values := []string{}
+	for _, row := range i.ModalSubmitData().Components {
+		values = append(values,
+			row.(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value)
+	}
+	err = profile.SetDetails(values[0], values[1], values[3], values[2])
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "profile.update.failed"),
+				strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "profile.SetDetails")
+	}
+	err = profile.Save(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "profile.Save")
+	}
+	updatePlayerProfilePanel(ctx, tx, b, player, profile, panelMsgID, i.User.ID)
+	err = b.FollowUp(b.T(i, "profile.updated"), i)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUp")
+	}
+	return nil
+}
+
+func getPlayerProfile(
+	ctx context.Context,
+	tx db.SafeTX,
+	discordID string,
+) (*models.Player, *models.PlayerProfile, error) {
+	player, err := models.GetPlayerByDiscordID(ctx, tx, discordID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "models.GetPlayerByDiscordID")
+	}
+	if player == nil {
+		return nil, nil, errors.New("VE:Not registered as a player")
+	}
+	profile, err := models.GetPlayerProfile(ctx, tx, player.ID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "models.GetPlayerProfile")
+	}
+	return player, profile, nil
+}
+
+func updatePlayerProfilePanel(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	player *models.Player,
+	profile *models.PlayerProfile,
+	panelMsgID string,
+	userID string,
+) {
+	panelMsg, err := b.GetDirectMessage(
+		ctx,
+		tx,
+		panelMsgID,
+		userID,
+		"Player Profile Panel",
+		5*time.Minute,
+		false,
+	)
+	if err != nil {
+		b.Logger.Warn().Err(errors.Wrap(err, "b.GetDirectMessage")).
+			Msg("Failed to update player profile panel")
+		return
+	}
+	err = panelMsg.Update(ctx, tx, PlayerProfileComponents(player, profile))
+	if err != nil {
+		b.Logger.Warn().Err(errors.Wrap(err, "panelMsg.Update")).
+			Msg("Failed to update player profile panel")
+		return
+	}
+}
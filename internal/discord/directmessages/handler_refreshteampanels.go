@@ -12,7 +12,7 @@ import (
 
 func handlerRefreshTeamPanel(
 	ctx context.Context,
-	tx db.SafeTX,
+	tx *db.SafeWTX,
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
@@ -81,11 +81,11 @@ func handleRegisterTeamSelectLeague(
 	if err != nil {
 		return errors.Wrap(err, "NewTeamApplicationMsg")
 	}
-	contents, err := teamapplications.TeamApplicationContents(ctx, tx, tr)
+	contents, err := teamapplications.TeamApplicationContents(ctx, tx, b.GuildLanguage(), tr)
 	if err != nil {
 		return errors.Wrap(err, "TeamApplicationContents")
 	}
-	err = regMsg.Send(contents)
+	err = regMsg.Send(ctx, tx, contents)
 	if err != nil {
 		return errors.Wrap(err, "regMsg.Send")
 	}
@@ -104,7 +104,7 @@ func checkRegistrationEligibility(
 	b *bot.Bot,
 	team *models.Team,
 ) (*models.Season, error) {
-	season, err := models.GetActiveSeason(ctx, tx)
+	season, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "models.GetActiveSeason")
 	}
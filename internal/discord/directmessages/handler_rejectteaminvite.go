@@ -2,10 +2,10 @@ package directmessages
 
 import (
 	"context"
-	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"strings"
 	"sync"
 
@@ -19,7 +19,7 @@ func handleRejectInvite(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	inviteIDstr string,
+	inviteID uint32,
 	panelMsgID string,
 ) error {
 	b.Acknowledge(i, ack)
@@ -27,11 +27,11 @@ func handleRejectInvite(
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByDiscordID")
 	}
-	invite, err := getValidInvite(ctx, tx, inviteIDstr, player)
+	invite, err := getValidInvite(ctx, tx, inviteID, player)
 	if err != nil {
 		if strings.Contains(err.Error(), "Invalid invite:") {
 			errmsg := strings.TrimPrefix(err.Error(), "Invalid invite:")
-			return b.Error("Failed to reject invite", errmsg, i, *ack)
+			return b.Error(b.T(i, "invite.reject.failed"), b.T(i, errmsg), i, *ack)
 		}
 		return errors.Wrap(err, "getValidInvite")
 	}
@@ -43,12 +43,9 @@ func handleRejectInvite(
 	if err != nil {
 		return errors.Wrap(err, "invite.Reject")
 	}
-	resultMsg := fmt.Sprintf("You have rejected an invite to %s!", team.Name)
-
-	expireInvite(b, i.Message.ID, i.User.ID, invite)
+	expireInvite(ctx, tx, b, i.Message.ID, i.User.ID, invite)
 
 	if invite.Approved == nil || *invite.Approved == 1 {
-		managerMsg := fmt.Sprintf("%s has rejected your invite to %s!", player.Name, team.Name)
 		var wg sync.WaitGroup
 		wg.Add(1)
 		go func() {
@@ -58,7 +55,12 @@ func handleRejectInvite(
 				b.Logger.Warn().Err(err).Msg("Failed to get team manager")
 				return
 			}
-			err = b.SendDirectMessage("Invite rejected", managerMsg, manager.DiscordID)
+			lang := b.UserLang(manager.DiscordID)
+			err = b.SendDirectMessage(ctx,
+				i18n.T(lang, "invite.rejected.title"),
+				i18n.T(lang, "invite.rejected.manager", player.Name, team.Name),
+				manager.DiscordID,
+			)
 			if err != nil {
 				b.Logger.Warn().Err(err).Msg("Failed to notify team manager of invite rejected")
 				return
@@ -67,7 +69,7 @@ func handleRejectInvite(
 		}()
 		wg.Wait()
 	}
-	err = b.FollowUp(resultMsg, i)
+	err = b.FollowUp(b.T(i, "invite.rejected", team.Name), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
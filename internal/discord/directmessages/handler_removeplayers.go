@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/transfers"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/discord/util"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"strconv"
 	"strings"
 	"time"
@@ -26,7 +30,7 @@ func handleRemovePlayersButton(
 	_, team, err := util.CheckPlayerIsManager(ctx, tx, i.User.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "VE:") {
-			return b.Error("Interaction failed", err.Error(), i, *ack)
+			return b.Error(b.T(i, "error.interaction"), err.Error(), i, *ack)
 		}
 		return errors.Wrap(err, "checkPlayerIsManager")
 	}
@@ -40,7 +44,7 @@ func handleRemovePlayersButton(
 		return errors.Wrap(err, "team.InvitedPlayers")
 	}
 
-	contents := removePlayersComponents(team, currentPlayers, invitedPlayers, i.Message.ID)
+	contents := removePlayersComponents(b.Lang(i), team, currentPlayers, invitedPlayers, i.Message.ID)
 	err = b.FollowUpComplex(contents, i, 30*time.Second)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUpComplex")
@@ -55,32 +59,44 @@ func handleRemovePlayerInteraction(
 	b *bot.Bot,
 	i *discordgo.InteractionCreate,
 	ack *bool,
-	playerIDstr string,
+	playerID uint32,
 	panelMsgID string,
 ) error {
 	b.Acknowledge(i, ack)
 	_, team, err := util.CheckPlayerIsManager(ctx, tx, i.User.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "VE:") {
-			return b.Error("Interaction failed", err.Error(), i, *ack)
+			return b.Error(b.T(i, "error.interaction"), err.Error(), i, *ack)
 		}
 		return errors.Wrap(err, "checkPlayerIsManager")
 	}
-	playerID, err := strconv.ParseUint(playerIDstr, 10, 0)
-	if err != nil {
-		return errors.Wrap(err, "strconv.ParseUint")
-	}
 	player, err := models.GetPlayerByID(ctx, tx, uint16(playerID))
 	if err != nil {
 		return errors.Wrap(err, "models.GetPlayerByID")
 	}
-	err = player.LeaveTeam(ctx, tx, team.ID)
+	err = player.LeaveTeam(ctx, tx, team.ID, models.MoveRemoved)
 	if err != nil {
 		return errors.Wrap(err, "player.LeaveTeam")
 	}
-	err = b.SendDirectMessage(
-		"Removed from Team",
-		fmt.Sprintf("You have been removed from %s", team.Name),
+	err = models.RecordAudit(ctx, tx, b.GuildID, &models.AuditEntry{
+		Actor:    bot.AuditActor(i),
+		Action:   models.AuditPlayerRemovedFromTeam,
+		Entity:   "player_team",
+		EntityID: strconv.FormatUint(uint64(playerID), 10),
+		TeamID:   &team.ID,
+		PlayerID: &player.ID,
+		Summary:  fmt.Sprintf("%s was removed from %s", player.Name, team.Name),
+	}, map[string]uint16{"team_id": team.ID}, nil)
+	if err != nil {
+		return errors.Wrap(err, "models.RecordAudit")
+	}
+	teamdiscord.SyncTeam(ctx, b, team.ID)
+	leagueroles.Sync(ctx, b)
+	transfers.Update(ctx, b, team.GuildID)
+	lang := b.UserLang(player.DiscordID)
+	err = b.SendDirectMessage(ctx,
+		i18n.T(lang, "panel.remove.dm.title"),
+		i18n.T(lang, "panel.remove.dm.message", team.Name),
 		player.DiscordID,
 	)
 	if err != nil {
@@ -90,7 +106,7 @@ func handleRemovePlayerInteraction(
 
 	updateTeamManagerPanel(ctx, tx, b, team, panelMsgID, i.User.ID)
 
-	err = b.FollowUp(fmt.Sprintf("%s was removed from %s", player.Name, team.Name), i)
+	err = b.FollowUp(b.T(i, "panel.remove.removed", player.Name, team.Name), i)
 	if err != nil {
 		return errors.Wrap(err, "b.FollowUp")
 	}
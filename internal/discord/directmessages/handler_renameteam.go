@@ -0,0 +1,95 @@
+package directmessages
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/channels/teamapplications"
+	"gosl/internal/discord/components"
+	"gosl/internal/discord/util"
+	"gosl/pkg/db"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+func handleRenameTeamButton(
+	ctx context.Context,
+	tx db.SafeTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+) error {
+	_, team, err := util.CheckPlayerIsManager(ctx, tx, i.User.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "error.interaction"), err.Error(), i, *ack)
+		}
+		return errors.Wrap(err, "checkPlayerIsManager")
+	}
+	modalComps := []discordgo.MessageComponent{
+		components.TextInput("team_name", b.T(i, "registration.team.name"), true, team.Name, 1, 64),
+		components.TextInput("team_abbr", b.T(i, "registration.team.abbr"), true, team.Abbreviation, 3, 5),
+	}
+	err = b.ReplyModal(
+		b.T(i, "panel.rename.modal"),
+		fmt.Sprintf("rename_team_modal_%s", i.Message.ID),
+		modalComps, i)
+	if err != nil {
+		return errors.Wrap(err, "b.ReplyModal")
+	}
+	return nil
+}
+
+func handleRenameTeamSubmit(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	b *bot.Bot,
+	i *discordgo.InteractionCreate,
+	ack *bool,
+	panelMsgID string,
+) error {
+	b.Acknowledge(i, ack)
+	_, team, err := util.CheckPlayerIsManager(ctx, tx, i.User.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "error.interaction"), err.Error(), i, *ack)
+		}
+		return errors.Wrap(err, "checkPlayerIsManager")
+	}
+	teamName := i.ModalSubmitData().Components[0].(*discordgo.ActionsRow).
+		Components[0].(*discordgo.TextInput).Value
+	teamAbbr := i.ModalSubmitData().Components[1].(*discordgo.ActionsRow).
+		Components[0].(*discordgo.TextInput).Value
+
+	req, err := team.RequestRename(ctx, tx, teamName, teamAbbr)
+	if err != nil {
+		if strings.Contains(err.Error(), "VE:") {
+			return b.Error(b.T(i, "panel.rename.failed"),
+				strings.TrimPrefix(err.Error(), "VE:"), i, *ack)
+		}
+		return errors.Wrap(err, "team.RequestRename")
+	}
+
+	reqMsg, err := teamapplications.NewTeamRenameRequestMsg(ctx, b)
+	if err != nil {
+		return errors.Wrap(err, "teamapplications.NewTeamRenameRequestMsg")
+	}
+	contents, err := teamapplications.TeamRenameRequestContents(ctx, tx, b.GuildLanguage(), req)
+	if err != nil {
+		return errors.Wrap(err, "teamapplications.TeamRenameRequestContents")
+	}
+	err = reqMsg.Send(ctx, tx, contents)
+	if err != nil {
+		return errors.Wrap(err, "reqMsg.Send")
+	}
+
+	updateTeamManagerPanel(ctx, tx, b, team, panelMsgID, i.User.ID)
+	err = b.FollowUp(b.T(i, "panel.rename.requested",
+		team.Name, req.Name, req.Abbreviation), i)
+	if err != nil {
+		return errors.Wrap(err, "b.FollowUp")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/discord/components"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/discord/util"
 	"gosl/pkg/db"
 	"regexp"
@@ -60,6 +61,7 @@ func handleSetTeamColor(
 	if err != nil {
 		return errors.Wrap(err, "team.SetColor")
 	}
+	teamdiscord.SyncTeam(ctx, b, team.ID)
 	updateTeamManagerPanel(ctx, tx, b, team, panelMsgID, i.User.ID)
 	err = b.FollowUp(fmt.Sprintf("Updated color for %s to #%s", team.Name, hexStr), i)
 	if err != nil {
@@ -2,108 +2,99 @@ package directmessages
 
 import (
 	"context"
-	"errors"
 	"gosl/internal/discord/bot"
-	"strings"
-	"time"
-
-	"github.com/bwmarrin/discordgo"
 )
 
 func HandleDMInteractions(ctx context.Context, b *bot.Bot) bot.Handler {
-	b.Logger.Debug().Msg("Adding handler for direct message interactions")
-	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if i.Type == discordgo.InteractionApplicationCommand {
-			return
-		}
-		if i.User == nil {
-			return
-		}
-		ack := false
-		timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-		tx, err := b.Conn.Begin(timeout, "DM interactions handler")
-		msg := "Failed to handle interaction in direct messages"
-		if err != nil {
-			b.TripleError(msg, err, i, ack)
-			return
-		}
-		defer tx.Rollback()
-		b.Logger.Debug().Msg("Handling direct message interaction")
-		switch i.Type {
-		case discordgo.InteractionMessageComponent:
-			// Handle message component interactions
-			customID := i.MessageComponentData().CustomID
-			b.Logger.Debug().Str("custom_id", customID).Msg("Handling Interaction")
-			switch {
-			case customID == "invite_players_button":
-				err = handleInvitePlayersInteraction(ctx, tx, b, i, &ack)
-			case strings.Contains(customID, "invite_selected_players_"):
-				panelMsgID := strings.TrimPrefix(customID, "invite_selected_players_")
-				err = handleInviteSelectedPlayersInteraction(ctx, tx, b, i, &ack, panelMsgID)
-			case customID == "remove_players_button":
-				err = handleRemovePlayersButton(ctx, tx, b, i, &ack)
-			case strings.Contains(customID, "remove_player_"):
-				args := strings.Split(strings.TrimPrefix(customID, "remove_player_"), "_")
-				playerID := args[0]
-				panelMsgID := args[1]
-				err = handleRemovePlayerInteraction(ctx, tx, b, i, &ack, playerID, panelMsgID)
-			case strings.Contains(customID, "revoke_invite_"):
-				args := strings.Split(strings.TrimPrefix(customID, "revoke_invite_"), "_")
-				inviteID := args[0]
-				panelMsgID := args[1]
-				err = handleRevokeInvite(ctx, tx, b, i, &ack, inviteID, panelMsgID)
-			case customID == "disband_team_button":
-				err = handleDisbandTeam(ctx, tx, b, i, &ack)
-			case strings.Contains(customID, "disband_team_confirm_"):
-				panelMsgID := strings.TrimPrefix(customID, "disband_team_confirm_")
-				err = handleDisbandTeamConfirm(ctx, tx, b, i, &ack, panelMsgID)
-			case customID == "set_color_button":
-				err = handleColorButton(b, i)
-			case customID == "register_team_button":
-				err = handleRegisterTeamButton(ctx, tx, b, i, &ack)
-			case strings.Contains(customID, "register_team_select_league_"):
-				panelMsgID := strings.TrimPrefix(customID, "register_team_select_league_")
-				err = handleRegisterTeamSelectLeague(ctx, tx, b, i, &ack, panelMsgID)
-			case strings.Contains(customID, "accept_invite_"):
-				args := strings.Split(strings.TrimPrefix(customID, "accept_invite_"), "_")
-				inviteID := args[0]
-				panelMsgID := args[1]
-				err = handleAcceptInvite(ctx, tx, b, i, &ack, inviteID, panelMsgID)
-			case strings.Contains(customID, "reject_invite_"):
-				args := strings.Split(strings.TrimPrefix(customID, "reject_invite_"), "_")
-				inviteID := args[0]
-				panelMsgID := args[1]
-				err = handleRejectInvite(ctx, tx, b, i, &ack, inviteID, panelMsgID)
-			case customID == "leave_team_button":
-				err = handleLeaveTeamButton(ctx, tx, b, i, &ack)
-			case strings.Contains(customID, "confirm_leave_team_"):
-				panelMsgID := strings.TrimPrefix(customID, "confirm_leave_team_")
-				err = handleLeaveTeamConfirm(ctx, tx, b, i, &ack, panelMsgID)
-			case customID == "refresh_team_panel":
-				err = handlerRefreshTeamPanel(ctx, tx, b, i, &ack)
-			default:
-				err = errors.New("no handler for interaction")
-			}
-			// error handling at end of function
-		case discordgo.InteractionModalSubmit:
-			// Handle modal interactions
-			customID := i.ModalSubmitData().CustomID
-			b.Logger.Debug().Str("custom_id", customID).Msg("Handling Interaction")
-			switch {
-			case strings.Contains(customID, "set_color_modal_"):
-				panelMsgID := strings.TrimPrefix(customID, "set_color_modal_")
-				err = handleSetTeamColor(ctx, tx, b, i, &ack, panelMsgID)
-			default:
-				err = errors.New("no handler for interaction")
-			}
-		}
-		// start error handling for interaction handlers
-		if err != nil {
-			msg := "Failed to handle interaction"
-			b.TripleError(msg, err, i, ack)
-			return
+	router := bot.NewRouter(b, "Direct messages", bot.InDirectMessage(b))
+	r := router.With(bot.MaintenanceGate(b), bot.Transaction(b))
+
+	// Team manager panel
+	r.Component("invite_players_button", func(r *bot.Request) error {
+		return handleInvitePlayersInteraction(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("invite_selected_players_{panel}", func(r *bot.Request) error {
+		return handleInviteSelectedPlayersInteraction(r.Ctx, r.Tx, b, r.I, &r.Ack,
+			r.Params.String("panel"))
+	})
+	r.Component("freeagent_offer_button", func(r *bot.Request) error {
+		return handleFreeAgentOfferButton(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("freeagent_offer_{offer}_{panel}", func(r *bot.Request) error {
+		return handleFreeAgentOfferSelect(r.Ctx, r.Tx, b, r.I, &r.Ack,
+			r.Params.String("offer"), r.Params.String("panel"))
+	})
+	r.Component("remove_players_button", func(r *bot.Request) error {
+		return handleRemovePlayersButton(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("remove_player_{player:id}_{panel}", func(r *bot.Request) error {
+		return handleRemovePlayerInteraction(r.Ctx, r.Tx, b, r.I, &r.Ack,
+			r.Params.ID("player"), r.Params.String("panel"))
+	})
+	r.Component("revoke_invite_{invite:id}_{panel}", func(r *bot.Request) error {
+		return handleRevokeInvite(r.Ctx, r.Tx, b, r.I, &r.Ack,
+			r.Params.ID("invite"), r.Params.String("panel"))
+	})
+	r.Component("disband_team_button", func(r *bot.Request) error {
+		return handleDisbandTeam(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("disband_team_confirm_{panel}", func(r *bot.Request) error {
+		return handleDisbandTeamConfirm(r.Ctx, r.Tx, b, r.I, &r.Ack, r.Params.String("panel"))
+	})
+	r.Component("set_color_button", func(r *bot.Request) error {
+		return handleColorButton(b, r.I)
+	})
+	r.Component("rename_team_button", func(r *bot.Request) error {
+		return handleRenameTeamButton(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("register_team_button", func(r *bot.Request) error {
+		return handleRegisterTeamButton(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("register_team_select_league_{panel}", func(r *bot.Request) error {
+		return handleRegisterTeamSelectLeague(r.Ctx, r.Tx, b, r.I, &r.Ack, r.Params.String("panel"))
+	})
+	r.Modal("set_color_modal_{panel}", func(r *bot.Request) error {
+		return handleSetTeamColor(r.Ctx, r.Tx, b, r.I, &r.Ack, r.Params.String("panel"))
+	})
+	r.Modal("rename_team_modal_{panel}", func(r *bot.Request) error {
+		return handleRenameTeamSubmit(r.Ctx, r.Tx, b, r.I, &r.Ack, r.Params.String("panel"))
+	})
+
+	// Team invites
+	r.Component("accept_invite_{invite:id}_{panel}", func(r *bot.Request) error {
+		return handleAcceptInvite(r.Ctx, r.Tx, b, r.I, &r.Ack,
+			r.Params.ID("invite"), r.Params.String("panel"))
+	})
+	r.Component("reject_invite_{invite:id}_{panel}", func(r *bot.Request) error {
+		return handleRejectInvite(r.Ctx, r.Tx, b, r.I, &r.Ack,
+			r.Params.ID("invite"), r.Params.String("panel"))
+	})
+
+	// Team player panel
+	r.Component("leave_team_button", func(r *bot.Request) error {
+		return handleLeaveTeamButton(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Component("confirm_leave_team_{panel}", func(r *bot.Request) error {
+		return handleLeaveTeamConfirm(r.Ctx, r.Tx, b, r.I, &r.Ack, r.Params.String("panel"))
+	})
+	r.Component("refresh_team_panel", func(r *bot.Request) error {
+		return handlerRefreshTeamPanel(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+
+	// Player profile
+	profileSelect := func(field string) bot.RouteFunc {
+		return func(r *bot.Request) error {
+			return handleProfileSelect(r.Ctx, r.Tx, b, r.I, &r.Ack, field)
 		}
-		tx.Commit()
 	}
+	r.Component("profile_positions_select", profileSelect("positions"))
+	r.Component("profile_region_select", profileSelect("region"))
+	r.Component("profile_availability_select", profileSelect("availability"))
+	r.Component("profile_details_button", func(r *bot.Request) error {
+		return handleProfileDetailsButton(r.Ctx, r.Tx, b, r.I, &r.Ack)
+	})
+	r.Modal("profile_details_modal_{panel}", func(r *bot.Request) error {
+		return handleProfileDetailsSubmit(r.Ctx, r.Tx, b, r.I, &r.Ack, r.Params.String("panel"))
+	})
+	return router.Handler(ctx)
 }
@@ -4,25 +4,23 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 
 	"github.com/bwmarrin/discordgo"
 )
 
-func disbandTeamComponents(team *models.Team, messageID string) *bot.MessageContents {
+func disbandTeamComponents(lang string, team *models.Team, messageID string) *bot.MessageContents {
 	embed := &discordgo.MessageEmbed{
-		Title: "Disband Team",
-		Description: fmt.Sprintf(`
-Are you sure you want to disband %s?
-This will remove all players including yourself.
-You **will** be able to rejoin this team in the future if you wish.`, team.Name),
-		Color: 0xff1919,
+		Title:       i18n.T(lang, "panel.disband.title"),
+		Description: "\n" + i18n.T(lang, "panel.disband.description", team.Name),
+		Color:       0xff1919,
 	}
 	comps := []discordgo.MessageComponent{
 		&discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				&discordgo.Button{
 					CustomID: fmt.Sprintf("disband_team_confirm_%s", messageID),
-					Label:    "Confirm Disband Team",
+					Label:    i18n.T(lang, "panel.disband.confirm"),
 					Style:    discordgo.DangerButton,
 				},
 			},
@@ -0,0 +1,78 @@
+package directmessages
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/components"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+func freeAgentOfferComponents(
+	ctx context.Context,
+	tx db.SafeTX,
+	lang string,
+	team *models.Team,
+	messageID string,
+) (*bot.MessageContents, error) {
+	freeAgents, err := models.GetFreeAgentListings(ctx, tx, team.GuildID, "", "", "")
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetFreeAgentListings")
+	}
+	invitedPlayers, err := team.InvitedPlayers(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "team.InvitedPlayers")
+	}
+	invited := map[uint16]bool{}
+	for _, invite := range *invitedPlayers {
+		invited[invite.PlayerID] = true
+	}
+	opts := []discordgo.SelectMenuOption{}
+	for _, fa := range *freeAgents {
+		if invited[fa.PlayerID] {
+			continue
+		}
+		// select menus are limited to 25 options
+		if len(opts) == 25 {
+			break
+		}
+		description := fa.League
+		if len(fa.Profile.Positions) > 0 {
+			description = description + " - " + strings.Join(fa.Profile.Positions, ", ")
+		}
+		opts = append(opts, discordgo.SelectMenuOption{
+			Label:       fa.PlayerName,
+			Value:       fa.DiscordID,
+			Description: description,
+		})
+	}
+	if len(opts) == 0 {
+		return nil, errors.New("No eligible players")
+	}
+	embed := &discordgo.MessageEmbed{
+		Color: team.Color,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   i18n.T(lang, "offer.panel.title"),
+				Value:  "\n" + i18n.T(lang, "offer.panel.description") + "\n",
+				Inline: false,
+			},
+		},
+	}
+	comps := components.StringSelect(
+		fmt.Sprintf("freeagent_offer_%s_%s", models.OfferTrial, messageID),
+		i18n.T(lang, "offer.panel.trial"), opts, 0, 1, false)
+	comps = append(comps, components.StringSelect(
+		fmt.Sprintf("freeagent_offer_%s_%s", models.OfferSigning, messageID),
+		i18n.T(lang, "offer.panel.signing"), opts, 0, 1, false)...)
+	return &bot.MessageContents{
+		Embed:      embed,
+		Components: comps,
+	}, nil
+}
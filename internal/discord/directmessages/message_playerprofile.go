@@ -0,0 +1,90 @@
+package directmessages
+
+import (
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/components"
+	"gosl/internal/discord/util"
+	"gosl/internal/models"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Get the embed showing the player profile
+func PlayerProfileEmbed(
+	player *models.Player,
+	profile *models.PlayerProfile,
+) *discordgo.MessageEmbed {
+	notSet := func(value string) string {
+		if value == "" {
+			return "Not set"
+		}
+		return value
+	}
+	positions := strings.Join(profile.Positions, ", ")
+	// availability is in the player's local time
+	availability := "Weekly availability"
+	if profile.Timezone != "" {
+		availability = availability + " (" + profile.Timezone + ")"
+	}
+	return &discordgo.MessageEmbed{
+		Title: "Player Profile - " + player.Name,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Positions", Value: notSet(positions), Inline: true},
+			{Name: "Region", Value: notSet(profile.Region), Inline: true},
+			{Name: "Timezone", Value: notSet(profile.Timezone), Inline: true},
+			{Name: "Playstyle", Value: notSet(profile.Playstyle), Inline: false},
+			{Name: "Bio", Value: notSet(profile.Bio), Inline: false},
+			{Name: "Notes for teams", Value: notSet(profile.Notes), Inline: false},
+			{Name: availability, Value: util.AvailabilityGrid(profile), Inline: false},
+		},
+		Color: 0x00ff00, // Green color
+	}
+}
+
+func PlayerProfileComponents(
+	player *models.Player,
+	profile *models.PlayerProfile,
+) *bot.MessageContents {
+	selectOpts := func(values []string, selected []string) []discordgo.SelectMenuOption {
+		opts := []discordgo.SelectMenuOption{}
+		for _, value := range values {
+			opts = append(opts, discordgo.SelectMenuOption{
+				Label:   value,
+				Value:   value,
+				Default: slices.Contains(selected, value),
+			})
+		}
+		return opts
+	}
+	embed := PlayerProfileEmbed(player, profile)
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name: "How to use:",
+		Value: `
+*Select your preferred positions, your region and when you are usually available to play in your local time*
+*Edit Details - Set your timezone, playstyle, bio and notes for teams looking at free agents. Dates you enter are read in your timezone*
+*Your profile is shown to your team, to teams browsing free agents, and on the website*
+`,
+		Inline: false,
+	})
+	slots := models.AvailabilitySlots()
+	msgcomps := components.StringSelect("profile_positions_select", "Preferred positions",
+		selectOpts(models.PlayerPositions, profile.Positions), 0, len(models.PlayerPositions), false)
+	msgcomps = append(msgcomps, components.StringSelect("profile_region_select", "Region",
+		selectOpts(models.PlayerRegions, []string{profile.Region}), 0, 1, false)...)
+	msgcomps = append(msgcomps, components.StringSelect("profile_availability_select",
+		"Weekly availability", selectOpts(slots, profile.Availability), 0, len(slots), false)...)
+	msgcomps = append(msgcomps, &discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			&discordgo.Button{
+				CustomID: "profile_details_button",
+				Label:    "Edit Details",
+			},
+		},
+	})
+	return &bot.MessageContents{
+		Embed:      embed,
+		Components: msgcomps,
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/i18n"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 func removePlayersComponents(
+	lang string,
 	team *models.Team,
 	currentPlayers *[]models.Player,
 	invitedPlayers *[]models.PlayerTeamInvite,
@@ -20,7 +22,7 @@ func removePlayersComponents(
 		if player.ID != team.ManagerID {
 			currentPlayerButtons = append(currentPlayerButtons, &discordgo.Button{
 				CustomID: fmt.Sprintf("remove_player_%v_%s", player.ID, messageID),
-				Label:    fmt.Sprintf("Remove %s", player.Name),
+				Label:    i18n.T(lang, "panel.remove.player", player.Name),
 				Style:    discordgo.DangerButton,
 			})
 		}
@@ -28,17 +30,15 @@ func removePlayersComponents(
 	for _, invite := range *invitedPlayers {
 		invitedPlayerButtons = append(invitedPlayerButtons, &discordgo.Button{
 			CustomID: fmt.Sprintf("revoke_invite_%v_%s", invite.ID, messageID),
-			Label:    fmt.Sprintf("Revoke invite for %s", invite.PlayerName),
+			Label:    i18n.T(lang, "panel.remove.invite", invite.PlayerName),
 			Style:    discordgo.DangerButton,
 		})
 	}
 	embed := &discordgo.MessageEmbed{
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name: fmt.Sprintf("Remove players from %s (%s)", team.Name, team.Abbreviation),
-				Value: `
-Click on the buttons below to remove players from the team, or revoke their invites.
-`,
+				Name:   i18n.T(lang, "panel.remove.title", team.Name, team.Abbreviation),
+				Value:  "\n" + i18n.T(lang, "panel.remove.description") + "\n",
 				Inline: false,
 			},
 		},
@@ -13,16 +13,26 @@ func TeamInviteComponents(
 	invite *models.PlayerTeamInvite,
 	panelMsgid string,
 ) (*bot.MessageContents, error) {
+	title := "Invite to team"
 	msg := "This invite is no longer valid"
 	inviteID := uint32(0)
 	if invite != nil {
-		msg = fmt.Sprintf("You've been invited to join ***%s***!", invite.TeamName)
+		switch invite.Offer {
+		case models.OfferTrial:
+			title = "Trial offer"
+			msg = fmt.Sprintf("***%s*** would like you to trial with the team!", invite.TeamName)
+		case models.OfferSigning:
+			title = "Signing offer"
+			msg = fmt.Sprintf("***%s*** would like to sign you to the team!", invite.TeamName)
+		default:
+			msg = fmt.Sprintf("You've been invited to join ***%s***!", invite.TeamName)
+		}
 		inviteID = invite.ID
 	}
 	embed := &discordgo.MessageEmbed{
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Invite to team",
+				Name:   title,
 				Value:  msg,
 				Inline: false,
 			},
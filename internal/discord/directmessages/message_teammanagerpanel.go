@@ -6,6 +6,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -16,11 +17,12 @@ func TeamManagerComponents(
 	ctx context.Context,
 	tx db.SafeTX,
 	b *bot.Bot,
+	lang string,
 	team *models.Team,
 ) (*bot.MessageContents, error) {
 	canRegister := true
 	canInvite := true
-	cantRegisterReason := "\nTo register, please complete the following:  "
+	cantRegisterReason := "\n" + i18n.T(lang, "panel.register.todo")
 	// Get current and invited players
 	now := time.Now()
 	currentPlayers, err := team.Players(ctx, tx, &now, &now)
@@ -33,21 +35,30 @@ func TeamManagerComponents(
 	}
 	if len(*currentPlayers) < 3 {
 		canRegister = false
-		cantRegisterReason = cantRegisterReason + "\n - Have at least 3 players"
+		cantRegisterReason = cantRegisterReason + "\n - " + i18n.T(lang, "panel.register.players")
 	}
 	if len(*currentPlayers) == 5 {
 		canInvite = false
 	}
 	if team.Color == 0x181825 {
 		canRegister = false
-		cantRegisterReason = cantRegisterReason + "\n - Set a team color"
+		cantRegisterReason = cantRegisterReason + "\n - " + i18n.T(lang, "panel.register.color")
 	}
 	if team.Logo == "" {
 		canRegister = false
-		cantRegisterReason = cantRegisterReason + "\n - Upload a logo"
+		cantRegisterReason = cantRegisterReason + "\n - " + i18n.T(lang, "panel.register.logo")
 	}
 
-	playersmsg := teamCurrentPlayersMsg(team, currentPlayers, invitedPlayers)
+	playersmsg := teamCurrentPlayersMsg(lang, team, currentPlayers, invitedPlayers)
+	profilesmsg, err := teamProfilesMsg(ctx, tx, lang, currentPlayers)
+	if err != nil {
+		return nil, errors.Wrap(err, "teamProfilesMsg")
+	}
+
+	brandingmsg, err := teamBrandingMsg(ctx, tx, lang, team)
+	if err != nil {
+		return nil, errors.Wrap(err, "teamBrandingMsg")
+	}
 
 	// Get team registration status
 	teamReg, err := team.RegistrationStatus(ctx, tx)
@@ -56,44 +67,22 @@ func TeamManagerComponents(
 	}
 	regMsg := ""
 	if teamReg == nil {
-		currentSeason, err := models.GetActiveSeason(ctx, tx)
+		currentSeason, err := models.GetActiveSeason(ctx, tx, b.GuildID)
 		if err != nil {
 			return nil, errors.Wrap(err, "models.GetActiveSeason")
 		}
 		if currentSeason == nil {
 			canRegister = false
-			cantRegisterReason = "\nThere is no active season right now"
+			cantRegisterReason = "\n" + i18n.T(lang, "season.none.message")
 		}
 		if !currentSeason.RegistrationOpen {
 			canRegister = false
-			cantRegisterReason = "\nRegistration is currently closed"
+			cantRegisterReason = "\n" + i18n.T(lang, "panel.register.closed")
 		}
-		regMsg = "Not currently registered"
-		regMsg = regMsg + cantRegisterReason
+		regMsg = i18n.T(lang, "panel.unregistered") + cantRegisterReason
 	} else {
 		canRegister = false
-		status := ""
-		league := fmt.Sprintf("\n__Preferred League:__ %s", teamReg.PreferredLeague)
-		if teamReg.Approved == nil {
-			status = fmt.Sprintf(
-				"\n__Status:__ Pending approval for %s",
-				teamReg.SeasonName,
-			)
-		} else {
-			if teamReg.Placed == 0 {
-				status = fmt.Sprintf(
-					"\n__Status:__ Pending placement for %s",
-					teamReg.SeasonName,
-				)
-			} else {
-				status = fmt.Sprintf(
-					"\n__Status:__ Placed in %s for %s",
-					teamReg.PlacedLeagueName,
-					teamReg.SeasonName,
-				)
-			}
-		}
-		regMsg = status + league
+		regMsg = teamRegistrationMsg(lang, teamReg)
 	}
 
 	embed := &discordgo.MessageEmbed{
@@ -104,24 +93,28 @@ func TeamManagerComponents(
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Players:",
+				Name:   i18n.T(lang, "panel.players"),
 				Value:  playersmsg,
 				Inline: false,
 			},
 			{
-				Name:   "Registration:",
+				Name:   i18n.T(lang, "panel.profiles"),
+				Value:  profilesmsg,
+				Inline: false,
+			},
+			{
+				Name:   i18n.T(lang, "panel.registration"),
 				Value:  regMsg,
 				Inline: false,
 			},
 			{
-				Name: "How to use:",
-				Value: `
-*Invite Players - Select from the list of eligible players to invite*
-*Remove Players - Remove individual players from the team*
-*Disband Team - Remove **ALL** players from the team, including yourself (you will be able to rejoin later if you want)*
-*Register Team - Select your preferred league and register to play in the current season!*
-*To upload a logo, use the **/uploadlogo** command*
-`,
+				Name:   i18n.T(lang, "panel.pastseasons"),
+				Value:  brandingmsg,
+				Inline: false,
+			},
+			{
+				Name:   i18n.T(lang, "panel.howto.title"),
+				Value:  "\n" + i18n.T(lang, "panel.howto.manager") + "\n",
 				Inline: false,
 			},
 		},
@@ -131,26 +124,26 @@ func TeamManagerComponents(
 			Components: []discordgo.MessageComponent{
 				&discordgo.Button{
 					CustomID: "invite_players_button",
-					Label:    "Invite Players",
+					Label:    i18n.T(lang, "panel.button.invite"),
 					Disabled: !canInvite,
 				},
 				&discordgo.Button{
 					CustomID: "remove_players_button",
-					Label:    "Remove Players",
+					Label:    i18n.T(lang, "panel.button.remove"),
 					Style:    discordgo.DangerButton,
 				},
 				&discordgo.Button{
 					CustomID: "disband_team_button",
-					Label:    "Disband Team",
+					Label:    i18n.T(lang, "panel.button.disband"),
 					Style:    discordgo.DangerButton,
 				},
 				&discordgo.Button{
 					CustomID: "set_color_button",
-					Label:    "Set Team Color",
+					Label:    i18n.T(lang, "panel.button.color"),
 				},
 				&discordgo.Button{
 					CustomID: "register_team_button",
-					Label:    "Register Team",
+					Label:    i18n.T(lang, "panel.button.register"),
 					Style:    discordgo.SuccessButton,
 					Disabled: !canRegister,
 				},
@@ -158,9 +151,18 @@ func TeamManagerComponents(
 		},
 		&discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
+				&discordgo.Button{
+					CustomID: "freeagent_offer_button",
+					Label:    i18n.T(lang, "panel.button.freeagent"),
+					Disabled: !canInvite,
+				},
+				&discordgo.Button{
+					CustomID: "rename_team_button",
+					Label:    i18n.T(lang, "panel.button.rename"),
+				},
 				&discordgo.Button{
 					CustomID: "refresh_team_panel",
-					Label:    "Refresh",
+					Label:    i18n.T(lang, "button.refresh"),
 				},
 			},
 		},
@@ -6,6 +6,7 @@ import (
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
 	"gosl/pkg/db"
+	"gosl/pkg/i18n"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -15,6 +16,7 @@ import (
 func TeamPlayerComponents(
 	ctx context.Context,
 	tx db.SafeTX,
+	lang string,
 	team *models.Team,
 ) (*bot.MessageContents, error) {
 	// Get current and invited players
@@ -27,7 +29,11 @@ func TeamPlayerComponents(
 	if err != nil {
 		return nil, errors.Wrap(err, "team.InvitedPlayers")
 	}
-	playersmsg := teamCurrentPlayersMsg(team, currentPlayers, invitedPlayers)
+	playersmsg := teamCurrentPlayersMsg(lang, team, currentPlayers, invitedPlayers)
+	profilesmsg, err := teamProfilesMsg(ctx, tx, lang, currentPlayers)
+	if err != nil {
+		return nil, errors.Wrap(err, "teamProfilesMsg")
+	}
 	// Get team registration status
 	teamReg, err := team.RegistrationStatus(ctx, tx)
 	if err != nil {
@@ -35,30 +41,9 @@ func TeamPlayerComponents(
 	}
 	regMsg := ""
 	if teamReg == nil {
-		regMsg = "Not currently registered"
+		regMsg = i18n.T(lang, "panel.unregistered")
 	} else {
-		status := ""
-		league := fmt.Sprintf("\n__Preferred League:__ %s", teamReg.PreferredLeague)
-		if teamReg.Approved == nil {
-			status = fmt.Sprintf(
-				"\n__Status:__ Pending approval for %s",
-				teamReg.SeasonName,
-			)
-		} else {
-			if teamReg.Placed == 0 {
-				status = fmt.Sprintf(
-					"\n__Status:__ Pending placement for %s",
-					teamReg.SeasonName,
-				)
-			} else {
-				status = fmt.Sprintf(
-					"\n__Status:__ Placed in %s for %s",
-					teamReg.PlacedLeagueName,
-					teamReg.SeasonName,
-				)
-			}
-		}
-		regMsg = status + league
+		regMsg = teamRegistrationMsg(lang, teamReg)
 	}
 	embed := &discordgo.MessageEmbed{
 		Color: team.Color,
@@ -68,12 +53,17 @@ func TeamPlayerComponents(
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Players:",
+				Name:   i18n.T(lang, "panel.players"),
 				Value:  playersmsg,
 				Inline: false,
 			},
 			{
-				Name:   "Registration:",
+				Name:   i18n.T(lang, "panel.profiles"),
+				Value:  profilesmsg,
+				Inline: false,
+			},
+			{
+				Name:   i18n.T(lang, "panel.registration"),
 				Value:  regMsg,
 				Inline: false,
 			},
@@ -84,11 +74,11 @@ func TeamPlayerComponents(
 			Components: []discordgo.MessageComponent{
 				&discordgo.Button{
 					CustomID: "refresh_team_panel",
-					Label:    "Refresh",
+					Label:    i18n.T(lang, "button.refresh"),
 				},
 				&discordgo.Button{
 					CustomID: "leave_team_button",
-					Label:    "Leave Team",
+					Label:    i18n.T(lang, "panel.button.leave"),
 					Style:    discordgo.DangerButton,
 				},
 			},
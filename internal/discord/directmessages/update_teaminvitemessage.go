@@ -1,17 +1,21 @@
 package directmessages
 
 import (
+	"context"
 	"gosl/internal/discord/bot"
 	"gosl/internal/models"
+	"gosl/pkg/db"
 )
 
 func expireInvite(
+	ctx context.Context,
+	tx *db.SafeWTX,
 	b *bot.Bot,
 	inviteMsgID string,
 	userID string,
 	invite *models.PlayerTeamInvite,
 ) {
-	invMsg, err := b.GetDirectMessage(inviteMsgID, userID, "Team invite", 0, false)
+	invMsg, err := b.GetDirectMessage(ctx, tx, inviteMsgID, userID, "Team invite", 0, false)
 	if err != nil {
 		b.Logger.Warn().Err(err).Msg("Failed to get direct message")
 		return
@@ -21,7 +25,7 @@ func expireInvite(
 		b.Logger.Warn().Err(err).Msg("Failed to get team invite components")
 		return
 	}
-	err = invMsg.Expire(contents)
+	err = invMsg.Expire(ctx, tx, contents)
 	if err != nil {
 		b.Logger.Warn().Err(err).Msg("Failed to expire invite message")
 		return
@@ -12,13 +12,15 @@ import (
 
 func updateTeamManagerPanel(
 	ctx context.Context,
-	tx db.SafeTX,
+	tx *db.SafeWTX,
 	b *bot.Bot,
 	team *models.Team,
 	panelMsgID string,
 	userID string,
 ) {
 	panelMsg, err := b.GetDirectMessage(
+		ctx,
+		tx,
 		panelMsgID,
 		userID,
 		"Team Manager Panel",
@@ -30,13 +32,13 @@ func updateTeamManagerPanel(
 			Msg("Failed to update team manager panel")
 		return
 	}
-	contents, err := TeamManagerComponents(ctx, tx, b, team)
+	contents, err := TeamManagerComponents(ctx, tx, b, b.UserLang(userID), team)
 	if err != nil {
 		b.Logger.Warn().Err(errors.Wrap(err, "TeamManagerComponents")).
 			Msg("Failed to update team manager panel")
 		return
 	}
-	err = panelMsg.Update(contents)
+	err = panelMsg.Update(ctx, tx, contents)
 	if err != nil {
 		b.Logger.Warn().Err(errors.Wrap(err, "panelMsg.Update")).
 			Msg("Failed to update team manager panel")
@@ -12,7 +12,7 @@ import (
 
 func updateTeamPlayerPanel(
 	ctx context.Context,
-	tx db.SafeTX,
+	tx *db.SafeWTX,
 	b *bot.Bot,
 	team *models.Team,
 	panelMsgID string,
@@ -20,6 +20,8 @@ func updateTeamPlayerPanel(
 	locked bool,
 ) {
 	panelMsg, err := b.GetDirectMessage(
+		ctx,
+		tx,
 		panelMsgID,
 		userID,
 		"Team Player Panel",
@@ -31,21 +33,21 @@ func updateTeamPlayerPanel(
 			Msg("Failed to update team player panel")
 		return
 	}
-	contents, err := TeamPlayerComponents(ctx, tx, team)
+	contents, err := TeamPlayerComponents(ctx, tx, b.UserLang(userID), team)
 	if err != nil {
 		b.Logger.Warn().Err(errors.Wrap(err, "TeamPlayerComponents")).
 			Msg("Failed to update team player panel")
 		return
 	}
 	if locked {
-		err = panelMsg.Expire(contents)
+		err = panelMsg.Expire(ctx, tx, contents)
 		if err != nil {
 			b.Logger.Warn().Err(errors.Wrap(err, "panelMsg.Expire")).
 				Msg("Failed to update team player panel")
 			return
 		}
 	} else {
-		err = panelMsg.Update(contents)
+		err = panelMsg.Update(ctx, tx, contents)
 		if err != nil {
 			b.Logger.Warn().Err(errors.Wrap(err, "panelMsg.Update")).
 				Msg("Failed to update team player panel")
@@ -0,0 +1,93 @@
+package discordtest
+
+import (
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Add a member to the guild with the given roles
+func (s *Session) AddMember(userID, username string, roleIDs ...string) *discordgo.Member {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	member := &discordgo.Member{
+		GuildID: s.GuildID,
+		User:    &discordgo.User{ID: userID, Username: username},
+		Roles:   slices.Clone(roleIDs),
+	}
+	s.members[userID] = member
+	return member
+}
+
+// Remove a member from the guild
+func (s *Session) RemoveMember(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.members, userID)
+}
+
+// Create a role in the guild, returning its ID
+func (s *Session) AddRole(name string) string {
+	role, _ := s.GuildRoleCreate(s.GuildID, &discordgo.RoleParams{Name: name})
+	return role.ID
+}
+
+// Get a snapshot of a guild member, or nil if they are not in the guild
+func (s *Session) Member(userID string) *discordgo.Member {
+	member, err := s.GuildMember(s.GuildID, userID)
+	if err != nil {
+		return nil
+	}
+	return member
+}
+
+// Find a guild channel by name, or nil if none exists
+func (s *Session) ChannelByName(name string) *discordgo.Channel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, channel := range s.channels {
+		if channel.GuildID == s.GuildID && channel.Name == name {
+			return channel
+		}
+	}
+	return nil
+}
+
+// Get the messages currently in a channel, oldest first
+func (s *Session) Messages(channelID string) []*discordgo.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.messages[channelID])
+}
+
+// Get the messages currently in the bot's DM channel with a user, oldest first
+func (s *Session) DirectMessages(userID string) []*discordgo.Message {
+	s.mu.Lock()
+	channelID, exists := s.dmChannels[userID]
+	s.mu.Unlock()
+	if !exists {
+		return nil
+	}
+	return s.Messages(channelID)
+}
+
+// Get the custom status last set by the bot
+func (s *Session) Status() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// Get the application commands registered by the bot
+func (s *Session) Commands() []*discordgo.ApplicationCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.commands)
+}
+
+// Get the number of times the bot has overwritten its registered commands
+func (s *Session) CommandOverwrites() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.overwrites
+}
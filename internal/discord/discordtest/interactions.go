@@ -0,0 +1,138 @@
+package discordtest
+
+import (
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// A value entered into a text input on a modal
+type ModalField struct {
+	CustomID string
+	Value    string
+}
+
+// Build a button press or select menu interaction on a message. If the
+// channel is a DM channel the interaction comes from the user, otherwise from
+// the guild member
+func (s *Session) ComponentInteraction(
+	channelID, messageID, userID, customID string,
+	values ...string,
+) *discordgo.InteractionCreate {
+	ctype := discordgo.ButtonComponent
+	if len(values) > 0 {
+		ctype = discordgo.SelectMenuComponent
+	}
+	i := s.newInteraction(discordgo.InteractionMessageComponent, channelID, userID)
+	i.Data = discordgo.MessageComponentInteractionData{
+		CustomID:      customID,
+		ComponentType: ctype,
+		Values:        values,
+	}
+	i.Message = s.interactionMessage(channelID, messageID)
+	return i
+}
+
+// Build a modal submission interaction for a modal opened from a component
+// on a message. Each field is put in its own action row, in the order given
+func (s *Session) ModalInteraction(
+	channelID, messageID, userID, customID string,
+	fields ...ModalField,
+) *discordgo.InteractionCreate {
+	i := s.newInteraction(discordgo.InteractionModalSubmit, channelID, userID)
+	i.Message = s.interactionMessage(channelID, messageID)
+	rows := make([]discordgo.MessageComponent, len(fields))
+	for idx, field := range fields {
+		rows[idx] = &discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			&discordgo.TextInput{CustomID: field.CustomID, Value: field.Value},
+		}}
+	}
+	i.Data = discordgo.ModalSubmitInteractionData{CustomID: customID, Components: rows}
+	return i
+}
+
+// Build a slash command interaction
+func (s *Session) CommandInteraction(
+	channelID, userID, name string,
+	options ...*discordgo.ApplicationCommandInteractionDataOption,
+) *discordgo.InteractionCreate {
+	i := s.newInteraction(discordgo.InteractionApplicationCommand, channelID, userID)
+	i.Data = discordgo.ApplicationCommandInteractionData{
+		Name:        name,
+		CommandType: discordgo.ChatApplicationCommand,
+		Options:     options,
+	}
+	return i
+}
+
+func (s *Session) newInteraction(
+	itype discordgo.InteractionType,
+	channelID, userID string,
+) *discordgo.InteractionCreate {
+	i := &discordgo.Interaction{
+		ID:        s.newID(),
+		AppID:     BotUserID,
+		Type:      itype,
+		ChannelID: channelID,
+		Token:     "token-" + s.newID(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, exists := s.channels[channelID]
+	if exists && channel.Type == discordgo.ChannelTypeDM {
+		i.User = &discordgo.User{ID: userID}
+		if member, exists := s.members[userID]; exists {
+			i.User = member.User
+		}
+		return &discordgo.InteractionCreate{Interaction: i}
+	}
+	i.GuildID = s.GuildID
+	member, exists := s.members[userID]
+	if !exists {
+		member = &discordgo.Member{GuildID: s.GuildID, User: &discordgo.User{ID: userID}}
+	}
+	copied := *member
+	copied.Roles = slices.Clone(member.Roles)
+	i.Member = &copied
+	return &discordgo.InteractionCreate{Interaction: i}
+}
+
+// Get the message an interaction was made on, or a stub if the message isn't
+// in the fake, e.g. an ephemeral follow up
+func (s *Session) interactionMessage(channelID, messageID string) *discordgo.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, _ := s.findMessage(channelID, messageID)
+	if msg == nil {
+		msg = &discordgo.Message{ID: messageID, ChannelID: channelID}
+	}
+	return msg
+}
+
+// Dispatch the interaction to the registered handlers, blocking until they
+// return
+func (s *Session) Interact(i *discordgo.InteractionCreate) {
+	s.Dispatch(i)
+}
+
+// Get the response the bot gave to an interaction, or nil if it hasn't
+// responded
+func (s *Session) Response(i *discordgo.InteractionCreate) *discordgo.InteractionResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.responses[i.ID]
+}
+
+// Get the follow up messages the bot sent for an interaction
+func (s *Session) Followups(i *discordgo.InteractionCreate) []*discordgo.WebhookParams {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.followups[i.ID])
+}
+
+// Check if the bot deleted its response to an interaction
+func (s *Session) ResponseDeleted(i *discordgo.InteractionCreate) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deletedResp[i.ID]
+}
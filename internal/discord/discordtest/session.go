@@ -0,0 +1,630 @@
+// Package discordtest provides an in-memory fake of the discord API for
+// testing the bot end to end without a network connection
+package discordtest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// The user ID the fake bot is logged in as
+const BotUserID = "100000000000000001"
+
+// An in-memory discord guild and API session. Safe for concurrent use
+type Session struct {
+	GuildID string
+
+	mu          sync.Mutex
+	nextID      atomic.Uint64
+	status      string
+	handlers    []*handler
+	channels    map[string]*discordgo.Channel
+	messages    map[string][]*discordgo.Message
+	dmChannels  map[string]string // userID -> channelID
+	members     map[string]*discordgo.Member
+	roles       map[string]*discordgo.Role
+	commands    []*discordgo.ApplicationCommand
+	overwrites  int
+	responses   map[string]*discordgo.InteractionResponse
+	followups   map[string][]*discordgo.WebhookParams
+	deletedResp map[string]bool
+}
+
+type handler struct {
+	event reflect.Type
+	fn    reflect.Value
+}
+
+// Create a new fake session for a guild with no channels, members or roles
+func NewSession(guildID string) *Session {
+	s := &Session{
+		GuildID:     guildID,
+		channels:    make(map[string]*discordgo.Channel),
+		messages:    make(map[string][]*discordgo.Message),
+		dmChannels:  make(map[string]string),
+		members:     make(map[string]*discordgo.Member),
+		roles:       make(map[string]*discordgo.Role),
+		responses:   make(map[string]*discordgo.InteractionResponse),
+		followups:   make(map[string][]*discordgo.WebhookParams),
+		deletedResp: make(map[string]bool),
+	}
+	s.nextID.Store(200000000000000000)
+	return s
+}
+
+// Generate a new snowflake-like ID
+func (s *Session) newID() string {
+	return strconv.FormatUint(s.nextID.Add(1), 10)
+}
+
+// Returns an error shaped like the one discordgo returns for a 404 response
+func notFound(kind, id string) error {
+	body := fmt.Sprintf(`{"message": "Unknown %s", "code": 10000}`, kind)
+	return &discordgo.RESTError{
+		Request: &http.Request{},
+		Response: &http.Response{
+			Status:     "404 Not Found",
+			StatusCode: http.StatusNotFound,
+		},
+		ResponseBody: []byte(body),
+		Message:      &discordgo.APIErrorMessage{Code: 10000, Message: "Unknown " + kind + " " + id},
+	}
+}
+
+func botUser() *discordgo.User {
+	return &discordgo.User{ID: BotUserID, Username: "gosl", Bot: true}
+}
+
+// ===========================================================================
+// CONNECTION
+// ===========================================================================
+
+func (s *Session) UserID() string {
+	return BotUserID
+}
+
+// Register an event handler. Handlers have the same signature as with a
+// discordgo session, and are called with a nil *discordgo.Session
+func (s *Session) AddHandler(fn any) func() {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != 2 {
+		panic(fmt.Sprintf("discordtest: invalid handler type %T", fn))
+	}
+	h := &handler{event: t.In(1), fn: v}
+	s.mu.Lock()
+	s.handlers = append(s.handlers, h)
+	s.mu.Unlock()
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.handlers = slices.DeleteFunc(s.handlers, func(o *handler) bool { return o == h })
+	}
+}
+
+// Open the session and dispatch a Ready event
+func (s *Session) Open() error {
+	s.Dispatch(&discordgo.Ready{User: botUser()})
+	return nil
+}
+
+func (s *Session) Close() error {
+	s.Dispatch(&discordgo.Disconnect{})
+	return nil
+}
+
+func (s *Session) UpdateCustomStatus(state string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = state
+	return nil
+}
+
+// Call each handler registered for the event type, blocking until all have
+// returned
+func (s *Session) Dispatch(event any) {
+	s.mu.Lock()
+	handlers := slices.Clone(s.handlers)
+	s.mu.Unlock()
+	ev := reflect.ValueOf(event)
+	nilSession := reflect.Zero(reflect.TypeOf((*discordgo.Session)(nil)))
+	for _, h := range handlers {
+		if ev.Type() == h.event {
+			h.fn.Call([]reflect.Value{nilSession, ev})
+		}
+	}
+}
+
+// ===========================================================================
+// INTERACTIONS
+// ===========================================================================
+
+func (s *Session) InteractionRespond(
+	interaction *discordgo.Interaction,
+	resp *discordgo.InteractionResponse,
+	options ...discordgo.RequestOption,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.responses[interaction.ID]; exists {
+		return &discordgo.RESTError{
+			Response:     &http.Response{Status: "400 Bad Request", StatusCode: http.StatusBadRequest},
+			ResponseBody: []byte(`{"message": "Interaction has already been acknowledged.", "code": 40060}`),
+			Message:      &discordgo.APIErrorMessage{Code: 40060, Message: "Interaction has already been acknowledged."},
+		}
+	}
+	s.responses[interaction.ID] = resp
+	return nil
+}
+
+func (s *Session) InteractionResponseDelete(
+	interaction *discordgo.Interaction,
+	options ...discordgo.RequestOption,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.responses[interaction.ID]; !exists {
+		return notFound("Webhook", interaction.ID)
+	}
+	s.deletedResp[interaction.ID] = true
+	return nil
+}
+
+func (s *Session) FollowupMessageCreate(
+	interaction *discordgo.Interaction,
+	wait bool,
+	data *discordgo.WebhookParams,
+	options ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.responses[interaction.ID]; !exists {
+		return nil, notFound("Webhook", interaction.ID)
+	}
+	s.followups[interaction.ID] = append(s.followups[interaction.ID], data)
+	return &discordgo.Message{
+		ID:         s.newID(),
+		ChannelID:  interaction.ChannelID,
+		Content:    data.Content,
+		Embeds:     data.Embeds,
+		Components: data.Components,
+		Author:     botUser(),
+	}, nil
+}
+
+func (s *Session) ApplicationCommands(
+	appID string,
+	guildID string,
+	options ...discordgo.RequestOption,
+) ([]*discordgo.ApplicationCommand, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	commands := []*discordgo.ApplicationCommand{}
+	for _, cmd := range s.commands {
+		if cmd.GuildID == guildID {
+			copied := *cmd
+			commands = append(commands, &copied)
+		}
+	}
+	return commands, nil
+}
+
+// Replaces all the commands registered for the guild, or the global commands
+// if the guild ID is empty. Commands keep their ID if one with the same name
+// was already registered
+func (s *Session) ApplicationCommandBulkOverwrite(
+	appID string,
+	guildID string,
+	commands []*discordgo.ApplicationCommand,
+	options ...discordgo.RequestOption,
+) ([]*discordgo.ApplicationCommand, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing := map[string]string{}
+	for _, cmd := range s.commands {
+		if cmd.GuildID == guildID {
+			existing[cmd.Name] = cmd.ID
+		}
+	}
+	s.commands = slices.DeleteFunc(s.commands, func(c *discordgo.ApplicationCommand) bool {
+		return c.GuildID == guildID
+	})
+	created := make([]*discordgo.ApplicationCommand, len(commands))
+	for idx, cmd := range commands {
+		copied := *cmd
+		copied.ID = existing[cmd.Name]
+		if copied.ID == "" {
+			copied.ID = s.newID()
+		}
+		copied.ApplicationID = appID
+		copied.GuildID = guildID
+		copied.Type = discordgo.ChatApplicationCommand
+		s.commands = append(s.commands, &copied)
+		created[idx] = &copied
+	}
+	s.overwrites++
+	return created, nil
+}
+
+// ===========================================================================
+// MESSAGES
+// ===========================================================================
+
+func (s *Session) findMessage(channelID, messageID string) (*discordgo.Message, int) {
+	for idx, msg := range s.messages[channelID] {
+		if msg.ID == messageID {
+			return msg, idx
+		}
+	}
+	return nil, -1
+}
+
+func (s *Session) ChannelMessage(
+	channelID string,
+	messageID string,
+	options ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, _ := s.findMessage(channelID, messageID)
+	if msg == nil {
+		return nil, notFound("Message", messageID)
+	}
+	return msg, nil
+}
+
+func (s *Session) ChannelMessageSendComplex(
+	channelID string,
+	data *discordgo.MessageSend,
+	options ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.channels[channelID]; !exists {
+		return nil, notFound("Channel", channelID)
+	}
+	embeds := data.Embeds
+	if data.Embed != nil {
+		embeds = append(embeds, data.Embed)
+	}
+	msg := &discordgo.Message{
+		ID:         s.newID(),
+		ChannelID:  channelID,
+		GuildID:    s.channels[channelID].GuildID,
+		Content:    data.Content,
+		Embeds:     embeds,
+		Components: data.Components,
+		Author:     botUser(),
+	}
+	for _, file := range data.Files {
+		msg.Attachments = append(msg.Attachments,
+			&discordgo.MessageAttachment{ID: s.newID(), Filename: file.Name})
+	}
+	s.messages[channelID] = append(s.messages[channelID], msg)
+	return msg, nil
+}
+
+func (s *Session) ChannelMessageEditComplex(
+	m *discordgo.MessageEdit,
+	options ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, _ := s.findMessage(m.Channel, m.ID)
+	if msg == nil {
+		return nil, notFound("Message", m.ID)
+	}
+	if m.Content != nil {
+		msg.Content = *m.Content
+	}
+	if m.Embeds != nil {
+		msg.Embeds = *m.Embeds
+	}
+	if m.Components != nil {
+		msg.Components = *m.Components
+	}
+	return msg, nil
+}
+
+func (s *Session) ChannelMessageDelete(
+	channelID string,
+	messageID string,
+	options ...discordgo.RequestOption,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, idx := s.findMessage(channelID, messageID)
+	if idx == -1 {
+		return notFound("Message", messageID)
+	}
+	s.messages[channelID] = slices.Delete(s.messages[channelID], idx, idx+1)
+	return nil
+}
+
+func (s *Session) ChannelFileSendWithMessage(
+	channelID string,
+	content string,
+	name string,
+	r io.Reader,
+	options ...discordgo.RequestOption,
+) (*discordgo.Message, error) {
+	return s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: content,
+		Files:   []*discordgo.File{{Name: name, Reader: r}},
+	})
+}
+
+// ===========================================================================
+// CHANNELS AND DMS
+// ===========================================================================
+
+func (s *Session) Channel(
+	channelID string,
+	options ...discordgo.RequestOption,
+) (*discordgo.Channel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, exists := s.channels[channelID]
+	if !exists {
+		return nil, notFound("Channel", channelID)
+	}
+	return channel, nil
+}
+
+func (s *Session) ChannelEdit(
+	channelID string,
+	data *discordgo.ChannelEdit,
+	options ...discordgo.RequestOption,
+) (*discordgo.Channel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, exists := s.channels[channelID]
+	if !exists {
+		return nil, notFound("Channel", channelID)
+	}
+	if data.Name != "" {
+		channel.Name = data.Name
+	}
+	if data.Topic != "" {
+		channel.Topic = data.Topic
+	}
+	if data.ParentID != "" {
+		channel.ParentID = data.ParentID
+	}
+	if data.Position != nil {
+		channel.Position = *data.Position
+	}
+	if data.PermissionOverwrites != nil {
+		channel.PermissionOverwrites = data.PermissionOverwrites
+	}
+	return channel, nil
+}
+
+func (s *Session) ChannelDelete(
+	channelID string,
+	options ...discordgo.RequestOption,
+) (*discordgo.Channel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, exists := s.channels[channelID]
+	if !exists {
+		return nil, notFound("Channel", channelID)
+	}
+	delete(s.channels, channelID)
+	delete(s.messages, channelID)
+	return channel, nil
+}
+
+func (s *Session) GuildChannelCreate(
+	guildID string,
+	name string,
+	ctype discordgo.ChannelType,
+	options ...discordgo.RequestOption,
+) (*discordgo.Channel, error) {
+	return s.GuildChannelCreateComplex(guildID, discordgo.GuildChannelCreateData{
+		Name: name,
+		Type: ctype,
+	})
+}
+
+func (s *Session) GuildChannelCreateComplex(
+	guildID string,
+	data discordgo.GuildChannelCreateData,
+	options ...discordgo.RequestOption,
+) (*discordgo.Channel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if guildID != s.GuildID {
+		return nil, notFound("Guild", guildID)
+	}
+	channel := &discordgo.Channel{
+		ID:                   s.newID(),
+		GuildID:              guildID,
+		Name:                 data.Name,
+		Type:                 data.Type,
+		Topic:                data.Topic,
+		Position:             data.Position,
+		ParentID:             data.ParentID,
+		PermissionOverwrites: data.PermissionOverwrites,
+	}
+	s.channels[channel.ID] = channel
+	return channel, nil
+}
+
+func (s *Session) UserChannelCreate(
+	recipientID string,
+	options ...discordgo.RequestOption,
+) (*discordgo.Channel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if channelID, exists := s.dmChannels[recipientID]; exists {
+		return s.channels[channelID], nil
+	}
+	recipient := &discordgo.User{ID: recipientID}
+	if member, exists := s.members[recipientID]; exists {
+		recipient = member.User
+	}
+	channel := &discordgo.Channel{
+		ID:         s.newID(),
+		Type:       discordgo.ChannelTypeDM,
+		Recipients: []*discordgo.User{recipient},
+	}
+	s.channels[channel.ID] = channel
+	s.dmChannels[recipientID] = channel.ID
+	return channel, nil
+}
+
+// ===========================================================================
+// GUILD MEMBERS AND ROLES
+// ===========================================================================
+
+func (s *Session) GuildMember(
+	guildID string,
+	userID string,
+	options ...discordgo.RequestOption,
+) (*discordgo.Member, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	member, exists := s.members[userID]
+	if guildID != s.GuildID || !exists {
+		return nil, notFound("Member", userID)
+	}
+	copied := *member
+	copied.Roles = slices.Clone(member.Roles)
+	return &copied, nil
+}
+
+func (s *Session) GuildMemberRoleAdd(
+	guildID string,
+	userID string,
+	roleID string,
+	options ...discordgo.RequestOption,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	member, exists := s.members[userID]
+	if guildID != s.GuildID || !exists {
+		return notFound("Member", userID)
+	}
+	if _, exists := s.roles[roleID]; !exists {
+		return notFound("Role", roleID)
+	}
+	if !slices.Contains(member.Roles, roleID) {
+		member.Roles = append(member.Roles, roleID)
+	}
+	return nil
+}
+
+func (s *Session) GuildMemberRoleRemove(
+	guildID string,
+	userID string,
+	roleID string,
+	options ...discordgo.RequestOption,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	member, exists := s.members[userID]
+	if guildID != s.GuildID || !exists {
+		return notFound("Member", userID)
+	}
+	if _, exists := s.roles[roleID]; !exists {
+		return notFound("Role", roleID)
+	}
+	member.Roles = slices.DeleteFunc(member.Roles, func(r string) bool { return r == roleID })
+	return nil
+}
+
+func (s *Session) GuildRoles(
+	guildID string,
+	options ...discordgo.RequestOption,
+) ([]*discordgo.Role, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if guildID != s.GuildID {
+		return nil, notFound("Guild", guildID)
+	}
+	roles := make([]*discordgo.Role, 0, len(s.roles))
+	for _, role := range s.roles {
+		roles = append(roles, role)
+	}
+	slices.SortFunc(roles, func(a, b *discordgo.Role) int { return a.Position - b.Position })
+	return roles, nil
+}
+
+func (s *Session) GuildRoleCreate(
+	guildID string,
+	data *discordgo.RoleParams,
+	options ...discordgo.RequestOption,
+) (*discordgo.Role, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if guildID != s.GuildID {
+		return nil, notFound("Guild", guildID)
+	}
+	role := &discordgo.Role{ID: s.newID(), Name: "new role", Position: len(s.roles) + 1}
+	applyRoleParams(role, data)
+	s.roles[role.ID] = role
+	return role, nil
+}
+
+func (s *Session) GuildRoleEdit(
+	guildID string,
+	roleID string,
+	data *discordgo.RoleParams,
+	options ...discordgo.RequestOption,
+) (*discordgo.Role, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	role, exists := s.roles[roleID]
+	if guildID != s.GuildID || !exists {
+		return nil, notFound("Role", roleID)
+	}
+	applyRoleParams(role, data)
+	return role, nil
+}
+
+func (s *Session) GuildRoleDelete(
+	guildID string,
+	roleID string,
+	options ...discordgo.RequestOption,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.roles[roleID]; guildID != s.GuildID || !exists {
+		return notFound("Role", roleID)
+	}
+	delete(s.roles, roleID)
+	for _, member := range s.members {
+		member.Roles = slices.DeleteFunc(member.Roles, func(r string) bool { return r == roleID })
+	}
+	return nil
+}
+
+func applyRoleParams(role *discordgo.Role, data *discordgo.RoleParams) {
+	if data == nil {
+		return
+	}
+	if data.Name != "" {
+		role.Name = data.Name
+	}
+	if data.Color != nil {
+		role.Color = *data.Color
+	}
+	if data.Hoist != nil {
+		role.Hoist = *data.Hoist
+	}
+	if data.Permissions != nil {
+		role.Permissions = *data.Permissions
+	}
+	if data.Mentionable != nil {
+		role.Mentionable = *data.Mentionable
+	}
+}
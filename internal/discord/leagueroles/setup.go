@@ -0,0 +1,45 @@
+package leagueroles
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// How often the league roles are re-synced, so roles are removed once the
+// season has finished
+const syncInterval = time.Hour
+
+// Reconcile the league roles with the active season and keep them in sync
+// until the context is cancelled
+func Setup(
+	wg *sync.WaitGroup,
+	errch chan error,
+	ctx context.Context,
+	b *bot.Bot,
+) {
+	defer wg.Done()
+	err := syncWithTx(ctx, b)
+	if err != nil {
+		errch <- errors.Wrap(err, "syncWithTx")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(syncInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := syncWithTx(ctx, b)
+				if err != nil {
+					b.Logger.Warn().Err(err).Msg("Failed to sync league roles")
+				}
+			}
+		}
+	}()
+}
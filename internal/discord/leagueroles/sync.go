@@ -0,0 +1,184 @@
+package leagueroles
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Sync the league and free agent roles in discord with the placements in the
+// active season. Players on a placed team or placed as a free agent are given
+// the role for their league, and any player the bot has previously given a
+// role to that is no longer placed has it removed.
+//
+// Runs in the background so it doesnt block/get blocked by the transaction
+// that triggered it, and runs as soon as that transaction is committed
+func Sync(ctx context.Context, b *bot.Bot) {
+	go func() {
+		err := syncWithTx(ctx, b)
+		if err != nil {
+			b.DoubleError("Failed to sync league roles", err)
+		}
+	}()
+}
+
+// Syncs are run one at a time so two syncs cant both grant the same role.
+// Only the discord calls are serialised, the transactions are kept short so
+// other writes arent blocked by discord
+var syncLock sync.Mutex
+
+// A role the bot has given to or taken from a member
+type roleChange struct {
+	roleID    string
+	discordID string
+}
+
+func syncWithTx(ctx context.Context, b *bot.Bot) error {
+	syncLock.Lock()
+	defer syncLock.Unlock()
+	assignments, granted, err := getRoleState(ctx, b)
+	if err != nil {
+		return errors.Wrap(err, "getRoleState")
+	}
+	timeout, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	added, removed, err := syncRoles(timeout, b, assignments, granted)
+	// save the changes that were made even if the sync failed part way
+	saveErr := saveRoleChanges(ctx, b, added, removed)
+	if err != nil {
+		if saveErr != nil {
+			b.Logger.Warn().Err(saveErr).Msg("Failed to save league role members")
+		}
+		return errors.Wrap(err, "syncRoles")
+	}
+	if saveErr != nil {
+		return errors.Wrap(saveErr, "saveRoleChanges")
+	}
+	return nil
+}
+
+// Get the roles each member should have and the roles the bot has given them
+func getRoleState(
+	ctx context.Context,
+	b *bot.Bot,
+) (map[string][]string, map[string][]string, error) {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// we use a WTX here to force it to block until commit of the transaction
+	// that changed the placements
+	tx, err := b.Conn.Begin(timeout, "leagueroles.getRoleState()")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	assignments, err := models.GetLeagueRoleAssignments(timeout, tx, b.GuildID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "models.GetLeagueRoleAssignments")
+	}
+	granted, err := models.GetLeagueRoleMembers(timeout, tx, b.GuildID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "models.GetLeagueRoleMembers")
+	}
+	return assignments, granted, nil
+}
+
+// Add and remove the roles in discord, returning the changes made
+func syncRoles(
+	ctx context.Context,
+	b *bot.Bot,
+	assignments map[string][]string,
+	granted map[string][]string,
+) ([]roleChange, []roleChange, error) {
+	added := []roleChange{}
+	removed := []roleChange{}
+	// Remove roles from anyone no longer assigned to them
+	for roleID, discordIDs := range granted {
+		assigned := toSet(assignments[roleID])
+		for _, discordID := range discordIDs {
+			if assigned[discordID] {
+				continue
+			}
+			err := b.Schedule(ctx, bot.PriorityBulk, http.MethodDelete,
+				discordgo.EndpointGuildMemberRole(b.GuildID, discordID, roleID))
+			if err != nil {
+				return added, removed, errors.Wrap(err, "b.Schedule")
+			}
+			err = b.Session.GuildMemberRoleRemove(b.GuildID, discordID, roleID)
+			if err != nil && !bot.IsNotFound(err) {
+				return added, removed, errors.Wrap(err, "b.Session.GuildMemberRoleRemove")
+			}
+			removed = append(removed, roleChange{roleID, discordID})
+		}
+	}
+	// Give roles to anyone newly assigned to them
+	for roleID, discordIDs := range assignments {
+		alreadyGranted := toSet(granted[roleID])
+		for discordID := range toSet(discordIDs) {
+			if alreadyGranted[discordID] {
+				continue
+			}
+			err := b.Schedule(ctx, bot.PriorityBulk, http.MethodPut,
+				discordgo.EndpointGuildMemberRole(b.GuildID, discordID, roleID))
+			if err != nil {
+				return added, removed, errors.Wrap(err, "b.Schedule")
+			}
+			err = b.Session.GuildMemberRoleAdd(b.GuildID, discordID, roleID)
+			if err != nil {
+				if bot.IsNotFound(err) {
+					// player has left the server
+					continue
+				}
+				return added, removed, errors.Wrap(err, "b.Session.GuildMemberRoleAdd")
+			}
+			added = append(added, roleChange{roleID, discordID})
+		}
+	}
+	return added, removed, nil
+}
+
+// Record the roles the bot has given and taken in a short transaction
+func saveRoleChanges(
+	ctx context.Context,
+	b *bot.Bot,
+	added []roleChange,
+	removed []roleChange,
+) error {
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.Begin(timeout, "leagueroles.saveRoleChanges()")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	for _, change := range removed {
+		err = models.RemoveLeagueRoleMember(timeout, tx, change.roleID, change.discordID)
+		if err != nil {
+			return errors.Wrap(err, "models.RemoveLeagueRoleMember")
+		}
+	}
+	for _, change := range added {
+		err = models.AddLeagueRoleMember(timeout, tx, b.GuildID, change.roleID, change.discordID)
+		if err != nil {
+			return errors.Wrap(err, "models.AddLeagueRoleMember")
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
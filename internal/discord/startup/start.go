@@ -12,31 +12,70 @@ import (
 	"gosl/internal/discord/channels/teamlogos"
 	"gosl/internal/discord/channels/teamrosters"
 	"gosl/internal/discord/channels/transferapprovals"
+	"gosl/internal/discord/channels/transfers"
 	"gosl/internal/discord/commands"
 	"gosl/internal/discord/directmessages"
+	"gosl/internal/discord/leagueroles"
+	"gosl/internal/discord/teamdiscord"
 	"gosl/internal/models"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/bwmarrin/discordgo"
 	"github.com/pkg/errors"
 )
 
-// Start the bot
+// Start the bot, with a bot for each of the configured guilds sharing the
+// session
 func Start(ctx context.Context, b *bot.Bot) error {
 	starttime := time.Now()
+	trackConnection(b)
 	err := b.Session.Open()
 	if err != nil {
 		return errors.Wrap(err, "b.session.Open")
 	}
+	err = claimUnassignedData(ctx, b)
+	if err != nil {
+		return errors.Wrap(err, "claimUnassignedData")
+	}
+	err = b.LoadLanguages(ctx)
+	if err != nil {
+		return errors.Wrap(err, "b.LoadLanguages")
+	}
+
+	// Start the queue watching
+	// TODO: add context and use ticker
+	b.StartWatchingQueue(ctx)
+	b.StartSyncingPlayers(ctx)
+
+	var failed []string
+	for _, guildID := range b.Config.DiscordGuildIDs {
+		err = startGuild(ctx, b.ForGuild(guildID))
+		if err != nil {
+			b.Logger.Error().Err(err).Str("guild_id", guildID).Msg("Error in guild startup")
+			failed = append(failed, guildID)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New("Error(s) during bot startup for guild(s): " + strings.Join(failed, ", "))
+	}
+	b.Health.StartupComplete()
+	b.Logger.Info().Dur("startup_time", time.Since(starttime)).
+		Int("guilds", len(b.Config.DiscordGuildIDs)).Msg("Bot startup complete!")
+	return nil
+}
 
+// Set up the channels, messages and commands of a guild's bot
+func startGuild(ctx context.Context, b *bot.Bot) error {
 	// Setup log channel first so startup issues can be reported in discord
-	b.AddChannel(logchannel.LogChannel)
-	err = b.Channels[models.ChannelLog].Setup(ctx, true)
+	b.AddChannel(logchannel.LogChannel())
+	err := b.Channels[models.ChannelLog].Setup(ctx, true)
 	if err != nil {
 		return errors.Wrap(err, "Channel.Setup (LogChannel)")
 	}
 	// Add interaction handlers for DM's
-	b.Session.AddHandler(directmessages.HandleDMInteractions(ctx, b))
+	b.AddInteractionHandler(directmessages.HandleDMInteractions(ctx, b))
 
 	// Do other setup concurrently to reduce startup time
 	var wg sync.WaitGroup
@@ -52,12 +91,13 @@ func Start(ctx context.Context, b *bot.Bot) error {
 		freeagentapplications.Setup,
 		teamrosters.Setup,
 		transferapprovals.Setup,
+		transfers.Setup,
 		teamlogos.Setup,
+		teamdiscord.Setup,
+		leagueroles.Setup,
 	}
 
-	// Start the queue watching
-	// TODO: add context and use ticker
-	b.StartWatchingQueue(ctx)
+	b.StartMessageSweeper(ctx)
 
 	// Run all the setup commands
 	for _, setup := range setups {
@@ -86,11 +126,45 @@ func Start(ctx context.Context, b *bot.Bot) error {
 		b.Log().Error("**Error(s) during bot startup**", err)
 		return errors.New("Error(s) during bot startup")
 	}
-	b.Logger.Info().Dur("startup_time", time.Since(starttime)).Msg("Bot startup complete!")
 	b.Log().Info("Bot startup complete")
 	return nil
 }
 
+// Give the configuration and seasons from before multiple guilds were
+// supported to the primary guild
+func claimUnassignedData(ctx context.Context, b *bot.Bot) error {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	tx, err := b.Conn.Begin(timeout, "startup.claimUnassignedData()")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	claimed, err := models.ClaimUnassignedGuildData(ctx, tx, b.GuildID)
+	if err != nil {
+		return errors.Wrap(err, "models.ClaimUnassignedGuildData")
+	}
+	tx.Commit()
+	if claimed > 0 {
+		b.Logger.Info().Int64("rows", claimed).Str("guild_id", b.GuildID).
+			Msg("Assigned existing league data to the primary guild")
+	}
+	return nil
+}
+
+// Keep the health status up to date with the state of the discord session
+func trackConnection(b *bot.Bot) {
+	b.Session.AddHandler(func(s *discordgo.Session, e *discordgo.Ready) {
+		b.Health.SetDiscordConnected(true)
+	})
+	b.Session.AddHandler(func(s *discordgo.Session, e *discordgo.Resumed) {
+		b.Health.SetDiscordConnected(true)
+	})
+	b.Session.AddHandler(func(s *discordgo.Session, e *discordgo.Disconnect) {
+		b.Health.SetDiscordConnected(false)
+	})
+}
+
 // Stop the bot
 func Stop(b *bot.Bot) error {
 	err := b.Session.Close()
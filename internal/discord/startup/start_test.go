@@ -0,0 +1,288 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/discord/discordtest"
+	"gosl/internal/health"
+	"gosl/internal/maintenance"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/embedfs"
+	"gosl/pkg/tests"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Get the custom ID of the first button or select menu on the message that
+// starts with the prefix, or an empty string if there isn't one
+func customIDWithPrefix(msg *discordgo.Message, prefix string) string {
+	for _, row := range msg.Components {
+		actions, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			if value, isValue := row.(discordgo.ActionsRow); isValue {
+				actions, ok = &value, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		for _, cmp := range actions.Components {
+			customID := ""
+			switch c := cmp.(type) {
+			case discordgo.Button:
+				customID = c.CustomID
+			case *discordgo.Button:
+				customID = c.CustomID
+			case discordgo.SelectMenu:
+				customID = c.CustomID
+			case *discordgo.SelectMenu:
+				customID = c.CustomID
+			}
+			if strings.HasPrefix(customID, prefix) {
+				return customID
+			}
+		}
+	}
+	return ""
+}
+
+// Get the content of the follow ups sent for the interaction, or the titles
+// and fields of any error embeds
+func followupText(session *discordtest.Session, i *discordgo.InteractionCreate) string {
+	text := ""
+	for _, followup := range session.Followups(i) {
+		text = text + followup.Content
+		for _, embed := range followup.Embeds {
+			text = text + embed.Title
+			for _, field := range embed.Fields {
+				text = text + field.Name + field.Value
+			}
+		}
+	}
+	return text
+}
+
+// Drive a player registering, creating a team, inviting another player and
+// staff approving the transfer through the interactions a user would make,
+// with the bot started against the fake discord session
+func TestRegistrationToTransferApproval(t *testing.T) {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	slapshot := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var slapid uint32
+		_, err := fmt.Sscanf(r.URL.Path, "/api/public/players/%d", &slapid)
+		if err != nil || strings.HasSuffix(r.URL.Path, "/ranked") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"id":%v,"username":"InGame%v"}`, slapid, slapid)
+	}))
+	t.Cleanup(slapshot.Close)
+	cfg.SlapshotAPIURL = slapshot.URL
+	logger := tests.NilLogger()
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	// the channels are set up concurrently, which the shared in-memory test
+	// database can't handle, so a database file is used instead
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	conn, err := db.ConnectToDatabase(filepath.Join(t.TempDir(), "gosl.db"), logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	_, err = conn.Migrate(ctx, ver, "", 5*time.Second)
+	require.NoError(t, err)
+	files, err := embedfs.GetEmbeddedFS()
+	require.NoError(t, err)
+
+	session := discordtest.NewSession(cfg.DiscordGuildID)
+	staffRole := session.AddRole("League Manager")
+	manager := session.AddMember("400", "manager")
+	player := session.AddMember("401", "player")
+	staff := session.AddMember("402", "staff", staffRole)
+
+	// channels the flow goes through, configured as an admin would
+	tx, err := conn.Begin(ctx, "TestRegistrationToTransferApproval setup")
+	require.NoError(t, err)
+	for purpose, name := range map[uint16]string{
+		models.ChannelRegistration:      "registration",
+		models.ChannelTeamApplications:  "team-applications",
+		models.ChannelTransferApprovals: "transfer-approvals",
+		models.ChannelTeamRosters:       "team-rosters",
+	} {
+		channel, err := session.GuildChannelCreate(session.GuildID, name, discordgo.ChannelTypeGuildText)
+		require.NoError(t, err)
+		require.NoError(t, models.SetChannel(ctx, tx, cfg.DiscordGuildID, channel.ID, purpose))
+	}
+	require.NoError(t, models.AddPermission(ctx, tx, cfg.DiscordGuildID, staffRole,
+		models.PermLeagueManager))
+	season, err := models.CreateSeason(ctx, tx, cfg.DiscordGuildID, "S1", "Season 1")
+	require.NoError(t, err)
+	require.NoError(t, models.SetActiveSeason(ctx, tx, cfg.DiscordGuildID, season.ID))
+	tx.Commit()
+
+	b, err := bot.NewBotWithSession(session, logger, &files, conn, cfg,
+		health.NewStatus(true), maintenance.New(new(uint32), conn, cfg, logger))
+	require.NoError(t, err)
+	require.NoError(t, Start(ctx, b))
+	registration := session.ChannelByName("registration").ID
+	transferApprovals := session.ChannelByName("transfer-approvals").ID
+
+	getPlayer := func(discordID string) *models.Player {
+		rtx, err := conn.RBegin(ctx, "TestRegistrationToTransferApproval player")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		player, err := models.GetPlayerByDiscordID(ctx, rtx, discordID)
+		require.NoError(t, err)
+		return player
+	}
+	// Joins are only current from the second after, so wait for them
+	currentTeam := func(discordID string, wait bool) *models.PlayerTeam {
+		var current *models.PlayerTeam
+		player := getPlayer(discordID)
+		check := func() bool {
+			rtx, err := conn.RBegin(ctx, "TestRegistrationToTransferApproval team")
+			require.NoError(t, err)
+			defer rtx.Rollback()
+			current, err = player.CurrentTeam(ctx, rtx)
+			require.NoError(t, err)
+			return current != nil
+		}
+		if wait {
+			require.Eventually(t, check, 3*time.Second, 50*time.Millisecond)
+		} else {
+			check()
+		}
+		return current
+	}
+	// the message in the channel with a component matching the prefix
+	findMessage := func(messages []*discordgo.Message, prefix string) (*discordgo.Message, string) {
+		for idx := len(messages) - 1; idx >= 0; idx-- {
+			if customID := customIDWithPrefix(messages[idx], prefix); customID != "" {
+				return messages[idx], customID
+			}
+		}
+		t.Fatalf("no message has a component starting with %q", prefix)
+		return nil, ""
+	}
+
+	register := func(member *discordgo.Member, slapid uint32, name string) {
+		msg, _ := findMessage(session.Messages(registration), "player_registration_button")
+		customID := fmt.Sprintf("confirm_slapid_%v", slapid)
+		i := session.ComponentInteraction(registration, msg.ID, member.User.ID, customID)
+		session.Interact(i)
+		resp := session.Response(i)
+		require.NotNil(t, resp)
+		require.Equal(t, discordgo.InteractionResponseModal, resp.Type)
+		require.Equal(t, fmt.Sprintf("player_reg_display_name_%v", slapid), resp.Data.CustomID)
+
+		i = session.ModalInteraction(registration, msg.ID, member.User.ID, resp.Data.CustomID,
+			discordtest.ModalField{CustomID: "player_name", Value: name})
+		session.Interact(i)
+		require.Equal(t, "Player registration successful!", followupText(session, i))
+	}
+
+	t.Run("Players register with their SlapID and display name", func(t *testing.T) {
+		register(manager, 1001, "Manager")
+		register(player, 1002, "Player")
+		registered := getPlayer(player.User.ID)
+		require.NotNil(t, registered)
+		assert.Equal(t, uint32(1002), registered.SlapID)
+		assert.Equal(t, "Player", registered.Name)
+	})
+
+	var panel *discordgo.Message
+	t.Run("Player creates a team and gets the manager panel", func(t *testing.T) {
+		msg, customID := findMessage(session.Messages(registration), "new_team_registration_button")
+		i := session.ComponentInteraction(registration, msg.ID, manager.User.ID, customID)
+		session.Interact(i)
+		resp := session.Response(i)
+		require.NotNil(t, resp)
+		require.Equal(t, "new_team_registration_details", resp.Data.CustomID)
+
+		i = session.ModalInteraction(registration, msg.ID, manager.User.ID, resp.Data.CustomID,
+			discordtest.ModalField{CustomID: "team_name", Value: "Flow Testers"},
+			discordtest.ModalField{CustomID: "team_abbr", Value: "FLOW"})
+		session.Interact(i)
+		require.Equal(t, "Team registration started, check your DM's to continue",
+			followupText(session, i))
+		panel, _ = findMessage(session.DirectMessages(manager.User.ID), "invite_players_button")
+
+		current := currentTeam(manager.User.ID, true)
+		assert.Equal(t, "Flow Testers", current.TeamName)
+
+		// registering for the season needs a full roster, so the team is
+		// approved directly. Players joining it then need staff approval
+		tx, err := conn.Begin(ctx, "TestRegistrationToTransferApproval approve team")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		team, err := models.GetTeamByID(ctx, tx, current.TeamID)
+		require.NoError(t, err)
+		app, err := team.Register(ctx, tx, season.ID, "Open")
+		require.NoError(t, err)
+		require.NoError(t, app.Approve(ctx, tx))
+		tx.Commit()
+	})
+
+	var accept string
+	t.Run("Manager invites a player who accepts pending approval", func(t *testing.T) {
+		require.NotNil(t, panel)
+		i := session.ComponentInteraction(panel.ChannelID, panel.ID, manager.User.ID,
+			"invite_selected_players_"+panel.ID, player.User.ID)
+		session.Interact(i)
+		require.Equal(t, "Players invited", followupText(session, i))
+
+		invite, customID := findMessage(session.DirectMessages(player.User.ID), "accept_invite_")
+		accept = customID
+		i = session.ComponentInteraction(invite.ChannelID, invite.ID, player.User.ID, accept)
+		session.Interact(i)
+		assert.Equal(t,
+			"You have accepted the invite to join Flow Testers and are awaiting staff approval",
+			followupText(session, i))
+		assert.Nil(t, currentTeam(player.User.ID, false))
+	})
+
+	t.Run("Only staff can approve the transfer, which moves the player", func(t *testing.T) {
+		require.NotEmpty(t, accept)
+		request, approve := findMessage(session.Messages(transferApprovals), "approve_transfer_")
+		i := session.ComponentInteraction(transferApprovals, request.ID, player.User.ID, approve)
+		session.Interact(i)
+		assert.Contains(t, followupText(session, i), "Forbidden")
+
+		i = session.ComponentInteraction(transferApprovals, request.ID, staff.User.ID, approve)
+		session.Interact(i)
+		assert.Equal(t,
+			"The invite for Player to join Flow Testers has been approved. The player has joined the team",
+			followupText(session, i))
+
+		assert.Equal(t, "Flow Testers", currentTeam(player.User.ID, true).TeamName)
+		rtx, err := conn.RBegin(ctx, "TestRegistrationToTransferApproval career")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		joined := getPlayer(player.User.ID)
+		career, err := models.GetPlayerCareer(ctx, rtx, cfg.DiscordGuildID, joined.ID)
+		require.NoError(t, err)
+		require.Len(t, *career, 1)
+		assert.Equal(t, models.MoveInvite, (*career)[0].JoinReason)
+		assert.Eventually(t, func() bool {
+			for _, msg := range session.DirectMessages(player.User.ID) {
+				for _, embed := range msg.Embeds {
+					if embed.Title == "Team Invite Approved" {
+						return true
+					}
+				}
+			}
+			return false
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,144 @@
+package teamdiscord
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"net/http"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+const (
+	textPerms = discordgo.PermissionViewChannel |
+		discordgo.PermissionSendMessages |
+		discordgo.PermissionReadMessageHistory |
+		discordgo.PermissionAttachFiles |
+		discordgo.PermissionEmbedLinks
+	voicePerms = discordgo.PermissionViewChannel |
+		discordgo.PermissionVoiceConnect |
+		discordgo.PermissionVoiceSpeak |
+		discordgo.PermissionVoiceUseVAD
+)
+
+// Makes sure the teams private text and voice channels exist and have the
+// correct name and permissions, creating them if they dont
+func syncChannels(
+	ctx context.Context,
+	b *bot.Bot,
+	team *models.Team,
+	td *models.TeamDiscord,
+	managerRoles []string,
+) error {
+	textChannelID, err := syncChannel(ctx, b, td.TextChannelID,
+		textChannelName(team), discordgo.ChannelTypeGuildText,
+		overwrites(b, td.RoleID, managerRoles, textPerms))
+	if err != nil {
+		return errors.Wrap(err, "syncChannel (text)")
+	}
+	td.TextChannelID = textChannelID
+	voiceChannelID, err := syncChannel(ctx, b, td.VoiceChannelID,
+		team.Name, discordgo.ChannelTypeGuildVoice,
+		overwrites(b, td.RoleID, managerRoles, voicePerms))
+	if err != nil {
+		return errors.Wrap(err, "syncChannel (voice)")
+	}
+	td.VoiceChannelID = voiceChannelID
+	return nil
+}
+
+func syncChannel(
+	ctx context.Context,
+	b *bot.Bot,
+	channelID string,
+	name string,
+	channelType discordgo.ChannelType,
+	perms []*discordgo.PermissionOverwrite,
+) (string, error) {
+	if channelID != "" {
+		err := b.Schedule(ctx, bot.PriorityBulk, http.MethodGet,
+			discordgo.EndpointChannel(channelID))
+		if err != nil {
+			return "", errors.Wrap(err, "b.Schedule")
+		}
+		channel, err := b.Session.Channel(channelID)
+		if err != nil && !bot.IsNotFound(err) {
+			return "", errors.Wrap(err, "b.Session.Channel")
+		}
+		if channel != nil {
+			err = b.Schedule(ctx, bot.PriorityBulk, http.MethodPatch,
+				discordgo.EndpointChannel(channelID))
+			if err != nil {
+				return "", errors.Wrap(err, "b.Schedule")
+			}
+			_, err = b.Session.ChannelEdit(channelID, &discordgo.ChannelEdit{
+				Name:                 name,
+				PermissionOverwrites: perms,
+			})
+			if err != nil {
+				return "", errors.Wrap(err, "b.Session.ChannelEdit")
+			}
+			return channelID, nil
+		}
+	}
+	err := b.Schedule(ctx, bot.PriorityBulk, http.MethodPost,
+		discordgo.EndpointGuildChannels(b.GuildID))
+	if err != nil {
+		return "", errors.Wrap(err, "b.Schedule")
+	}
+	channel, err := b.Session.GuildChannelCreateComplex(
+		b.GuildID,
+		discordgo.GuildChannelCreateData{
+			Name:                 name,
+			Type:                 channelType,
+			PermissionOverwrites: perms,
+		},
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "b.Session.GuildChannelCreateComplex")
+	}
+	return channel.ID, nil
+}
+
+// Hide the channel from everyone except the team, the league managers and
+// the bot
+func overwrites(
+	b *bot.Bot,
+	teamRoleID string,
+	managerRoles []string,
+	allow int64,
+) []*discordgo.PermissionOverwrite {
+	perms := []*discordgo.PermissionOverwrite{
+		{
+			// the @everyone role has the same ID as the guild
+			ID:   b.GuildID,
+			Type: discordgo.PermissionOverwriteTypeRole,
+			Deny: discordgo.PermissionViewChannel,
+		},
+		{
+			ID:    teamRoleID,
+			Type:  discordgo.PermissionOverwriteTypeRole,
+			Allow: allow,
+		},
+		{
+			ID:    b.Session.UserID(),
+			Type:  discordgo.PermissionOverwriteTypeMember,
+			Allow: allow | discordgo.PermissionManageChannels,
+		},
+	}
+	for _, roleID := range managerRoles {
+		perms = append(perms, &discordgo.PermissionOverwrite{
+			ID:    roleID,
+			Type:  discordgo.PermissionOverwriteTypeRole,
+			Allow: allow,
+		})
+	}
+	return perms
+}
+
+// Discord text channel names are lowercase with no spaces
+func textChannelName(team *models.Team) string {
+	return strings.ReplaceAll(strings.ToLower(team.Name), " ", "-")
+}
@@ -0,0 +1,70 @@
+package teamdiscord
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"net/http"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Give the team role to the current players and take it from any player that
+// has left the team
+func syncMembers(
+	ctx context.Context,
+	b *bot.Bot,
+	td *models.TeamDiscord,
+	currentPlayers *[]models.Player,
+	allPlayers *[]models.Player,
+) error {
+	current := map[uint16]bool{}
+	for _, player := range *currentPlayers {
+		current[player.ID] = true
+	}
+	for _, player := range *allPlayers {
+		if player.DiscordID == "" {
+			continue
+		}
+		err := b.Schedule(ctx, bot.PriorityBulk, http.MethodGet,
+			discordgo.EndpointGuildMember(b.GuildID, player.DiscordID))
+		if err != nil {
+			return errors.Wrap(err, "b.Schedule")
+		}
+		member, err := b.Session.GuildMember(b.GuildID, player.DiscordID)
+		if err != nil {
+			if bot.IsNotFound(err) {
+				// player has left the server
+				continue
+			}
+			return errors.Wrap(err, "b.Session.GuildMember")
+		}
+		hasRole := slices.Contains(member.Roles, td.RoleID)
+		if current[player.ID] && !hasRole {
+			err = b.Schedule(ctx, bot.PriorityBulk, http.MethodPut,
+				discordgo.EndpointGuildMemberRole(b.GuildID, player.DiscordID, td.RoleID))
+			if err != nil {
+				return errors.Wrap(err, "b.Schedule")
+			}
+			err = b.Session.GuildMemberRoleAdd(
+				b.GuildID, player.DiscordID, td.RoleID)
+			if err != nil {
+				return errors.Wrap(err, "b.Session.GuildMemberRoleAdd")
+			}
+		} else if !current[player.ID] && hasRole {
+			err = b.Schedule(ctx, bot.PriorityBulk, http.MethodDelete,
+				discordgo.EndpointGuildMemberRole(b.GuildID, player.DiscordID, td.RoleID))
+			if err != nil {
+				return errors.Wrap(err, "b.Schedule")
+			}
+			err = b.Session.GuildMemberRoleRemove(
+				b.GuildID, player.DiscordID, td.RoleID)
+			if err != nil {
+				return errors.Wrap(err, "b.Session.GuildMemberRoleRemove")
+			}
+		}
+	}
+	return nil
+}
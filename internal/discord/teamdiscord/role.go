@@ -0,0 +1,70 @@
+package teamdiscord
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"net/http"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Makes sure the team role exists in discord with the teams current name and
+// colour, creating it if it doesnt
+func syncRole(
+	ctx context.Context,
+	b *bot.Bot,
+	team *models.Team,
+	td *models.TeamDiscord,
+) error {
+	err := b.Schedule(ctx, bot.PriorityBulk, http.MethodGet,
+		discordgo.EndpointGuildRoles(b.GuildID))
+	if err != nil {
+		return errors.Wrap(err, "b.Schedule")
+	}
+	roles, err := b.Session.GuildRoles(b.GuildID)
+	if err != nil {
+		return errors.Wrap(err, "b.Session.GuildRoles")
+	}
+	var role *discordgo.Role
+	for _, r := range roles {
+		if r.ID == td.RoleID {
+			role = r
+			break
+		}
+	}
+	mentionable := true
+	params := &discordgo.RoleParams{
+		Name:        team.Name,
+		Color:       &team.Color,
+		Mentionable: &mentionable,
+	}
+	if role == nil {
+		b.Logger.Debug().Uint16("team_id", team.ID).Msg("Creating team role")
+		err = b.Schedule(ctx, bot.PriorityBulk, http.MethodPost,
+			discordgo.EndpointGuildRoles(b.GuildID))
+		if err != nil {
+			return errors.Wrap(err, "b.Schedule")
+		}
+		role, err = b.Session.GuildRoleCreate(b.GuildID, params)
+		if err != nil {
+			return errors.Wrap(err, "b.Session.GuildRoleCreate")
+		}
+		td.RoleID = role.ID
+		return nil
+	}
+	if role.Name != team.Name || role.Color != team.Color {
+		b.Logger.Debug().Uint16("team_id", team.ID).Msg("Updating team role")
+		err = b.Schedule(ctx, bot.PriorityBulk, http.MethodPatch,
+			discordgo.EndpointGuildRole(b.GuildID, role.ID))
+		if err != nil {
+			return errors.Wrap(err, "b.Schedule")
+		}
+		_, err = b.Session.GuildRoleEdit(b.GuildID, role.ID, params)
+		if err != nil {
+			return errors.Wrap(err, "b.Session.GuildRoleEdit")
+		}
+	}
+	return nil
+}
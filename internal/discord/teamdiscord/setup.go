@@ -0,0 +1,87 @@
+package teamdiscord
+
+import (
+	"context"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Reconcile the team roles and channels in discord with the database. Creates
+// any that are missing for active teams, and removes any left over for teams
+// that are no longer active
+func Setup(
+	wg *sync.WaitGroup,
+	errch chan error,
+	ctx context.Context,
+	b *bot.Bot,
+) {
+	defer wg.Done()
+	err := reconcile(ctx, b)
+	if err != nil {
+		errch <- errors.Wrap(err, "reconcile")
+	}
+}
+
+// Reconcile the roles and channels of every team in the background. Used when
+// a change affects more than one team, e.g. the active season changing
+func SyncAll(ctx context.Context, b *bot.Bot) {
+	go func() {
+		err := reconcile(ctx, b)
+		if err != nil {
+			b.DoubleError("Failed to sync team roles and channels", err)
+		}
+	}()
+}
+
+func reconcile(ctx context.Context, b *bot.Bot) error {
+	teamIDs, err := getTeamsToSync(ctx, b)
+	if err != nil {
+		return errors.Wrap(err, "getTeamsToSync")
+	}
+	b.Logger.Debug().Int("teams", len(teamIDs)).Msg("Reconciling team roles and channels")
+	for _, teamID := range teamIDs {
+		err = syncTeamWithTx(ctx, b, teamID)
+		if err != nil {
+			b.Logger.Warn().Err(err).Uint16("team_id", teamID).
+				Msg("Failed to reconcile team role and channels")
+		}
+	}
+	return nil
+}
+
+// Get the IDs of every active team and every team that has a role or
+// channels in discord
+func getTeamsToSync(ctx context.Context, b *bot.Bot) ([]uint16, error) {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// we use a WTX here to force it to block until commit if a change to the
+	// active season resulted in this function being called
+	tx, err := b.Conn.Begin(timeout, "teamdiscord.getTeamsToSync()")
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	teamIDs, err := models.GetTeamDiscordTeamIDs(ctx, tx, b.GuildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetTeamDiscordTeamIDs")
+	}
+	activeTeams, err := models.GetActiveTeams(ctx, tx, b.GuildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetActiveTeams")
+	}
+	seen := map[uint16]bool{}
+	for _, teamID := range teamIDs {
+		seen[teamID] = true
+	}
+	for _, team := range *activeTeams {
+		if !seen[team.ID] {
+			teamIDs = append(teamIDs, team.ID)
+			seen[team.ID] = true
+		}
+	}
+	return teamIDs, nil
+}
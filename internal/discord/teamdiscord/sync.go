@@ -0,0 +1,223 @@
+package teamdiscord
+
+import (
+	"context"
+	"fmt"
+	"gosl/internal/discord/bot"
+	"gosl/internal/models"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+)
+
+// Sync the discord role and private channels of the team with its roster.
+// Teams that are placed in the active season get a role in the team colour
+// and a private text and voice channel. Teams that are not placed or have no
+// players have their role and channels removed.
+//
+// Runs in the background so it doesnt block/get blocked by the transaction
+// that triggered it, and runs as soon as that transaction is committed
+func SyncTeam(ctx context.Context, b *bot.Bot, teamID uint16) {
+	go func() {
+		err := syncTeamWithTx(ctx, b, teamID)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to sync discord role and channels for team %v", teamID)
+			b.DoubleError(msg, err)
+		}
+	}()
+}
+
+// Team syncs are run one at a time so two syncs of the same team cant both
+// create a role or channels. Only the discord calls are serialised, the
+// transactions are kept short so other writes arent blocked by discord
+var syncLock sync.Mutex
+
+// The team and its roster as of the commit that triggered the sync
+type teamState struct {
+	team           *models.Team
+	td             *models.TeamDiscord
+	active         bool
+	currentPlayers *[]models.Player
+	allPlayers     *[]models.Player
+	managerRoles   []string
+}
+
+func syncTeamWithTx(ctx context.Context, b *bot.Bot, teamID uint16) error {
+	syncLock.Lock()
+	defer syncLock.Unlock()
+	state, err := getTeamState(ctx, b, teamID)
+	if err != nil {
+		return errors.Wrap(err, "getTeamState")
+	}
+	// the role and channels belong to the guild the team plays in
+	b = b.Guild(state.team.GuildID)
+	if b == nil {
+		return nil
+	}
+	timeout, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	err = syncTeam(timeout, b, state)
+	if err != nil {
+		return errors.Wrap(err, "syncTeam")
+	}
+	return nil
+}
+
+// Read everything the sync needs in a single short transaction
+func getTeamState(
+	ctx context.Context,
+	b *bot.Bot,
+	teamID uint16,
+) (*teamState, error) {
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// we use a WTX here to force it to block until commit of the transaction
+	// that changed the team
+	tx, err := b.Conn.Begin(timeout, "teamdiscord.getTeamState()")
+	if err != nil {
+		return nil, errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	team, err := models.GetTeamByID(timeout, tx, teamID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetTeamByID")
+	}
+	if team == nil {
+		return nil, errors.New("Team not found")
+	}
+	state := &teamState{team: team}
+	state.td, err = models.GetTeamDiscord(timeout, tx, team.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetTeamDiscord")
+	}
+	state.active, err = team.IsActive(timeout, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "team.IsActive")
+	}
+	now := time.Now()
+	state.currentPlayers, err = team.Players(timeout, tx, &now, &now)
+	if err != nil {
+		return nil, errors.Wrap(err, "team.Players")
+	}
+	state.allPlayers, err = team.Players(timeout, tx, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "team.Players")
+	}
+	state.managerRoles, err = models.GetRoles(timeout, tx, team.GuildID, models.PermLeagueManager)
+	if err != nil {
+		return nil, errors.Wrap(err, "models.GetRoles")
+	}
+	return state, nil
+}
+
+// Make the discord calls to sync the team with no transaction open, then save
+// the resulting role and channel IDs
+func syncTeam(ctx context.Context, b *bot.Bot, state *teamState) error {
+	team := state.team
+	td := state.td
+	if !state.active || len(*state.currentPlayers) == 0 {
+		if td == nil {
+			return nil
+		}
+		err := cleanupTeam(ctx, b, team, td)
+		if err != nil {
+			return errors.Wrap(err, "cleanupTeam")
+		}
+		return nil
+	}
+	if td == nil {
+		td = &models.TeamDiscord{TeamID: team.ID}
+	}
+	err := syncRole(ctx, b, team, td)
+	if err != nil {
+		// save any role created before the failure so it isnt orphaned
+		saveErr := saveTeamDiscord(ctx, b, td)
+		if saveErr != nil {
+			b.Logger.Warn().Err(saveErr).Msg("Failed to save team discord")
+		}
+		return errors.Wrap(err, "syncRole")
+	}
+	err = syncChannels(ctx, b, team, td, state.managerRoles)
+	if err != nil {
+		saveErr := saveTeamDiscord(ctx, b, td)
+		if saveErr != nil {
+			b.Logger.Warn().Err(saveErr).Msg("Failed to save team discord")
+		}
+		return errors.Wrap(err, "syncChannels")
+	}
+	err = saveTeamDiscord(ctx, b, td)
+	if err != nil {
+		return errors.Wrap(err, "saveTeamDiscord")
+	}
+	err = syncMembers(ctx, b, td, state.currentPlayers, state.allPlayers)
+	if err != nil {
+		return errors.Wrap(err, "syncMembers")
+	}
+	return nil
+}
+
+// Save the role and channel IDs of the team in a short transaction
+func saveTeamDiscord(ctx context.Context, b *bot.Bot, td *models.TeamDiscord) error {
+	tx, err := b.Conn.Begin(ctx, "teamdiscord.saveTeamDiscord()")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	err = td.Save(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "td.Save")
+	}
+	tx.Commit()
+	return nil
+}
+
+// Delete the teams role and channels from discord, then from the database
+func cleanupTeam(
+	ctx context.Context,
+	b *bot.Bot,
+	team *models.Team,
+	td *models.TeamDiscord,
+) error {
+	b.Logger.Debug().Uint16("team_id", team.ID).
+		Msg("Removing team discord role and channels")
+	for _, channelID := range []string{td.TextChannelID, td.VoiceChannelID} {
+		if channelID == "" {
+			continue
+		}
+		err := b.Schedule(ctx, bot.PriorityBulk, http.MethodDelete,
+			discordgo.EndpointChannel(channelID))
+		if err != nil {
+			return errors.Wrap(err, "b.Schedule")
+		}
+		_, err = b.Session.ChannelDelete(channelID)
+		if err != nil && !bot.IsNotFound(err) {
+			return errors.Wrap(err, "b.Session.ChannelDelete")
+		}
+	}
+	if td.RoleID != "" {
+		err := b.Schedule(ctx, bot.PriorityBulk, http.MethodDelete,
+			discordgo.EndpointGuildRole(b.GuildID, td.RoleID))
+		if err != nil {
+			return errors.Wrap(err, "b.Schedule")
+		}
+		err = b.Session.GuildRoleDelete(b.GuildID, td.RoleID)
+		if err != nil && !bot.IsNotFound(err) {
+			return errors.Wrap(err, "b.Session.GuildRoleDelete")
+		}
+	}
+	tx, err := b.Conn.Begin(ctx, "teamdiscord.cleanupTeam()")
+	if err != nil {
+		return errors.Wrap(err, "b.Conn.Begin")
+	}
+	defer tx.Rollback()
+	err = td.Remove(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "td.Remove")
+	}
+	tx.Commit()
+	b.Log().Info(fmt.Sprintf("Removed discord role and channels for %s", team.Name))
+	return nil
+}
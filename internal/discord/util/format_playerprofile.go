@@ -0,0 +1,61 @@
+package util
+
+import (
+	"fmt"
+	"gosl/internal/models"
+	"gosl/pkg/i18n"
+	"strings"
+)
+
+// Format the players weekly availability as a grid in a code block, with a
+// row for each day and a column for each block of time
+func AvailabilityGrid(profile *models.PlayerProfile) string {
+	grid := "```\n    "
+	for _, block := range models.AvailabilityBlocks {
+		grid = grid + fmt.Sprintf(" %-9s", block)
+	}
+	for _, day := range models.AvailabilityDays {
+		grid = grid + "\n" + day + " "
+		for _, block := range models.AvailabilityBlocks {
+			mark := "-"
+			if profile.IsAvailable(day, block) {
+				mark = "X"
+			}
+			grid = grid + fmt.Sprintf("    %-6s", mark)
+		}
+	}
+	return grid + "\n```"
+}
+
+// Format the players weekly availability on a single line, grouped by day
+// i.e. "Mon: Evening, Late | Wed: Afternoon"
+func AvailabilitySummary(profile *models.PlayerProfile) string {
+	days := []string{}
+	for _, day := range models.AvailabilityDays {
+		blocks := []string{}
+		for _, block := range models.AvailabilityBlocks {
+			if profile.IsAvailable(day, block) {
+				blocks = append(blocks, block)
+			}
+		}
+		if len(blocks) > 0 {
+			days = append(days, day+": "+strings.Join(blocks, ", "))
+		}
+	}
+	if len(days) == 0 {
+		return "Not set"
+	}
+	return strings.Join(days, " | ")
+}
+
+// Format the players positions and region on a single line
+func ProfileSummary(lang string, profile *models.PlayerProfile) string {
+	positions := i18n.T(lang, "panel.nopositions")
+	if len(profile.Positions) > 0 {
+		positions = strings.Join(profile.Positions, ", ")
+	}
+	if profile.Region == "" {
+		return positions
+	}
+	return positions + " - " + profile.Region
+}
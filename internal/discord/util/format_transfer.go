@@ -0,0 +1,50 @@
+package util
+
+import (
+	"fmt"
+	"gosl/internal/models"
+)
+
+// Format the player joining or leaving a team on a single line, with the date
+// as a discord timestamp
+// i.e. "<t:1741937700:d> **Player** joined **Team** - Trial offer #4"
+func TransferLine(transfer *models.Transfer) string {
+	action := "left"
+	if transfer.Joined {
+		action = "joined"
+	}
+	line := fmt.Sprintf("<t:%d:d> **%s** %s **%s**",
+		transfer.Time.Unix(), transfer.PlayerName, action, transfer.TeamName)
+	if cause := transfer.Cause(); cause != "" {
+		line = line + " - " + cause
+	}
+	return line
+}
+
+// Format the period the player spent on the team on a single line, with the
+// dates as discord timestamps
+// i.e. "**Team** <t:1741937700:d> to <t:1742937700:d> (joined from Invite #3, Left)"
+func CareerLine(team *models.PlayerTeam) string {
+	left := "now"
+	if team.Left != nil {
+		left = fmt.Sprintf("<t:%d:d>", team.Left.Unix())
+	}
+	joined := &models.Transfer{
+		Joined: true, Reason: team.JoinReason, InviteID: team.InviteID,
+	}
+	line := fmt.Sprintf("**%s** <t:%d:d> to %s", team.TeamName, team.Joined.Unix(), left)
+	details := ""
+	if cause := joined.Cause(); cause != "" {
+		details = "joined from " + cause
+	}
+	if team.Left != nil && team.LeftReason != "" {
+		if details != "" {
+			details = details + ", "
+		}
+		details = details + models.MoveReasonName(team.LeftReason)
+	}
+	if details != "" {
+		line = line + " (" + details + ")"
+	}
+	return line
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"gosl/internal/models"
+	"gosl/internal/view/page"
+	"gosl/pkg/config"
+	"gosl/pkg/db"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Page showing the audit log of league administration actions. Can be
+// filtered by team (name or abbreviation), player ID and actor discord ID
+// using the query parameters "team", "player" and "actor". Teams are looked
+// up in the guild given by the "guild" query parameter, or the primary guild.
+// Requires the admin API token as a bearer token, same as the admin API
+func AuditLog(
+	logger *zerolog.Logger,
+	config *config.Config,
+	conn *db.SafeConn,
+) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if !checkAdminToken(config, w, r) {
+				return
+			}
+			query := r.URL.Query()
+			filters := page.AuditLogFilters{
+				Team:   query.Get("team"),
+				Player: query.Get("player"),
+				Actor:  query.Get("actor"),
+			}
+			filter := models.AuditFilter{ActorID: filters.Actor}
+			guildID, ok := requestGuild(config, r)
+			if !ok {
+				ErrorPage(http.StatusBadRequest, w, r)
+				return
+			}
+			if filters.Player != "" {
+				playerID, err := strconv.ParseUint(filters.Player, 10, 16)
+				if err != nil {
+					ErrorPage(http.StatusBadRequest, w, r)
+					return
+				}
+				id := uint16(playerID)
+				filter.PlayerID = &id
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+			defer cancel()
+			tx, err := conn.RBegin(ctx, "AuditLog()")
+			if err != nil {
+				logger.Warn().Err(err).Msg("Failed to start transaction")
+				ErrorPage(http.StatusServiceUnavailable, w, r)
+				return
+			}
+			defer tx.Rollback()
+			entries := &[]models.AuditEntry{}
+			teamFound := true
+			if filters.Team != "" {
+				team, err := models.GetTeamByName(ctx, tx, guildID, filters.Team)
+				if err != nil {
+					logger.Error().Err(errors.Wrap(err, "models.GetTeamByName")).
+						Msg("Failed to load audit log")
+					ErrorPage(http.StatusInternalServerError, w, r)
+					return
+				}
+				if team == nil {
+					teamFound = false
+				} else {
+					filter.TeamID = &team.ID
+				}
+			}
+			if teamFound {
+				entries, err = models.GetAuditLog(ctx, tx, guildID, filter)
+				if err != nil {
+					logger.Error().Err(errors.Wrap(err, "models.GetAuditLog")).
+						Msg("Failed to load audit log")
+					ErrorPage(http.StatusInternalServerError, w, r)
+					return
+				}
+			}
+			tx.Commit()
+			page.AuditLog(entries, filters).Render(r.Context(), w)
+		},
+	)
+}
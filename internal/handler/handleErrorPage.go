@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"fmt"
 	"gosl/internal/view/page"
+	"gosl/pkg/contexts"
+	"gosl/pkg/i18n"
 	"net/http"
 )
 
@@ -10,15 +13,14 @@ func ErrorPage(
 	w http.ResponseWriter,
 	r *http.Request,
 ) {
-	message := map[int]string{
-		401: "You need to login to view this page.",
-		403: "You do not have permission to view this page.",
-		404: "The page or resource you have requested does not exist.",
-		500: `An error occured on the server. Please try again, and if this
-        continues to happen contact an administrator.`,
-		503: "The server is currently down for maintenance and should be back soon. =)",
+	lang := contexts.GetLanguage(r.Context())
+	// Codes without a translated title fall back to the standard status text
+	title, ok := i18n.Lookup(lang, fmt.Sprintf("error.page.%v.title", errorCode))
+	if !ok {
+		title = http.StatusText(errorCode)
 	}
+	message, _ := i18n.Lookup(lang, fmt.Sprintf("error.page.%v.message", errorCode))
 	w.WriteHeader(errorCode)
-	page.Error(errorCode, http.StatusText(errorCode), message[errorCode]).
+	page.Error(errorCode, title, message).
 		Render(r.Context(), w)
 }
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"gosl/internal/health"
+	"gosl/internal/maintenance"
+	"gosl/pkg/db"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Statuses of a readiness check. Only a failed check makes the application
+// not ready, warnings are reported but still ready
+const (
+	checkOK      = "ok"
+	checkWarn    = "warn"
+	checkFail    = "fail"
+	checkSkipped = "skipped"
+)
+
+// How long since the last successful queue poll before warning it's stale.
+// The queue is polled every 10 seconds
+const queuePollStaleAfter = 2 * time.Minute
+
+// Result of a single readiness check
+type readinessCheck struct {
+	Status string         `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Since  *time.Time     `json:"since,omitempty"` // time the checked state started
+	Info   map[string]any `json:"info,omitempty"`
+}
+
+type readinessResponse struct {
+	Status string                    `json:"status"`
+	Checks map[string]readinessCheck `json:"checks"`
+}
+
+// Liveness check, responds as long as the HTTP server is running
+func Healthz() http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]string{"status": checkOK})
+		},
+	)
+}
+
+// Readiness check, reporting the state of the database, maintenance mode and
+// the discord bot as JSON. Responds 503 if any check fails
+func Readyz(
+	logger *zerolog.Logger,
+	conn *db.SafeConn,
+	mode *maintenance.Mode,
+	status *health.Status,
+) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			snapshot := status.Snapshot()
+			resp := readinessResponse{
+				Status: checkOK,
+				Checks: map[string]readinessCheck{
+					"database":    checkDatabase(r.Context(), conn),
+					"maintenance": checkMaintenance(mode),
+					"discord":     checkDiscord(snapshot),
+					"bot_setup":   checkBotSetup(snapshot),
+					"queue_poll":  checkQueuePoll(snapshot),
+				},
+			}
+			code := http.StatusOK
+			for name, check := range resp.Checks {
+				if check.Status == checkFail {
+					resp.Status = checkFail
+					code = http.StatusServiceUnavailable
+					logger.Debug().Str("check", name).Str("detail", check.Detail).
+						Msg("Readiness check failed")
+				}
+			}
+			writeJSON(w, code, resp)
+		},
+	)
+}
+
+func checkDatabase(ctx context.Context, conn *db.SafeConn) readinessCheck {
+	stats := conn.LockStats()
+	check := readinessCheck{
+		Status: checkOK,
+		Info: map[string]any{
+			"readers":       stats.Readers,
+			"waiting":       stats.Waiting,
+			"paused":        stats.Paused,
+			"pause_waiting": stats.PauseWaiting,
+		},
+	}
+	// a paused database can't be queried until it's resumed
+	if stats.Paused {
+		check.Status = checkWarn
+		check.Detail = "Database is paused"
+		return check
+	}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	tx, err := conn.RBegin(ctx, "Readiness check")
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+		return check
+	}
+	defer tx.Rollback()
+	row, err := tx.QueryRow(ctx, "SELECT 1;")
+	if err == nil {
+		var one int
+		err = row.Scan(&one)
+	}
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = err.Error()
+	}
+	return check
+}
+
+func checkMaintenance(mode *maintenance.Mode) readinessCheck {
+	info := mode.Info()
+	if info.Active {
+		check := readinessCheck{
+			Status: checkWarn,
+			Detail: "Maintenance mode is active",
+			Since:  info.Started,
+			Info:   map[string]any{"reason": info.Reason},
+		}
+		if info.ETA != nil {
+			check.Info["eta"] = info.ETA
+		}
+		return check
+	}
+	return readinessCheck{Status: checkOK}
+}
+
+func checkDiscord(snapshot health.Snapshot) readinessCheck {
+	if !snapshot.BotEnabled {
+		return readinessCheck{Status: checkSkipped, Detail: "Discord bot is not running"}
+	}
+	check := readinessCheck{Status: checkOK, Since: timeOrNil(snapshot.DiscordChanged)}
+	if !snapshot.DiscordConnected {
+		check.Status = checkFail
+		check.Detail = "Discord session is not connected"
+	}
+	return check
+}
+
+func checkBotSetup(snapshot health.Snapshot) readinessCheck {
+	if !snapshot.BotEnabled {
+		return readinessCheck{Status: checkSkipped, Detail: "Discord bot is not running"}
+	}
+	channels := map[string]any{}
+	for label, setup := range snapshot.ChannelsSetup {
+		channels[label] = setup
+	}
+	check := readinessCheck{
+		Status: checkOK,
+		Since:  timeOrNil(snapshot.StartupComplete),
+		Info:   map[string]any{"channels_setup": channels},
+	}
+	if snapshot.StartupComplete.IsZero() {
+		check.Status = checkFail
+		check.Detail = "Bot startup has not completed"
+	}
+	return check
+}
+
+func checkQueuePoll(snapshot health.Snapshot) readinessCheck {
+	if !snapshot.BotEnabled {
+		return readinessCheck{Status: checkSkipped, Detail: "Discord bot is not running"}
+	}
+	check := readinessCheck{Status: checkOK, Since: timeOrNil(snapshot.LastQueuePoll)}
+	if snapshot.LastQueuePoll.IsZero() {
+		check.Status = checkWarn
+		check.Detail = "Slapshot queue has not been polled successfully yet"
+	} else if time.Since(snapshot.LastQueuePoll) > queuePollStaleAfter {
+		check.Status = checkWarn
+		check.Detail = "Last successful Slapshot queue poll is stale"
+	}
+	return check
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
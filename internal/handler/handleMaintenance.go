@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"gosl/internal/maintenance"
+	"gosl/pkg/config"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Body of a request to start maintenance
+type startMaintenanceRequest struct {
+	Reason string `json:"reason"`
+	ETA    string `json:"eta"` // RFC3339 timestamp, optional
+}
+
+// Admin API for viewing, starting and ending maintenance mode.
+// Requires the admin API token as a bearer token, and responds 404 if no
+// token is configured
+func Maintenance(
+	logger *zerolog.Logger,
+	config *config.Config,
+	mode *maintenance.Mode,
+) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if !checkAdminToken(config, w, r) {
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				writeJSON(w, http.StatusOK, mode.Info())
+			case http.MethodPost:
+				startMaintenance(logger, mode, w, r)
+			case http.MethodDelete:
+				err := mode.End("admin API")
+				if err != nil {
+					writeMaintenanceError(logger, w, err)
+					return
+				}
+				writeJSON(w, http.StatusOK, mode.Info())
+			}
+		},
+	)
+}
+
+func startMaintenance(
+	logger *zerolog.Logger,
+	mode *maintenance.Mode,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	var req startMaintenanceRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+		return
+	}
+	var eta *time.Time
+	if req.ETA != "" {
+		parsed, err := time.Parse(time.RFC3339, req.ETA)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest,
+				map[string]string{"error": "ETA must be an RFC3339 timestamp"})
+			return
+		}
+		eta = &parsed
+	}
+	if mode.Active() {
+		writeJSON(w, http.StatusConflict, map[string]string{"error": "Maintenance is already active"})
+		return
+	}
+	// starting waits for open transactions to finish, which can take up to
+	// the lock timeout, so don't hold the request open
+	go func() {
+		err := mode.Start(req.Reason, eta, "admin API")
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to start maintenance")
+		}
+	}()
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "starting"})
+}
+
+func writeMaintenanceError(logger *zerolog.Logger, w http.ResponseWriter, err error) {
+	if strings.HasPrefix(err.Error(), "VE:") {
+		msg := strings.TrimPrefix(err.Error(), "VE:")
+		writeJSON(w, http.StatusConflict, map[string]string{"error": msg})
+		return
+	}
+	logger.Error().Err(err).Msg("Maintenance admin API error")
+	writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Internal server error"})
+}
+
+// Check the request has the admin API token as a bearer token. Responds 404
+// if no token is configured or 401 if the token is wrong, and returns false
+// if the request shouldn't be handled
+func checkAdminToken(config *config.Config, w http.ResponseWriter, r *http.Request) bool {
+	if config.AdminAPIToken == "" {
+		ErrorPage(http.StatusNotFound, w, r)
+		return false
+	}
+	if !validAdminToken(config.AdminAPIToken, r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "Invalid token"})
+		return false
+	}
+	return true
+}
+
+func validAdminToken(token string, r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	provided, found := strings.CutPrefix(header, "Bearer ")
+	if !found {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"gosl/pkg/db"
+	"gosl/pkg/metrics"
+	"net/http"
+)
+
+// Serves the metrics in the Prometheus text exposition format
+func Metrics(conn *db.SafeConn) http.Handler {
+	serve := metrics.Handler()
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			conn.UpdateMetrics()
+			serve.ServeHTTP(w, r)
+		},
+	)
+}
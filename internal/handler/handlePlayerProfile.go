@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"gosl/internal/models"
+	"gosl/internal/view/page"
+	"gosl/pkg/config"
+	"gosl/pkg/db"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Public page showing a players profile, current team and the teams they
+// have been on in the guild given by the "guild" query parameter, or the
+// primary guild if not given
+func PlayerProfile(
+	logger *zerolog.Logger,
+	config *config.Config,
+	conn *db.SafeConn,
+) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			playerID, err := strconv.ParseUint(r.PathValue("id"), 10, 16)
+			if err != nil {
+				ErrorPage(http.StatusNotFound, w, r)
+				return
+			}
+			guildID, ok := requestGuild(config, r)
+			if !ok {
+				ErrorPage(http.StatusNotFound, w, r)
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+			defer cancel()
+			tx, err := conn.RBegin(ctx, "PlayerProfile()")
+			if err != nil {
+				logger.Warn().Err(err).Msg("Failed to start transaction")
+				ErrorPage(http.StatusServiceUnavailable, w, r)
+				return
+			}
+			defer tx.Rollback()
+			player, err := models.GetPlayerByID(ctx, tx, uint16(playerID))
+			if err != nil {
+				logger.Error().Err(errors.Wrap(err, "models.GetPlayerByID")).
+					Msg("Failed to load player profile")
+				ErrorPage(http.StatusInternalServerError, w, r)
+				return
+			}
+			if player == nil {
+				ErrorPage(http.StatusNotFound, w, r)
+				return
+			}
+			profile, err := models.GetPlayerProfile(ctx, tx, player.ID)
+			if err != nil {
+				logger.Error().Err(errors.Wrap(err, "models.GetPlayerProfile")).
+					Msg("Failed to load player profile")
+				ErrorPage(http.StatusInternalServerError, w, r)
+				return
+			}
+			career, err := models.GetPlayerCareer(ctx, tx, guildID, player.ID)
+			if err != nil {
+				logger.Error().Err(errors.Wrap(err, "models.GetPlayerCareer")).
+					Msg("Failed to load player profile")
+				ErrorPage(http.StatusInternalServerError, w, r)
+				return
+			}
+			tx.Commit()
+			// the player can only be on one team at a time, and
+			// joins are only current from the second after
+			teamName := ""
+			now := time.Now()
+			for _, pt := range *career {
+				if pt.Left == nil && pt.Joined.Before(now) {
+					teamName = pt.TeamName
+					break
+				}
+			}
+			page.PlayerProfile(player, profile, teamName, career).Render(r.Context(), w)
+		},
+	)
+}
+
+// Get the guild given by the "guild" query parameter, or the primary guild if
+// not given. Returns false if the guild isn't one the bot serves
+func requestGuild(config *config.Config, r *http.Request) (string, bool) {
+	guildID := r.URL.Query().Get("guild")
+	if guildID == "" {
+		return config.DiscordGuildID, true
+	}
+	return guildID, slices.Contains(config.DiscordGuildIDs, guildID)
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// State of the parts of the application that run in the background, shared
+// between the discord bot that updates it and the readiness endpoint that
+// reports it
+type Status struct {
+	mu               sync.RWMutex
+	botEnabled       bool                 // is the discord bot being run?
+	discordConnected bool                 // is the discord session connected?
+	discordChanged   time.Time            // time the connection state last changed
+	startupComplete  time.Time            // time the bot finished starting up
+	channelsSetup    map[string]time.Time // time each channel's messages were last set up
+	lastQueuePoll    time.Time            // time of the last successful slapshot queue poll
+}
+
+// Create a new status. If botEnabled is false the discord checks are skipped
+func NewStatus(botEnabled bool) *Status {
+	return &Status{
+		botEnabled:    botEnabled,
+		channelsSetup: map[string]time.Time{},
+	}
+}
+
+// Snapshot of the status at a point in time
+type Snapshot struct {
+	BotEnabled       bool
+	DiscordConnected bool
+	DiscordChanged   time.Time
+	StartupComplete  time.Time
+	ChannelsSetup    map[string]time.Time
+	LastQueuePoll    time.Time
+}
+
+// Get a snapshot of the current status
+func (s *Status) Snapshot() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	channels := make(map[string]time.Time, len(s.channelsSetup))
+	for label, setup := range s.channelsSetup {
+		channels[label] = setup
+	}
+	return Snapshot{
+		BotEnabled:       s.botEnabled,
+		DiscordConnected: s.discordConnected,
+		DiscordChanged:   s.discordChanged,
+		StartupComplete:  s.startupComplete,
+		ChannelsSetup:    channels,
+		LastQueuePoll:    s.lastQueuePoll,
+	}
+}
+
+// Record the discord session connecting or disconnecting
+func (s *Status) SetDiscordConnected(connected bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.discordConnected == connected && !s.discordChanged.IsZero() {
+		return
+	}
+	s.discordConnected = connected
+	s.discordChanged = time.Now()
+}
+
+// Record the bot finishing startup
+func (s *Status) StartupComplete() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.startupComplete = time.Now()
+}
+
+// Record the messages in the channel being set up
+func (s *Status) ChannelSetup(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channelsSetup[label] = time.Now()
+}
+
+// Record a successful poll of the slapshot queue
+func (s *Status) QueuePolled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastQueuePoll = time.Now()
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"gosl/internal/handler"
+	"gosl/internal/health"
+	"gosl/internal/maintenance"
 	"gosl/pkg/config"
 	"gosl/pkg/db"
 
@@ -16,11 +18,17 @@ func addRoutes(
 	config *config.Config,
 	conn *db.SafeConn,
 	staticFS *http.FileSystem,
+	mode *maintenance.Mode,
+	status *health.Status,
 ) {
 	route := mux.Handle
 
-	// Health check
-	mux.HandleFunc("GET /healthz", func(http.ResponseWriter, *http.Request) {})
+	// Liveness and readiness checks
+	route("GET /healthz", handler.Healthz())
+	route("GET /readyz", handler.Readyz(logger, conn, mode, status))
+
+	// Prometheus metrics
+	route("GET /metrics", handler.Metrics(conn))
 
 	// Static files
 	route("GET /static/", http.StripPrefix("/static/", handler.HandleFS(staticFS)))
@@ -30,4 +38,16 @@ func addRoutes(
 
 	// Player Registration help page
 	route("GET /registration-help", handler.RegistrationHelp())
+
+	// Player profile page
+	route("GET /players/{id}", handler.PlayerProfile(logger, config, conn))
+
+	// Admin API for maintenance mode
+	maintenanceAPI := handler.Maintenance(logger, config, mode)
+	route("GET /admin/maintenance", maintenanceAPI)
+	route("POST /admin/maintenance", maintenanceAPI)
+	route("DELETE /admin/maintenance", maintenanceAPI)
+
+	// Audit log of league administration actions
+	route("GET /admin/audit", handler.AuditLog(logger, config, conn))
 }
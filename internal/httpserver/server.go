@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"gosl/internal/health"
+	"gosl/internal/maintenance"
 	"gosl/internal/middleware"
 	"gosl/pkg/config"
 	"gosl/pkg/db"
@@ -18,10 +20,11 @@ func NewServer(
 	logger *zerolog.Logger,
 	conn *db.SafeConn,
 	staticFS *fs.FS,
-	maint *uint32,
+	mode *maintenance.Mode,
+	status *health.Status,
 ) *http.Server {
 	fs := http.FS(*staticFS)
-	srv := createServer(config, logger, conn, &fs, maint)
+	srv := createServer(config, logger, conn, &fs, mode, status)
 	httpServer := &http.Server{
 		Addr:              net.JoinHostPort(config.Host, config.Port),
 		Handler:           srv,
@@ -38,7 +41,8 @@ func createServer(
 	logger *zerolog.Logger,
 	conn *db.SafeConn,
 	staticFS *http.FileSystem,
-	maint *uint32,
+	mode *maintenance.Mode,
+	status *health.Status,
 ) http.Handler {
 	mux := http.NewServeMux()
 	addRoutes(
@@ -47,16 +51,23 @@ func createServer(
 		config,
 		conn,
 		staticFS,
+		mode,
+		status,
 	)
 	var handler http.Handler = mux
 	// Add middleware here, must be added in reverse order of execution
 	// i.e. First in list will get executed last during the request handling
+	handler = middleware.Maintenance(mode, handler)
 	handler = middleware.Logging(logger, handler)
 	// handler = middleware.Authentication(logger, config, conn, handler, maint)
 
 	// Gzip
 	// handler = middleware.Gzip(handler, config.GZIP)
 
+	// Set the language before logging so the route the request matched is
+	// still recorded on the request the logger sees
+	handler = middleware.Language(handler)
+
 	// Start the timer for the request chain so logger can have accurate info
 	handler = middleware.StartTimer(handler)
 	return handler
@@ -0,0 +1,157 @@
+package maintenance
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gosl/internal/models"
+	"gosl/pkg/config"
+	"gosl/pkg/db"
+	"gosl/pkg/i18n"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Controls maintenance mode. While active the database is paused, the website
+// responds with 503 and the bot replies to interactions with a maintenance
+// message. Can be started and ended from signals, the admin channel and the
+// admin HTTP endpoint
+type Mode struct {
+	active     *uint32 // atomic: 1 if in maintenance mode
+	conn       *db.SafeConn
+	config     *config.Config
+	logger     *zerolog.Logger
+	mu         sync.Mutex // held while starting or ending
+	info       Info
+	adminRoles []string // roles with admin permission when maintenance started
+}
+
+// Details of the current maintenance
+type Info struct {
+	Active    bool       `json:"active"`
+	Reason    string     `json:"reason,omitempty"`
+	ETA       *time.Time `json:"eta,omitempty"`     // expected end of the maintenance
+	Started   *time.Time `json:"started,omitempty"` // time maintenance started
+	StartedBy string     `json:"started_by,omitempty"`
+}
+
+// Create the maintenance controller. The active flag is shared with anything
+// that only needs to check if maintenance is active
+func New(
+	active *uint32,
+	conn *db.SafeConn,
+	cfg *config.Config,
+	logger *zerolog.Logger,
+) *Mode {
+	return &Mode{active: active, conn: conn, config: cfg, logger: logger}
+}
+
+// Check if maintenance mode is active
+func (m *Mode) Active() bool {
+	return atomic.LoadUint32(m.active) == 1
+}
+
+// Get the details of the current maintenance
+func (m *Mode) Info() Info {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	info := m.info
+	info.Active = m.Active()
+	return info
+}
+
+// Start maintenance, pausing the database once all open transactions finish.
+// The ETA can be nil if unknown. Must not be called while holding a
+// transaction or it will wait until it times out
+func (m *Mode) Start(reason string, eta *time.Time, startedBy string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Active() {
+		return errors.New("VE:Maintenance is already active")
+	}
+	adminRoles, err := m.getAdminRoles()
+	if err != nil {
+		return errors.Wrap(err, "m.getAdminRoles")
+	}
+	// set the flag first so no new transactions are started while waiting
+	atomic.StoreUint32(m.active, 1)
+	m.logger.Info().Str("reason", reason).Str("by", startedBy).Msg("Starting maintenance")
+	if !m.conn.Pause(m.config.DBLockTimeout * time.Second) {
+		atomic.StoreUint32(m.active, 0)
+		return errors.New("Timed out waiting for open transactions to finish")
+	}
+	now := time.Now()
+	m.info = Info{Reason: reason, ETA: eta, Started: &now, StartedBy: startedBy}
+	m.adminRoles = adminRoles
+	return nil
+}
+
+// End maintenance, resuming the database
+func (m *Mode) End(endedBy string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.Active() {
+		return errors.New("VE:Maintenance is not active")
+	}
+	m.logger.Info().Str("by", endedBy).Msg("Ending maintenance")
+	m.conn.Resume()
+	atomic.StoreUint32(m.active, 0)
+	m.info = Info{}
+	m.adminRoles = nil
+	return nil
+}
+
+// Check if the member can end maintenance. The database can't be used while
+// in maintenance, so uses the admin roles from when maintenance started
+func (m *Mode) CanEnd(member *discordgo.Member) bool {
+	if member.Permissions&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, role := range member.Roles {
+		if slices.Contains(m.adminRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get a message describing the maintenance for users in the language. The
+// expected end time is formatted with formatTime so it can be shown in each
+// user's timezone
+func (info Info) Message(lang string, formatTime func(*time.Time) string) string {
+	msg := i18n.T(lang, "maintenance.message")
+	if info.Reason != "" {
+		msg = msg + "\n" + i18n.T(lang, "maintenance.reason", info.Reason)
+	}
+	if info.ETA != nil {
+		msg = msg + "\n" + i18n.T(lang, "maintenance.eta", formatTime(info.ETA))
+	}
+	return msg + "\n" + i18n.T(lang, "maintenance.retry")
+}
+
+func (m *Mode) getAdminRoles() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tx, err := m.conn.RBegin(ctx, "maintenance.getAdminRoles")
+	if err != nil {
+		return nil, errors.Wrap(err, "m.conn.RBegin")
+	}
+	defer tx.Rollback()
+	// maintenance covers every guild, so an admin of any of them can end it
+	roles := []string{}
+	for _, guildID := range m.config.DiscordGuildIDs {
+		guildRoles, err := models.GetRoles(ctx, tx, guildID, models.PermAdmin)
+		if err != nil {
+			return nil, errors.Wrap(err, "models.GetRoles")
+		}
+		roles = append(roles, guildRoles...)
+	}
+	return roles, nil
+}
@@ -0,0 +1,104 @@
+package maintenance_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"gosl/internal/maintenance"
+	"gosl/internal/models"
+	"gosl/pkg/db"
+	"gosl/pkg/tests"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMode(t *testing.T) {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	wconn, rconn, err := tests.SetupTestDB(ver)
+	require.NoError(t, err)
+	conn := db.MakeSafe(wconn, rconn, tests.NilLogger())
+	t.Cleanup(func() { conn.Close() })
+	cfg.DBLockTimeout = 1
+
+	ctx := t.Context()
+	tx, err := conn.Begin(ctx, "TestMode setup")
+	require.NoError(t, err)
+	require.NoError(t, models.AddPermission(ctx, tx, cfg.DiscordGuildID, "admins", models.PermAdmin))
+	tx.Commit()
+
+	active := new(uint32)
+	mode := maintenance.New(active, conn, cfg, tests.NilLogger())
+	eta := time.Now().Add(time.Hour).Truncate(time.Second)
+	admin := &discordgo.Member{Roles: []string{"admins"}}
+	member := &discordgo.Member{Roles: []string{"members"}}
+
+	steps := []struct {
+		name   string
+		action func() error
+		err    string
+		active bool
+		info   maintenance.Info
+	}{
+		{
+			name:   "Start pauses the database",
+			action: func() error { return mode.Start("upgrade", &eta, "staff") },
+			active: true,
+			info:   maintenance.Info{Active: true, Reason: "upgrade", ETA: &eta, StartedBy: "staff"},
+		},
+		{
+			name:   "Start while active keeps the first maintenance",
+			action: func() error { return mode.Start("other", nil, "someone") },
+			err:    "VE:Maintenance is already active",
+			active: true,
+			info:   maintenance.Info{Active: true, Reason: "upgrade", ETA: &eta, StartedBy: "staff"},
+		},
+		{
+			name:   "End resumes the database",
+			action: func() error { return mode.End("staff") },
+			active: false,
+		},
+		{
+			name:   "End while not active does nothing",
+			action: func() error { return mode.End("staff") },
+			err:    "VE:Maintenance is not active",
+			active: false,
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			err := step.action()
+			if step.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, step.err)
+			}
+			assert.Equal(t, step.active, mode.Active())
+			assert.Equal(t, step.active, *active == 1)
+
+			info := mode.Info()
+			assert.Equal(t, step.active, info.Active)
+			assert.Equal(t, step.info.Reason, info.Reason)
+			assert.Equal(t, step.info.ETA, info.ETA)
+			assert.Equal(t, step.info.StartedBy, info.StartedBy)
+			assert.Equal(t, step.active, info.Started != nil)
+			assert.Equal(t, step.active, mode.CanEnd(admin))
+			assert.False(t, mode.CanEnd(member))
+
+			timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			rtx, err := conn.RBegin(timeout, "TestMode paused")
+			if step.active {
+				assert.Error(t, err, "database should be paused")
+			} else if assert.NoError(t, err) {
+				rtx.Rollback()
+			}
+		})
+	}
+}
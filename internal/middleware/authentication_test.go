@@ -20,7 +20,7 @@ func TestAuthenticationMiddleware(t *testing.T) {
 	cfg, err := tests.TestConfig()
 	require.NoError(t, err)
 	logger := tests.NilLogger()
-	ver, err := strconv.ParseInt(cfg.DBName, 10, 0)
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
 	require.NoError(t, err)
 	wconn, rconn, err := tests.SetupTestDB(ver)
 	require.NoError(t, err)
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"gosl/pkg/contexts"
+	"gosl/pkg/cookies"
+	"gosl/pkg/i18n"
+	"net/http"
+)
+
+// Name of the cookie storing the language chosen by the user
+const languageCookie = "lang"
+
+// Sets the language the page is shown in. A language chosen with the lang
+// query parameter is remembered in a cookie, otherwise the language is
+// picked from the browser's Accept-Language header
+func Language(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := ""
+		if chosen := r.URL.Query().Get("lang"); i18n.Supported(chosen) {
+			lang = chosen
+			cookies.SetCookie(w, languageCookie, "/", lang, 365*24*60*60)
+		} else if cookie, err := r.Cookie(languageCookie); err == nil && i18n.Supported(cookie.Value) {
+			lang = cookie.Value
+		} else {
+			lang = i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language"))
+		}
+		if lang == "" {
+			lang = i18n.Default
+		}
+		next.ServeHTTP(w, r.WithContext(contexts.SetLanguage(r.Context(), lang)))
+	})
+}
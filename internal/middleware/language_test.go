@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gosl/pkg/contexts"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguage(t *testing.T) {
+	// Handler to check outcome of Language middleware
+	var got string
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = contexts.GetLanguage(r.Context())
+	})
+	handler := Language(testHandler)
+
+	tests := []struct {
+		name           string
+		query          string
+		cookie         string
+		acceptLanguage string
+		expectedLang   string
+		expectedCookie bool
+	}{
+		{name: "No preference", expectedLang: "en"},
+		{name: "Accept-Language header", acceptLanguage: "de-DE,de;q=0.9", expectedLang: "de"},
+		{name: "Unsupported header", acceptLanguage: "ja", expectedLang: "en"},
+		{name: "Cookie over header", cookie: "es", acceptLanguage: "de", expectedLang: "es"},
+		{name: "Unsupported cookie", cookie: "xx", acceptLanguage: "de", expectedLang: "de"},
+		{
+			name:           "Query parameter is remembered",
+			query:          "?lang=fr",
+			cookie:         "es",
+			expectedLang:   "fr",
+			expectedCookie: true,
+		},
+		{name: "Unsupported query parameter", query: "?lang=xx", cookie: "es", expectedLang: "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: languageCookie, Value: tt.cookie})
+			}
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectedLang, got)
+			setCookie := false
+			for _, cookie := range rec.Result().Cookies() {
+				if cookie.Name == languageCookie {
+					setCookie = true
+					assert.Equal(t, tt.expectedLang, cookie.Value)
+				}
+			}
+			assert.Equal(t, tt.expectedCookie, setCookie)
+		})
+	}
+}
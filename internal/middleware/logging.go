@@ -23,11 +23,6 @@ func (w *wrappedWriter) WriteHeader(statusCode int) {
 // Middleware to add logs to console with details of the request
 func Logging(logger *zerolog.Logger, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/static/css/output.css" ||
-			r.URL.Path == "/static/favicon.ico" {
-			next.ServeHTTP(w, r)
-			return
-		}
 		start, err := contexts.GetStartTime(r.Context())
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -38,6 +33,11 @@ func Logging(logger *zerolog.Logger, next http.Handler) http.Handler {
 			statusCode:     http.StatusOK,
 		}
 		next.ServeHTTP(wrapped, r)
+		observeRequest(r, wrapped.statusCode, time.Since(start))
+		if r.URL.Path == "/static/css/output.css" ||
+			r.URL.Path == "/static/favicon.ico" {
+			return
+		}
 		logger.Info().
 			Int("status", wrapped.statusCode).
 			Str("method", r.Method).
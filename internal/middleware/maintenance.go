@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"gosl/internal/handler"
+	"gosl/internal/maintenance"
+	"gosl/pkg/contexts"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Paths that keep working during maintenance. The admin API is needed to end
+// maintenance and the others don't use the database
+var maintenanceExempt = []string{
+	"/static/",
+	"/healthz",
+	"/readyz",
+	"/metrics",
+	"/admin/",
+}
+
+// Responds with 503 to requests made while maintenance mode is active instead
+// of waiting on the paused database. Full page requests get the error page
+// with the maintenance popup shown
+func Maintenance(mode *maintenance.Mode, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !mode.Active() || maintenanceExemptPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		info := mode.Info()
+		if info.ETA != nil {
+			retry := int(time.Until(*info.ETA).Seconds())
+			if retry > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(retry))
+			}
+		}
+		// htmx shows the popup itself when it gets a 503
+		if r.Header.Get("HX-Request") == "true" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		r = r.WithContext(contexts.SetMaintenance(r.Context()))
+		handler.ErrorPage(http.StatusServiceUnavailable, w, r)
+	})
+}
+
+func maintenanceExemptPath(path string) bool {
+	for _, prefix := range maintenanceExempt {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
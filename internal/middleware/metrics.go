@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"gosl/pkg/metrics"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	httpRequests = metrics.NewCounterVec("gosl_http_requests_total",
+		"HTTP requests served", "method", "route", "status")
+	httpDuration = metrics.NewHistogramVec("gosl_http_request_duration_seconds",
+		"Time taken to serve HTTP requests", nil, "method", "route")
+)
+
+// Record the request in the HTTP metrics. The route is the pattern the
+// request matched so paths containing IDs share a series
+func observeRequest(r *http.Request, status int, elapsed time.Duration) {
+	route := r.Pattern
+	// patterns can start with the method, which is already a label
+	if _, path, found := strings.Cut(route, " "); found {
+		route = path
+	}
+	if route == "" {
+		route = "unmatched"
+	}
+	httpRequests.Inc(r.Method, route, strconv.Itoa(status))
+	httpDuration.ObserveDuration(elapsed, r.Method, route)
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"gosl/pkg/db"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditTeamApplicationApproved = "team_application_approved"
+	AuditTeamApplicationRejected = "team_application_rejected"
+	AuditTeamPlaced              = "team_placed"
+	AuditTeamRenameApproved      = "team_rename_approved"
+	AuditTeamRenameRejected      = "team_rename_rejected"
+	AuditFreeAgentApproved       = "free_agent_approved"
+	AuditFreeAgentDenied         = "free_agent_denied"
+	AuditFreeAgentPlaced         = "free_agent_placed"
+	AuditTransferApproved        = "transfer_approved"
+	AuditTransferDenied          = "transfer_denied"
+	AuditSeasonCreated           = "season_created"
+	AuditSeasonActivated         = "season_activated"
+	AuditSeasonDatesSet          = "season_dates_set"
+	AuditRegistrationToggled     = "registration_toggled"
+	AuditSeasonLeaguesSet        = "season_leagues_set"
+	AuditChannelSet              = "channel_set"
+	AuditRolesSet                = "roles_set"
+	AuditLeagueRoleSet           = "league_role_set"
+	AuditLanguageSet             = "language_set"
+	AuditPlayerJoinedTeam        = "player_joined_team"
+	AuditPlayerLeftTeam          = "player_left_team"
+	AuditPlayerRemovedFromTeam   = "player_removed_from_team"
+	AuditTeamDisbanded           = "team_disbanded"
+	AuditPlayerEdited            = "player_edited"
+	AuditPlayerRelinked          = "player_relinked"
+	AuditPlayerAddedToTeam       = "player_added_to_team"
+	AuditTeamManagerChanged      = "team_manager_changed"
+	AuditTeamUnplaced            = "team_unplaced"
+	AuditFreeAgentUnplaced       = "free_agent_unplaced"
+	AuditInviteDecisionUndone    = "invite_decision_undone"
+)
+
+// Max number of entries returned by GetAuditLog
+const maxAuditLogEntries = 200
+
+// The discord user that performed an audited action
+type AuditActor struct {
+	DiscordID string // discord ID of the user
+	Name      string // discord username of the user
+}
+
+// Model of the audit_log table in the database
+// Each row represents an administration action performed by a discord user
+type AuditEntry struct {
+	ID         uint32     // unique ID
+	Created    *time.Time // time the action was performed
+	Actor      AuditActor // user that performed the action
+	Action     string     // action performed, one of the Audit constants
+	Entity     string     // type of the entity changed, i.e. "team"
+	EntityID   string     // ID of the entity changed
+	TeamID     *uint16    // FK -> Team.ID, team affected by the action if any
+	TeamName   string     // from Team.Name
+	PlayerID   *uint16    // FK -> Player.ID, player affected by the action if any
+	PlayerName string     // from Player.Name
+	Summary    string     // human readable description of the action
+	Before     string     // JSON of the entity before the action, empty if none
+	After      string     // JSON of the entity after the action, empty if none
+}
+
+// Filters for searching the audit log. Empty filters are ignored
+type AuditFilter struct {
+	TeamID   *uint16 // only entries affecting the team
+	PlayerID *uint16 // only entries affecting the player
+	ActorID  string  // only entries performed by the discord user
+	Limit    int     // max number of entries to return, up to 200
+}
+
+// Record an action in the guild's audit log. The actor, action, entity,
+// summary and affected team/player are taken from the entry. Must use the same transaction
+// as the change being audited so the entry is only kept if the change is
+// committed. Before and after are stored as JSON and can be nil
+func RecordAudit(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	entry *AuditEntry,
+	before any,
+	after any,
+) error {
+	beforeJSON, err := auditJSON(before)
+	if err != nil {
+		return errors.Wrap(err, "auditJSON (before)")
+	}
+	afterJSON, err := auditJSON(after)
+	if err != nil {
+		return errors.Wrap(err, "auditJSON (after)")
+	}
+	query := `
+INSERT INTO audit_log (guild_id, created, actor_id, actor_name, action, entity,
+    entity_id, team_id, player_id, summary, before, after)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	now := time.Now()
+	_, err = tx.Exec(ctx, query, guildID, formatISO8601(&now), entry.Actor.DiscordID,
+		entry.Actor.Name, entry.Action, entry.Entity, entry.EntityID,
+		entry.TeamID, entry.PlayerID, entry.Summary, beforeJSON, afterJSON)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Get the entries in the guild's audit log matching the filter, newest first
+func GetAuditLog(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+	filter AuditFilter,
+) (*[]AuditEntry, error) {
+	limit := filter.Limit
+	if limit <= 0 || limit > maxAuditLogEntries {
+		limit = maxAuditLogEntries
+	}
+	query := `
+SELECT a.id, a.created, a.actor_id, a.actor_name, a.action, a.entity,
+    a.entity_id, a.team_id, COALESCE(t.name, ''), a.player_id,
+    COALESCE(p.name, ''), a.summary, COALESCE(a.before, ''),
+    COALESCE(a.after, '')
+FROM audit_log a
+LEFT JOIN team t ON a.team_id = t.id
+LEFT JOIN player p ON a.player_id = p.id
+WHERE a.guild_id = ?1
+AND (?2 IS NULL OR a.team_id = ?2)
+AND (?3 IS NULL OR a.player_id = ?3)
+AND (?4 = '' OR a.actor_id = ?4)
+ORDER BY a.id DESC
+LIMIT ?5;`
+	rows, err := tx.Query(ctx, query, guildID, filter.TeamID, filter.PlayerID,
+		filter.ActorID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	entries := []AuditEntry{}
+	for rows.Next() {
+		var entry AuditEntry
+		var created string
+		var teamID, playerID sql.NullInt32
+		err = rows.Scan(&entry.ID, &created, &entry.Actor.DiscordID,
+			&entry.Actor.Name, &entry.Action, &entry.Entity, &entry.EntityID,
+			&teamID, &entry.TeamName, &playerID, &entry.PlayerName,
+			&entry.Summary, &entry.Before, &entry.After)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		entry.Created = parseISO8601(&created)
+		if teamID.Valid {
+			id := uint16(teamID.Int32)
+			entry.TeamID = &id
+		}
+		if playerID.Valid {
+			id := uint16(playerID.Int32)
+			entry.PlayerID = &id
+		}
+		entries = append(entries, entry)
+	}
+	return &entries, nil
+}
+
+// Marshal the value to JSON for the audit log. Returns nil if the value is nil
+func auditJSON(value any) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+	str := string(data)
+	return &str, nil
+}
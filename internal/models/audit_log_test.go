@@ -0,0 +1,115 @@
+package models_test
+
+import (
+	"gosl/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	conn, cfg := testConn(t)
+	ctx := t.Context()
+
+	tx, err := conn.Begin(ctx, "TestAuditLog setup")
+	require.NoError(t, err)
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1001, "1", "Manager"))
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1002, "2", "Player"))
+	manager, err := models.GetPlayerBySlapID(ctx, tx, 1001)
+	require.NoError(t, err)
+	player, err := models.GetPlayerBySlapID(ctx, tx, 1002)
+	require.NoError(t, err)
+	team, err := models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Auditors", "AUD", manager.ID)
+	require.NoError(t, err)
+	staff := models.AuditActor{DiscordID: "900", Name: "staff"}
+	other := models.AuditActor{DiscordID: "901", Name: "other"}
+	require.NoError(t, models.RecordAudit(ctx, tx, cfg.DiscordGuildID, &models.AuditEntry{
+		Actor: staff, Action: models.AuditTeamPlaced, Entity: "team",
+		EntityID: "1", TeamID: &team.ID, Summary: "Auditors placed",
+	}, nil, map[string]string{"league": "Open"}))
+	require.NoError(t, models.RecordAudit(ctx, tx, cfg.DiscordGuildID, &models.AuditEntry{
+		Actor: other, Action: models.AuditPlayerEdited, Entity: "player",
+		EntityID: "2", PlayerID: &player.ID, Summary: "Player renamed",
+	}, map[string]string{"name": "Old"}, map[string]string{"name": "Player"}))
+	require.NoError(t, models.RecordAudit(ctx, tx, cfg.DiscordGuildID, &models.AuditEntry{
+		Actor: staff, Action: models.AuditPlayerAddedToTeam, Entity: "player_team",
+		EntityID: "2", TeamID: &team.ID, PlayerID: &player.ID, Summary: "Player added",
+	}, nil, nil))
+	tx.Commit()
+
+	guildActions := func(guildID string, filter models.AuditFilter) []string {
+		rtx, err := conn.RBegin(ctx, "TestAuditLog")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		entries, err := models.GetAuditLog(ctx, rtx, guildID, filter)
+		require.NoError(t, err)
+		found := []string{}
+		for _, entry := range *entries {
+			found = append(found, entry.Action)
+		}
+		return found
+	}
+	actions := func(filter models.AuditFilter) []string {
+		return guildActions(cfg.DiscordGuildID, filter)
+	}
+
+	t.Run("Entries are recorded with their changes, newest first", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestAuditLog")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		entries, err := models.GetAuditLog(ctx, rtx, cfg.DiscordGuildID, models.AuditFilter{})
+		require.NoError(t, err)
+		require.Len(t, *entries, 3)
+		added := (*entries)[0]
+		assert.Equal(t, models.AuditPlayerAddedToTeam, added.Action)
+		assert.Equal(t, "Auditors", added.TeamName)
+		assert.Equal(t, "Player", added.PlayerName)
+		assert.Empty(t, added.Before)
+		assert.Empty(t, added.After)
+		assert.NotNil(t, added.Created)
+		edited := (*entries)[1]
+		assert.Equal(t, other, edited.Actor)
+		assert.JSONEq(t, `{"name": "Old"}`, edited.Before)
+		assert.JSONEq(t, `{"name": "Player"}`, edited.After)
+	})
+
+	t.Run("Entries are filtered by team, player and actor", func(t *testing.T) {
+		assert.Equal(t, []string{models.AuditPlayerAddedToTeam, models.AuditTeamPlaced},
+			actions(models.AuditFilter{TeamID: &team.ID}))
+		assert.Equal(t, []string{models.AuditPlayerAddedToTeam, models.AuditPlayerEdited},
+			actions(models.AuditFilter{PlayerID: &player.ID}))
+		assert.Equal(t, []string{models.AuditPlayerEdited},
+			actions(models.AuditFilter{ActorID: "901"}))
+		assert.Equal(t, []string{models.AuditPlayerAddedToTeam},
+			actions(models.AuditFilter{TeamID: &team.ID, ActorID: "900", PlayerID: &player.ID}))
+		assert.Equal(t, []string{models.AuditPlayerAddedToTeam},
+			actions(models.AuditFilter{Limit: 1}))
+	})
+
+	t.Run("Entries are only shown to the guild they were recorded in", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestAuditLog other guild")
+		require.NoError(t, err)
+		require.NoError(t, models.RecordAudit(ctx, tx, "other-guild", &models.AuditEntry{
+			Actor: staff, Action: models.AuditSeasonCreated, Entity: "season",
+			EntityID: "1", Summary: "Season created",
+		}, nil, nil))
+		tx.Commit()
+		assert.Equal(t, []string{models.AuditSeasonCreated},
+			guildActions("other-guild", models.AuditFilter{}))
+		assert.NotContains(t, actions(models.AuditFilter{}), models.AuditSeasonCreated)
+		assert.Equal(t, []string{models.AuditSeasonCreated},
+			guildActions("other-guild", models.AuditFilter{ActorID: "900"}))
+	})
+
+	t.Run("Entries are only kept if the change is committed", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestAuditLog rollback")
+		require.NoError(t, err)
+		require.NoError(t, models.RecordAudit(ctx, tx, cfg.DiscordGuildID, &models.AuditEntry{
+			Actor: staff, Action: models.AuditTeamDisbanded, Entity: "team",
+			EntityID: "1", TeamID: &team.ID, Summary: "Auditors disbanded",
+		}, nil, nil))
+		tx.Rollback()
+		assert.Len(t, actions(models.AuditFilter{}), 3)
+	})
+}
@@ -9,29 +9,45 @@ import (
 )
 
 const (
-	ChannelAdmin                 uint16 = 1 // Channel used for admin panel
-	ChannelLog                   uint16 = 2 // Channel used for logging
-	ChannelManager               uint16 = 3 // Channel used for league manager panel
-	ChannelRegistration          uint16 = 4 // Channel used for player and team registrations
-	ChannelTeamApplications      uint16 = 5 // Channel used for approving team applications
-	ChannelTeamRosters           uint16 = 6 // Channel used for viewing team rosters
-	ChannelFreeAgentApplications uint16 = 7 // Channel used for approving freeagent apps
-	ChannelTransferApprovals     uint16 = 8 // Channel used for approving tranfers
-	ChannelTeamLogos             uint16 = 9 // Channel for bot to upload team logos
+	ChannelAdmin                 uint16 = 1  // Channel used for admin panel
+	ChannelLog                   uint16 = 2  // Channel used for logging
+	ChannelManager               uint16 = 3  // Channel used for league manager panel
+	ChannelRegistration          uint16 = 4  // Channel used for player and team registrations
+	ChannelTeamApplications      uint16 = 5  // Channel used for approving team applications
+	ChannelTeamRosters           uint16 = 6  // Channel used for viewing team rosters
+	ChannelFreeAgentApplications uint16 = 7  // Channel used for approving freeagent apps
+	ChannelTransferApprovals     uint16 = 8  // Channel used for approving tranfers
+	ChannelTeamLogos             uint16 = 9  // Channel for bot to upload team logos
+	ChannelTransfers             uint16 = 10 // Channel for the transfers this window feed
 )
 
-// Add a channel to the database with the provided purpose
-func AddChannel(ctx context.Context, tx *db.SafeWTX, channelID string, purpose uint16) error {
-	query := `INSERT INTO config_channels (channel_id, purpose) VALUES (?, ?) ON CONFLICT DO NOTHING;`
-	_, err := tx.Exec(ctx, query, channelID, purpose)
+// Add a channel in the guild to the database with the provided purpose
+func AddChannel(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	channelID string,
+	purpose uint16,
+) error {
+	query := `
+INSERT INTO config_channels (guild_id, channel_id, purpose) VALUES (?, ?, ?)
+ON CONFLICT DO NOTHING;`
+	_, err := tx.Exec(ctx, query, guildID, channelID, purpose)
 	return err
 }
 
-// Set a channel in the database as the only channel with the provided purpose
-func SetChannel(ctx context.Context, tx *db.SafeWTX, channelID string, purpose uint16) error {
+// Set a channel in the database as the only channel in the guild with the
+// provided purpose
+func SetChannel(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	channelID string,
+	purpose uint16,
+) error {
 	var count int
-	query := `SELECT COUNT(*) FROM config_channels WHERE purpose = ?;`
-	row, err := tx.QueryRow(ctx, query, purpose)
+	query := `SELECT COUNT(*) FROM config_channels WHERE guild_id = ? AND purpose = ?;`
+	row, err := tx.QueryRow(ctx, query, guildID, purpose)
 	if err != nil {
 		return errors.Wrap(err, "tx.QueryRow")
 	}
@@ -41,11 +57,11 @@ func SetChannel(ctx context.Context, tx *db.SafeWTX, channelID string, purpose u
 	}
 	switch {
 	case count == 1:
-		query = `UPDATE config_channels SET channel_id = ? WHERE purpose = ?;`
-		_, err = tx.Exec(ctx, query, channelID, purpose)
+		query = `UPDATE config_channels SET channel_id = ? WHERE guild_id = ? AND purpose = ?;`
+		_, err = tx.Exec(ctx, query, channelID, guildID, purpose)
 	case count == 0:
-		query = `INSERT INTO config_channels (channel_id, purpose) VALUES (?,?);`
-		_, err = tx.Exec(ctx, query, channelID, purpose)
+		query = `INSERT INTO config_channels (guild_id, channel_id, purpose) VALUES (?,?,?);`
+		_, err = tx.Exec(ctx, query, guildID, channelID, purpose)
 	default:
 		return errors.Errorf("Invalid row count for purpose %v. Expecting 0 or 1, got %v", purpose, count)
 	}
@@ -55,21 +71,29 @@ func SetChannel(ctx context.Context, tx *db.SafeWTX, channelID string, purpose u
 	return nil
 }
 
-// Remove a channel from the database with the provided purpose
-func RemoveChannel(ctx context.Context, tx *db.SafeWTX, channelID string, purpose uint16) error {
-	query := `DELETE FROM config_channels WHERE channel_id = ? AND purpose = ?;`
-	_, err := tx.Exec(ctx, query, channelID, purpose)
+// Remove a channel in the guild from the database with the provided purpose
+func RemoveChannel(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	channelID string,
+	purpose uint16,
+) error {
+	query := `DELETE FROM config_channels WHERE guild_id = ? AND channel_id = ? AND purpose = ?;`
+	_, err := tx.Exec(ctx, query, guildID, channelID, purpose)
 	return err
 }
 
-// Get a single channel that has the purpose provided set in the database
+// Get a single channel in the guild that has the purpose provided set in the
+// database
 func GetChannel(
 	ctx context.Context,
 	tx db.SafeTX,
+	guildID string,
 	purpose uint16,
 ) (string, error) {
-	query := `SELECT channel_id FROM config_channels WHERE purpose = ? LIMIT 1;`
-	row, err := tx.QueryRow(ctx, query, purpose)
+	query := `SELECT channel_id FROM config_channels WHERE guild_id = ? AND purpose = ? LIMIT 1;`
+	row, err := tx.QueryRow(ctx, query, guildID, purpose)
 	if err != nil {
 		return "", err
 	}
@@ -84,14 +108,15 @@ func GetChannel(
 	return channelID, nil
 }
 
-// Get all the channels from the database with the provided purpose
+// Get all the channels in the guild from the database with the provided purpose
 func GetChannels(
 	ctx context.Context,
 	tx db.SafeTX,
+	guildID string,
 	purpose uint16,
 ) ([]string, error) {
-	query := `SELECT channel_id FROM config_channels WHERE purpose = ?;`
-	rows, err := tx.Query(ctx, query, purpose)
+	query := `SELECT channel_id FROM config_channels WHERE guild_id = ? AND purpose = ?;`
+	rows, err := tx.Query(ctx, query, guildID, purpose)
 	if err != nil {
 		return nil, errors.Wrap(err, "tx.Query")
 	}
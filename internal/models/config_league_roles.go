@@ -0,0 +1,186 @@
+package models
+
+import (
+	"context"
+	"gosl/pkg/db"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Key used in config_league_roles for the free agent role. All other keys
+// are league divisions
+const LeagueRoleFreeAgent = "FreeAgent"
+
+// Set the discord role in the guild given to players in the league. If roleID
+// is empty the role is removed
+func SetLeagueRole(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	league string,
+	roleID string,
+) error {
+	if league != "Open" && league != "IM" && league != "Pro" &&
+		league != LeagueRoleFreeAgent {
+		return errors.New("Invalid league, must be 'Open', 'IM', 'Pro' or 'FreeAgent'")
+	}
+	if roleID == "" {
+		query := `DELETE FROM config_league_roles WHERE guild_id = ? AND league = ?;`
+		_, err := tx.Exec(ctx, query, guildID, league)
+		if err != nil {
+			return errors.Wrap(err, "tx.Exec")
+		}
+		return nil
+	}
+	query := `
+INSERT INTO config_league_roles(guild_id, league, role_id) VALUES (?, ?, ?)
+ON CONFLICT(guild_id, league) DO UPDATE SET role_id = excluded.role_id;`
+	_, err := tx.Exec(ctx, query, guildID, league, roleID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Get the discord roles in the guild for each league, mapped by league
+// division. The free agent role is mapped by LeagueRoleFreeAgent
+func GetLeagueRoles(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+) (map[string]string, error) {
+	query := `SELECT league, role_id FROM config_league_roles WHERE guild_id = ?;`
+	rows, err := tx.Query(ctx, query, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	roles := map[string]string{}
+	for rows.Next() {
+		var league, roleID string
+		err = rows.Scan(&league, &roleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		roles[league] = roleID
+	}
+	return roles, nil
+}
+
+// Get the discord users that have been given each league role in the guild by
+// the bot, mapped by role ID
+func GetLeagueRoleMembers(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+) (map[string][]string, error) {
+	query := `SELECT role_id, discord_id FROM league_role_member WHERE guild_id = ?;`
+	rows, err := tx.Query(ctx, query, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	members := map[string][]string{}
+	for rows.Next() {
+		var roleID, discordID string
+		err = rows.Scan(&roleID, &discordID)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		members[roleID] = append(members[roleID], discordID)
+	}
+	return members, nil
+}
+
+// Record that the bot has given the role in the guild to the discord user
+func AddLeagueRoleMember(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	roleID string,
+	discordID string,
+) error {
+	query := `
+INSERT INTO league_role_member(guild_id, role_id, discord_id) VALUES (?, ?, ?)
+ON CONFLICT DO NOTHING;`
+	_, err := tx.Exec(ctx, query, guildID, roleID, discordID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Record that the bot has taken the role from the discord user
+func RemoveLeagueRoleMember(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	roleID string,
+	discordID string,
+) error {
+	query := `DELETE FROM league_role_member WHERE role_id = ? AND discord_id = ?;`
+	_, err := tx.Exec(ctx, query, roleID, discordID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Get the league roles each discord user should have in the guild, based on
+// the teams and free agents placed in its active season. Returns an empty map
+// if there is no active season or the season has finished
+func GetLeagueRoleAssignments(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+) (map[string][]string, error) {
+	assignments := map[string][]string{}
+	season, err := GetActiveSeason(ctx, tx, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetActiveSeason")
+	}
+	if season == nil {
+		return assignments, nil
+	}
+	if season.FinalsEnd != nil && time.Now().After(*season.FinalsEnd) {
+		return assignments, nil
+	}
+	roles, err := GetLeagueRoles(ctx, tx, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetLeagueRoles")
+	}
+	query := `
+SELECT p.discord_id, l.division, 0 FROM team_league tl
+JOIN league l ON tl.league_id = l.id
+JOIN player_team pt ON pt.team_id = tl.team_id AND pt.left IS NULL
+JOIN player p ON pt.player_id = p.id
+WHERE l.season_id = ? AND l.enabled = 1
+UNION
+SELECT p.discord_id, l.division, 1 FROM free_agent fa
+JOIN league l ON fa.league_id = l.id
+JOIN player p ON fa.player_id = p.id
+WHERE l.season_id = ? AND l.enabled = 1
+AND NOT EXISTS (
+    SELECT 1 FROM player_team pt WHERE pt.player_id = p.id AND pt.left IS NULL
+);`
+	rows, err := tx.Query(ctx, query, season.ID, season.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	for rows.Next() {
+		var discordID, division string
+		var freeAgent uint16
+		err = rows.Scan(&discordID, &division, &freeAgent)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		if discordID == "" {
+			continue
+		}
+		if roleID, exists := roles[division]; exists {
+			assignments[roleID] = append(assignments[roleID], discordID)
+		}
+		if roleID, exists := roles[LeagueRoleFreeAgent]; exists && freeAgent == 1 {
+			assignments[roleID] = append(assignments[roleID], discordID)
+		}
+	}
+	return assignments, nil
+}
@@ -10,9 +10,13 @@ import (
 
 const (
 	// Admin channel messages
-	MsgSelectLogChannel uint16 = 1 // select log channel message
-	MsgSelectRoles      uint16 = 2 // select manager roles message
-	MsgSelectChannels   uint16 = 3 // select registration channel message
+	MsgSelectLogChannel  uint16 = 1 // select log channel message
+	MsgSelectRoles       uint16 = 2 // select manager roles message
+	MsgSelectChannels    uint16 = 3 // select registration channel message
+	MsgSelectLeagueRoles uint16 = 4 // select league roles message
+	MsgBackups           uint16 = 5 // database backups message
+	MsgMaintenance       uint16 = 6 // maintenance mode message
+	MsgSelectLanguage    uint16 = 7 // select default language message
 
 	// Manager channel messages
 	MsgSelectSeason uint16 = 11 // select season message
@@ -35,26 +39,30 @@ const (
 
 	// Free agent applications channel messages
 	MsgFreeAgentAppsInfo uint16 = 61 // free agent applications channel information
+
+	// Transfers channel messages
+	MsgTransfers uint16 = 71 // players that joined or left teams this transfer window
 )
 
 // Set the provided message as the message used for the provided purpose
-// Only one message can be used for a given purpose at a time
+// Only one message can be used for a given purpose in each guild at a time
 // Setting a new message will overwrite the previous one
 func SetMessage(
 	ctx context.Context,
 	tx *db.SafeWTX,
+	guildID string,
 	messageID string,
 	channelID string,
 	purpose uint16,
 ) error {
 	query := `
-INSERT INTO config_messages (message_id, channel_id, purpose) 
-VALUES (?, ?, ?) 
-ON CONFLICT(purpose) DO UPDATE
+INSERT INTO config_messages (guild_id, message_id, channel_id, purpose) 
+VALUES (?, ?, ?, ?) 
+ON CONFLICT(guild_id, purpose) DO UPDATE
 SET message_id = excluded.message_id,
     channel_id = excluded.channel_id;
 `
-	_, err := tx.Exec(ctx, query, messageID, channelID, purpose)
+	_, err := tx.Exec(ctx, query, guildID, messageID, channelID, purpose)
 	return err
 }
 
@@ -62,30 +70,33 @@ SET message_id = excluded.message_id,
 func RemoveMessage(
 	ctx context.Context,
 	tx *db.SafeWTX,
+	guildID string,
 	messageID string,
 	channelID string,
 	purpose uint16,
 ) error {
 	query := `
-DELETE FROM config_messages WHERE message_id = ? AND channel_id = ? AND purpose = ?;
+DELETE FROM config_messages
+WHERE guild_id = ? AND message_id = ? AND channel_id = ? AND purpose = ?;
 `
-	_, err := tx.Exec(ctx, query, messageID, channelID, purpose)
+	_, err := tx.Exec(ctx, query, guildID, messageID, channelID, purpose)
 	return err
 }
 
-// Get the message that has been set for the provided purpose
+// Get the message that has been set in the guild for the provided purpose
 // Returns messageID, channelID, err
 func GetMessageForPurpose(
 	ctx context.Context,
 	tx db.SafeTX,
+	guildID string,
 	purpose uint16,
 ) (string, string, error) {
 	query := `
-SELECT message_id, channel_id FROM config_messages WHERE purpose = ?;
+SELECT message_id, channel_id FROM config_messages WHERE guild_id = ? AND purpose = ?;
 `
 	var messageID string
 	var channelID string
-	row, err := tx.QueryRow(ctx, query, purpose)
+	row, err := tx.QueryRow(ctx, query, guildID, purpose)
 	if err != nil {
 		return "", "", errors.Wrap(err, "tx.QueryRow")
 	}
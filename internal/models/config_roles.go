@@ -15,28 +15,33 @@ const (
 	PermLeagueManager uint16 = 2 // League Manager permission
 )
 
-// Add a permission to the provided role
-func AddPermission(ctx context.Context, tx *db.SafeWTX, roleid string, perm uint16) error {
+// Add a permission to the provided role in the guild
+func AddPermission(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	roleid string,
+	perm uint16,
+) error {
 	query := `
-INSERT INTO config_roles (role_id, permission) 
-VALUES (?, ?) ON CONFLICT DO NOTHING;
+INSERT INTO config_roles (guild_id, role_id, permission) 
+VALUES (?, ?, ?) ON CONFLICT DO NOTHING;
 `
-	_, err := tx.Exec(ctx, query, roleid, perm)
+	_, err := tx.Exec(ctx, query, guildID, roleid, perm)
 	return err
 }
-func Remove(ctx context.Context, tx *db.SafeWTX, roleid string, perm uint16) error {
+func Remove(ctx context.Context, tx *db.SafeWTX, guildID string, roleid string, perm uint16) error {
 	query := `
-DELETE FROM config_roles WHERE role_id = ? AND permission = ?;
+DELETE FROM config_roles WHERE guild_id = ? AND role_id = ? AND permission = ?;
 `
-	_, err := tx.Exec(ctx, query, roleid, perm)
+	_, err := tx.Exec(ctx, query, guildID, roleid, perm)
 	return err
 }
 
-// Check if the provided role has the provided permission
+// Check if the member has the provided permission in the guild
 func MemberHasPermission(
 	ctx context.Context,
 	tx db.SafeTX,
-	s *discordgo.Session,
 	guildID string,
 	member *discordgo.Member,
 	permid uint16,
@@ -50,13 +55,15 @@ func MemberHasPermission(
 	}
 	query := `
 SELECT 1 FROM config_roles WHERE 
+    guild_id = ? AND
     permission = ? AND 
     role_id IN (` + strings.Repeat("?,", len(member.Roles)-1) + `? ) LIMIT 1;
 `
-	args := make([]any, len(member.Roles)+1)
-	args[0] = permid
+	args := make([]any, len(member.Roles)+2)
+	args[0] = guildID
+	args[1] = permid
 	for i, roleID := range member.Roles {
-		args[i+1] = roleID
+		args[i+2] = roleID
 	}
 	var exists int
 	row, err := tx.QueryRow(ctx, query, args...)
@@ -70,14 +77,15 @@ SELECT 1 FROM config_roles WHERE
 	return err == nil, err
 }
 
-// Get all roles with the provided permission
+// Get all roles in the guild with the provided permission
 func GetRoles(
 	ctx context.Context,
 	tx db.SafeTX,
+	guildID string,
 	permid uint16,
 ) ([]string, error) {
-	query := `SELECT role_id FROM config_roles WHERE permission = ?;`
-	rows, err := tx.Query(ctx, query, permid)
+	query := `SELECT role_id FROM config_roles WHERE guild_id = ? AND permission = ?;`
+	rows, err := tx.Query(ctx, query, guildID, permid)
 	if err != nil {
 		return nil, errors.Wrap(err, "tx.Query")
 	}
@@ -95,24 +103,25 @@ func GetRoles(
 }
 
 // Grants the permission to the provided roles and removes it from any roles
-// not provided
+// in the guild not provided
 func SetRoles(
 	ctx context.Context,
 	tx *db.SafeWTX,
+	guildID string,
 	roles []string,
 	permid uint16,
 ) error {
-	args := make([]any, 0, len(roles)+1)
-	query := `DELETE FROM config_roles WHERE permission = ?`
-	args = []any{permid}
+	args := make([]any, 0, len(roles)+2)
+	query := `DELETE FROM config_roles WHERE guild_id = ? AND permission = ?`
+	args = append(args, guildID, permid)
 	if len(roles) != 0 {
 		query = `
-        DELETE FROM config_roles WHERE permission = ?
+        DELETE FROM config_roles WHERE guild_id = ? AND permission = ?
         AND role_id NOT IN (` + strings.Repeat("?,", len(roles)-1) + `?);
         `
 		for _, role := range roles {
 			args = append(args, role)
-			err := AddPermission(ctx, tx, role, permid)
+			err := AddPermission(ctx, tx, guildID, role, permid)
 			if err != nil {
 				return errors.Wrap(err, "addPermission")
 			}
@@ -179,3 +179,24 @@ SELECT EXISTS (
 	fa.Placed = leagueID
 	return nil
 }
+
+// Remove the player from the league they were placed in so they can be placed
+// again
+func (fa *FreeAgentRegistration) Unplace(ctx context.Context, tx *db.SafeWTX) error {
+	if fa.Placed == 0 {
+		return errors.New("VE:Player has not been placed into a League")
+	}
+	query := `DELETE FROM free_agent WHERE player_id = ? AND league_id = ?;`
+	_, err := tx.Exec(ctx, query, fa.PlayerID, fa.Placed)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	query = `UPDATE free_agent_registration SET placed = 0 WHERE id = ?;`
+	_, err = tx.Exec(ctx, query, fa.ID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	fa.Placed = 0
+	fa.PlacedLeagueName = "Not yet placed"
+	return nil
+}
@@ -6,15 +6,6 @@ import (
 	"time"
 )
 
-// Set the timezone for the time to the configured locale
-// Preserves the time of day. E.g. if the time provided has timestamp at midnight
-// the returned time will be at midnight in the specified locale
-func TimeInLocale(t *time.Time, locale string) *time.Time {
-	loc, _ := time.LoadLocation(locale)
-	dateInTZ := t.In(loc)
-	return &dateInTZ
-}
-
 // Converts a string value in format "2006-01-02T15:04:05Z07:00" to time.Time.
 // If nil value provided, will return nil
 func parseISO8601(isostr *string) *time.Time {
@@ -37,13 +28,13 @@ func uint16ToBool(u uint16) bool {
 	}
 }
 
-// Parses string in format "02/01/2006" to time.Time
-func parseTextDate(datestr string) *time.Time {
+// Parses string in format "02/01/2006" to time.Time at midnight in the location
+func parseTextDate(datestr string, loc *time.Location) *time.Time {
 	format := "02/01/2006"
 	if datestr == "" {
 		return nil
 	}
-	parsed, err := time.Parse(format, datestr)
+	parsed, err := time.ParseInLocation(format, datestr, loc)
 	if err != nil {
 		return nil
 	}
@@ -68,6 +59,15 @@ func DateStr(t *time.Time) string {
 	return formatted
 }
 
+// Formats time.Time to format "02/01/2006" as the date in the location
+func DateStrIn(t *time.Time, loc *time.Location) string {
+	if t == nil {
+		return ""
+	}
+	inLoc := t.In(loc)
+	return DateStr(&inLoc)
+}
+
 // Parses a hex string to an integer. E.g. color hex codes #00FF00 -> 65280
 func hexToInt(hexStr string) (int, error) {
 	if strings.HasPrefix(hexStr, "#") {
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"context"
+	"gosl/pkg/db"
+
+	"github.com/pkg/errors"
+)
+
+// Tables with a guild_id column. Rows created before the bot supported
+// multiple guilds have an empty guild_id
+var guildTables = []string{
+	"audit_log",
+	"config_roles",
+	"config_channels",
+	"config_messages",
+	"config_league_roles",
+	"league_role_member",
+	"season",
+	"team",
+	"tracked_message",
+}
+
+// Assign all the rows that don't belong to a guild to the provided guild.
+// Returns the number of rows claimed
+func ClaimUnassignedGuildData(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+) (int64, error) {
+	var claimed int64
+	for _, table := range guildTables {
+		query := `UPDATE ` + table + ` SET guild_id = ? WHERE guild_id = '';`
+		res, err := tx.Exec(ctx, query, guildID)
+		if err != nil {
+			return 0, errors.Wrap(err, "tx.Exec ("+table+")")
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return 0, errors.Wrap(err, "res.RowsAffected")
+		}
+		claimed += rows
+	}
+	return claimed, nil
+}
@@ -0,0 +1,24 @@
+package models_test
+
+import (
+	"gosl/pkg/config"
+	"gosl/pkg/db"
+	"gosl/pkg/tests"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Get a connection to a fresh test database at the current version
+func testConn(t *testing.T) (*db.SafeConn, *config.Config) {
+	cfg, err := tests.TestConfig()
+	require.NoError(t, err)
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
+	require.NoError(t, err)
+	wconn, rconn, err := tests.SetupTestDB(ver)
+	require.NoError(t, err)
+	conn := db.MakeSafe(wconn, rconn, tests.NilLogger())
+	t.Cleanup(func() { conn.Close() })
+	return conn, cfg
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"context"
+	"gosl/pkg/db"
+
+	"github.com/pkg/errors"
+)
+
+// Set the default language of the guild. If language is empty the default
+// language of the bot is used
+func SetGuildLanguage(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	language string,
+) error {
+	if language == "" {
+		query := `DELETE FROM config_guild_language WHERE guild_id = ?;`
+		_, err := tx.Exec(ctx, query, guildID)
+		if err != nil {
+			return errors.Wrap(err, "tx.Exec")
+		}
+		return nil
+	}
+	query := `
+INSERT INTO config_guild_language(guild_id, language) VALUES (?, ?)
+ON CONFLICT(guild_id) DO UPDATE SET language = excluded.language;`
+	_, err := tx.Exec(ctx, query, guildID, language)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Get the default language of every guild that has one set, mapped by
+// guild ID
+func GetGuildLanguages(ctx context.Context, tx db.SafeTX) (map[string]string, error) {
+	query := `SELECT guild_id, language FROM config_guild_language;`
+	return scanLanguages(ctx, tx, query)
+}
+
+// Set the language the user has chosen. If language is empty the user's
+// choice is removed
+func SetUserLanguage(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	discordID string,
+	language string,
+) error {
+	if language == "" {
+		query := `DELETE FROM user_language WHERE discord_id = ?;`
+		_, err := tx.Exec(ctx, query, discordID)
+		if err != nil {
+			return errors.Wrap(err, "tx.Exec")
+		}
+		return nil
+	}
+	query := `
+INSERT INTO user_language(discord_id, language) VALUES (?, ?)
+ON CONFLICT(discord_id) DO UPDATE SET language = excluded.language;`
+	_, err := tx.Exec(ctx, query, discordID, language)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Get the language chosen by every user that has chosen one, mapped by
+// discord ID
+func GetUserLanguages(ctx context.Context, tx db.SafeTX) (map[string]string, error) {
+	query := `SELECT discord_id, language FROM user_language;`
+	return scanLanguages(ctx, tx, query)
+}
+
+func scanLanguages(ctx context.Context, tx db.SafeTX, query string) (map[string]string, error) {
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	languages := map[string]string{}
+	for rows.Next() {
+		var id, language string
+		err = rows.Scan(&id, &language)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		languages[id] = language
+	}
+	return languages, nil
+}
@@ -18,6 +18,9 @@ type Player struct {
 	SlapID    uint32 // unique slapshot player ID
 	Name      string // unique player name
 	DiscordID string // unique discord ID
+	// in-game details from the slapshot API. nil if not synced yet or not
+	// loaded by the query used to get the player
+	Slapshot *SlapshotInfo
 }
 
 func GetPlayerByID(
@@ -89,6 +92,33 @@ func GetPlayerBySlapID(
 	return &player, nil
 }
 
+// Searches the database for a player with the given name, ignoring case. If
+// none found returns nil
+func GetPlayerByName(
+	ctx context.Context,
+	tx db.SafeTX,
+	name string,
+) (*Player, error) {
+	query := `SELECT id FROM player WHERE name = ? COLLATE NOCASE;`
+	row, err := tx.QueryRow(ctx, query, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	var id uint16
+	err = row.Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	player, err := GetPlayerByID(ctx, tx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetPlayerByID")
+	}
+	return player, nil
+}
+
 func CreatePlayer(
 	ctx context.Context,
 	tx *db.SafeWTX,
@@ -145,6 +175,42 @@ AND NOT EXISTS (
 	return &players, nil
 }
 
+// Set the display name of the player. Names must be unique
+func (p *Player) UpdateName(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	name string,
+) error {
+	query := `UPDATE player SET name = ? WHERE id = ?;`
+	_, err := tx.Exec(ctx, query, name, p.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return errors.New(fmt.Sprintf("VE:Display name must be unique: '%s' is taken", name))
+		}
+		return errors.Wrap(err, "tx.Exec")
+	}
+	p.Name = name
+	return nil
+}
+
+// Set the slapshot ID of the player. IDs must be unique
+func (p *Player) UpdateSlapID(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	slapID uint32,
+) error {
+	query := `UPDATE player SET slap_id = ? WHERE id = ?;`
+	_, err := tx.Exec(ctx, query, slapID, p.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return errors.New(fmt.Sprintf("VE:Slap ID %v belongs to another player", slapID))
+		}
+		return errors.Wrap(err, "tx.Exec")
+	}
+	p.SlapID = slapID
+	return nil
+}
+
 func (p *Player) UpdateDiscordID(
 	ctx context.Context,
 	tx *db.SafeWTX,
@@ -153,6 +219,9 @@ func (p *Player) UpdateDiscordID(
 	query := `UPDATE player SET discord_id = ? WHERE slap_id = ?;`
 	_, err := tx.Exec(ctx, query, discordID, p.SlapID)
 	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return errors.New("VE:Discord account is linked to another player")
+		}
 		return errors.Wrap(err, "tx.Exec")
 	}
 	p.DiscordID = discordID
@@ -193,10 +262,27 @@ WHERE pt.player_id = ? AND pt.joined < ? AND pt.left IS NULL;`
 	return &team, nil
 }
 
+// Add the player to the team now. The reason is one of the Move constants, and
+// inviteID is the invite that moved the player onto the team, if any
 func (p *Player) JoinTeam(
 	ctx context.Context,
 	tx *db.SafeWTX,
 	teamid uint16,
+	reason string,
+	inviteID *uint32,
+) error {
+	return p.JoinTeamAt(ctx, tx, teamid, time.Now(), reason, inviteID)
+}
+
+// Add the player to the team as of the joined time, which can be in the past.
+// Fails if the player is on a team, or was on a team after the joined time
+func (p *Player) JoinTeamAt(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	teamid uint16,
+	joined time.Time,
+	reason string,
+	inviteID *uint32,
 ) error {
 	currentTeam, err := p.CurrentTeam(ctx, tx)
 	if err != nil {
@@ -212,19 +298,47 @@ func (p *Player) JoinTeam(
 	if team == nil {
 		return errors.New("Team does not exist")
 	}
-	query := `INSERT INTO player_team (player_id, team_id, joined) VALUES (?,?,?);`
-	now := time.Now()
-	_, err = tx.Exec(ctx, query, p.ID, teamid, formatISO8601(&now))
+	var overlaps int
+	query := `SELECT EXISTS (SELECT 1 FROM player_team WHERE player_id = ? AND left > ?);`
+	row, err := tx.QueryRow(ctx, query, p.ID, formatISO8601(&joined))
+	if err != nil {
+		return errors.Wrap(err, "tx.QueryRow")
+	}
+	err = row.Scan(&overlaps)
+	if err != nil {
+		return errors.Wrap(err, "row.Scan")
+	}
+	if overlaps == 1 {
+		return errors.New("VE:Player was on a team after that date")
+	}
+	query = `
+INSERT INTO player_team (player_id, team_id, joined, join_reason, invite_id)
+VALUES (?,?,?,?,?);`
+	_, err = tx.Exec(ctx, query, p.ID, teamid, formatISO8601(&joined), reason, inviteID)
 	if err != nil {
 		return errors.Wrap(err, "tx.Exec")
 	}
 	return nil
 }
 
+// Remove the player from the team now. The reason is one of the Move constants
 func (p *Player) LeaveTeam(
 	ctx context.Context,
 	tx *db.SafeWTX,
 	teamid uint16,
+	reason string,
+) error {
+	return p.LeaveTeamAt(ctx, tx, teamid, time.Now(), reason)
+}
+
+// Remove the player from the team as of the left time, which can be in the
+// past but not before the player joined the team
+func (p *Player) LeaveTeamAt(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	teamid uint16,
+	left time.Time,
+	reason string,
 ) error {
 	currentTeam, err := p.CurrentTeam(ctx, tx)
 	if err != nil {
@@ -243,12 +357,14 @@ func (p *Player) LeaveTeam(
 	if team.ID != currentTeam.TeamID {
 		return errors.New("Player is not on that team!")
 	}
+	if left.Before(currentTeam.Joined) {
+		return errors.New("VE:Player can't leave the team before they joined it")
+	}
 	query := `
-UPDATE player_team SET left = ?
+UPDATE player_team SET left = ?, left_reason = ?
 WHERE team_id = ? AND player_id = ? AND left IS NULL;
     `
-	now := time.Now()
-	_, err = tx.Exec(ctx, query, formatISO8601(&now), team.ID, p.ID)
+	_, err = tx.Exec(ctx, query, formatISO8601(&left), reason, team.ID, p.ID)
 	if err != nil {
 		return errors.Wrap(err, "tx.Exec")
 	}
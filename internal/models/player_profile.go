@@ -0,0 +1,304 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"gosl/pkg/db"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Positions a player can list as a preference
+var PlayerPositions = []string{"Forward", "Defence", "Goalie"}
+
+// Regions a player can list as playing from
+var PlayerRegions = []string{
+	"Australia (East)",
+	"Australia (Central)",
+	"Australia (West)",
+	"New Zealand",
+	"Asia",
+	"Other",
+}
+
+// Days of the week in the weekly availability grid
+var AvailabilityDays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// Blocks of time on each day in the weekly availability grid
+var AvailabilityBlocks = []string{"Afternoon", "Evening", "Late"}
+
+// Get every slot in the weekly availability grid, i.e. "Mon Afternoon"
+func AvailabilitySlots() []string {
+	slots := []string{}
+	for _, day := range AvailabilityDays {
+		for _, block := range AvailabilityBlocks {
+			slots = append(slots, day+" "+block)
+		}
+	}
+	return slots
+}
+
+// Model of the player_profile table in the database
+// Each row represents the details a player has provided about themselves
+// to help teams find players and build their rosters
+type PlayerProfile struct {
+	PlayerID     uint16     // FK -> Player.ID
+	Positions    []string   // preferred positions, from PlayerPositions
+	Region       string     // region the player plays from, from PlayerRegions
+	Timezone     string     // IANA TZ identifier of the players timezone
+	Availability []string   // slots available each week, from AvailabilitySlots
+	Playstyle    string     // short description of the players playstyle
+	Notes        string     // notes for teams looking for free agents
+	Bio          string     // short bio about the player
+	Updated      *time.Time // time the profile was last updated, nil if never set up
+}
+
+// Model of a free agent in the active season as listed to teams
+type FreeAgentListing struct {
+	PlayerID   uint16        // FK -> Player.ID
+	PlayerName string        // from Player.Name
+	DiscordID  string        // from Player.DiscordID
+	League     string        // league placed in, or the preferred league if not placed
+	Placed     bool          // has the player been placed into a league?
+	Profile    PlayerProfile // empty if the player hasn't set up a profile
+}
+
+// Get the profile of the player. If the player hasn't set up their profile
+// an empty profile is returned
+func GetPlayerProfile(
+	ctx context.Context,
+	tx db.SafeTX,
+	playerID uint16,
+) (*PlayerProfile, error) {
+	query := `
+SELECT player_id, positions, region, timezone, availability, playstyle, notes,
+    bio, updated
+FROM player_profile WHERE player_id = ?;`
+	row, err := tx.QueryRow(ctx, query, playerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	var profile PlayerProfile
+	var positions, availability, updated string
+	err = row.Scan(&profile.PlayerID, &positions, &profile.Region,
+		&profile.Timezone, &availability, &profile.Playstyle, &profile.Notes,
+		&profile.Bio, &updated)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &PlayerProfile{
+				PlayerID:     playerID,
+				Positions:    []string{},
+				Availability: []string{},
+			}, nil
+		}
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	profile.Positions = splitList(positions)
+	profile.Availability = splitList(availability)
+	profile.Updated = parseISO8601(&updated)
+	return &profile, nil
+}
+
+// Get the location of the timezone set in the profile of the player linked to
+// the discord user. Uses the fallback timezone if the user isn't a player or
+// hasn't set a timezone
+func GetPlayerLocation(
+	ctx context.Context,
+	tx db.SafeTX,
+	discordID string,
+	fallback string,
+) (*time.Location, error) {
+	query := `
+SELECT pp.timezone FROM player_profile pp
+JOIN player p ON p.id = pp.player_id
+WHERE p.discord_id = ?;`
+	row, err := tx.QueryRow(ctx, query, discordID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	var timezone string
+	err = row.Scan(&timezone)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	if timezone == "" {
+		timezone = fallback
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errors.Wrap(err, "time.LoadLocation")
+	}
+	return loc, nil
+}
+
+// Set the preferred positions of the player. Must be from PlayerPositions
+func (p *PlayerProfile) SetPositions(positions []string) error {
+	ordered, err := orderedSubset(positions, PlayerPositions)
+	if err != nil {
+		return errors.Wrap(err, "VE:Invalid position")
+	}
+	p.Positions = ordered
+	return nil
+}
+
+// Set the region of the player. Must be from PlayerRegions or empty
+func (p *PlayerProfile) SetRegion(region string) error {
+	if region != "" && !slices.Contains(PlayerRegions, region) {
+		return errors.New(fmt.Sprintf("VE:Invalid region: %s", region))
+	}
+	p.Region = region
+	return nil
+}
+
+// Set the weekly availability of the player. Must be from AvailabilitySlots
+func (p *PlayerProfile) SetAvailability(slots []string) error {
+	ordered, err := orderedSubset(slots, AvailabilitySlots())
+	if err != nil {
+		return errors.Wrap(err, "VE:Invalid availability")
+	}
+	p.Availability = ordered
+	return nil
+}
+
+// Set the free text details of the player. Timezone must be a valid IANA TZ
+// identifier (i.e. Australia/Sydney) or empty
+func (p *PlayerProfile) SetDetails(
+	timezone string,
+	playstyle string,
+	notes string,
+	bio string,
+) error {
+	timezone = strings.TrimSpace(timezone)
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil || timezone == "Local" {
+			return errors.New(fmt.Sprintf(
+				"VE:'%s' is not a valid timezone. Use the format 'Australia/Sydney'", timezone))
+		}
+		timezone = loc.String()
+	}
+	p.Timezone = timezone
+	p.Playstyle = strings.TrimSpace(playstyle)
+	p.Notes = strings.TrimSpace(notes)
+	p.Bio = strings.TrimSpace(bio)
+	return nil
+}
+
+// Check if the player is available in the block of time on the day
+func (p *PlayerProfile) IsAvailable(day string, block string) bool {
+	return slices.Contains(p.Availability, day+" "+block)
+}
+
+// Create or update the player profile
+func (p *PlayerProfile) Save(ctx context.Context, tx *db.SafeWTX) error {
+	now := time.Now()
+	query := `
+INSERT INTO player_profile
+    (player_id, positions, region, timezone, availability, playstyle, notes,
+    bio, updated)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(player_id) DO UPDATE SET
+    positions = excluded.positions,
+    region = excluded.region,
+    timezone = excluded.timezone,
+    availability = excluded.availability,
+    playstyle = excluded.playstyle,
+    notes = excluded.notes,
+    bio = excluded.bio,
+    updated = excluded.updated;`
+	_, err := tx.Exec(ctx, query, p.PlayerID, strings.Join(p.Positions, ","),
+		p.Region, p.Timezone, strings.Join(p.Availability, ","), p.Playstyle,
+		p.Notes, p.Bio, formatISO8601(&now))
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	p.Updated = &now
+	return nil
+}
+
+// Get the approved free agents in the active season that are not currently on
+// a team. Filters are ignored if empty. League matches the league the player
+// was placed in, or their preferred league if not yet placed. Day matches any
+// availability on that day
+func GetFreeAgentListings(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+	league string,
+	position string,
+	day string,
+) (*[]FreeAgentListing, error) {
+	query := `
+SELECT p.id, p.name, p.discord_id, COALESCE(l.division, far.preferred_league),
+    l.id IS NOT NULL, COALESCE(pp.positions, ''), COALESCE(pp.region, ''),
+    COALESCE(pp.timezone, ''), COALESCE(pp.availability, ''),
+    COALESCE(pp.playstyle, ''), COALESCE(pp.notes, ''), COALESCE(pp.bio, ''),
+    pp.updated
+FROM free_agent_registration far
+JOIN season s ON far.season_id = s.id
+JOIN player p ON far.player_id = p.id
+LEFT JOIN league l ON far.placed = l.id
+LEFT JOIN player_profile pp ON pp.player_id = p.id
+LEFT JOIN player_team pt ON pt.player_id = p.id AND pt.left IS NULL
+WHERE s.active = 1 AND s.guild_id = ?4
+AND far.approved = 1
+AND pt.player_id IS NULL
+AND (?1 = '' OR COALESCE(l.division, far.preferred_league) = ?1)
+AND (?2 = '' OR ',' || pp.positions || ',' LIKE '%,' || ?2 || ',%')
+AND (?3 = '' OR ',' || pp.availability LIKE '%,' || ?3 || ' %')
+ORDER BY p.name COLLATE NOCASE;`
+	rows, err := tx.Query(ctx, query, league, position, day, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	listings := []FreeAgentListing{}
+	for rows.Next() {
+		var fa FreeAgentListing
+		var positions, availability string
+		var updated sql.NullString
+		err = rows.Scan(&fa.PlayerID, &fa.PlayerName, &fa.DiscordID, &fa.League,
+			&fa.Placed, &positions, &fa.Profile.Region, &fa.Profile.Timezone,
+			&availability, &fa.Profile.Playstyle, &fa.Profile.Notes,
+			&fa.Profile.Bio, &updated)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		fa.Profile.PlayerID = fa.PlayerID
+		fa.Profile.Positions = splitList(positions)
+		fa.Profile.Availability = splitList(availability)
+		if updated.Valid {
+			fa.Profile.Updated = parseISO8601(&updated.String)
+		}
+		listings = append(listings, fa)
+	}
+	return &listings, nil
+}
+
+// Check the values are all in valid, and return them in the order of valid
+func orderedSubset(values []string, valid []string) ([]string, error) {
+	ordered := []string{}
+	for _, value := range values {
+		if !slices.Contains(valid, value) {
+			return nil, errors.New(value)
+		}
+	}
+	for _, v := range valid {
+		if slices.Contains(values, v) {
+			ordered = append(ordered, v)
+		}
+	}
+	return ordered, nil
+}
+
+// Split a comma separated list stored in the database
+func splitList(list string) []string {
+	if list == "" {
+		return []string{}
+	}
+	return strings.Split(list, ",")
+}
@@ -0,0 +1,182 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"gosl/pkg/db"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Model of the player_slapshot table in the database
+// Each row holds the in-game details of a player, synced from the slapshot API
+type SlapshotInfo struct {
+	Username string     // current in-game name
+	Rank     string     // ranked tier, empty if unranked
+	MMR      *uint16    // ranked MMR, nil if unranked
+	Updated  *time.Time // time the details were last synced
+}
+
+// Minimum ranked MMR suggested for each division. A rough guide for players
+// choosing a league, managers still decide placements
+const (
+	ProMinMMR  uint16 = 1600
+	IMMinMMR   uint16 = 1300
+	OpenMinMMR uint16 = 0
+)
+
+// Divisions in the order they are suggested, highest first
+var divisionMinMMR = []struct {
+	Division string
+	MinMMR   uint16
+}{
+	{"Pro", ProMinMMR},
+	{"IM", IMMinMMR},
+	{"Open", OpenMinMMR},
+}
+
+// Get the division suggested for the player from their ranked MMR. Returns an
+// empty string if the player is unranked or hasn't been synced
+func (info *SlapshotInfo) SuggestedDivision() string {
+	if info == nil || info.MMR == nil {
+		return ""
+	}
+	for _, division := range divisionMinMMR {
+		if *info.MMR >= division.MinMMR {
+			return division.Division
+		}
+	}
+	return ""
+}
+
+// Get a short description of the in-game name and rank, e.g.
+// "InGameName, Gold 1450". Returns an empty string if not synced
+func (info *SlapshotInfo) Summary() string {
+	if info == nil {
+		return ""
+	}
+	if info.MMR == nil {
+		return info.Username + ", Unranked"
+	}
+	return fmt.Sprintf("%s, %s %v", info.Username, info.Rank, *info.MMR)
+}
+
+// Columns of player_slapshot scanned from a LEFT JOIN, where every column is
+// null if the player hasn't been synced
+type nullSlapshotInfo struct {
+	username sql.NullString
+	rank     sql.NullString
+	mmr      sql.NullInt64
+	updated  sql.NullString
+}
+
+func (n *nullSlapshotInfo) info() *SlapshotInfo {
+	if !n.username.Valid {
+		return nil
+	}
+	info := &SlapshotInfo{
+		Username: n.username.String,
+		Rank:     n.rank.String,
+		Updated:  parseISO8601(&n.updated.String),
+	}
+	if n.mmr.Valid {
+		mmr := uint16(n.mmr.Int64)
+		info.MMR = &mmr
+	}
+	return info
+}
+
+// Get the synced in-game details of the player. Returns nil if not synced yet
+func GetSlapshotInfo(
+	ctx context.Context,
+	tx db.SafeTX,
+	playerID uint16,
+) (*SlapshotInfo, error) {
+	query := `
+SELECT username, rank, mmr, updated FROM player_slapshot WHERE player_id = ?;`
+	row, err := tx.QueryRow(ctx, query, playerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	var info nullSlapshotInfo
+	err = row.Scan(&info.username, &info.rank, &info.mmr, &info.updated)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	return info.info(), nil
+}
+
+// Store the in-game details of the player, replacing any previously synced
+func SetSlapshotInfo(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	playerID uint16,
+	info *SlapshotInfo,
+) error {
+	query := `
+INSERT INTO player_slapshot (player_id, username, rank, mmr, updated)
+VALUES (?,?,?,?,?)
+ON CONFLICT(player_id) DO UPDATE SET
+    username = excluded.username,
+    rank = excluded.rank,
+    mmr = excluded.mmr,
+    updated = excluded.updated;`
+	var mmr *int64
+	if info.MMR != nil {
+		value := int64(*info.MMR)
+		mmr = &value
+	}
+	now := time.Now()
+	_, err := tx.Exec(ctx, query, playerID, info.Username, info.Rank, mmr,
+		formatISO8601(&now))
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	info.Updated = &now
+	return nil
+}
+
+// Get players whose in-game details haven't been synced since the time
+// provided, least recently synced first
+func GetPlayersToSync(
+	ctx context.Context,
+	tx db.SafeTX,
+	syncedBefore time.Time,
+	limit int,
+) (*[]Player, error) {
+	query := `
+SELECT p.id, p.slap_id, p.name, p.discord_id FROM player p
+LEFT JOIN player_slapshot ps ON ps.player_id = p.id
+WHERE ps.updated IS NULL OR ps.updated < ?
+ORDER BY ps.updated IS NOT NULL, ps.updated
+LIMIT ?;`
+	rows, err := tx.Query(ctx, query, formatISO8601(&syncedBefore), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	players := []Player{}
+	for rows.Next() {
+		var player Player
+		err = rows.Scan(&player.ID, &player.SlapID, &player.Name, &player.DiscordID)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		players = append(players, player)
+	}
+	return &players, nil
+}
+
+// Get the player's name followed by their in-game name and rank if they have
+// been synced, e.g. "Name (InGameName, Gold 1450)"
+func (p *Player) NameWithRank() string {
+	if p.Slapshot == nil {
+		return p.Name
+	}
+	return fmt.Sprintf("%s (%s)", p.Name, p.Slapshot.Summary())
+}
@@ -1,6 +1,15 @@
 package models
 
-import "time"
+import (
+	"context"
+	"database/sql"
+	"gosl/pkg/db"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 // Model of the player_team table in the database
 // Each row represents a continuous period the player was on a team
@@ -12,4 +21,207 @@ type PlayerTeam struct {
 	ManagerID  uint16     // from Team.ManagerID
 	Joined     time.Time  // timestamp when player joined the team
 	Left       *time.Time // timestamp when player left the team
+	JoinReason string     // how the player joined, one of the Move constants
+	LeftReason string     // how the player left, one of the Move constants
+	InviteID   *uint32    // FK -> PlayerTeamInvite.ID, the invite the player joined from
+}
+
+// Reasons a player joined or left a team, recorded in the transfer ledger.
+// Moves made before reasons were recorded have an empty reason
+const (
+	MoveCreated   = "created"   // player created the team as its manager
+	MoveRejoined  = "rejoined"  // manager re-registered a team they managed before
+	MoveInvite    = "invite"    // player accepted an invite or offer from the team
+	MoveLeft      = "left"      // player left the team
+	MoveRemoved   = "removed"   // player was removed by the team manager
+	MoveDisbanded = "disbanded" // team was disbanded
+	MoveAdmin     = "admin"     // roster was corrected by an admin
+)
+
+// Get a readable description of the reason a player joined or left a team
+func MoveReasonName(reason string) string {
+	switch reason {
+	case MoveCreated:
+		return "Created the team"
+	case MoveRejoined:
+		return "Re-registered the team"
+	case MoveInvite:
+		return "Invite"
+	case MoveLeft:
+		return "Left"
+	case MoveRemoved:
+		return "Removed by manager"
+	case MoveDisbanded:
+		return "Team disbanded"
+	case MoveAdmin:
+		return "Admin action"
+	default:
+		return "Unknown"
+	}
+}
+
+// A player joining or leaving a team, as listed in the transfer ledger
+type Transfer struct {
+	PlayerID   uint16    // FK -> Player.ID
+	PlayerName string    // from Player.Name
+	TeamID     uint16    // FK -> Team.ID
+	TeamName   string    // from Team.Name
+	Joined     bool      // true if the player joined the team, false if they left
+	Time       time.Time // time the player joined or left
+	Reason     string    // one of the Move constants
+	InviteID   *uint32   // FK -> PlayerTeamInvite.ID, only set for joins
+	Offer      string    // offer type of the invite, only set if InviteID is
+}
+
+// Get a readable description of what caused the move, i.e. "Trial offer #4".
+// Empty if the player left by choice or the cause wasn't recorded
+func (t *Transfer) Cause() string {
+	if t.InviteID != nil {
+		invite := PlayerTeamInvite{ID: *t.InviteID, Offer: t.Offer}
+		return invite.OfferName() + " #" + strconv.FormatUint(uint64(invite.ID), 10)
+	}
+	if t.Reason == "" || t.Reason == MoveLeft {
+		return ""
+	}
+	return MoveReasonName(t.Reason)
+}
+
+// Get every period the player has spent on a team in the guild, most recent
+// first
+func GetPlayerCareer(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+	playerID uint16,
+) (*[]PlayerTeam, error) {
+	query := `
+SELECT pt.team_id, t.name, pt.player_id, p.name, t.manager_id, pt.joined, pt.left,
+    pt.join_reason, pt.left_reason, pt.invite_id
+FROM player_team pt
+JOIN team t ON pt.team_id = t.id
+JOIN player p ON pt.player_id = p.id
+WHERE t.guild_id = ? AND pt.player_id = ?;`
+	rows, err := tx.Query(ctx, query, guildID, playerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	career := []PlayerTeam{}
+	for rows.Next() {
+		var team PlayerTeam
+		var joined string
+		var left sql.NullString
+		var inviteID sql.NullInt64
+		err = rows.Scan(&team.TeamID, &team.TeamName, &team.PlayerID, &team.PlayerName,
+			&team.ManagerID, &joined, &left, &team.JoinReason, &team.LeftReason, &inviteID)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		team.Joined = *parseISO8601(&joined)
+		if left.Valid {
+			team.Left = parseISO8601(&left.String)
+		}
+		if inviteID.Valid {
+			id := uint32(inviteID.Int64)
+			team.InviteID = &id
+		}
+		career = append(career, team)
+	}
+	// times are stored with their offsets so are sorted once parsed
+	sort.SliceStable(career, func(a, b int) bool {
+		return career[a].Joined.After(career[b].Joined)
+	})
+	return &career, nil
+}
+
+// Get every player that has joined or left the team, most recent first
+func GetTeamHistory(
+	ctx context.Context,
+	tx db.SafeTX,
+	teamID uint16,
+) (*[]Transfer, error) {
+	transfers, err := getTransfers(ctx, tx, "pt.team_id = ?", teamID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getTransfers")
+	}
+	return transfers, nil
+}
+
+// Get every player that has joined or left a team in the guild since the
+// time, most recent first
+func GetTransfersSince(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+	since time.Time,
+) (*[]Transfer, error) {
+	// times are stored with their offsets so are compared as julian days.
+	// A row that joined before the time can still have left after it, so the
+	// joins and leaves are filtered again once split
+	condition := `t.guild_id = ?1
+AND (julianday(pt.joined) >= julianday(?2) OR julianday(pt.left) >= julianday(?2))`
+	transfers, err := getTransfers(ctx, tx, condition, guildID, formatISO8601(&since))
+	if err != nil {
+		return nil, errors.Wrap(err, "getTransfers")
+	}
+	filtered := []Transfer{}
+	for _, transfer := range *transfers {
+		if !transfer.Time.Before(since) {
+			filtered = append(filtered, transfer)
+		}
+	}
+	return &filtered, nil
+}
+
+// Get the joins and leaves of the player_team rows matching the condition
+func getTransfers(
+	ctx context.Context,
+	tx db.SafeTX,
+	condition string,
+	args ...any,
+) (*[]Transfer, error) {
+	query := `
+SELECT pt.player_id, p.name, pt.team_id, t.name, pt.joined, pt.left,
+    pt.join_reason, pt.left_reason, pt.invite_id, COALESCE(pti.offer, '')
+FROM player_team pt
+JOIN team t ON pt.team_id = t.id
+JOIN player p ON pt.player_id = p.id
+LEFT JOIN player_team_invite pti ON pt.invite_id = pti.id
+WHERE ` + condition + `;`
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	transfers := []Transfer{}
+	for rows.Next() {
+		var join Transfer
+		var joined, joinReason, leftReason string
+		var left sql.NullString
+		var inviteID sql.NullInt64
+		err = rows.Scan(&join.PlayerID, &join.PlayerName, &join.TeamID, &join.TeamName,
+			&joined, &left, &joinReason, &leftReason, &inviteID, &join.Offer)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		leave := join
+		leave.Offer = ""
+		join.Joined = true
+		join.Time = *parseISO8601(&joined)
+		join.Reason = joinReason
+		if inviteID.Valid {
+			id := uint32(inviteID.Int64)
+			join.InviteID = &id
+		}
+		transfers = append(transfers, join)
+		if left.Valid {
+			leave.Time = *parseISO8601(&left.String)
+			leave.Reason = leftReason
+			transfers = append(transfers, leave)
+		}
+	}
+	sort.SliceStable(transfers, func(a, b int) bool {
+		return transfers[a].Time.After(transfers[b].Time)
+	})
+	return &transfers, nil
 }
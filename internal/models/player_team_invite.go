@@ -18,6 +18,27 @@ type PlayerTeamInvite struct {
 	TeamName   string  // from Team.Name
 	Status     *uint16 // nil for pending, 0 for rejected, 1 for accepted
 	Approved   *uint16 // nil for pending, 0 for denied, 1 for approved
+	Offer      string  // type of offer, one of OfferInvite, OfferTrial or OfferSigning
+}
+
+// Types of offers a team can make to a player. All offers follow the same
+// accept and approval process, the type is shown to the player and staff
+const (
+	OfferInvite  = "invite"  // regular invite to join the team
+	OfferTrial   = "trial"   // offer to a free agent to trial with the team
+	OfferSigning = "signing" // offer to a free agent to sign with the team
+)
+
+// Get a readable name of the offer type
+func (i *PlayerTeamInvite) OfferName() string {
+	switch i.Offer {
+	case OfferTrial:
+		return "Trial offer"
+	case OfferSigning:
+		return "Signing offer"
+	default:
+		return "Invite"
+	}
 }
 
 func GetPlayerTeamInvite(
@@ -26,7 +47,8 @@ func GetPlayerTeamInvite(
 	inviteID uint32,
 ) (*PlayerTeamInvite, error) {
 	query := `
-SELECT pti.id, pti.player_id, p.name, pti.team_id, t.name, pti.status, pti.approved 
+SELECT pti.id, pti.player_id, p.name, pti.team_id, t.name, pti.status, pti.approved,
+    pti.offer
 FROM player_team_invite pti
 JOIN player p ON pti.player_id = p.id
 JOIN team t ON pti.team_id = t.id
@@ -46,6 +68,7 @@ WHERE pti.id = ?;`
 		&pti.TeamName,
 		&status,
 		&approved,
+		&pti.Offer,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -108,3 +131,34 @@ func (i *PlayerTeamInvite) Deny(ctx context.Context, tx *db.SafeWTX) error {
 	i.Approved = &approved
 	return nil
 }
+
+// Undo the latest decision made on the invite, returning it to pending. A
+// staff approval or denial is undone before the player's response. Returns
+// a description of the decision undone
+func (i *PlayerTeamInvite) UndoDecision(ctx context.Context, tx *db.SafeWTX) (string, error) {
+	query := `UPDATE player_team_invite SET approved = NULL WHERE id = ?;`
+	decision := "approval"
+	if i.Approved != nil && *i.Approved == 0 {
+		decision = "denial"
+	}
+	if i.Approved == nil {
+		if i.Status == nil {
+			return "", errors.New("VE:No decision has been made on the invite")
+		}
+		query = `UPDATE player_team_invite SET status = NULL WHERE id = ?;`
+		decision = "acceptance"
+		if *i.Status == 0 {
+			decision = "rejection"
+		}
+	}
+	_, err := tx.Exec(ctx, query, i.ID)
+	if err != nil {
+		return "", errors.Wrap(err, "tx.Exec")
+	}
+	if i.Approved != nil {
+		i.Approved = nil
+	} else {
+		i.Status = nil
+	}
+	return decision, nil
+}
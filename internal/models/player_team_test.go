@@ -0,0 +1,158 @@
+package models_test
+
+import (
+	"gosl/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerTeamHistory(t *testing.T) {
+	conn, cfg := testConn(t)
+	ctx := t.Context()
+	const otherGuild = "other-guild"
+	now := time.Now().Truncate(time.Second)
+
+	tx, err := conn.Begin(ctx, "TestPlayerTeamHistory setup")
+	require.NoError(t, err)
+	require.NoError(t, models.CreatePlayer(ctx, tx, 2001, "1", "Manager"))
+	require.NoError(t, models.CreatePlayer(ctx, tx, 2002, "2", "Trialist"))
+	require.NoError(t, models.CreatePlayer(ctx, tx, 2003, "3", "Elsewhere"))
+	manager, err := models.GetPlayerBySlapID(ctx, tx, 2001)
+	require.NoError(t, err)
+	trialist, err := models.GetPlayerBySlapID(ctx, tx, 2002)
+	require.NoError(t, err)
+	elsewhere, err := models.GetPlayerBySlapID(ctx, tx, 2003)
+	require.NoError(t, err)
+	team, err := models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Historians", "HST", manager.ID)
+	require.NoError(t, err)
+	otherTeam, err := models.CreateTeam(ctx, tx, otherGuild, "Strangers", "STR", elsewhere.ID)
+	require.NoError(t, err)
+	invite, err := team.InvitePlayer(ctx, tx, trialist.ID, models.OfferTrial)
+	require.NoError(t, err)
+	require.NoError(t, manager.JoinTeamAt(ctx, tx, team.ID,
+		now.Add(-3*time.Hour), models.MoveCreated, nil))
+	require.NoError(t, trialist.JoinTeamAt(ctx, tx, team.ID,
+		now.Add(-2*time.Hour), models.MoveInvite, &invite.ID))
+	require.NoError(t, trialist.LeaveTeamAt(ctx, tx, team.ID,
+		now.Add(-time.Hour), models.MoveRemoved))
+	require.NoError(t, elsewhere.JoinTeamAt(ctx, tx, otherTeam.ID,
+		now.Add(-30*time.Minute), models.MoveCreated, nil))
+	tx.Commit()
+
+	t.Run("Team history lists the joins and leaves most recent first", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestPlayerTeamHistory team")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		history, err := models.GetTeamHistory(ctx, rtx, team.ID)
+		require.NoError(t, err)
+		require.Len(t, *history, 3)
+
+		left := (*history)[0]
+		assert.Equal(t, trialist.ID, left.PlayerID)
+		assert.False(t, left.Joined)
+		assert.True(t, now.Add(-time.Hour).Equal(left.Time))
+		assert.Equal(t, models.MoveRemoved, left.Reason)
+		assert.Nil(t, left.InviteID)
+		assert.Equal(t, "Removed by manager", left.Cause())
+
+		joined := (*history)[1]
+		assert.Equal(t, trialist.ID, joined.PlayerID)
+		assert.True(t, joined.Joined)
+		assert.Equal(t, models.MoveInvite, joined.Reason)
+		require.NotNil(t, joined.InviteID)
+		assert.Equal(t, invite.ID, *joined.InviteID)
+		assert.Equal(t, models.OfferTrial, joined.Offer)
+
+		created := (*history)[2]
+		assert.Equal(t, manager.ID, created.PlayerID)
+		assert.True(t, created.Joined)
+		assert.Equal(t, "Created the team", created.Cause())
+	})
+
+	t.Run("Transfers since a time only include moves in the guild after it", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestPlayerTeamHistory since")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		transfers, err := models.GetTransfersSince(ctx, rtx, cfg.DiscordGuildID,
+			now.Add(-90*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, *transfers, 1)
+		assert.Equal(t, trialist.ID, (*transfers)[0].PlayerID)
+		assert.False(t, (*transfers)[0].Joined)
+
+		transfers, err = models.GetTransfersSince(ctx, rtx, otherGuild, now.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Len(t, *transfers, 1)
+		assert.Equal(t, elsewhere.ID, (*transfers)[0].PlayerID)
+
+		transfers, err = models.GetTransfersSince(ctx, rtx, cfg.DiscordGuildID, now)
+		require.NoError(t, err)
+		assert.Empty(t, *transfers)
+	})
+
+	t.Run("Careers record the reasons and invite of each period", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestPlayerTeamHistory career")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		career, err := models.GetPlayerCareer(ctx, rtx, cfg.DiscordGuildID, trialist.ID)
+		require.NoError(t, err)
+		require.Len(t, *career, 1)
+		period := (*career)[0]
+		assert.Equal(t, team.ID, period.TeamID)
+		assert.Equal(t, "Historians", period.TeamName)
+		assert.Equal(t, manager.ID, period.ManagerID)
+		assert.Equal(t, models.MoveInvite, period.JoinReason)
+		assert.Equal(t, models.MoveRemoved, period.LeftReason)
+		require.NotNil(t, period.InviteID)
+		assert.Equal(t, invite.ID, *period.InviteID)
+		require.NotNil(t, period.Left)
+		assert.True(t, now.Add(-time.Hour).Equal(*period.Left))
+
+		career, err = models.GetPlayerCareer(ctx, rtx, cfg.DiscordGuildID, elsewhere.ID)
+		require.NoError(t, err)
+		assert.Empty(t, *career)
+	})
+
+	t.Run("Moves can't overlap the player's other periods", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestPlayerTeamHistory overlaps")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		err = manager.JoinTeamAt(ctx, tx, otherTeam.ID, now, models.MoveInvite, nil)
+		assert.EqualError(t, err, "Player currently on a team")
+		err = trialist.JoinTeamAt(ctx, tx, otherTeam.ID,
+			now.Add(-90*time.Minute), models.MoveInvite, nil)
+		assert.EqualError(t, err, "VE:Player was on a team after that date")
+		err = manager.LeaveTeamAt(ctx, tx, otherTeam.ID, now, models.MoveLeft)
+		assert.EqualError(t, err, "Player is not on that team!")
+		err = manager.LeaveTeamAt(ctx, tx, team.ID, now.Add(-4*time.Hour), models.MoveLeft)
+		assert.EqualError(t, err, "VE:Player can't leave the team before they joined it")
+		err = trialist.LeaveTeamAt(ctx, tx, team.ID, now, models.MoveLeft)
+		assert.EqualError(t, err, "Player not on a team")
+	})
+
+	t.Run("Disbanding a team removes only its current players", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestPlayerTeamHistory disband")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		require.NoError(t, team.Disband(ctx, tx))
+		current, err := manager.CurrentTeam(ctx, tx)
+		require.NoError(t, err)
+		assert.Nil(t, current)
+
+		history, err := models.GetTeamHistory(ctx, tx, team.ID)
+		require.NoError(t, err)
+		require.Len(t, *history, 4)
+		disbanded := (*history)[0]
+		assert.Equal(t, manager.ID, disbanded.PlayerID)
+		assert.False(t, disbanded.Joined)
+		assert.Equal(t, models.MoveDisbanded, disbanded.Reason)
+		assert.Equal(t, models.MoveRemoved, (*history)[1].Reason)
+
+		others, err := models.GetTeamHistory(ctx, tx, otherTeam.ID)
+		require.NoError(t, err)
+		assert.Len(t, *others, 1)
+	})
+}
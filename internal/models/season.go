@@ -15,6 +15,7 @@ import (
 // Each record in the table represents a single season covering all leagues
 type Season struct {
 	ID               string     // unique identifier e.g. S21
+	GuildID          string     // discord server the season is run in
 	Name             string     // unique display name e.g. Season 21
 	Start            *time.Time // timestamp ISO8601 format
 	RegSeasonEnd     *time.Time // timestamp ISO8601 format
@@ -26,11 +27,12 @@ type Season struct {
 func CreateSeason(
 	ctx context.Context,
 	tx *db.SafeWTX,
+	guildID string,
 	id string,
 	name string,
 ) (*Season, error) {
-	query := `INSERT INTO season (id, name) VALUES (?, ?);`
-	_, err := tx.Exec(ctx, query, id, name)
+	query := `INSERT INTO season (id, name, guild_id) VALUES (?, ?, ?);`
+	_, err := tx.Exec(ctx, query, id, name, guildID)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
 			msg := ""
@@ -45,6 +47,7 @@ func CreateSeason(
 	}
 	var s Season
 	s.ID = id
+	s.GuildID = guildID
 	s.Name = name
 	s.Active = false
 	s.RegistrationOpen = false
@@ -65,7 +68,7 @@ func CreateSeason(
 
 func GetSeason(ctx context.Context, tx db.SafeTX, id string) (*Season, error) {
 	query := `
-SELECT id, name, start, reg_season_end, finals_end, active, registration_open
+SELECT id, guild_id, name, start, reg_season_end, finals_end, active, registration_open
 FROM season WHERE id = ?;
 `
 	row, err := tx.QueryRow(ctx, query, id)
@@ -79,13 +82,14 @@ FROM season WHERE id = ?;
 	return season, nil
 }
 
-func GetSeasons(ctx context.Context, tx db.SafeTX) ([]*Season, error) {
+// Get all the seasons run in the guild
+func GetSeasons(ctx context.Context, tx db.SafeTX, guildID string) ([]*Season, error) {
 	var seasons []*Season
 	query := `
-SELECT id, name, start, reg_season_end, finals_end, active, registration_open
-FROM season;
+SELECT id, guild_id, name, start, reg_season_end, finals_end, active, registration_open
+FROM season WHERE guild_id = ?;
 `
-	rows, err := tx.Query(ctx, query)
+	rows, err := tx.Query(ctx, query, guildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "tx.Query")
 	}
@@ -99,12 +103,13 @@ FROM season;
 	return seasons, nil
 }
 
-func GetActiveSeason(ctx context.Context, tx db.SafeTX) (*Season, error) {
+// Get the active season of the guild. Returns nil if there is none
+func GetActiveSeason(ctx context.Context, tx db.SafeTX, guildID string) (*Season, error) {
 	query := `
-SELECT id, name, start, reg_season_end, finals_end, active, registration_open
-FROM season WHERE active = 1;
+SELECT id, guild_id, name, start, reg_season_end, finals_end, active, registration_open
+FROM season WHERE active = 1 AND guild_id = ?;
 `
-	row, err := tx.QueryRow(ctx, query)
+	row, err := tx.QueryRow(ctx, query, guildID)
 	if err != nil {
 		return nil, errors.Wrap(err, "tx.QueryRow")
 	}
@@ -118,19 +123,19 @@ FROM season WHERE active = 1;
 	return season, nil
 }
 
-// Sets the active season to the given season ID. Providing "NOACTIVESEASON" as the ID will
-// set any active season as inactive. DB has a trigger setup to ensure only 1
-// season is set as active at once
-func SetActiveSeason(ctx context.Context, tx *db.SafeWTX, id string) error {
+// Sets the active season of the guild to the given season ID. Providing
+// "NOACTIVESEASON" as the ID will set any active season as inactive. DB has a
+// trigger setup to ensure only 1 season in each guild is set as active at once
+func SetActiveSeason(ctx context.Context, tx *db.SafeWTX, guildID string, id string) error {
 	if id == "NOACTIVESEASON" {
-		query := `UPDATE season SET active = 0 WHERE active = 1;`
-		_, err := tx.Exec(ctx, query)
+		query := `UPDATE season SET active = 0 WHERE active = 1 AND guild_id = ?;`
+		_, err := tx.Exec(ctx, query, guildID)
 		if err != nil {
 			return errors.Wrap(err, "tx.Exec")
 		}
 	} else {
-		query := `UPDATE season SET active = 1 WHERE id = ?`
-		_, err := tx.Exec(ctx, query, id)
+		query := `UPDATE season SET active = 1 WHERE id = ? AND guild_id = ?`
+		_, err := tx.Exec(ctx, query, id, guildID)
 		if err != nil {
 			return errors.Wrap(err, "tx.Exec")
 		}
@@ -147,7 +152,7 @@ func scanSeason(row any) (*Season, error) {
 	var regOpen uint16
 	switch r := row.(type) {
 	case *sql.Row:
-		err := r.Scan(&s.ID, &s.Name, &start, &regSeasonEnd, &finalsEnd, &active, &regOpen)
+		err := r.Scan(&s.ID, &s.GuildID, &s.Name, &start, &regSeasonEnd, &finalsEnd, &active, &regOpen)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, err
@@ -155,7 +160,7 @@ func scanSeason(row any) (*Season, error) {
 			return nil, errors.Wrap(err, "row.Scan")
 		}
 	case *sql.Rows:
-		err := r.Scan(&s.ID, &s.Name, &start, &regSeasonEnd, &finalsEnd, &active, &regOpen)
+		err := r.Scan(&s.ID, &s.GuildID, &s.Name, &start, &regSeasonEnd, &finalsEnd, &active, &regOpen)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, err
@@ -204,11 +209,13 @@ func (s *Season) RegistrationStatusString() string {
 	}
 }
 
+// Set the dates of the season from text dates in format "02/01/2006". Each
+// date is midnight at the start of that day in the location
 func (s *Season) SetDates(
 	ctx context.Context,
 	tx *db.SafeWTX,
 	startStr, endStr, finalsStr string,
-	locale string,
+	loc *time.Location,
 ) error {
 	query := ""
 	var startTime *time.Time
@@ -217,7 +224,7 @@ func (s *Season) SetDates(
 	if startStr == "" {
 		query = query + `UPDATE season SET start = NULL WHERE id = "` + s.ID + `";`
 	} else {
-		startTime = parseTextDate(startStr)
+		startTime = parseTextDate(startStr, loc)
 		if startTime != nil {
 			timeStr := formatISO8601(startTime)
 			newq := fmt.Sprintf(
@@ -231,9 +238,8 @@ func (s *Season) SetDates(
 	if endStr == "" {
 		query = query + `UPDATE season SET reg_season_end = NULL WHERE id = "` + s.ID + `";`
 	} else {
-		endTime = parseTextDate(endStr)
+		endTime = parseTextDate(endStr, loc)
 		if endTime != nil {
-			endTime = TimeInLocale(endTime, locale)
 			timeStr := formatISO8601(endTime)
 			newq := fmt.Sprintf(
 				`UPDATE season SET reg_season_end = "%s" WHERE id = "%s";`,
@@ -246,9 +252,8 @@ func (s *Season) SetDates(
 	if finalsStr == "" {
 		query = query + `UPDATE season SET finals_end = NULL WHERE id = "` + s.ID + `";`
 	} else {
-		finalsEndTime = parseTextDate(finalsStr)
+		finalsEndTime = parseTextDate(finalsStr, loc)
 		if finalsEndTime != nil {
-			finalsEndTime = TimeInLocale(finalsEndTime, locale)
 			timeStr := formatISO8601(finalsEndTime)
 			newq := fmt.Sprintf(
 				`UPDATE season SET finals_end = "%s" WHERE id = "%s";`,
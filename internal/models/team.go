@@ -14,6 +14,7 @@ import (
 // Each row represents a single team
 type Team struct {
 	ID           uint16 // unique ID
+	GuildID      string // discord server the team plays in
 	Abbreviation string // unique abbreviation of team name
 	Name         string // unique team name
 	ManagerID    uint16 // FK -> Player.ID
@@ -22,16 +23,18 @@ type Team struct {
 	Logo         string // logo URL from team_logo table
 }
 
+// Check if a team in the guild has the name, ignoring case
 func CheckTeamNameExists(
 	ctx context.Context,
 	tx db.SafeTX,
+	guildID string,
 	name string,
 ) (bool, error) {
 	query := `
 SELECT EXISTS (
-    SELECT 1 FROM team WHERE name = ? COLLATE NOCASE
+    SELECT 1 FROM team WHERE guild_id = ? AND name = ? COLLATE NOCASE
 );`
-	row, err := tx.QueryRow(ctx, query, name)
+	row, err := tx.QueryRow(ctx, query, guildID, name)
 	if err != nil {
 		return false, errors.Wrap(err, "tx.QueryRow")
 	}
@@ -47,16 +50,18 @@ SELECT EXISTS (
 	}
 }
 
+// Check if a team in the guild has the abbreviation, ignoring case
 func CheckTeamAbbrExists(
 	ctx context.Context,
 	tx db.SafeTX,
+	guildID string,
 	abbr string,
 ) (bool, error) {
 	query := `
 SELECT EXISTS (
-    SELECT 1 FROM team WHERE abbreviation = ? COLLATE NOCASE
+    SELECT 1 FROM team WHERE guild_id = ? AND abbreviation = ? COLLATE NOCASE
 );`
-	row, err := tx.QueryRow(ctx, query, abbr)
+	row, err := tx.QueryRow(ctx, query, guildID, abbr)
 	if err != nil {
 		return false, errors.Wrap(err, "tx.QueryRow")
 	}
@@ -78,7 +83,7 @@ func GetTeamByID(
 	id uint16,
 ) (*Team, error) {
 	query := `
-SELECT t.id, t.abbreviation, t.name, t.manager_id, p.name, t.color, tl.url
+SELECT t.id, t.guild_id, t.abbreviation, t.name, t.manager_id, p.name, t.color, tl.url
 FROM team t
 JOIN player p ON t.manager_id = p.id
 LEFT JOIN team_logo tl ON tl.team_id = t.id
@@ -95,7 +100,7 @@ WHERE t.id = ?;`
 	var team Team
 	var color string
 	var logo sql.NullString
-	err = row.Scan(&team.ID, &team.Abbreviation, &team.Name,
+	err = row.Scan(&team.ID, &team.GuildID, &team.Abbreviation, &team.Name,
 		&team.ManagerID, &team.ManagerName, &color, &logo)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -115,17 +120,50 @@ WHERE t.id = ?;`
 	return &team, nil
 }
 
+// Get a team in the guild by its name or abbreviation, ignoring case.
+// Returns nil if no team matches
+func GetTeamByName(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+	name string,
+) (*Team, error) {
+	query := `
+SELECT id FROM team
+WHERE guild_id = ?1 AND (name = ?2 COLLATE NOCASE OR abbreviation = ?2 COLLATE NOCASE)
+LIMIT 1;`
+	row, err := tx.QueryRow(ctx, query, guildID, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	var id uint16
+	err = row.Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	team, err := GetTeamByID(ctx, tx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTeamByID")
+	}
+	return team, nil
+}
+
+// Create a team playing in the guild
 func CreateTeam(
 	ctx context.Context,
 	tx *db.SafeWTX,
+	guildID string,
 	name string,
 	abbr string,
 	managerid uint16,
 ) (*Team, error) {
 	query := `
-INSERT INTO team (name, abbreviation, manager_id)
-VALUES (?,?,?);`
-	res, err := tx.Exec(ctx, query, name, abbr, managerid)
+INSERT INTO team (guild_id, name, abbreviation, manager_id)
+VALUES (?,?,?,?);`
+	res, err := tx.Exec(ctx, query, guildID, name, abbr, managerid)
 	if err != nil {
 		return nil, errors.Wrap(err, "tx.Exec")
 	}
@@ -151,8 +189,10 @@ func (t *Team) Players(
 	windowEnd *time.Time,
 ) (*[]Player, error) {
 	query := `
-SELECT p.id, p.slap_id, p.name, p.discord_id FROM player p
+SELECT p.id, p.slap_id, p.name, p.discord_id,
+    ps.username, ps.rank, ps.mmr, ps.updated FROM player p
 JOIN player_team pt ON p.id = pt.player_id
+LEFT JOIN player_slapshot ps ON ps.player_id = p.id
 WHERE pt.team_id = ?
 AND (
     pt.joined <= ? 
@@ -175,10 +215,13 @@ AND (
 	players := []Player{}
 	for rows.Next() {
 		var player Player
-		err = rows.Scan(&player.ID, &player.SlapID, &player.Name, &player.DiscordID)
+		var slapshot nullSlapshotInfo
+		err = rows.Scan(&player.ID, &player.SlapID, &player.Name, &player.DiscordID,
+			&slapshot.username, &slapshot.rank, &slapshot.mmr, &slapshot.updated)
 		if err != nil {
 			return nil, errors.Wrap(err, "rows.Scan")
 		}
+		player.Slapshot = slapshot.info()
 		players = append(players, player)
 	}
 	return &players, nil
@@ -209,11 +252,11 @@ SELECT EXISTS (
 		return errors.New("Team cannot be disbanded as they are in an active league")
 	}
 	query = `
-UPDATE player_team SET left = ?
+UPDATE player_team SET left = ?, left_reason = ?
 WHERE team_id = ? AND left IS NULL;
     `
 	now := time.Now()
-	_, err = tx.Exec(ctx, query, formatISO8601(&now), t.ID)
+	_, err = tx.Exec(ctx, query, formatISO8601(&now), MoveDisbanded, t.ID)
 	if err != nil {
 		return errors.Wrap(err, "tx.Exec")
 	}
@@ -224,10 +267,14 @@ func (t *Team) InvitePlayer(
 	ctx context.Context,
 	tx *db.SafeWTX,
 	playerID uint16,
+	offer string,
 ) (*PlayerTeamInvite, error) {
+	if offer != OfferInvite && offer != OfferTrial && offer != OfferSigning {
+		return nil, errors.New("Invalid offer type")
+	}
 	query := `
-INSERT INTO player_team_invite (player_id, team_id, approved)
-SELECT ?, ?, 
+INSERT INTO player_team_invite (player_id, team_id, offer, approved)
+SELECT ?, ?, ?, 
     CASE
         WHEN EXISTS (
             SELECT 1 FROM team_registration tr
@@ -238,7 +285,7 @@ SELECT ?, ?,
         ) THEN NULL
         ELSE 1
     END;`
-	result, err := tx.Exec(ctx, query, playerID, t.ID, t.ID)
+	result, err := tx.Exec(ctx, query, playerID, t.ID, offer, t.ID)
 	if err != nil {
 		return nil, errors.Wrap(err, "tx.Exec")
 	}
@@ -259,7 +306,8 @@ func (t *Team) InvitedPlayers(
 	tx db.SafeTX,
 ) (*[]PlayerTeamInvite, error) {
 	query := `
-SELECT pti.id, pti.player_id, p.name, pti.team_id, t.name, pti.status, pti.approved
+SELECT pti.id, pti.player_id, p.name, pti.team_id, t.name, pti.status, pti.approved,
+    pti.offer
 FROM player_team_invite pti
 JOIN player p ON pti.player_id = p.id
 JOIN team t ON pti.team_id = t.id
@@ -285,6 +333,7 @@ WHERE pti.team_id = ? AND (
 			&playerinv.TeamName,
 			&status,
 			&approved,
+			&playerinv.Offer,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "rows.Scan")
@@ -386,6 +435,34 @@ func (t *Team) GetManager(ctx context.Context, tx db.SafeTX) (*Player, error) {
 	return &player, nil
 }
 
+// Make the player the manager of the team. The player must be on the team
+func (t *Team) SetManager(ctx context.Context, tx *db.SafeWTX, player *Player) error {
+	var onTeam int
+	query := `
+SELECT EXISTS (
+    SELECT 1 FROM player_team WHERE team_id = ? AND player_id = ? AND left IS NULL
+);`
+	row, err := tx.QueryRow(ctx, query, t.ID, player.ID)
+	if err != nil {
+		return errors.Wrap(err, "tx.QueryRow")
+	}
+	err = row.Scan(&onTeam)
+	if err != nil {
+		return errors.Wrap(err, "row.Scan")
+	}
+	if onTeam == 0 {
+		return errors.New("VE:" + player.Name + " is not on " + t.Name)
+	}
+	query = `UPDATE team SET manager_id = ? WHERE id = ?;`
+	_, err = tx.Exec(ctx, query, player.ID, t.ID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	t.ManagerID = player.ID
+	t.ManagerName = player.Name
+	return nil
+}
+
 func (t *Team) SetColor(ctx context.Context, tx *db.SafeWTX, hexStr string) error {
 	matched, _ := regexp.MatchString("^[0-9a-fA-F]{6}$", hexStr)
 	if !matched {
@@ -401,6 +478,10 @@ func (t *Team) SetColor(ctx context.Context, tx *db.SafeWTX, hexStr string) erro
 		return errors.Wrap(err, "hexToInt")
 	}
 	t.Color = hexInt
+	err = t.updateActiveBranding(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "t.updateActiveBranding")
+	}
 	return nil
 }
 
@@ -413,7 +494,24 @@ func (t *Team) Register(
 	if preferredLeague != "Open" && preferredLeague != "IM" && preferredLeague != "Pro" {
 		return nil, errors.New("Invalid division, must be 'Open', 'IM', or 'Pro'")
 	}
+	var sameGuild int
 	query := `
+SELECT EXISTS (
+    SELECT 1 FROM season s JOIN team t ON t.guild_id = s.guild_id
+    WHERE s.id = ? AND t.id = ?
+);`
+	row, err := tx.QueryRow(ctx, query, seasonID, t.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	err = row.Scan(&sameGuild)
+	if err != nil {
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	if sameGuild == 0 {
+		return nil, errors.New("VE:Team plays in another server's league")
+	}
+	query = `
 INSERT INTO team_registration(team_id, season_id, preferred_league)
 VALUES (?, ?, ?);
 `
@@ -431,7 +529,7 @@ FROM team_registration tr
 JOIN season s ON tr.season_id = s.id
 WHERE tr.id = ?;
 `
-	row, err := tx.QueryRow(ctx, query, trID)
+	row, err = tx.QueryRow(ctx, query, trID)
 	if err != nil {
 		return nil, errors.Wrap(err, "tx.QueryRow")
 	}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"gosl/pkg/db"
+
+	"github.com/pkg/errors"
+)
+
+// Model of the team_branding table in the database
+// Each row represents the name, abbreviation, colour and logo a team had
+// while playing in a season
+type TeamBranding struct {
+	TeamID       uint16 // FK -> Team.ID
+	SeasonID     string // FK -> Season.ID
+	SeasonName   string // from Season.Name
+	Name         string // team name during the season
+	Abbreviation string // team abbreviation during the season
+	Color        int    // colour hex during the season
+	Logo         string // logo URL during the season
+}
+
+// Record the teams current branding against the season. If the season already
+// has branding recorded for the team it will be overwritten
+func (t *Team) RecordBranding(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	seasonID string,
+) error {
+	query := `
+INSERT INTO team_branding(team_id, season_id, name, abbreviation, color, logo)
+SELECT t.id, ?, t.name, t.abbreviation, COALESCE(t.color, ''),
+    COALESCE((
+        SELECT url FROM team_logo
+        WHERE team_id = t.id
+        ORDER BY uploaded DESC LIMIT 1
+    ), '')
+FROM team t WHERE t.id = ?
+ON CONFLICT(team_id, season_id) DO UPDATE SET
+    name = excluded.name,
+    abbreviation = excluded.abbreviation,
+    color = excluded.color,
+    logo = excluded.logo;`
+	_, err := tx.Exec(ctx, query, seasonID, t.ID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// If the team is placed into a league in the active season, update the
+// branding recorded for that season. Past seasons are left untouched
+func (t *Team) updateActiveBranding(ctx context.Context, tx *db.SafeWTX) error {
+	query := `
+SELECT l.season_id FROM team_league tl
+JOIN league l ON tl.league_id = l.id
+JOIN season s ON l.season_id = s.id
+WHERE tl.team_id = ? AND s.active = 1;`
+	row, err := tx.QueryRow(ctx, query, t.ID)
+	if err != nil {
+		return errors.Wrap(err, "tx.QueryRow")
+	}
+	var seasonID string
+	err = row.Scan(&seasonID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return errors.Wrap(err, "row.Scan")
+	}
+	err = t.RecordBranding(ctx, tx, seasonID)
+	if err != nil {
+		return errors.Wrap(err, "t.RecordBranding")
+	}
+	return nil
+}
+
+// Get the branding the team has had in every season it has played in,
+// ordered from oldest to newest season
+func (t *Team) BrandingHistory(
+	ctx context.Context,
+	tx db.SafeTX,
+) (*[]TeamBranding, error) {
+	query := `
+SELECT tb.team_id, tb.season_id, s.name, tb.name, tb.abbreviation,
+    tb.color, tb.logo
+FROM team_branding tb
+JOIN season s ON tb.season_id = s.id
+WHERE tb.team_id = ?
+ORDER BY s.start;`
+	rows, err := tx.Query(ctx, query, t.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	history := []TeamBranding{}
+	for rows.Next() {
+		branding, err := scanTeamBranding(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "scanTeamBranding")
+		}
+		history = append(history, *branding)
+	}
+	return &history, nil
+}
+
+func scanTeamBranding(row any) (*TeamBranding, error) {
+	var branding TeamBranding
+	var color string
+	dest := []any{
+		&branding.TeamID,
+		&branding.SeasonID,
+		&branding.SeasonName,
+		&branding.Name,
+		&branding.Abbreviation,
+		&color,
+		&branding.Logo,
+	}
+	switch r := row.(type) {
+	case *sql.Row:
+		err := r.Scan(dest...)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, err
+			}
+			return nil, errors.Wrap(err, "row.Scan")
+		}
+	case *sql.Rows:
+		err := r.Scan(dest...)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+	default:
+		return nil, errors.New("invalid row type")
+	}
+	colorint, err := hexToInt(color)
+	if err != nil {
+		branding.Color = 0x181825
+	} else {
+		branding.Color = colorint
+	}
+	return &branding, nil
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"gosl/pkg/db"
+
+	"github.com/pkg/errors"
+)
+
+// Model of the team_discord table in the database
+// Each row represents the discord role and private channels created for a team
+type TeamDiscord struct {
+	TeamID         uint16 // FK -> Team.ID
+	RoleID         string // discord role ID for the team
+	TextChannelID  string // discord ID of the private text channel
+	VoiceChannelID string // discord ID of the private voice channel
+}
+
+// Get the discord role and channels for the team. Returns nil if none have
+// been created
+func GetTeamDiscord(
+	ctx context.Context,
+	tx db.SafeTX,
+	teamID uint16,
+) (*TeamDiscord, error) {
+	query := `
+SELECT team_id, role_id, text_channel_id, voice_channel_id
+FROM team_discord WHERE team_id = ?;`
+	row, err := tx.QueryRow(ctx, query, teamID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	var td TeamDiscord
+	err = row.Scan(&td.TeamID, &td.RoleID, &td.TextChannelID, &td.VoiceChannelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	return &td, nil
+}
+
+// Get the IDs of all the teams in the guild with a discord role or channels
+func GetTeamDiscordTeamIDs(ctx context.Context, tx db.SafeTX, guildID string) ([]uint16, error) {
+	query := `
+SELECT td.team_id FROM team_discord td
+JOIN team t ON td.team_id = t.id
+WHERE t.guild_id = ?;`
+	rows, err := tx.Query(ctx, query, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	teamIDs := []uint16{}
+	for rows.Next() {
+		var teamID uint16
+		err = rows.Scan(&teamID)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		teamIDs = append(teamIDs, teamID)
+	}
+	return teamIDs, nil
+}
+
+// Save the discord role and channel IDs for the team
+func (td *TeamDiscord) Save(ctx context.Context, tx *db.SafeWTX) error {
+	query := `
+INSERT INTO team_discord(team_id, role_id, text_channel_id, voice_channel_id)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(team_id) DO UPDATE SET
+    role_id = excluded.role_id,
+    text_channel_id = excluded.text_channel_id,
+    voice_channel_id = excluded.voice_channel_id;`
+	_, err := tx.Exec(ctx, query, td.TeamID, td.RoleID, td.TextChannelID, td.VoiceChannelID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Remove the discord role and channel IDs for the team
+func (td *TeamDiscord) Remove(ctx context.Context, tx *db.SafeWTX) error {
+	query := `DELETE FROM team_discord WHERE team_id = ?;`
+	_, err := tx.Exec(ctx, query, td.TeamID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Get the teams that are placed into an enabled league in the active season of
+// the guild
+func GetActiveTeams(ctx context.Context, tx db.SafeTX, guildID string) (*[]Team, error) {
+	query := `
+SELECT tl.team_id FROM team_league tl
+JOIN league l ON tl.league_id = l.id
+JOIN season s ON l.season_id = s.id
+WHERE s.active = 1 AND s.guild_id = ? AND l.enabled = 1;`
+	rows, err := tx.Query(ctx, query, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	teamIDs := []uint16{}
+	for rows.Next() {
+		var teamID uint16
+		err = rows.Scan(&teamID)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		teamIDs = append(teamIDs, teamID)
+	}
+	rows.Close()
+	teams := []Team{}
+	for _, teamID := range teamIDs {
+		team, err := GetTeamByID(ctx, tx, teamID)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetTeamByID")
+		}
+		if team != nil {
+			teams = append(teams, *team)
+		}
+	}
+	return &teams, nil
+}
+
+// Check if the team is placed into an enabled league in the active season
+func (t *Team) IsActive(ctx context.Context, tx db.SafeTX) (bool, error) {
+	query := `
+SELECT EXISTS (
+    SELECT 1 FROM team_league tl
+    JOIN league l ON tl.league_id = l.id
+    JOIN season s ON l.season_id = s.id
+    WHERE tl.team_id = ? AND s.active = 1 AND l.enabled = 1
+);`
+	row, err := tx.QueryRow(ctx, query, t.ID)
+	if err != nil {
+		return false, errors.Wrap(err, "tx.QueryRow")
+	}
+	var exists int
+	err = row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "row.Scan")
+	}
+	return exists == 1, nil
+}
@@ -25,5 +25,10 @@ VALUES (?, ?, ?, ?, ?);
 	if err != nil {
 		return errors.Wrap(err, "tx.Exec")
 	}
+	team := &Team{ID: teamID}
+	err = team.updateActiveBranding(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "team.updateActiveBranding")
+	}
 	return nil
 }
@@ -147,6 +147,11 @@ SELECT EXISTS (
 	if err != nil {
 		return errors.Wrap(err, "tx.Exec")
 	}
+	team := &Team{ID: tr.TeamID}
+	err = team.RecordBranding(ctx, tx, tr.SeasonID)
+	if err != nil {
+		return errors.Wrap(err, "team.RecordBranding")
+	}
 	query = `SELECT division FROM league WHERE id = ?;`
 	row, err = tx.QueryRow(ctx, query, leagueID)
 	if err != nil {
@@ -161,3 +166,23 @@ SELECT EXISTS (
 	tr.Placed = leagueID
 	return nil
 }
+
+// Remove the team from the league it was placed in so it can be placed again
+func (tr *TeamRegistration) Unplace(ctx context.Context, tx *db.SafeWTX) error {
+	if tr.Placed == 0 {
+		return errors.New("VE:Team has not been placed into a League")
+	}
+	query := `DELETE FROM team_league WHERE team_id = ? AND league_id = ?;`
+	_, err := tx.Exec(ctx, query, tr.TeamID, tr.Placed)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	query = `UPDATE team_registration SET placed = 0 WHERE id = ?;`
+	_, err = tx.Exec(ctx, query, tr.ID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	tr.Placed = 0
+	tr.PlacedLeagueName = "Not yet placed"
+	return nil
+}
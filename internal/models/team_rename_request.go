@@ -0,0 +1,259 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"gosl/pkg/db"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Minimum time between a teams approved renames
+const TeamRenameCooldown = 90 * 24 * time.Hour
+
+// Model of the team_rename_request table in the database
+// Each row represents a request from a team manager to change the team name
+// and/or abbreviation
+type TeamRenameRequest struct {
+	ID           uint32     // unique ID
+	TeamID       uint16     // FK -> Team.ID
+	TeamName     string     // from Team.Name
+	TeamAbbr     string     // from Team.Abbreviation
+	ManagerID    string     // from Team.ManagerID -> Player.DiscordID
+	Name         string     // requested team name
+	Abbreviation string     // requested team abbreviation
+	Requested    *time.Time // time the request was made
+	Approved     *uint16    // nil for pending, 0 for rejected, 1 for approved
+	Reviewed     *time.Time // time the request was approved or rejected
+}
+
+func GetTeamRenameRequest(
+	ctx context.Context,
+	tx db.SafeTX,
+	requestID uint32,
+) (*TeamRenameRequest, error) {
+	query := `
+SELECT trr.id, t.id, t.name, t.abbreviation, p.discord_id, trr.name,
+    trr.abbreviation, trr.requested, trr.approved, trr.reviewed
+FROM team_rename_request trr
+JOIN team t ON trr.team_id = t.id
+JOIN player p ON t.manager_id = p.id
+WHERE trr.id = ?;`
+	row, err := tx.QueryRow(ctx, query, requestID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	var req TeamRenameRequest
+	var requested string
+	var approved sql.NullInt16
+	var reviewed sql.NullString
+	err = row.Scan(
+		&req.ID,
+		&req.TeamID,
+		&req.TeamName,
+		&req.TeamAbbr,
+		&req.ManagerID,
+		&req.Name,
+		&req.Abbreviation,
+		&requested,
+		&approved,
+		&reviewed,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	req.Requested = parseISO8601(&requested)
+	if approved.Valid {
+		appr := uint16(approved.Int16)
+		req.Approved = &appr
+	}
+	if reviewed.Valid {
+		req.Reviewed = parseISO8601(&reviewed.String)
+	}
+	return &req, nil
+}
+
+// Create a request to rename the team. Returns a VE error if the team has a
+// pending request, has been renamed within the cooldown period, or the
+// requested name or abbreviation is taken by another team
+func (t *Team) RequestRename(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	name string,
+	abbr string,
+) (*TeamRenameRequest, error) {
+	name = strings.TrimSpace(name)
+	abbr = strings.TrimSpace(abbr)
+	if name == t.Name && abbr == t.Abbreviation {
+		return nil, errors.New("VE:Name and abbreviation are unchanged")
+	}
+	var pending int
+	query := `
+SELECT EXISTS (
+    SELECT 1 FROM team_rename_request WHERE team_id = ? AND approved IS NULL
+);`
+	row, err := tx.QueryRow(ctx, query, t.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	err = row.Scan(&pending)
+	if err != nil {
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	if pending == 1 {
+		return nil, errors.New("VE:Team already has a pending rename request")
+	}
+	lastRenamed, err := t.LastRenamed(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "t.LastRenamed")
+	}
+	if lastRenamed != nil {
+		nextAllowed := lastRenamed.Add(TeamRenameCooldown)
+		if time.Now().Before(nextAllowed) {
+			return nil, errors.New(fmt.Sprintf(
+				"VE:Team was renamed recently, next rename allowed after %s",
+				DateStr(&nextAllowed)))
+		}
+	}
+	err = checkRenameAvailable(ctx, tx, t, name, abbr)
+	if err != nil {
+		return nil, err
+	}
+	query = `
+INSERT INTO team_rename_request(team_id, name, abbreviation, requested)
+VALUES (?, ?, ?, ?);`
+	now := time.Now()
+	res, err := tx.Exec(ctx, query, t.ID, name, abbr, formatISO8601(&now))
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Exec")
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, errors.Wrap(err, "res.LastInsertId")
+	}
+	req, err := GetTeamRenameRequest(ctx, tx, uint32(id))
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTeamRenameRequest")
+	}
+	return req, nil
+}
+
+// Get the time of the teams last approved rename. Returns nil if the team
+// has never been renamed
+func (t *Team) LastRenamed(ctx context.Context, tx db.SafeTX) (*time.Time, error) {
+	query := `
+SELECT MAX(reviewed) FROM team_rename_request
+WHERE team_id = ? AND approved = 1;`
+	row, err := tx.QueryRow(ctx, query, t.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+	var reviewed sql.NullString
+	err = row.Scan(&reviewed)
+	if err != nil {
+		return nil, errors.Wrap(err, "row.Scan")
+	}
+	if !reviewed.Valid {
+		return nil, nil
+	}
+	return parseISO8601(&reviewed.String), nil
+}
+
+func checkRenameAvailable(
+	ctx context.Context,
+	tx db.SafeTX,
+	t *Team,
+	name string,
+	abbr string,
+) error {
+	msg := ""
+	if !strings.EqualFold(name, t.Name) {
+		nameTaken, err := CheckTeamNameExists(ctx, tx, t.GuildID, name)
+		if err != nil {
+			return errors.Wrap(err, "CheckTeamNameExists")
+		}
+		if nameTaken {
+			msg = fmt.Sprintf("Team name '%s' is taken\n", name)
+		}
+	}
+	if !strings.EqualFold(abbr, t.Abbreviation) {
+		abbrTaken, err := CheckTeamAbbrExists(ctx, tx, t.GuildID, abbr)
+		if err != nil {
+			return errors.Wrap(err, "CheckTeamAbbrExists")
+		}
+		if abbrTaken {
+			msg = msg + fmt.Sprintf("Team abbreviation '%s' is taken", abbr)
+		}
+	}
+	if msg != "" {
+		return errors.New("VE:" + msg)
+	}
+	return nil
+}
+
+// Approve the rename request and apply the new name and abbreviation to the
+// team. Returns a VE error if the name or abbreviation has been taken since
+// the request was made
+func (r *TeamRenameRequest) Approve(ctx context.Context, tx *db.SafeWTX) error {
+	if r.Approved != nil {
+		return errors.New("VE:Rename request has already been reviewed")
+	}
+	team, err := GetTeamByID(ctx, tx, r.TeamID)
+	if err != nil {
+		return errors.Wrap(err, "GetTeamByID")
+	}
+	err = checkRenameAvailable(ctx, tx, team, r.Name, r.Abbreviation)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE team SET name = ?, abbreviation = ? WHERE id = ?;`
+	_, err = tx.Exec(ctx, query, r.Name, r.Abbreviation, r.TeamID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	err = r.review(ctx, tx, 1)
+	if err != nil {
+		return errors.Wrap(err, "r.review")
+	}
+	team.Name = r.Name
+	team.Abbreviation = r.Abbreviation
+	err = team.updateActiveBranding(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "team.updateActiveBranding")
+	}
+	return nil
+}
+
+func (r *TeamRenameRequest) Reject(ctx context.Context, tx *db.SafeWTX) error {
+	if r.Approved != nil {
+		return errors.New("VE:Rename request has already been reviewed")
+	}
+	err := r.review(ctx, tx, 0)
+	if err != nil {
+		return errors.Wrap(err, "r.review")
+	}
+	return nil
+}
+
+func (r *TeamRenameRequest) review(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	approved uint16,
+) error {
+	query := `
+UPDATE team_rename_request SET approved = ?, reviewed = ? WHERE id = ?;`
+	now := time.Now()
+	_, err := tx.Exec(ctx, query, approved, formatISO8601(&now), r.ID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	r.Approved = &approved
+	r.Reviewed = &now
+	return nil
+}
@@ -0,0 +1,142 @@
+package models_test
+
+import (
+	"gosl/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamRenameRequest(t *testing.T) {
+	conn, cfg := testConn(t)
+	ctx := t.Context()
+
+	tx, err := conn.Begin(ctx, "TestTeamRenameRequest setup")
+	require.NoError(t, err)
+	require.NoError(t, models.CreatePlayer(ctx, tx, 3001, "1", "Manager"))
+	require.NoError(t, models.CreatePlayer(ctx, tx, 3002, "2", "Rival"))
+	manager, err := models.GetPlayerBySlapID(ctx, tx, 3001)
+	require.NoError(t, err)
+	rival, err := models.GetPlayerBySlapID(ctx, tx, 3002)
+	require.NoError(t, err)
+	team, err := models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Renamers", "REN", manager.ID)
+	require.NoError(t, err)
+	_, err = models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Rivals", "RIV", rival.ID)
+	require.NoError(t, err)
+	tx.Commit()
+
+	t.Run("Requests need a new name that isn't taken", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestTeamRenameRequest taken")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		_, err = team.RequestRename(ctx, tx, " Renamers ", "REN")
+		assert.EqualError(t, err, "VE:Name and abbreviation are unchanged")
+		_, err = team.RequestRename(ctx, tx, "rivals", "riv")
+		assert.EqualError(t, err,
+			"VE:Team name 'rivals' is taken\nTeam abbreviation 'riv' is taken")
+		_, err = team.RequestRename(ctx, tx, "Rivals", "NEW")
+		assert.EqualError(t, err, "VE:Team name 'Rivals' is taken\n")
+		req, err := team.RequestRename(ctx, tx, "renamers", "RNM")
+		require.NoError(t, err)
+		assert.Equal(t, "renamers", req.Name)
+	})
+
+	var requestID uint32
+	t.Run("A team can only have one pending request", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestTeamRenameRequest pending")
+		require.NoError(t, err)
+		req, err := team.RequestRename(ctx, tx, "New Renamers", "NRN")
+		require.NoError(t, err)
+		assert.Equal(t, team.ID, req.TeamID)
+		assert.Equal(t, "Renamers", req.TeamName)
+		assert.Equal(t, "REN", req.TeamAbbr)
+		assert.Equal(t, "1", req.ManagerID)
+		assert.Equal(t, "New Renamers", req.Name)
+		assert.Equal(t, "NRN", req.Abbreviation)
+		assert.NotNil(t, req.Requested)
+		assert.Nil(t, req.Approved)
+		assert.Nil(t, req.Reviewed)
+		_, err = team.RequestRename(ctx, tx, "Other Renamers", "ORN")
+		assert.EqualError(t, err, "VE:Team already has a pending rename request")
+		requestID = req.ID
+		tx.Commit()
+	})
+	require.NotZero(t, requestID)
+
+	t.Run("Approving renames the team and starts the cooldown", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestTeamRenameRequest approve")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		req, err := models.GetTeamRenameRequest(ctx, tx, requestID)
+		require.NoError(t, err)
+		require.NoError(t, req.Approve(ctx, tx))
+		require.NotNil(t, req.Approved)
+		assert.Equal(t, uint16(1), *req.Approved)
+		assert.NotNil(t, req.Reviewed)
+		assert.EqualError(t, req.Approve(ctx, tx), "VE:Rename request has already been reviewed")
+		assert.EqualError(t, req.Reject(ctx, tx), "VE:Rename request has already been reviewed")
+
+		renamed, err := models.GetTeamByID(ctx, tx, team.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "New Renamers", renamed.Name)
+		assert.Equal(t, "NRN", renamed.Abbreviation)
+		stored, err := models.GetTeamRenameRequest(ctx, tx, req.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.Approved)
+		assert.Equal(t, uint16(1), *stored.Approved)
+		assert.Equal(t, "New Renamers", stored.TeamName)
+
+		lastRenamed, err := renamed.LastRenamed(ctx, tx)
+		require.NoError(t, err)
+		require.NotNil(t, lastRenamed)
+		_, err = renamed.RequestRename(ctx, tx, "Newer Renamers", "NNR")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "VE:Team was renamed recently")
+
+		cooledDown := time.Now().Add(-models.TeamRenameCooldown - time.Hour)
+		_, err = tx.Exec(ctx, `UPDATE team_rename_request SET reviewed = ? WHERE id = ?;`,
+			cooledDown.Format(time.RFC3339), req.ID)
+		require.NoError(t, err)
+		_, err = renamed.RequestRename(ctx, tx, "Newer Renamers", "NNR")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Approving checks the name is still free", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestTeamRenameRequest taken since")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		req, err := models.GetTeamRenameRequest(ctx, tx, requestID)
+		require.NoError(t, err)
+		_, err = models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "New Renamers", "NR2", rival.ID)
+		require.NoError(t, err)
+		assert.EqualError(t, req.Approve(ctx, tx), "VE:Team name 'New Renamers' is taken\n")
+		assert.Nil(t, req.Approved)
+		unchanged, err := models.GetTeamByID(ctx, tx, team.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamers", unchanged.Name)
+	})
+
+	t.Run("Rejecting leaves the team name and cooldown alone", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestTeamRenameRequest reject")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		req, err := models.GetTeamRenameRequest(ctx, tx, requestID)
+		require.NoError(t, err)
+		require.NoError(t, req.Reject(ctx, tx))
+		require.NotNil(t, req.Approved)
+		assert.Equal(t, uint16(0), *req.Approved)
+		assert.NotNil(t, req.Reviewed)
+
+		unchanged, err := models.GetTeamByID(ctx, tx, team.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamers", unchanged.Name)
+		assert.Equal(t, "REN", unchanged.Abbreviation)
+		lastRenamed, err := unchanged.LastRenamed(ctx, tx)
+		require.NoError(t, err)
+		assert.Nil(t, lastRenamed)
+		_, err = unchanged.RequestRename(ctx, tx, "Renamed Again", "RAG")
+		assert.NoError(t, err)
+	})
+}
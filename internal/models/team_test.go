@@ -0,0 +1,98 @@
+package models_test
+
+import (
+	"gosl/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamGuilds(t *testing.T) {
+	conn, cfg := testConn(t)
+	ctx := t.Context()
+	const otherGuild = "other-guild"
+
+	tx, err := conn.Begin(ctx, "TestTeamGuilds setup")
+	require.NoError(t, err)
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1001, "1", "Manager"))
+	require.NoError(t, models.CreatePlayer(ctx, tx, 1002, "2", "Other"))
+	manager, err := models.GetPlayerBySlapID(ctx, tx, 1001)
+	require.NoError(t, err)
+	other, err := models.GetPlayerBySlapID(ctx, tx, 1002)
+	require.NoError(t, err)
+	team, err := models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Guilders", "GLD", manager.ID)
+	require.NoError(t, err)
+	require.NoError(t, manager.JoinTeamAt(ctx, tx, team.ID,
+		time.Now().Add(-2*time.Hour), models.MoveCreated, nil))
+	require.NoError(t, manager.LeaveTeamAt(ctx, tx, team.ID,
+		time.Now().Add(-time.Hour), models.MoveLeft))
+	tx.Commit()
+
+	t.Run("Names and abbreviations are only unique within a guild", func(t *testing.T) {
+		tx, err := conn.Begin(ctx, "TestTeamGuilds unique")
+		require.NoError(t, err)
+		defer tx.Rollback()
+		_, err = models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Guilders", "GLD2", other.ID)
+		assert.Error(t, err)
+		_, err = models.CreateTeam(ctx, tx, cfg.DiscordGuildID, "Guilders 2", "GLD", other.ID)
+		assert.Error(t, err)
+		copied, err := models.CreateTeam(ctx, tx, otherGuild, "Guilders", "GLD", other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, otherGuild, copied.GuildID)
+	})
+
+	tx, err = conn.Begin(ctx, "TestTeamGuilds other guild")
+	require.NoError(t, err)
+	otherTeam, err := models.CreateTeam(ctx, tx, otherGuild, "Guilders", "GLD", other.ID)
+	require.NoError(t, err)
+	require.NoError(t, manager.JoinTeamAt(ctx, tx, otherTeam.ID,
+		time.Now().Add(-time.Hour), models.MoveInvite, nil))
+	tx.Commit()
+
+	t.Run("Names and abbreviations are checked in the guild", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestTeamGuilds exists")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		for guildID, expected := range map[string]bool{
+			cfg.DiscordGuildID: true,
+			otherGuild:         true,
+			"third-guild":      false,
+		} {
+			taken, err := models.CheckTeamNameExists(ctx, rtx, guildID, "guilders")
+			require.NoError(t, err)
+			assert.Equal(t, expected, taken, guildID)
+			taken, err = models.CheckTeamAbbrExists(ctx, rtx, guildID, "gld")
+			require.NoError(t, err)
+			assert.Equal(t, expected, taken, guildID)
+		}
+	})
+
+	t.Run("Teams are found by name in the guild", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestTeamGuilds by name")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		found, err := models.GetTeamByName(ctx, rtx, cfg.DiscordGuildID, "gld")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, team.ID, found.ID)
+		found, err = models.GetTeamByName(ctx, rtx, otherGuild, "Guilders")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, otherTeam.ID, found.ID)
+		found, err = models.GetTeamByName(ctx, rtx, "third-guild", "Guilders")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Careers only include the teams in the guild", func(t *testing.T) {
+		rtx, err := conn.RBegin(ctx, "TestTeamGuilds career")
+		require.NoError(t, err)
+		defer rtx.Rollback()
+		career, err := models.GetPlayerCareer(ctx, rtx, otherGuild, manager.ID)
+		require.NoError(t, err)
+		require.Len(t, *career, 1)
+		assert.Equal(t, otherTeam.ID, (*career)[0].TeamID)
+	})
+}
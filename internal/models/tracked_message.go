@@ -0,0 +1,163 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"gosl/pkg/db"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	TrackedDirect  = "direct"  // direct message sent to a user
+	TrackedDynamic = "dynamic" // message sent to a channel on demand
+)
+
+// Model of the tracked_message table in the database
+// Each row holds a direct or dynamic message the bot is tracking, so it can
+// be expired after a restart
+type TrackedMessage struct {
+	MessageID         string
+	GuildID           string // discord server of the bot that sent the message
+	Kind              string
+	Label             string
+	ChannelID         string
+	UserID            string        // empty for dynamic messages
+	Expiry            time.Duration // zero if the message never expires
+	ExpiresAt         *time.Time    // nil if the message never expires
+	DeleteAfterExpiry bool
+	TrackedAt         time.Time // when the message was last tracked
+}
+
+// Store the tracked message, overwriting it if it is already tracked.
+// The time it was tracked is set to now
+func SetTrackedMessage(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	msg *TrackedMessage,
+) error {
+	query := `
+INSERT INTO tracked_message
+(message_id, guild_id, kind, label, channel_id, user_id, expiry, expires_at,
+    delete_after_expiry, tracked_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(message_id) DO UPDATE
+SET guild_id = excluded.guild_id,
+    kind = excluded.kind,
+    label = excluded.label,
+    channel_id = excluded.channel_id,
+    user_id = excluded.user_id,
+    expiry = excluded.expiry,
+    expires_at = excluded.expires_at,
+    delete_after_expiry = excluded.delete_after_expiry,
+    tracked_at = excluded.tracked_at;
+`
+	var expiresAt any
+	if msg.ExpiresAt != nil {
+		utc := msg.ExpiresAt.UTC()
+		expiresAt = formatISO8601(&utc)
+	}
+	msg.TrackedAt = time.Now().UTC()
+	_, err := tx.Exec(ctx, query, msg.MessageID, msg.GuildID, msg.Kind, msg.Label, msg.ChannelID,
+		msg.UserID, int64(msg.Expiry/time.Second), expiresAt, msg.DeleteAfterExpiry,
+		formatISO8601(&msg.TrackedAt))
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Stop tracking the message
+func RemoveTrackedMessage(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	messageID string,
+) error {
+	query := `
+DELETE FROM tracked_message WHERE message_id = ?;
+`
+	_, err := tx.Exec(ctx, query, messageID)
+	if err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+	return nil
+}
+
+// Get all the messages tracked for the guild
+func GetTrackedMessages(
+	ctx context.Context,
+	tx db.SafeTX,
+	guildID string,
+) (*[]TrackedMessage, error) {
+	query := `
+SELECT message_id, guild_id, kind, label, channel_id, user_id, expiry, expires_at,
+    delete_after_expiry, tracked_at
+FROM tracked_message WHERE guild_id = ?;
+`
+	rows, err := tx.Query(ctx, query, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	msgs := []TrackedMessage{}
+	for rows.Next() {
+		var msg TrackedMessage
+		var expiry int64
+		var expiresAt sql.NullString
+		var trackedAt string
+		err = rows.Scan(&msg.MessageID, &msg.GuildID, &msg.Kind, &msg.Label, &msg.ChannelID,
+			&msg.UserID, &expiry, &expiresAt, &msg.DeleteAfterExpiry, &trackedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		msg.Expiry = time.Duration(expiry) * time.Second
+		if expiresAt.Valid {
+			msg.ExpiresAt = parseISO8601(&expiresAt.String)
+		}
+		if parsed := parseISO8601(&trackedAt); parsed != nil {
+			msg.TrackedAt = *parsed
+		}
+		msgs = append(msgs, msg)
+	}
+	return &msgs, nil
+}
+
+// Stop tracking the messages for the guild that never expire and were last
+// tracked before the time provided. Returns the IDs of the messages removed
+func PruneTrackedMessages(
+	ctx context.Context,
+	tx *db.SafeWTX,
+	guildID string,
+	before time.Time,
+) (*[]string, error) {
+	utc := before.UTC()
+	trackedBefore := formatISO8601(&utc)
+	query := `
+SELECT message_id FROM tracked_message
+WHERE guild_id = ? AND expires_at IS NULL AND tracked_at < ?;
+`
+	rows, err := tx.Query(ctx, query, guildID, trackedBefore)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	messageIDs := []string{}
+	for rows.Next() {
+		var messageID string
+		err = rows.Scan(&messageID)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+	query = `
+DELETE FROM tracked_message
+WHERE guild_id = ? AND expires_at IS NULL AND tracked_at < ?;
+`
+	_, err = tx.Exec(ctx, query, guildID, trackedBefore)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Exec")
+	}
+	return &messageIDs, nil
+}
@@ -1,6 +1,13 @@
 package models
 
-import "time"
+import (
+	"context"
+	"database/sql"
+	"gosl/pkg/db"
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 // Model of the transfer_window table in the database
 type TransferWindow struct {
@@ -9,3 +16,43 @@ type TransferWindow struct {
 	Start    time.Time // start of the transfer window
 	End      time.Time // end of the transfer window
 }
+
+// Get the transfer window of the season that is open, or the one that most
+// recently closed if none are open. Returns nil if no window has started
+func GetCurrentTransferWindow(
+	ctx context.Context,
+	tx db.SafeTX,
+	seasonID string,
+) (*TransferWindow, error) {
+	query := `
+SELECT id, season_id, start, end FROM transfer_window
+WHERE season_id = ? AND start IS NOT NULL;`
+	rows, err := tx.Query(ctx, query, seasonID)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	defer rows.Close()
+	now := time.Now()
+	var current *TransferWindow
+	for rows.Next() {
+		var window TransferWindow
+		var start string
+		var end sql.NullString
+		err = rows.Scan(&window.ID, &window.SeasonID, &start, &end)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		startParsed := parseISO8601(&start)
+		if startParsed == nil || startParsed.After(now) {
+			continue
+		}
+		window.Start = *startParsed
+		if end.Valid && parseISO8601(&end.String) != nil {
+			window.End = *parseISO8601(&end.String)
+		}
+		if current == nil || window.Start.After(current.Start) {
+			current = &window
+		}
+	}
+	return current, nil
+}
@@ -11,6 +11,7 @@ import templruntime "github.com/a-h/templ/runtime"
 import "gosl/internal/view/component/nav"
 import "gosl/internal/view/component/footer"
 import "gosl/internal/view/component/popup"
+import "gosl/pkg/contexts"
 
 // Global page layout. Includes HTML document settings, header tags
 // navbar and footer
@@ -35,20 +36,33 @@ func Global(title string) templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\" x-data=\"{\n            theme: localStorage.getItem(&#39;theme&#39;)\n            || &#39;system&#39;}\" x-init=\"$watch(&#39;theme&#39;, (val) =&gt; localStorage.setItem(&#39;theme&#39;, val))\" x-bind:class=\"{&#39;dark&#39;: theme === &#39;dark&#39; || (theme === &#39;system&#39; &amp;&amp;\n            window.matchMedia(&#39;(prefers-color-scheme: dark)&#39;).matches)}\"><head><script>\n                (function () {\n                    let theme = localStorage.getItem(\"theme\") || \"system\";\n                    if (theme === \"system\") {\n                        theme = window.matchMedia(\"(prefers-color-scheme: dark)\").matches ? \"dark\" : \"light\";\n                    }\n                    if (theme === \"dark\") {\n                        document.documentElement.classList.add(\"dark\");\n                    } else {\n                        document.documentElement.classList.remove(\"dark\");\n                    }\n                })();\n            </script><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1\"><title>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var2 string
-		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(title)
+		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(contexts.GetLanguage(ctx))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/view/layout/global.templ`, Line: 36, Col: 17}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/view/layout/global.templ`, Line: 13, Col: 34}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</title><link rel=\"icon\" type=\"image/x-icon\" href=\"/static/favicon.ico\"><link href=\"/static/css/output.css\" rel=\"stylesheet\"><script src=\"https://unpkg.com/htmx.org@2.0.4\" integrity=\"sha384-HGfztofotfshcF7+8n44JQL2oJmowVChPTg48S+jvZoztPfvwD79OC/LTtG6dMp+\" crossorigin=\"anonymous\"></script><script defer src=\"https://cdn.jsdelivr.net/npm/@alpinejs/persist@3.x.x/dist/cdn.min.js\"></script><script src=\"https://unpkg.com/alpinejs\" defer></script><script>\n                // uncomment this line to enable logging of htmx events\n                // htmx.logAll();\n            </script><script>\n                const bodyData = {\n                    showError500: false,\n                    showError503: false,\n                    showConfirmPasswordModal: false,\n                    handleHtmxBeforeOnLoad(event) {\n                        const requestPath = event.detail.pathInfo.requestPath;\n                        if (requestPath === \"/reauthenticate\") {\n                            // handle password incorrect on refresh attempt\n                            if (event.detail.xhr.status === 445) {\n                                event.detail.shouldSwap = true;\n                                event.detail.isError = false;\n                            } else if (event.detail.xhr.status === 200) {\n                                this.showConfirmPasswordModal = false;\n                            }\n                        }\n                    },\n                    // handle errors from the server on HTMX requests\n                    handleHtmxError(event) {\n                        const errorCode = event.detail.errorInfo.error;\n                  \n                        // internal server error \n                        if (errorCode.includes('Code 500')) {\n                            this.showError500 = true;\n                            setTimeout(() => this.showError500 = false, 6000);\n                        }\n                        // service not available error\n                        if (errorCode.includes('Code 503')) {\n                            this.showError503 = true;\n                            setTimeout(() => this.showError503 = false, 6000);\n                        }\n                  \n                        // user is authorized but needs to refresh their login\n                        if (errorCode.includes('Code 444')) {\n                            this.showConfirmPasswordModal = true;\n                        }\n                    },\n                };\n            </script></head><body class=\"bg-base text-text ubuntu-mono-regular overflow-x-hidden\" x-data=\"bodyData\" x-on:htmx:error=\"handleHtmxError($event)\" x-on:htmx:before-on-load=\"handleHtmxBeforeOnLoad($event)\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "\" x-data=\"{\n            theme: localStorage.getItem(&#39;theme&#39;)\n            || &#39;system&#39;}\" x-init=\"$watch(&#39;theme&#39;, (val) =&gt; localStorage.setItem(&#39;theme&#39;, val))\" x-bind:class=\"{&#39;dark&#39;: theme === &#39;dark&#39; || (theme === &#39;system&#39; &amp;&amp;\n            window.matchMedia(&#39;(prefers-color-scheme: dark)&#39;).matches)}\"><head><script>\n                (function () {\n                    let theme = localStorage.getItem(\"theme\") || \"system\";\n                    if (theme === \"system\") {\n                        theme = window.matchMedia(\"(prefers-color-scheme: dark)\").matches ? \"dark\" : \"light\";\n                    }\n                    if (theme === \"dark\") {\n                        document.documentElement.classList.add(\"dark\");\n                    } else {\n                        document.documentElement.classList.remove(\"dark\");\n                    }\n                })();\n            </script><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1\"><title>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var3 string
+		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(title)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/view/layout/global.templ`, Line: 37, Col: 17}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "</title><link rel=\"icon\" type=\"image/x-icon\" href=\"/static/favicon.ico\"><link href=\"/static/css/output.css\" rel=\"stylesheet\"><script src=\"https://unpkg.com/htmx.org@2.0.4\" integrity=\"sha384-HGfztofotfshcF7+8n44JQL2oJmowVChPTg48S+jvZoztPfvwD79OC/LTtG6dMp+\" crossorigin=\"anonymous\"></script><script defer src=\"https://cdn.jsdelivr.net/npm/@alpinejs/persist@3.x.x/dist/cdn.min.js\"></script><script src=\"https://unpkg.com/alpinejs\" defer></script><script>\n                // uncomment this line to enable logging of htmx events\n                // htmx.logAll();\n            </script><script>\n                const bodyData = {\n                    showError500: false,\n                    showError503: false,\n                    showConfirmPasswordModal: false,\n                    handleHtmxBeforeOnLoad(event) {\n                        const requestPath = event.detail.pathInfo.requestPath;\n                        if (requestPath === \"/reauthenticate\") {\n                            // handle password incorrect on refresh attempt\n                            if (event.detail.xhr.status === 445) {\n                                event.detail.shouldSwap = true;\n                                event.detail.isError = false;\n                            } else if (event.detail.xhr.status === 200) {\n                                this.showConfirmPasswordModal = false;\n                            }\n                        }\n                    },\n                    // handle errors from the server on HTMX requests\n                    handleHtmxError(event) {\n                        const errorCode = event.detail.errorInfo.error;\n                  \n                        // internal server error \n                        if (errorCode.includes('Code 500')) {\n                            this.showError500 = true;\n                            setTimeout(() => this.showError500 = false, 6000);\n                        }\n                        // service not available error\n                        if (errorCode.includes('Code 503')) {\n                            this.showError503 = true;\n                            setTimeout(() => this.showError503 = false, 6000);\n                        }\n                  \n                        // user is authorized but needs to refresh their login\n                        if (errorCode.includes('Code 444')) {\n                            this.showConfirmPasswordModal = true;\n                        }\n                    },\n                };\n            </script></head><body class=\"bg-base text-text ubuntu-mono-regular overflow-x-hidden\" x-data=\"bodyData\" x-on:htmx:error=\"handleHtmxError($event)\" x-on:htmx:before-on-load=\"handleHtmxBeforeOnLoad($event)\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -60,11 +74,17 @@ func Global(title string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
+		if contexts.InMaintenance(ctx) {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<div x-init=\"showError503 = true\"></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
 		templ_7745c5c3_Err = popup.ConfirmPasswordModal().Render(ctx, templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<div id=\"main-content\" class=\"flex flex-col h-screen justify-between\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<div id=\"main-content\" class=\"flex flex-col h-screen justify-between\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -72,7 +92,7 @@ func Global(title string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<div id=\"page-content\" class=\"mb-auto md:px-5 md:pt-5\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<div id=\"page-content\" class=\"mb-auto md:px-5 md:pt-5\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -80,7 +100,7 @@ func Global(title string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -88,7 +108,7 @@ func Global(title string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</div></body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</div></body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"gosl/pkg/logging"
@@ -21,8 +22,12 @@ type Config struct {
 	ReadHeaderTimeout  time.Duration // Timeout for reading request headers in seconds
 	WriteTimeout       time.Duration // Timeout for writing requests in seconds
 	IdleTimeout        time.Duration // Timeout for idle connections in seconds
-	DBName             string        // Filename of the db - hardcoded and doubles as DB version
+	DBVersion          string        // Version of the db schema required - hardcoded
+	DBFile             string        // Path to the db file. Stays the same between versions
 	DBLockTimeout      time.Duration // Timeout for acquiring database lock
+	BackupDir          string        // Path to store database backups
+	BackupInterval     time.Duration // Time between scheduled backups in hours. 0 to disable
+	BackupRetention    int           // Number of backups to keep
 	SecretKey          string        // Secret key for signing tokens
 	AccessTokenExpiry  int64         // Access token expiry in minutes
 	RefreshTokenExpiry int64         // Refresh token expiry in minutes
@@ -31,11 +36,16 @@ type Config struct {
 	LogOutput          string        // "file", "console", or "both". Defaults to console
 	LogDir             string        // Path to create log files
 	DiscordBotToken    string        // Discord Bot Token
-	DiscordGuildID     string        // ID of the discord server
+	DiscordGuildID     string        // ID of the primary discord server
+	DiscordGuildIDs    []string      // IDs of all the discord servers, starting with the primary
+	DiscordUserToken   string        // User bearer token for editing command permissions. Disabled if empty
 	SteamAPIKey        string        // Steam API Key
 	SlapshotAPIKey     string        // Slapshot API Key
 	SlapshotAPIEnv     string        // Slapshot API Env
-	Locale             string        // IANA TZ Locale
+	SlapshotAPIURL     string        // Overrides the Slapshot API URL for the env if set
+	SlapshotRegions    []string      // Matchmaking regions to show the queue status for
+	Locale             string        // IANA TZ used for users that havent set their timezone
+	AdminAPIToken      string        // Bearer token for the admin HTTP API. Disabled if empty
 }
 
 // Load the application configuration and get a pointer to the Config object
@@ -93,8 +103,12 @@ func GetConfig(args map[string]string) (*Config, error) {
 		ReadHeaderTimeout:  GetEnvDur("READ_HEADER_TIMEOUT", 2),
 		WriteTimeout:       GetEnvDur("WRITE_TIMEOUT", 10),
 		IdleTimeout:        GetEnvDur("IDLE_TIMEOUT", 120),
-		DBName:             "00004",
+		DBVersion:          "00018",
+		DBFile:             GetEnvDefault("DB_FILE", "gosl.db"),
 		DBLockTimeout:      GetEnvDur("DB_LOCK_TIMEOUT", 60),
+		BackupDir:          GetEnvDefault("BACKUP_DIR", "backups"),
+		BackupInterval:     GetEnvDur("BACKUP_INTERVAL", 24),
+		BackupRetention:    GetEnvInt("BACKUP_RETENTION", 14),
 		SecretKey:          os.Getenv("SECRET_KEY"),
 		AccessTokenExpiry:  GetEnvInt64("ACCESS_TOKEN_EXPIRY", 5),
 		RefreshTokenExpiry: GetEnvInt64("REFRESH_TOKEN_EXPIRY", 1440), // defaults to 1 day
@@ -103,11 +117,15 @@ func GetConfig(args map[string]string) (*Config, error) {
 		LogOutput:          logOutput,
 		LogDir:             GetEnvDefault("LOG_DIR", ""),
 		DiscordBotToken:    os.Getenv("DISCORD_BOT_TOKEN"),
-		DiscordGuildID:     os.Getenv("DISCORD_GUILD_ID"),
+		DiscordGuildIDs:    GetEnvList("DISCORD_GUILD_ID"),
+		DiscordUserToken:   os.Getenv("DISCORD_USER_TOKEN"),
 		SteamAPIKey:        os.Getenv("STEAM_API_KEY"),
 		SlapshotAPIKey:     os.Getenv("SLAPSHOT_API_KEY"),
 		SlapshotAPIEnv:     GetEnvDefault("SLAPSHOT_API_ENV", "staging"),
+		SlapshotAPIURL:     os.Getenv("SLAPSHOT_API_URL"),
+		SlapshotRegions:    strings.Split(GetEnvDefault("SLAPSHOT_REGIONS", "oce-east"), ","),
 		Locale:             GetEnvDefault("LOCALE_TZ", "UTC"),
+		AdminAPIToken:      os.Getenv("ADMIN_API_TOKEN"),
 	}
 
 	if config.SecretKey == "" && args["dbver"] != "true" {
@@ -116,6 +134,9 @@ func GetConfig(args map[string]string) (*Config, error) {
 	if config.DiscordBotToken == "" && args["dbver"] != "true" {
 		return nil, errors.New("Envar not set: DISCORD_BOT_TOKEN")
 	}
+	if len(config.DiscordGuildIDs) > 0 {
+		config.DiscordGuildID = config.DiscordGuildIDs[0]
+	}
 	if config.DiscordGuildID == "" && args["dbver"] != "true" {
 		return nil, errors.New("Envar not set: DISCORD_GUILD_ID")
 	}
@@ -92,3 +92,16 @@ func GetEnvBool(key string, defaultValue bool) bool {
 
 	return defaultValue
 }
+
+// Get an environment variable as a comma separated list, dropping empty
+// entries. Returns nil if not set
+func GetEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
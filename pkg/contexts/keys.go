@@ -9,4 +9,6 @@ func (c contextKey) String() string {
 var (
 	contextKeyAuthorizedUser = contextKey("auth-user")
 	contextKeyRequestTime    = contextKey("req-time")
+	contextKeyMaintenance    = contextKey("maintenance")
+	contextKeyLanguage       = contextKey("language")
 )
@@ -0,0 +1,21 @@
+package contexts
+
+import (
+	"context"
+	"gosl/pkg/i18n"
+)
+
+// Return a new context with the language to show the page in
+func SetLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, contextKeyLanguage, lang)
+}
+
+// Get the language to show the page in. Returns the default language if
+// none was set
+func GetLanguage(ctx context.Context) string {
+	lang, ok := ctx.Value(contextKeyLanguage).(string)
+	if !ok || lang == "" {
+		return i18n.Default
+	}
+	return lang
+}
@@ -0,0 +1,14 @@
+package contexts
+
+import "context"
+
+// Return a new context marking the request as made during maintenance
+func SetMaintenance(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyMaintenance, true)
+}
+
+// Check if the request was made during maintenance
+func InMaintenance(ctx context.Context) bool {
+	maint, ok := ctx.Value(contextKeyMaintenance).(bool)
+	return ok && maint
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Write a point-in-time snapshot of the database to the path using VACUUM INTO.
+// Holds a read lock while the snapshot is taken so it waits for the database
+// to be unpaused, but doesn't block or get blocked by other transactions.
+// The path must not already exist
+func (conn *SafeConn) Backup(ctx context.Context, path string) error {
+	label := "db.Backup"
+	acquired, err := conn.waitForReadLock(ctx, label)
+	if err != nil {
+		return errors.Wrap(err, "conn.waitForReadLock")
+	}
+	defer conn.releaseReadLock(label, acquired)
+	_, err = conn.rconn.ExecContext(ctx, "VACUUM INTO ?;", path)
+	if err != nil {
+		return errors.Wrap(err, "conn.rconn.ExecContext")
+	}
+	return nil
+}
+
+// Check the database file at the path is at the expected version
+func CheckDBFileVersion(path string, expectVer int) error {
+	file := fmt.Sprintf("file:%s?mode=ro", path)
+	conn, err := sql.Open("sqlite3", file)
+	if err != nil {
+		return errors.Wrap(err, "sql.Open")
+	}
+	defer conn.Close()
+	err = checkDBVersion(conn, expectVer)
+	if err != nil {
+		return errors.Wrap(err, "checkDBVersion")
+	}
+	return nil
+}
@@ -3,7 +3,6 @@ package db
 import (
 	"database/sql"
 	"fmt"
-	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -11,34 +10,31 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
-// Returns a database connection handle for the DB
+// Returns a database connection handle for the DB file at the path. The
+// database file is created if it doesn't exist. The schema version is not
+// checked, use SchemaVersion to check it before using the connection
 func ConnectToDatabase(
-	dbName string,
+	path string,
 	logger *zerolog.Logger,
 ) (*SafeConn, error) {
 	opts := "_journal_mode=WAL&_synchronous=NORMAL&_txlock=IMMEDIATE"
-	file := fmt.Sprintf("file:%s.db?%s", dbName, opts)
+	file := fmt.Sprintf("file:%s?%s", path, opts)
 	wconn, err := sql.Open("sqlite3", file)
 	if err != nil {
 		return nil, errors.Wrap(err, "sql.Open (rw)")
 	}
 	wconn.SetMaxOpenConns(1)
+	err = wconn.Ping()
+	if err != nil {
+		return nil, errors.Wrap(err, "wconn.Ping")
+	}
 	opts = "_synchronous=NORMAL&mode=ro"
-	file = fmt.Sprintf("file:%s.db?%s", dbName, opts)
+	file = fmt.Sprintf("file:%s?%s", path, opts)
 
 	rconn, err := sql.Open("sqlite3", file)
 	if err != nil {
 		return nil, errors.Wrap(err, "sql.Open (ro)")
 	}
-
-	version, err := strconv.Atoi(dbName)
-	if err != nil {
-		return nil, errors.Wrap(err, "strconv.Atoi")
-	}
-	err = checkDBVersion(rconn, version)
-	if err != nil {
-		return nil, errors.Wrap(err, "checkDBVersion")
-	}
 	conn := MakeSafe(wconn, rconn, logger)
 	return conn, nil
 }
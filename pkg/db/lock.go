@@ -0,0 +1,215 @@
+package db
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// A read/write lock with fair (FIFO) queuing. Read locks are held by
+// transactions and any number can be held at once. The global lock is held
+// while the connection is paused and excludes all other locks. Requests are
+// granted in the order they were made, so a waiting global lock blocks any
+// read locks requested after it. Waiters can be cancelled with their context
+type rwLock struct {
+	mu      sync.Mutex
+	readers int            // number of read locks held
+	global  bool           // is the global lock held?
+	queue   *list.List     // waiting requests, *lockRequest
+	holders map[string]int // number of read locks held for each label
+	stats   lockCounters
+}
+
+// A request waiting in the queue for a lock
+type lockRequest struct {
+	global  bool          // is the request for the global lock?
+	label   string        // label of the transaction requesting a read lock
+	granted chan struct{} // closed once the lock is granted
+}
+
+type lockCounters struct {
+	acquired  uint64        // number of locks acquired
+	cancelled uint64        // number of requests cancelled before being granted
+	totalWait time.Duration // total time spent waiting by acquired locks
+	maxWait   time.Duration // longest time spent waiting by an acquired lock
+}
+
+// Snapshot of the state of the database lock
+type LockStats struct {
+	Readers       int            // number of read locks held
+	Paused        bool           // is the global lock held?
+	Waiting       int            // number of requests waiting for a lock
+	PauseWaiting  bool           // is a request for the global lock waiting?
+	Holders       map[string]int // number of read locks held by each label
+	Acquired      uint64         // total number of locks acquired
+	Cancelled     uint64         // total number of requests cancelled while waiting
+	TotalWaitTime time.Duration  // total time spent waiting by acquired locks
+	MaxWaitTime   time.Duration  // longest time spent waiting by an acquired lock
+}
+
+func newRWLock() *rwLock {
+	return &rwLock{queue: list.New(), holders: map[string]int{}}
+}
+
+// Acquire a read lock, waiting until any global lock held or requested before
+// it is released. Returns an error if the context is done before acquiring
+func (l *rwLock) rlock(ctx context.Context, label string) error {
+	return l.acquire(ctx, &lockRequest{label: label})
+}
+
+// Release a read lock
+func (l *rwLock) runlock(label string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.readers--
+	l.holders[label]--
+	if l.holders[label] <= 0 {
+		delete(l.holders, label)
+	}
+	l.grant()
+}
+
+// Acquire the global lock, waiting until all read locks held or requested
+// before it are released. Returns an error if the context is done before
+// acquiring
+func (l *rwLock) lock(ctx context.Context) error {
+	return l.acquire(ctx, &lockRequest{global: true})
+}
+
+// Acquire the global lock only if it is available right now
+func (l *rwLock) tryLock() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.global || l.readers > 0 || l.queue.Len() > 0 {
+		return false
+	}
+	l.global = true
+	l.stats.acquired++
+	return true
+}
+
+// Release the global lock. Returns false if it wasn't held
+func (l *rwLock) unlock() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.global {
+		return false
+	}
+	l.global = false
+	l.grant()
+	return true
+}
+
+func (l *rwLock) acquire(ctx context.Context, req *lockRequest) error {
+	start := time.Now()
+	l.mu.Lock()
+	if l.queue.Len() == 0 && l.available(req) {
+		l.take(req)
+		l.record(start)
+		l.mu.Unlock()
+		return nil
+	}
+	req.granted = make(chan struct{})
+	elem := l.queue.PushBack(req)
+	l.mu.Unlock()
+
+	select {
+	case <-req.granted:
+		l.mu.Lock()
+		l.record(start)
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-req.granted:
+			// granted while cancelling, so hand the lock back
+			if req.global {
+				l.global = false
+			} else {
+				l.readers--
+				l.holders[req.label]--
+				if l.holders[req.label] <= 0 {
+					delete(l.holders, req.label)
+				}
+			}
+		default:
+			l.queue.Remove(elem)
+		}
+		l.stats.cancelled++
+		// removing a request may unblock the ones behind it
+		l.grant()
+		return ctx.Err()
+	}
+}
+
+// Check if the request could be granted right now. Must hold l.mu
+func (l *rwLock) available(req *lockRequest) bool {
+	if req.global {
+		return !l.global && l.readers == 0
+	}
+	return !l.global
+}
+
+// Mark the lock as held by the request. Must hold l.mu
+func (l *rwLock) take(req *lockRequest) {
+	if req.global {
+		l.global = true
+	} else {
+		l.readers++
+		l.holders[req.label]++
+	}
+}
+
+// Grant the waiting requests at the front of the queue that can be granted,
+// stopping at the first that can't so requests are granted in order.
+// Must hold l.mu
+func (l *rwLock) grant() {
+	for elem := l.queue.Front(); elem != nil; elem = l.queue.Front() {
+		req := elem.Value.(*lockRequest)
+		if !l.available(req) {
+			return
+		}
+		l.queue.Remove(elem)
+		l.take(req)
+		close(req.granted)
+	}
+}
+
+// Record a lock being acquired after waiting since start. Must hold l.mu
+func (l *rwLock) record(start time.Time) {
+	wait := time.Since(start)
+	l.stats.acquired++
+	l.stats.totalWait += wait
+	if wait > l.stats.maxWait {
+		l.stats.maxWait = wait
+	}
+}
+
+// Get a snapshot of the state of the lock
+func (l *rwLock) snapshot() LockStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := LockStats{
+		Readers:       l.readers,
+		Paused:        l.global,
+		Waiting:       l.queue.Len(),
+		Holders:       make(map[string]int, len(l.holders)),
+		Acquired:      l.stats.acquired,
+		Cancelled:     l.stats.cancelled,
+		TotalWaitTime: l.stats.totalWait,
+		MaxWaitTime:   l.stats.maxWait,
+	}
+	for label, count := range l.holders {
+		stats.Holders[label] = count
+	}
+	for elem := l.queue.Front(); elem != nil; elem = elem.Next() {
+		if elem.Value.(*lockRequest).global {
+			stats.PauseWaiting = true
+			break
+		}
+	}
+	return stats
+}
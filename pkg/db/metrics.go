@@ -0,0 +1,47 @@
+package db
+
+import (
+	"gosl/pkg/metrics"
+	"time"
+)
+
+var (
+	txWaitTime = metrics.NewHistogramVec("gosl_db_transaction_wait_seconds",
+		"Time spent waiting for the database lock before a transaction starts",
+		nil, "label")
+	txHoldTime = metrics.NewHistogramVec("gosl_db_transaction_hold_seconds",
+		"Time a transaction held the database lock before finishing",
+		nil, "label")
+	txTimeouts = metrics.NewCounterVec("gosl_db_transaction_timeouts_total",
+		"Transactions that timed out waiting for the database lock", "label")
+	lockReaders = metrics.NewGaugeVec("gosl_db_lock_readers",
+		"Number of read locks currently held, by transaction label", "label")
+	lockWaiting = metrics.NewGaugeVec("gosl_db_lock_waiting",
+		"Number of requests currently waiting for the database lock")
+	lockPaused = metrics.NewGaugeVec("gosl_db_lock_paused",
+		"Whether the global database lock is held (1) or not (0)")
+)
+
+// Update the gauges describing the current state of the database lock.
+// Should be called before the metrics are written
+func (conn *SafeConn) UpdateMetrics() {
+	stats := conn.LockStats()
+	lockReaders.Reset()
+	for label, count := range stats.Holders {
+		lockReaders.Set(float64(count), label)
+	}
+	lockWaiting.Set(float64(stats.Waiting))
+	paused := 0.0
+	if stats.Paused {
+		paused = 1
+	}
+	lockPaused.Set(paused)
+}
+
+func observeWait(label string, start time.Time) {
+	txWaitTime.ObserveDuration(time.Since(start), label)
+}
+
+func observeHold(label string, acquired time.Time) {
+	txHoldTime.ObserveDuration(time.Since(acquired), label)
+}
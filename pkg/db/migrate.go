@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gosl/pkg/migrations"
+
+	"github.com/pkg/errors"
+)
+
+// A migration that hasn't been applied to the database
+type PendingMigration struct {
+	Version int64  // version the database will be at once applied
+	Name    string // filename of the migration
+}
+
+// Get the version of the database schema. Returns 0 if no migrations have
+// been applied
+func (conn *SafeConn) SchemaVersion(ctx context.Context) (int64, error) {
+	label := "db.SchemaVersion"
+	acquired, err := conn.waitForReadLock(ctx, label)
+	if err != nil {
+		return 0, errors.Wrap(err, "conn.waitForReadLock")
+	}
+	defer conn.releaseReadLock(label, acquired)
+	query := `SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version
+    WHERE is_applied = 1;`
+	var version int64
+	err = conn.rconn.QueryRowContext(ctx, query).Scan(&version)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "conn.rconn.QueryRowContext")
+	}
+	return version, nil
+}
+
+// Get the migrations that need to be applied to bring the database up to
+// the target version
+func (conn *SafeConn) PendingMigrations(
+	ctx context.Context,
+	target int64,
+) ([]PendingMigration, error) {
+	current, err := conn.SchemaVersion(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.SchemaVersion")
+	}
+	provider, err := migrations.NewProvider(conn.wconn)
+	if err != nil {
+		return nil, errors.Wrap(err, "migrations.NewProvider")
+	}
+	pending := []PendingMigration{}
+	for _, source := range provider.ListSources() {
+		if source.Version > current && source.Version <= target {
+			pending = append(pending, PendingMigration{
+				Version: source.Version,
+				Name:    filepath.Base(source.Path),
+			})
+		}
+	}
+	return pending, nil
+}
+
+// Apply the pending migrations up to the target version. The database is
+// paused while migrating so no other transactions can run. If backupPath is
+// not empty a snapshot is written there before any migrations are applied.
+// Returns the migrations that were applied
+func (conn *SafeConn) Migrate(
+	ctx context.Context,
+	target int64,
+	backupPath string,
+	lockTimeout time.Duration,
+) ([]PendingMigration, error) {
+	pending, err := conn.PendingMigrations(ctx, target)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.PendingMigrations")
+	}
+	if len(pending) == 0 {
+		return pending, nil
+	}
+	if !conn.Pause(lockTimeout) {
+		return nil, errors.New("Timed out waiting to pause the database for migration")
+	}
+	defer conn.Resume()
+	if backupPath != "" {
+		_, err = conn.wconn.ExecContext(ctx, "VACUUM INTO ?;", backupPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "conn.wconn.ExecContext")
+		}
+		conn.logger.Info().Str("path", backupPath).Msg("Pre-migration backup created")
+	}
+	provider, err := migrations.NewProvider(conn.wconn)
+	if err != nil {
+		return nil, errors.Wrap(err, "migrations.NewProvider")
+	}
+	results, err := provider.UpTo(ctx, target)
+	for _, result := range results {
+		conn.logger.Info().Int64("version", result.Source.Version).
+			Dur("duration", result.Duration).Msg("Migration applied")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "provider.UpTo")
+	}
+	return pending, nil
+}
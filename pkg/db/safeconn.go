@@ -10,153 +10,109 @@ import (
 )
 
 type SafeConn struct {
-	wconn               *sql.DB
-	rconn               *sql.DB
-	readLockCount       uint32
-	globalLockStatus    uint32
-	globalLockRequested uint32
-	logger              *zerolog.Logger
+	wconn  *sql.DB
+	rconn  *sql.DB
+	lock   *rwLock
+	logger *zerolog.Logger
 }
 
 // Make the provided db handle safe and attach a logger to it
 func MakeSafe(wconn *sql.DB, rconn *sql.DB, logger *zerolog.Logger) *SafeConn {
-	return &SafeConn{wconn: wconn, rconn: rconn, logger: logger}
+	return &SafeConn{wconn: wconn, rconn: rconn, lock: newRWLock(), logger: logger}
 }
 
-// Attempts to acquire a global lock on the database connection
-func (conn *SafeConn) acquireGlobalLock() bool {
-	if conn.readLockCount > 0 || conn.globalLockStatus == 1 {
-		return false
-	}
-	conn.globalLockStatus = 1
-	conn.logger.Debug().Uint32("global_lock_status", conn.globalLockStatus).
-		Msg("Global lock acquired")
-	return true
+// Release a read lock acquired at the time provided
+func (conn *SafeConn) releaseReadLock(label string, acquired time.Time) {
+	conn.lock.runlock(label)
+	observeHold(label, acquired)
+	conn.logger.Debug().Str("label", label).Msg("Read lock released")
 }
 
-// Releases a global lock on the database connection
-func (conn *SafeConn) releaseGlobalLock() {
-	conn.globalLockStatus = 0
-	conn.logger.Debug().Uint32("global_lock_status", conn.globalLockStatus).
-		Msg("Global lock released")
-}
-
-// Acquire a read lock on the connection. Multiple read locks can be acquired
-// at the same time
-func (conn *SafeConn) acquireReadLock(label string) bool {
-	if conn.globalLockStatus == 1 || conn.globalLockRequested == 1 {
-		return false
+// Wait to acquire a read lock on the connection. Multiple read locks can be
+// held at the same time. Will cancel if the context is closed/cancelled/done.
+// Returns the time the lock was acquired
+func (conn *SafeConn) waitForReadLock(ctx context.Context, label string) (time.Time, error) {
+	start := time.Now()
+	err := conn.lock.rlock(ctx, label)
+	if err != nil {
+		txTimeouts.Inc(label)
+		return time.Time{}, errors.New("Transaction time out due to database lock")
 	}
-	conn.readLockCount += 1
-	conn.logger.Debug().Uint32("read_lock_count", conn.readLockCount).
-		Str("label", label).Msg("Read lock acquired")
-	return true
+	observeWait(label, start)
+	conn.logger.Debug().Str("label", label).Msg("Read lock acquired")
+	return time.Now(), nil
 }
 
-// Release a read lock. Decrements read lock count by 1
-func (conn *SafeConn) releaseReadLock(label string) {
-	conn.readLockCount -= 1
-	conn.logger.Debug().Uint32("read_lock_count", conn.readLockCount).
-		Str("label", label).Msg("Read lock released")
+// Get a snapshot of the state of the database lock
+func (conn *SafeConn) LockStats() LockStats {
+	return conn.lock.snapshot()
 }
 
 // Starts a new transaction based on the current context. Will cancel if
 // the context is closed/cancelled/done
 func (conn *SafeConn) Begin(ctx context.Context, label string) (*SafeWTX, error) {
-	lockAcquired := make(chan struct{})
-	lockCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	go func() {
-		select {
-		case <-lockCtx.Done():
-			return
-		default:
-			if conn.acquireReadLock(label) {
-				close(lockAcquired)
-			}
-		}
-	}()
-
-	select {
-	case <-lockAcquired:
-		tx, err := conn.wconn.BeginTx(ctx, nil)
-		if err != nil {
-			conn.releaseReadLock(label)
-			return nil, err
-		}
-		return &SafeWTX{tx: tx, sc: conn, label: label}, nil
-	case <-ctx.Done():
-		cancel()
-		return nil, errors.New("Transaction time out due to database lock")
+	acquired, err := conn.waitForReadLock(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := conn.wconn.BeginTx(ctx, nil)
+	if err != nil {
+		conn.releaseReadLock(label, acquired)
+		return nil, err
 	}
+	return &SafeWTX{tx: tx, sc: conn, label: label, acquired: acquired}, nil
 }
 
 // Starts a new READONLY transaction based on the current context. Will cancel if
 // the context is closed/cancelled/done
 func (conn *SafeConn) RBegin(ctx context.Context, label string) (*SafeRTX, error) {
-	lockAcquired := make(chan struct{})
-	lockCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	go func() {
-		select {
-		case <-lockCtx.Done():
-			return
-		default:
-			if conn.acquireReadLock(label) {
-				close(lockAcquired)
-			}
-		}
-	}()
-
-	select {
-	case <-lockAcquired:
-		tx, err := conn.rconn.BeginTx(ctx, nil)
-		if err != nil {
-			conn.releaseReadLock(label)
-			return nil, err
-		}
-		return &SafeRTX{tx: tx, sc: conn, label: label}, nil
-	case <-ctx.Done():
-		cancel()
-		return nil, errors.New("Transaction time out due to database lock")
+	acquired, err := conn.waitForReadLock(ctx, label)
+	if err != nil {
+		return nil, err
 	}
+	tx, err := conn.rconn.BeginTx(ctx, nil)
+	if err != nil {
+		conn.releaseReadLock(label, acquired)
+		return nil, err
+	}
+	return &SafeRTX{tx: tx, sc: conn, label: label, acquired: acquired}, nil
 }
 
-// Acquire a global lock, preventing all transactions
-func (conn *SafeConn) Pause(timeoutAfter time.Duration) {
+// Acquire a global lock, preventing all transactions. Waits for any open
+// transactions to finish, and blocks any new ones while waiting.
+// Returns false if the lock could not be acquired before the timeout
+func (conn *SafeConn) Pause(timeoutAfter time.Duration) bool {
 	conn.logger.Info().Msg("Attempting to acquire global database lock")
-	conn.globalLockRequested = 1
-	defer func() { conn.globalLockRequested = 0 }()
-	timeout := time.After(timeoutAfter)
-	attempt := 0
-	for {
-		if conn.acquireGlobalLock() {
-			conn.logger.Info().Msg("Global database lock acquired")
-			return
-		}
-		select {
-		case <-timeout:
-			conn.logger.Info().Msg("Timeout: Global database lock abandoned")
-			return
-		case <-time.After(100 * time.Millisecond):
-			attempt++
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutAfter)
+	defer cancel()
+	err := conn.lock.lock(ctx)
+	if err != nil {
+		conn.logger.Info().Msg("Timeout: Global database lock abandoned")
+		return false
 	}
+	conn.logger.Info().Msg("Global database lock acquired")
+	return true
 }
 
 // Release the global lock
 func (conn *SafeConn) Resume() {
-	conn.releaseGlobalLock()
+	if !conn.lock.unlock() {
+		conn.logger.Warn().Msg("Global database lock not held")
+		return
+	}
 	conn.logger.Info().Msg("Global database lock released")
 }
 
 // Close the database connection
 func (conn *SafeConn) Close() error {
 	conn.logger.Debug().Msg("Acquiring global lock for connection close")
-	conn.acquireGlobalLock()
-	defer conn.releaseGlobalLock()
+	if conn.lock.tryLock() {
+		defer conn.lock.unlock()
+	}
 	conn.logger.Debug().Msg("Closing database connection")
+	err := conn.rconn.Close()
+	if err != nil {
+		return errors.Wrap(err, "conn.rconn.Close")
+	}
 	return conn.wconn.Close()
 }
@@ -16,7 +16,7 @@ func TestSafeConn(t *testing.T) {
 	cfg, err := tests.TestConfig()
 	require.NoError(t, err)
 	logger := tests.NilLogger()
-	ver, err := strconv.ParseInt(cfg.DBName, 10, 0)
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
 	require.NoError(t, err)
 	wconn, rconn, err := tests.SetupTestDB(ver)
 	require.NoError(t, err)
@@ -36,20 +36,21 @@ func TestSafeConn(t *testing.T) {
 			engaged.Done()
 		}()
 		requested.Wait()
-		assert.Equal(t, uint32(0), sconn.globalLockStatus)
-		assert.Equal(t, uint32(1), sconn.globalLockRequested)
+		assert.Eventually(t, func() bool { return sconn.LockStats().PauseWaiting },
+			time.Second, 10*time.Millisecond)
+		assert.False(t, sconn.LockStats().Paused)
 		tx.Commit()
 		engaged.Wait()
-		assert.Equal(t, uint32(1), sconn.globalLockStatus)
-		assert.Equal(t, uint32(0), sconn.globalLockRequested)
+		assert.True(t, sconn.LockStats().Paused)
+		assert.False(t, sconn.LockStats().PauseWaiting)
 		sconn.Resume()
 	})
 	t.Run("Lock abandons after timeout", func(t *testing.T) {
 		tx, err := sconn.Begin(t.Context(), "TestSafeConn()")
 		require.NoError(t, err)
-		sconn.Pause(250 * time.Millisecond)
-		assert.Equal(t, uint32(0), sconn.globalLockStatus)
-		assert.Equal(t, uint32(0), sconn.globalLockRequested)
+		assert.False(t, sconn.Pause(250*time.Millisecond))
+		assert.False(t, sconn.LockStats().Paused)
+		assert.False(t, sconn.LockStats().PauseWaiting)
 		tx.Commit()
 	})
 	t.Run("TX abandons after timeout", func(t *testing.T) {
@@ -73,8 +74,9 @@ func TestSafeConn(t *testing.T) {
 			engaged.Done()
 		}()
 		requested.Wait()
-		assert.Equal(t, uint32(0), sconn.globalLockStatus)
-		assert.Equal(t, uint32(1), sconn.globalLockRequested)
+		assert.Eventually(t, func() bool { return sconn.LockStats().PauseWaiting },
+			time.Second, 10*time.Millisecond)
+		assert.False(t, sconn.LockStats().Paused)
 		ctx, cancel := context.WithTimeout(t.Context(), 250*time.Millisecond)
 		defer cancel()
 		_, err = sconn.Begin(ctx, "TestSafeConn")
@@ -88,12 +90,91 @@ func TestSafeConn(t *testing.T) {
 		require.NoError(t, err)
 		tx.Commit()
 	})
+	t.Run("Cancelled pause unblocks transactions queued behind it", func(t *testing.T) {
+		tx, err := sconn.RBegin(t.Context(), "TestSafeConn")
+		require.NoError(t, err)
+		var paused sync.WaitGroup
+		paused.Add(1)
+		go func() {
+			defer paused.Done()
+			assert.False(t, sconn.Pause(250*time.Millisecond))
+		}()
+		require.Eventually(t, func() bool { return sconn.LockStats().PauseWaiting },
+			time.Second, 10*time.Millisecond)
+		tx2, err := sconn.RBegin(t.Context(), "TestSafeConn")
+		require.NoError(t, err)
+		assert.Equal(t, 2, sconn.LockStats().Readers)
+		paused.Wait()
+		tx.Commit()
+		tx2.Commit()
+	})
+	t.Run("Stats track holders by label", func(t *testing.T) {
+		tx1, err := sconn.RBegin(t.Context(), "label one")
+		require.NoError(t, err)
+		tx2, err := sconn.RBegin(t.Context(), "label one")
+		require.NoError(t, err)
+		tx3, err := sconn.Begin(t.Context(), "label two")
+		require.NoError(t, err)
+		stats := sconn.LockStats()
+		assert.Equal(t, 3, stats.Readers)
+		assert.Equal(t, map[string]int{"label one": 2, "label two": 1}, stats.Holders)
+		tx1.Commit()
+		tx2.Rollback()
+		tx3.Commit()
+		assert.Empty(t, sconn.LockStats().Holders)
+	})
+	t.Run("Concurrent transactions and pauses", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for n := range 200 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				timeout := time.Second
+				if n%5 == 0 {
+					timeout = time.Millisecond
+				}
+				ctx, cancel := context.WithTimeout(t.Context(), timeout)
+				defer cancel()
+				if n%2 == 0 {
+					tx, err := sconn.RBegin(ctx, "concurrent read")
+					if err == nil {
+						tx.Commit()
+					}
+				} else {
+					tx, err := sconn.Begin(ctx, "concurrent write")
+					if err == nil {
+						tx.Rollback()
+					}
+				}
+			}()
+			if n%40 == 0 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if sconn.Pause(time.Second) {
+						stats := sconn.LockStats()
+						assert.Equal(t, 0, stats.Readers)
+						sconn.Resume()
+					}
+				}()
+			}
+		}
+		wg.Wait()
+		stats := sconn.LockStats()
+		assert.Equal(t, 0, stats.Readers)
+		assert.Equal(t, 0, stats.Waiting)
+		assert.False(t, stats.Paused)
+		assert.Empty(t, stats.Holders)
+		tx, err := sconn.Begin(t.Context(), "TestSafeConn")
+		require.NoError(t, err)
+		tx.Commit()
+	})
 }
 func TestSafeTX(t *testing.T) {
 	cfg, err := tests.TestConfig()
 	require.NoError(t, err)
 	logger := tests.NilLogger()
-	ver, err := strconv.ParseInt(cfg.DBName, 10, 0)
+	ver, err := strconv.ParseInt(cfg.DBVersion, 10, 0)
 	require.NoError(t, err)
 	wconn, rconn, err := tests.SetupTestDB(ver)
 	require.NoError(t, err)
@@ -103,16 +184,16 @@ func TestSafeTX(t *testing.T) {
 	t.Run("Commit releases lock", func(t *testing.T) {
 		tx, err := sconn.Begin(t.Context(), "TestSafeConn")
 		require.NoError(t, err)
-		assert.Equal(t, uint32(1), sconn.readLockCount)
+		assert.Equal(t, 1, sconn.LockStats().Readers)
 		tx.Commit()
-		assert.Equal(t, uint32(0), sconn.readLockCount)
+		assert.Equal(t, 0, sconn.LockStats().Readers)
 	})
 	t.Run("Rollback releases lock", func(t *testing.T) {
 		tx, err := sconn.Begin(t.Context(), "TestSafeConn")
 		require.NoError(t, err)
-		assert.Equal(t, uint32(1), sconn.readLockCount)
+		assert.Equal(t, 1, sconn.LockStats().Readers)
 		tx.Rollback()
-		assert.Equal(t, uint32(0), sconn.readLockCount)
+		assert.Equal(t, 0, sconn.LockStats().Readers)
 	})
 	t.Run("Multiple RTX can gain read lock", func(t *testing.T) {
 		tx1, err := sconn.RBegin(t.Context(), "TestSafeConn")
@@ -128,15 +209,15 @@ func TestSafeTX(t *testing.T) {
 	t.Run("Lock acquiring times out after timeout", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), 250*time.Millisecond)
 		defer cancel()
-		sconn.acquireGlobalLock()
-		defer sconn.releaseGlobalLock()
+		require.True(t, sconn.Pause(time.Second))
+		defer sconn.Resume()
 		_, err := sconn.Begin(ctx, "TestSafeConn")
 		require.Error(t, err)
 	})
 	t.Run("Lock acquires if lock released", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(t.Context(), 250*time.Millisecond)
 		defer cancel()
-		sconn.acquireGlobalLock()
+		require.True(t, sconn.Pause(time.Second))
 		var wg sync.WaitGroup
 		wg.Add(1)
 		go func() {
@@ -145,7 +226,7 @@ func TestSafeTX(t *testing.T) {
 			tx.Commit()
 			wg.Done()
 		}()
-		sconn.releaseGlobalLock()
+		sconn.Resume()
 		wg.Wait()
 	})
 }
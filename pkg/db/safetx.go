@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -18,15 +19,17 @@ type SafeTX interface {
 
 // Extends sql.Tx for use with SafeConn
 type SafeWTX struct {
-	tx    *sql.Tx
-	sc    *SafeConn
-	label string
+	tx       *sql.Tx
+	sc       *SafeConn
+	label    string
+	acquired time.Time // time the read lock was acquired
 }
 
 type SafeRTX struct {
-	tx    *sql.Tx
-	sc    *SafeConn
-	label string
+	tx       *sql.Tx
+	sc       *SafeConn
+	label    string
+	acquired time.Time // time the read lock was acquired
 }
 
 func isWriteOperation(query string) bool {
@@ -128,7 +131,7 @@ func (stx *SafeRTX) Commit() error {
 	}
 	err := stx.tx.Commit()
 	stx.tx = nil
-	stx.sc.releaseReadLock(stx.label)
+	stx.sc.releaseReadLock(stx.label, stx.acquired)
 	return err
 }
 
@@ -139,7 +142,7 @@ func (stx *SafeWTX) Commit() error {
 	}
 	err := stx.tx.Commit()
 	stx.tx = nil
-	stx.sc.releaseReadLock(stx.label)
+	stx.sc.releaseReadLock(stx.label, stx.acquired)
 	return err
 }
 
@@ -150,7 +153,7 @@ func (stx *SafeRTX) Rollback() error {
 	}
 	err := stx.tx.Rollback()
 	stx.tx = nil
-	stx.sc.releaseReadLock(stx.label)
+	stx.sc.releaseReadLock(stx.label, stx.acquired)
 	return err
 }
 
@@ -161,6 +164,6 @@ func (stx *SafeWTX) Rollback() error {
 	}
 	err := stx.tx.Rollback()
 	stx.tx = nil
-	stx.sc.releaseReadLock(stx.label)
+	stx.sc.releaseReadLock(stx.label, stx.acquired)
 	return err
 }
@@ -0,0 +1,165 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language used when no other language is set, and for any message that
+// hasn't been translated
+const Default = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// A message catalogue for a language, loaded from locales/<code>.json
+type catalogue struct {
+	Name     string            `json:"name"`     // name of the language in that language
+	Messages map[string]string `json:"messages"` // message key -> text
+}
+
+var catalogues = mustLoad(localeFiles)
+
+func mustLoad(files fs.FS) map[string]*catalogue {
+	loaded, err := load(files)
+	if err != nil {
+		panic(fmt.Sprintf("i18n: %s", err))
+	}
+	return loaded
+}
+
+func load(files fs.FS) (map[string]*catalogue, error) {
+	paths, err := fs.Glob(files, "locales/*.json")
+	if err != nil {
+		return nil, err
+	}
+	loaded := make(map[string]*catalogue, len(paths))
+	for _, p := range paths {
+		data, err := fs.ReadFile(files, p)
+		if err != nil {
+			return nil, err
+		}
+		c := &catalogue{}
+		err = json.Unmarshal(data, c)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		loaded[strings.TrimSuffix(path.Base(p), ".json")] = c
+	}
+	if _, exists := loaded[Default]; !exists {
+		return nil, fmt.Errorf("no catalogue for the default language (%s)", Default)
+	}
+	return loaded, nil
+}
+
+// Get the message in the language, formatted with the args using fmt verbs.
+// Falls back to the default language if the message isn't translated, and
+// to the key if the message doesn't exist
+func T(lang, key string, args ...any) string {
+	text, exists := Lookup(lang, key)
+	if !exists {
+		text, exists = Lookup(Default, key)
+	}
+	if !exists {
+		return key
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Get the message in the language without falling back to the default
+// language. Reports if the message has been translated
+func Lookup(lang, key string) (string, bool) {
+	c, exists := catalogues[lang]
+	if !exists {
+		return "", false
+	}
+	text, exists := c.Messages[key]
+	return text, exists
+}
+
+// Get the codes of the supported languages, starting with the default
+func Languages() []string {
+	langs := make([]string, 0, len(catalogues))
+	for lang := range catalogues {
+		if lang != Default {
+			langs = append(langs, lang)
+		}
+	}
+	slices.Sort(langs)
+	return append([]string{Default}, langs...)
+}
+
+// Check if the language has a catalogue
+func Supported(lang string) bool {
+	_, exists := catalogues[lang]
+	return exists
+}
+
+// Get the name of the language in that language, e.g. "Français"
+func Name(lang string) string {
+	c, exists := catalogues[lang]
+	if !exists {
+		return lang
+	}
+	return c.Name
+}
+
+// Get the supported language for a locale such as "en-US" or "pt-BR".
+// Locales are matched exactly first, then by their base language. Returns
+// an empty string if the language isn't supported
+func Match(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	for lang := range catalogues {
+		if strings.EqualFold(lang, locale) {
+			return lang
+		}
+	}
+	base, _, _ := strings.Cut(locale, "-")
+	for lang := range catalogues {
+		if strings.EqualFold(lang, base) {
+			return lang
+		}
+	}
+	return ""
+}
+
+// Get the supported language the client prefers most from the value of an
+// Accept-Language header. Returns an empty string if none are supported
+func MatchAcceptLanguage(header string) string {
+	type preference struct {
+		locale string
+		q      float64
+	}
+	prefs := []preference{}
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if locale != "" && locale != "*" && q > 0 {
+			prefs = append(prefs, preference{locale, q})
+		}
+	}
+	sort.SliceStable(prefs, func(a, b int) bool { return prefs[a].q > prefs[b].q })
+	for _, pref := range prefs {
+		if lang := Match(pref.locale); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var verbPattern = regexp.MustCompile(`%[a-z]`)
+
+func TestCatalogues(t *testing.T) {
+	require.Contains(t, catalogues, Default)
+	defaults := catalogues[Default].Messages
+
+	for lang, c := range catalogues {
+		t.Run("Catalogue "+lang+" matches the default language", func(t *testing.T) {
+			assert.NotEmpty(t, c.Name)
+			for key, text := range c.Messages {
+				// command translations only localise the definitions in code
+				if strings.HasPrefix(key, "command.") {
+					continue
+				}
+				if assert.Contains(t, defaults, key) {
+					assert.Equal(t, verbPattern.FindAllString(defaults[key], -1),
+						verbPattern.FindAllString(text, -1), key)
+				}
+			}
+			for key := range defaults {
+				assert.Contains(t, c.Messages, key, "missing translation")
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Reason: down", T("en", "maintenance.reason", "down"))
+	assert.Equal(t, "Raison : down", T("fr", "maintenance.reason", "down"))
+	assert.Equal(t, "Reason: down", T("xx", "maintenance.reason", "down"))
+	assert.Equal(t, "missing.key", T("fr", "missing.key"))
+	_, exists := Lookup(Default, "command.team.description")
+	assert.False(t, exists)
+}
+
+func TestLanguages(t *testing.T) {
+	langs := Languages()
+	assert.Equal(t, Default, langs[0])
+	assert.Len(t, langs, len(catalogues))
+	assert.Equal(t, "Français", Name("fr"))
+	assert.Equal(t, "xx", Name("xx"))
+}
+
+func TestMatch(t *testing.T) {
+	assert.Equal(t, "en", Match("en-US"))
+	assert.Equal(t, "es", Match("es-ES"))
+	assert.Equal(t, "fr", Match("FR"))
+	assert.Equal(t, "de", Match("de_AT"))
+	assert.Equal(t, "", Match("ja"))
+	assert.Equal(t, "", Match(""))
+}
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	assert.Equal(t, "fr", MatchAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5"))
+	assert.Equal(t, "de", MatchAcceptLanguage("ja, en;q=0.5, de;q=0.7"))
+	assert.Equal(t, "en", MatchAcceptLanguage("ja, pt-BR;q=0.9, en-GB;q=0.1"))
+	assert.Equal(t, "", MatchAcceptLanguage("ja, *"))
+	assert.Equal(t, "", MatchAcceptLanguage(""))
+}
@@ -0,0 +1,347 @@
+// Package metrics provides counters, gauges and histograms that can be
+// exposed to Prometheus in its text exposition format.
+// Metrics are registered with a package level registry when created, so
+// packages can declare them as package level variables
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default histogram buckets in seconds, suitable for request latencies
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// A metric that can be written in the text exposition format
+type collector interface {
+	write(w io.Writer) error
+	metricName() string
+}
+
+var registry = struct {
+	mu         sync.Mutex
+	collectors []collector
+}{}
+
+func register(c collector) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for _, existing := range registry.collectors {
+		if existing.metricName() == c.metricName() {
+			panic("metrics: duplicate metric name " + c.metricName())
+		}
+	}
+	registry.collectors = append(registry.collectors, c)
+}
+
+// Name, help text and label names shared by all metric types
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (d *desc) metricName() string {
+	return d.name
+}
+
+// Get the key used to store the series with the label values
+func (d *desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d",
+			d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+func (d *desc) header(w io.Writer, kind string) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n",
+		d.name, escapeHelp(d.help), d.name, kind)
+	return err
+}
+
+// Format the label pairs for a series, with any extra pairs appended
+func (d *desc) labelPairs(key string, extra ...string) string {
+	pairs := []string{}
+	if len(d.labels) > 0 {
+		values := strings.Split(key, "\xff")
+		for i, label := range d.labels {
+			pairs = append(pairs, label+`="`+escapeValue(values[i])+`"`)
+		}
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeValue(extra[i+1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// A set of counters partitioned by label values
+type CounterVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// Create and register a new counter with the label names
+func NewCounterVec(name string, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{name: name, help: help, labels: labels},
+		values: map[string]float64{},
+	}
+	register(c)
+	return c
+}
+
+// Increment the counter with the label values by 1
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add the amount to the counter with the label values. Amount must not be
+// negative
+func (c *CounterVec) Add(amount float64, values ...string) {
+	key := c.key(values)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += amount
+}
+
+func (c *CounterVec) write(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := c.header(w, "counter")
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(c.values) {
+		_, err = fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(key),
+			formatFloat(c.values[key]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A set of gauges partitioned by label values
+type GaugeVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// Create and register a new gauge with the label names
+func NewGaugeVec(name string, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{
+		desc:   desc{name: name, help: help, labels: labels},
+		values: map[string]float64{},
+	}
+	register(g)
+	return g
+}
+
+// Set the gauge with the label values
+func (g *GaugeVec) Set(value float64, values ...string) {
+	key := g.key(values)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values[key] = value
+}
+
+// Remove all the series of the gauge. Used before setting gauges where the
+// label values seen can change between scrapes
+func (g *GaugeVec) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values = map[string]float64{}
+}
+
+func (g *GaugeVec) write(w io.Writer) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	err := g.header(w, "gauge")
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(g.values) {
+		_, err = fmt.Fprintf(w, "%s%s %s\n", g.name, g.labelPairs(key),
+			formatFloat(g.values[key]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A set of histograms partitioned by label values
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64 // number of observations in each bucket, not cumulative
+	count  uint64   // total number of observations
+	sum    float64  // sum of all observations
+}
+
+// Create and register a new histogram with the label names. Buckets are the
+// upper bounds of each bucket and must be sorted. If nil the DefaultBuckets
+// are used
+func NewHistogramVec(
+	name string,
+	help string,
+	buckets []float64,
+	labels ...string,
+) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, labels: labels},
+		buckets: buckets,
+		values:  map[string]*histogram{},
+	}
+	register(h)
+	return h
+}
+
+// Add an observation to the histogram with the label values
+func (h *HistogramVec) Observe(value float64, values ...string) {
+	key := h.key(values)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hist, exists := h.values[key]
+	if !exists {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = hist
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			hist.counts[i]++
+			break
+		}
+	}
+	hist.count++
+	hist.sum += value
+}
+
+// Add the duration as an observation in seconds
+func (h *HistogramVec) ObserveDuration(d time.Duration, values ...string) {
+	h.Observe(d.Seconds(), values...)
+}
+
+func (h *HistogramVec) write(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	err := h.header(w, "histogram")
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(h.values) {
+		hist := h.values[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += hist.counts[i]
+			_, err = fmt.Fprintf(w, "%s_bucket%s %d\n", h.name,
+				h.labelPairs(key, "le", formatFloat(bound)), cumulative)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.name, h.labelPairs(key, "le", "+Inf"), hist.count,
+			h.name, h.labelPairs(key), formatFloat(hist.sum),
+			h.name, h.labelPairs(key), hist.count)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write all the registered metrics in the text exposition format
+func Write(w io.Writer) error {
+	registry.mu.Lock()
+	collectors := make([]collector, len(registry.collectors))
+	copy(collectors, registry.collectors)
+	registry.mu.Unlock()
+	sort.Slice(collectors, func(i, j int) bool {
+		return collectors[i].metricName() < collectors[j].metricName()
+	})
+	for _, c := range collectors {
+		err := c.write(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler that serves all the registered metrics
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		Write(w)
+	})
+}
+
+// Replace any part of the value that contains a digit with a placeholder, so
+// values containing IDs don't create a new series for every ID.
+// Parts are separated by any of the separator characters
+func Normalize(value string, separators string) string {
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return strings.ContainsRune(separators, r)
+	})
+	sep := ""
+	if separators != "" {
+		sep = separators[:1]
+	}
+	for i, part := range parts {
+		if strings.ContainsAny(part, "0123456789") {
+			parts[i] = "{id}"
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func escapeValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
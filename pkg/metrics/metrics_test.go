@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	counter := NewCounterVec("test_requests_total", "Requests made", "route")
+	gauge := NewGaugeVec("test_queue_length", "Length of the queue")
+	hist := NewHistogramVec("test_duration_seconds", "Duration", []float64{0.1, 1}, "route")
+
+	counter.Inc("/players/{id}")
+	counter.Add(2, `quote"d`)
+	gauge.Set(3)
+	hist.Observe(0.05, "/")
+	hist.Observe(0.5, "/")
+	hist.Observe(5, "/")
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf))
+	out := buf.String()
+
+	expected := []string{
+		"# HELP test_requests_total Requests made\n# TYPE test_requests_total counter\n",
+		`test_requests_total{route="/players/{id}"} 1`,
+		`test_requests_total{route="quote\"d"} 2`,
+		"# TYPE test_queue_length gauge\ntest_queue_length 3\n",
+		`test_duration_seconds_bucket{route="/",le="0.1"} 1`,
+		`test_duration_seconds_bucket{route="/",le="1"} 2`,
+		`test_duration_seconds_bucket{route="/",le="+Inf"} 3`,
+		`test_duration_seconds_sum{route="/"} 5.55`,
+		`test_duration_seconds_count{route="/"} 3`,
+	}
+	for _, line := range expected {
+		require.Contains(t, out, line)
+	}
+	// metrics are written sorted by name
+	require.Less(t, strings.Index(out, "test_duration_seconds"),
+		strings.Index(out, "test_queue_length"))
+
+	t.Run("Wrong number of label values panics", func(t *testing.T) {
+		require.Panics(t, func() { counter.Inc() })
+	})
+
+	t.Run("Duplicate metric names panic", func(t *testing.T) {
+		require.Panics(t, func() { NewCounterVec("test_requests_total", "") })
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, "disband_team_{id}", Normalize("disband_team_12", "_"))
+	require.Equal(t, "remove_player_{id}_{id}", Normalize("remove_player_3_1234567890", "_"))
+	require.Equal(t, "api/public/players/steam/{id}",
+		Normalize("api/public/players/steam/76561198000000000", "/"))
+	require.Equal(t, "create_backup_button", Normalize("create_backup_button", "_"))
+}
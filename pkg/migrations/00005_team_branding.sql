@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS team_rename_request(
+    id INTEGER PRIMARY KEY,
+    team_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    abbreviation TEXT NOT NULL,
+    requested TEXT NOT NULL,
+    approved INTEGER,
+    reviewed TEXT,
+    FOREIGN KEY(team_id) REFERENCES team(id)
+) STRICT;
+
+CREATE TABLE IF NOT EXISTS team_branding(
+    team_id INTEGER NOT NULL,
+    season_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    abbreviation TEXT NOT NULL,
+    color TEXT NOT NULL DEFAULT "",
+    logo TEXT NOT NULL DEFAULT "",
+    PRIMARY KEY(team_id, season_id),
+    FOREIGN KEY(team_id) REFERENCES team(id),
+    FOREIGN KEY(season_id) REFERENCES season(id)
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_branding;
+DROP TABLE IF EXISTS team_rename_request;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS team_discord(
+    team_id INTEGER PRIMARY KEY,
+    role_id TEXT NOT NULL DEFAULT "",
+    text_channel_id TEXT NOT NULL DEFAULT "",
+    voice_channel_id TEXT NOT NULL DEFAULT "",
+    FOREIGN KEY(team_id) REFERENCES team(id)
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_discord;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS config_league_roles(
+    league TEXT PRIMARY KEY,
+    role_id TEXT NOT NULL
+) STRICT;
+
+CREATE TABLE IF NOT EXISTS league_role_member(
+    role_id TEXT NOT NULL,
+    discord_id TEXT NOT NULL,
+    PRIMARY KEY(role_id, discord_id)
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS league_role_member;
+DROP TABLE IF EXISTS config_league_roles;
+-- +goose StatementEnd
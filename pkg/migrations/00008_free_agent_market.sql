@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS free_agent_profile(
+    player_id INTEGER PRIMARY KEY,
+    positions TEXT NOT NULL DEFAULT '',
+    availability TEXT NOT NULL DEFAULT '',
+    playstyle TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    updated TEXT NOT NULL,
+    FOREIGN KEY(player_id) REFERENCES player(id)
+) STRICT;
+
+ALTER TABLE player_team_invite ADD COLUMN offer TEXT NOT NULL DEFAULT 'invite';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE player_team_invite DROP COLUMN offer;
+DROP TABLE IF EXISTS free_agent_profile;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE free_agent_profile RENAME TO player_profile;
+ALTER TABLE player_profile ADD COLUMN region TEXT NOT NULL DEFAULT '';
+ALTER TABLE player_profile ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE player_profile ADD COLUMN bio TEXT NOT NULL DEFAULT '';
+
+-- availability changes from a list of days to a list of time slots on each day
+-- players available on a day are assumed to be available for the whole day
+UPDATE player_profile SET
+    positions = REPLACE(positions, 'Defense', 'Defence'),
+    availability = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(availability,
+        'Mon', 'Mon Afternoon,Mon Evening,Mon Late'),
+        'Tue', 'Tue Afternoon,Tue Evening,Tue Late'),
+        'Wed', 'Wed Afternoon,Wed Evening,Wed Late'),
+        'Thu', 'Thu Afternoon,Thu Evening,Thu Late'),
+        'Fri', 'Fri Afternoon,Fri Evening,Fri Late'),
+        'Sat', 'Sat Afternoon,Sat Evening,Sat Late'),
+        'Sun', 'Sun Afternoon,Sun Evening,Sun Late');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE player_profile SET
+    positions = REPLACE(positions, 'Defence', 'Defense'),
+    availability = RTRIM(
+    (CASE WHEN ',' || availability LIKE '%,Mon %' THEN 'Mon,' ELSE '' END) ||
+    (CASE WHEN ',' || availability LIKE '%,Tue %' THEN 'Tue,' ELSE '' END) ||
+    (CASE WHEN ',' || availability LIKE '%,Wed %' THEN 'Wed,' ELSE '' END) ||
+    (CASE WHEN ',' || availability LIKE '%,Thu %' THEN 'Thu,' ELSE '' END) ||
+    (CASE WHEN ',' || availability LIKE '%,Fri %' THEN 'Fri,' ELSE '' END) ||
+    (CASE WHEN ',' || availability LIKE '%,Sat %' THEN 'Sat,' ELSE '' END) ||
+    (CASE WHEN ',' || availability LIKE '%,Sun %' THEN 'Sun,' ELSE '' END), ',');
+ALTER TABLE player_profile DROP COLUMN bio;
+ALTER TABLE player_profile DROP COLUMN timezone;
+ALTER TABLE player_profile DROP COLUMN region;
+ALTER TABLE player_profile RENAME TO free_agent_profile;
+-- +goose StatementEnd
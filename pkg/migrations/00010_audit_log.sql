@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_log(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created TEXT NOT NULL,
+    actor_id TEXT NOT NULL,
+    actor_name TEXT NOT NULL,
+    action TEXT NOT NULL,
+    entity TEXT NOT NULL,
+    entity_id TEXT NOT NULL DEFAULT "",
+    team_id INTEGER,
+    player_id INTEGER,
+    summary TEXT NOT NULL,
+    before TEXT,
+    after TEXT,
+    FOREIGN KEY(team_id) REFERENCES team(id),
+    FOREIGN KEY(player_id) REFERENCES player(id)
+) STRICT;
+CREATE INDEX IF NOT EXISTS idx_audit_log_team ON audit_log(team_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_player ON audit_log(player_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_log_actor;
+DROP INDEX IF EXISTS idx_audit_log_player;
+DROP INDEX IF EXISTS idx_audit_log_team;
+DROP TABLE IF EXISTS audit_log;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS player_slapshot(
+    player_id INTEGER PRIMARY KEY,
+    username TEXT NOT NULL,
+    rank TEXT NOT NULL DEFAULT "",
+    mmr INTEGER,
+    updated TEXT NOT NULL,
+    FOREIGN KEY(player_id) REFERENCES player(id)
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS player_slapshot;
+-- +goose StatementEnd
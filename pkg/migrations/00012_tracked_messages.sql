@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tracked_message(
+    message_id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL CHECK(kind IN ('direct', 'dynamic')),
+    label TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    user_id TEXT NOT NULL DEFAULT "",
+    expiry INTEGER NOT NULL DEFAULT 0,
+    expires_at TEXT,
+    delete_after_expiry INTEGER NOT NULL DEFAULT 0
+) STRICT;
+CREATE INDEX IF NOT EXISTS idx_tracked_message_expires_at
+ON tracked_message(expires_at) WHERE expires_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tracked_message_expires_at;
+DROP TABLE IF EXISTS tracked_message;
+-- +goose StatementEnd
@@ -0,0 +1,124 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Rows from before multiple guilds were supported have an empty guild_id and
+-- are claimed by the first configured guild when the bot starts
+CREATE TABLE config_roles_new(
+    guild_id TEXT NOT NULL DEFAULT '',
+    permission INTEGER NOT NULL,
+    role_id TEXT NOT NULL,
+    PRIMARY KEY(guild_id, permission, role_id)
+) STRICT;
+INSERT INTO config_roles_new (permission, role_id)
+SELECT permission, role_id FROM config_roles;
+DROP TABLE config_roles;
+ALTER TABLE config_roles_new RENAME TO config_roles;
+
+CREATE TABLE config_channels_new(
+    guild_id TEXT NOT NULL DEFAULT '',
+    purpose INTEGER NOT NULL,
+    channel_id TEXT NOT NULL,
+    PRIMARY KEY(guild_id, purpose, channel_id)
+) STRICT;
+INSERT INTO config_channels_new (purpose, channel_id)
+SELECT purpose, channel_id FROM config_channels;
+DROP TABLE config_channels;
+ALTER TABLE config_channels_new RENAME TO config_channels;
+
+CREATE TABLE config_messages_new(
+    guild_id TEXT NOT NULL DEFAULT '',
+    purpose INTEGER NOT NULL,
+    message_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    PRIMARY KEY(guild_id, purpose),
+    UNIQUE(message_id, channel_id)
+) STRICT;
+INSERT INTO config_messages_new (purpose, message_id, channel_id)
+SELECT purpose, message_id, channel_id FROM config_messages;
+DROP TABLE config_messages;
+ALTER TABLE config_messages_new RENAME TO config_messages;
+
+CREATE TABLE config_league_roles_new(
+    guild_id TEXT NOT NULL DEFAULT '',
+    league TEXT NOT NULL,
+    role_id TEXT NOT NULL,
+    PRIMARY KEY(guild_id, league)
+) STRICT;
+INSERT INTO config_league_roles_new (league, role_id)
+SELECT league, role_id FROM config_league_roles;
+DROP TABLE config_league_roles;
+ALTER TABLE config_league_roles_new RENAME TO config_league_roles;
+
+ALTER TABLE season ADD COLUMN guild_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE team ADD COLUMN guild_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE tracked_message ADD COLUMN guild_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE league_role_member ADD COLUMN guild_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_season_guild_id ON season(guild_id);
+CREATE INDEX IF NOT EXISTS idx_team_guild_id ON team(guild_id);
+
+-- each guild has its own active season
+DROP TRIGGER IF EXISTS enforce_single_active_season;
+CREATE TRIGGER IF NOT EXISTS enforce_single_active_season
+BEFORE UPDATE ON season
+FOR EACH ROW WHEN NEW.active = 1
+BEGIN
+UPDATE season SET active = 0 WHERE id != NEW.id AND guild_id = NEW.guild_id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS enforce_single_active_season;
+CREATE TRIGGER IF NOT EXISTS enforce_single_active_season
+BEFORE UPDATE ON season
+FOR EACH ROW WHEN NEW.active = 1
+BEGIN
+UPDATE season SET active = 0 WHERE id != NEW.id;
+END;
+
+DROP INDEX IF EXISTS idx_team_guild_id;
+DROP INDEX IF EXISTS idx_season_guild_id;
+ALTER TABLE league_role_member DROP COLUMN guild_id;
+ALTER TABLE tracked_message DROP COLUMN guild_id;
+ALTER TABLE team DROP COLUMN guild_id;
+ALTER TABLE season DROP COLUMN guild_id;
+
+CREATE TABLE config_league_roles_old(
+    league TEXT PRIMARY KEY,
+    role_id TEXT NOT NULL
+) STRICT;
+INSERT OR IGNORE INTO config_league_roles_old (league, role_id)
+SELECT league, role_id FROM config_league_roles;
+DROP TABLE config_league_roles;
+ALTER TABLE config_league_roles_old RENAME TO config_league_roles;
+
+CREATE TABLE config_messages_old(
+    purpose INTEGER PRIMARY KEY,
+    message_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    UNIQUE(message_id, channel_id)
+) STRICT;
+INSERT OR IGNORE INTO config_messages_old (purpose, message_id, channel_id)
+SELECT purpose, message_id, channel_id FROM config_messages;
+DROP TABLE config_messages;
+ALTER TABLE config_messages_old RENAME TO config_messages;
+
+CREATE TABLE config_channels_old(
+    purpose INTEGER NOT NULL,
+    channel_id TEXT NOT NULL,
+    PRIMARY KEY(purpose, channel_id)
+) STRICT;
+INSERT OR IGNORE INTO config_channels_old (purpose, channel_id)
+SELECT purpose, channel_id FROM config_channels;
+DROP TABLE config_channels;
+ALTER TABLE config_channels_old RENAME TO config_channels;
+
+CREATE TABLE config_roles_old(
+    permission INTEGER NOT NULL,
+    role_id TEXT NOT NULL,
+    PRIMARY KEY(permission, role_id)
+) STRICT;
+INSERT OR IGNORE INTO config_roles_old (permission, role_id)
+SELECT permission, role_id FROM config_roles;
+DROP TABLE config_roles;
+ALTER TABLE config_roles_old RENAME TO config_roles;
+-- +goose StatementEnd
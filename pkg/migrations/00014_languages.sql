@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS config_guild_language(
+    guild_id TEXT PRIMARY KEY,
+    language TEXT NOT NULL
+) STRICT;
+CREATE TABLE IF NOT EXISTS user_language(
+    discord_id TEXT PRIMARY KEY,
+    language TEXT NOT NULL
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_language;
+DROP TABLE IF EXISTS config_guild_language;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE player_team ADD COLUMN join_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE player_team ADD COLUMN left_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE player_team ADD COLUMN invite_id INTEGER REFERENCES player_team_invite(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE player_team DROP COLUMN invite_id;
+ALTER TABLE player_team DROP COLUMN left_reason;
+ALTER TABLE player_team DROP COLUMN join_reason;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tracked_message ADD COLUMN tracked_at TEXT NOT NULL DEFAULT '';
+UPDATE tracked_message SET tracked_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tracked_message DROP COLUMN tracked_at;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Team names and abbreviations only need to be unique within a guild. The
+-- UNIQUE constraints are part of the column definitions, so the table is
+-- rebuilt without them
+CREATE TABLE team_new(
+    id INTEGER PRIMARY KEY,
+    abbreviation TEXT NOT NULL,
+    name TEXT NOT NULL,
+    manager_id INTEGER NOT NULL,
+    color TEXT DEFAULT "",
+    guild_id TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY(manager_id) REFERENCES player(id)
+) STRICT;
+INSERT INTO team_new (id, abbreviation, name, manager_id, color, guild_id)
+SELECT id, abbreviation, name, manager_id, color, guild_id FROM team;
+DROP TABLE team;
+ALTER TABLE team_new RENAME TO team;
+CREATE INDEX IF NOT EXISTS idx_team_guild_id ON team(guild_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_guild_name ON team(guild_id, name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_guild_abbreviation ON team(guild_id, abbreviation);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE team_old(
+    id INTEGER PRIMARY KEY,
+    abbreviation TEXT UNIQUE NOT NULL,
+    name TEXT UNIQUE NOT NULL,
+    manager_id INTEGER NOT NULL,
+    color TEXT DEFAULT "",
+    guild_id TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY(manager_id) REFERENCES player(id)
+) STRICT;
+INSERT OR IGNORE INTO team_old (id, abbreviation, name, manager_id, color, guild_id)
+SELECT id, abbreviation, name, manager_id, color, guild_id FROM team;
+DROP TABLE team;
+ALTER TABLE team_old RENAME TO team;
+CREATE INDEX IF NOT EXISTS idx_team_guild_id ON team(guild_id);
+-- +goose StatementEnd
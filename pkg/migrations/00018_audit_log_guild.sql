@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Entries recorded before the audit log was scoped to a guild have an empty
+-- guild_id and are claimed by the first configured guild when the bot starts
+ALTER TABLE audit_log ADD COLUMN guild_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_audit_log_guild ON audit_log(guild_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_log_guild;
+ALTER TABLE audit_log DROP COLUMN guild_id;
+-- +goose StatementEnd
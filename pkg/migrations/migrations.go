@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+
+	"github.com/pkg/errors"
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed *.sql
+var migrationFiles embed.FS
+
+// Get a goose migration provider for the embedded migrations using the
+// provided db handle
+func NewProvider(db *sql.DB) (*goose.Provider, error) {
+	provider, err := goose.NewProvider(goose.DialectSQLite3, db, migrationFiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "goose.NewProvider")
+	}
+	return provider, nil
+}
@@ -0,0 +1,45 @@
+package slapshotapi
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache of successful API responses by endpoint
+type cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+func newCache() *cache {
+	return &cache{entries: map[string]cacheEntry{}}
+}
+
+// Get the cached response for the endpoint if it hasn't expired
+func (c *cache) get(endpoint string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, found := c.entries[endpoint]
+	if !found || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.data, true
+}
+
+// Store the response for the endpoint, removing any expired entries
+func (c *cache) set(endpoint string, data []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[endpoint] = cacheEntry{data: data, expires: now.Add(ttl)}
+}
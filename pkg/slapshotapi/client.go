@@ -0,0 +1,123 @@
+package slapshotapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Defaults used by new clients
+const (
+	defaultTimeout    = 10 * time.Second // timeout for a single request
+	defaultMaxRetries = 3                // retries after the first attempt
+	defaultBackoff    = 500 * time.Millisecond
+	defaultMaxWait    = 30 * time.Second // longest Retry-After that will be waited for
+)
+
+// Client for the Slapshot public API. Requests that fail from network errors,
+// rate limiting or server errors are retried with backoff, and successful
+// responses are cached for a time depending on the endpoint.
+// Safe for concurrent use
+type Client struct {
+	baseURL    string
+	key        string
+	regions    []string // matchmaking regions used when none are given
+	http       *http.Client
+	maxRetries int
+	backoff    time.Duration // wait before the first retry, doubled for each retry
+	maxWait    time.Duration
+	cache      *cache
+}
+
+// Create a new client for the API at the base URL. Regions are the default
+// matchmaking regions to use
+func NewClient(baseURL string, key string, regions []string) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		key:        key,
+		regions:    regions,
+		http:       &http.Client{Timeout: defaultTimeout},
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+		maxWait:    defaultMaxWait,
+		cache:      newCache(),
+	}
+}
+
+// Get the base URL of the API for the env, either 'api' or 'staging'
+func EnvURL(env string) (string, error) {
+	if env != "api" && env != "staging" {
+		return "", errors.New("Invalid Env specified, must be 'api' or 'staging'")
+	}
+	return fmt.Sprintf("https://%s.slapshot.gg", env), nil
+}
+
+// Get the endpoint, using the cached response if one was stored less than ttl
+// ago. If ttl is 0 the response is not cached
+func (c *Client) get(ctx context.Context, endpoint string, ttl time.Duration) ([]byte, error) {
+	if ttl > 0 {
+		data, found := c.cache.get(endpoint)
+		if found {
+			return data, nil
+		}
+	}
+	var err error
+	for attempt := 0; ; attempt++ {
+		var data []byte
+		data, err = c.request(ctx, http.MethodGet, endpoint, nil)
+		if err == nil {
+			if ttl > 0 {
+				c.cache.set(endpoint, data, ttl)
+			}
+			return data, nil
+		}
+		if attempt >= c.maxRetries || !retryable(err) {
+			break
+		}
+		wait := c.backoff << attempt
+		apiErr := &APIError{}
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		if wait > c.maxWait {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "ctx.Done")
+		case <-time.After(wait):
+		}
+	}
+	return nil, err
+}
+
+// Send a request that changes state on the server. Only retried when rate
+// limited, as the request may have been acted on for other errors
+func (c *Client) send(ctx context.Context, method string, endpoint string, body any) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		data, err := c.request(ctx, method, endpoint, body)
+		if err == nil {
+			return data, nil
+		}
+		apiErr := &APIError{}
+		if attempt >= c.maxRetries || !errors.Is(err, ErrRateLimited) ||
+			!errors.As(err, &apiErr) {
+			return nil, err
+		}
+		wait := apiErr.RetryAfter
+		if wait == 0 {
+			wait = c.backoff << attempt
+		}
+		if wait > c.maxWait {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "ctx.Done")
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package slapshotapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// Create a client for a test server running the handler, with short waits
+// between retries
+func testClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := NewClient(srv.URL, "key", []string{"oce-east"})
+	c.backoff = time.Millisecond
+	return c
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Retries server errors", func(t *testing.T) {
+		var calls atomic.Int32
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			require.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+			w.Write([]byte(`{"id":42}`))
+		})
+		slapid, err := c.GetSlapID(ctx, "7656")
+		require.NoError(t, err)
+		require.Equal(t, uint32(42), slapid)
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("Honours Retry-After", func(t *testing.T) {
+		var calls atomic.Int32
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write([]byte(`{"id":42}`))
+		})
+		start := time.Now()
+		_, err := c.GetSlapID(ctx, "7656")
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Gives up when rate limited too long", func(t *testing.T) {
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+		_, err := c.GetSlapID(ctx, "7656")
+		require.ErrorIs(t, err, ErrRateLimited)
+		apiErr := &APIError{}
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, time.Hour, apiErr.RetryAfter)
+	})
+
+	t.Run("Returns typed errors without retrying", func(t *testing.T) {
+		var calls atomic.Int32
+		status := http.StatusNotFound
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(status)
+		})
+		_, err := c.GetSlapID(ctx, "1")
+		require.ErrorIs(t, err, ErrNotFound)
+		status = http.StatusUnauthorized
+		_, err = c.GetSlapID(ctx, "2")
+		require.ErrorIs(t, err, ErrUnauthorized)
+		require.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("Reports invalid responses", func(t *testing.T) {
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`not json`))
+		})
+		_, err := c.GetQueueStatus(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("Caches responses and selects regions", func(t *testing.T) {
+		var calls atomic.Int32
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if r.URL.Query().Get("regions") == "eu-west" {
+				w.Write([]byte(`{"playlists":{"in_queue":1,"in_match":2}}`))
+				return
+			}
+			w.Write([]byte(`{"playlists":{"in_queue":3,"in_match":4}}`))
+		})
+		for range 2 {
+			queue, err := c.GetQueueStatus(ctx)
+			require.NoError(t, err)
+			require.Equal(t, PubsQueue{InQueue: 3, InMatch: 4}, *queue)
+		}
+		queue, err := c.GetQueueStatus(ctx, "eu-west")
+		require.NoError(t, err)
+		require.Equal(t, PubsQueue{InQueue: 1, InMatch: 2}, *queue)
+		require.Equal(t, int32(2), calls.Load())
+	})
+	t.Run("Gets player details", func(t *testing.T) {
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/public/players/7":
+				w.Write([]byte(`{"id":7,"username":"Puck"}`))
+			case "/api/public/players/7/ranked":
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		player, err := c.GetPlayer(ctx, 7)
+		require.NoError(t, err)
+		require.Equal(t, "Puck", player.Username)
+		rank, err := c.GetPlayerRank(ctx, 7)
+		require.NoError(t, err)
+		require.Nil(t, rank)
+	})
+	t.Run("Creates lobbies and gets their matches", func(t *testing.T) {
+		var creates atomic.Int32
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && r.URL.Path == "/api/public/lobbies":
+				// rate limited once, then created
+				if creates.Add(1) == 1 {
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				settings := LobbySettings{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&settings))
+				require.Equal(t, "Fixture 1", settings.Name)
+				require.True(t, settings.Periods)
+				w.Write([]byte(`{"success":true,"lobby_id":"lobby1"}`))
+			case r.URL.Path == "/api/public/lobbies/lobby1/matches":
+				w.Write([]byte(`["match1"]`))
+			case r.URL.Path == "/api/public/games/match1":
+				w.Write([]byte(`{"match_id":"match1"}`))
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		})
+		lobby, err := c.CreateLobby(ctx, LobbySettings{Name: "Fixture 1", Periods: true})
+		require.NoError(t, err)
+		require.Equal(t, "lobby1", lobby.ID)
+		require.Equal(t, int32(2), creates.Load())
+		matches, err := c.GetLobbyMatches(ctx, lobby.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"match1"}, matches)
+		log, err := c.GetMatch(ctx, matches[0])
+		require.NoError(t, err)
+		require.JSONEq(t, `{"match_id":"match1"}`, string(log))
+		// state changing requests aren't retried on server errors
+		err = c.DeleteLobby(ctx, lobby.ID)
+		apiErr := &APIError{}
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	})
+}
@@ -0,0 +1,74 @@
+package slapshotapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Errors returned by the API, check for them using errors.Is
+var (
+	ErrUnauthorized = errors.New("slapshot api: unauthorized")
+	ErrNotFound     = errors.New("slapshot api: not found")
+	ErrRateLimited  = errors.New("slapshot api: rate limited")
+)
+
+// Returned when the API responds with a status other than 200
+type APIError struct {
+	Endpoint   string
+	StatusCode int
+	RetryAfter time.Duration // from the Retry-After header, 0 if not set
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("slapshot api: %s responded %d %s",
+		e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Allows checking the error against ErrUnauthorized, ErrNotFound and
+// ErrRateLimited
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// Check if the request that returned the error should be retried
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		// network errors
+		return true
+	}
+	return apiErr.StatusCode == http.StatusTooManyRequests ||
+		apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+// Parse a Retry-After header, which is either a number of seconds or a date
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	date, err := http.ParseTime(header)
+	if err != nil {
+		return 0
+	}
+	return max(time.Until(date), 0)
+}
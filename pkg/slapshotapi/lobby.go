@@ -0,0 +1,93 @@
+package slapshotapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// How long a finished match's log is cached for. Logs don't change once the
+// match has ended
+const matchCacheTTL = time.Hour
+
+// Settings for a private lobby. Matches the match settings recorded in game
+// logs
+type LobbySettings struct {
+	Region        string `json:"region"`
+	Name          string `json:"name"`
+	Password      string `json:"password"`
+	CreatorName   string `json:"creator_name"`
+	Periods       bool   `json:"is_periods"`     // play in periods instead of a single game
+	CurrentPeriod uint8  `json:"current_period"` // period to start the match in
+	Arena         string `json:"arena"`
+	MercyRule     uint8  `json:"mercy_rule"`   // goal difference that ends the match, 0 to disable
+	MatchLength   uint16 `json:"match_length"` // length of each period in seconds
+	GameMode      string `json:"game_mode"`
+}
+
+// A private lobby created with the API
+type Lobby struct {
+	ID      string `json:"lobby_id"`
+	Success bool   `json:"success"`
+}
+
+// Create a private lobby with the settings. Players join using the lobby's
+// name and password
+func (c *Client) CreateLobby(ctx context.Context, settings LobbySettings) (*Lobby, error) {
+	data, err := c.send(ctx, http.MethodPost, "api/public/lobbies", settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "c.send")
+	}
+	lobby := Lobby{}
+	err = decode(data, &lobby)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
+	if !lobby.Success || lobby.ID == "" {
+		return nil, errors.New("Lobby was not created")
+	}
+	return &lobby, nil
+}
+
+// Close the lobby
+func (c *Client) DeleteLobby(ctx context.Context, lobbyID string) error {
+	endpoint := fmt.Sprintf("api/public/lobbies/%s", lobbyID)
+	_, err := c.send(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return errors.Wrap(err, "c.send")
+	}
+	return nil
+}
+
+// Get the IDs of the matches played in the lobby
+func (c *Client) GetLobbyMatches(ctx context.Context, lobbyID string) ([]string, error) {
+	endpoint := fmt.Sprintf("api/public/lobbies/%s/matches", lobbyID)
+	data, err := c.get(ctx, endpoint, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "c.get")
+	}
+	matches := []string{}
+	err = decode(data, &matches)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
+	return matches, nil
+}
+
+// Get the log of a finished match. The log is in the same format as the log
+// files saved by the game, so can be decoded the same way as uploaded logs
+func (c *Client) GetMatch(ctx context.Context, matchID string) (json.RawMessage, error) {
+	endpoint := fmt.Sprintf("api/public/games/%s", matchID)
+	data, err := c.get(ctx, endpoint, matchCacheTTL)
+	if err != nil {
+		return nil, errors.Wrap(err, "c.get")
+	}
+	if !json.Valid(data) {
+		return nil, errors.New("Match log is not valid JSON")
+	}
+	return data, nil
+}
@@ -0,0 +1,25 @@
+package slapshotapi
+
+import (
+	"gosl/pkg/metrics"
+	"strings"
+	"time"
+)
+
+var (
+	apiRequests = metrics.NewCounterVec("gosl_slapshot_api_requests_total",
+		"Requests made to the Slapshot API, by endpoint and result (status code or error)",
+		"endpoint", "result")
+	apiDuration = metrics.NewHistogramVec("gosl_slapshot_api_request_duration_seconds",
+		"Time taken for requests to the Slapshot API", nil, "endpoint")
+)
+
+// Record a request to the endpoint started at the time provided.
+// The query is removed and parts of the endpoint containing IDs are replaced
+// so they share a series
+func observeRequest(endpoint string, result string, start time.Time) {
+	endpoint, _, _ = strings.Cut(endpoint, "?")
+	endpoint = metrics.Normalize(endpoint, "/")
+	apiRequests.Inc(endpoint, result)
+	apiDuration.ObserveDuration(time.Since(start), endpoint)
+}
@@ -0,0 +1,58 @@
+package slapshotapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// How long player details are cached for
+const playerCacheTTL = 10 * time.Minute
+
+// Public details of a slapshot player
+type Player struct {
+	ID       uint32 `json:"id"`
+	Username string `json:"username"` // current in-game name
+}
+
+// Ranked matchmaking standing of a player
+type Rank struct {
+	Rank string `json:"rank"` // name of the rank tier
+	MMR  uint16 `json:"mmr"`
+}
+
+// Get the public details of the player with the SlapID. Returns ErrNotFound
+// if no player has the SlapID
+func (c *Client) GetPlayer(ctx context.Context, slapID uint32) (*Player, error) {
+	endpoint := fmt.Sprintf("api/public/players/%v", slapID)
+	data, err := c.get(ctx, endpoint, playerCacheTTL)
+	if err != nil {
+		return nil, errors.Wrap(err, "c.get")
+	}
+	player := Player{}
+	err = decode(data, &player)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
+	return &player, nil
+}
+
+// Get the ranked standing of the player. Returns nil if the player is unranked
+func (c *Client) GetPlayerRank(ctx context.Context, slapID uint32) (*Rank, error) {
+	endpoint := fmt.Sprintf("api/public/players/%v/ranked", slapID)
+	data, err := c.get(ctx, endpoint, playerCacheTTL)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "c.get")
+	}
+	rank := Rank{}
+	err = decode(data, &rank)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
+	return &rank, nil
+}
@@ -1,11 +1,17 @@
 package slapshotapi
 
 import (
-	"encoding/json"
+	"context"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
+// How long the queue status is cached for. Shorter than the bot's poll interval
+const queueStatusCacheTTL = 5 * time.Second
+
 type matchmakingresp struct {
 	Playlists PubsQueue `json:"playlists"`
 }
@@ -15,14 +21,24 @@ type PubsQueue struct {
 	InMatch uint16 `json:"in_match"`
 }
 
-// Get the SlapID of the steam user
-func GetQueueStatus(apikey string, env string) (*PubsQueue, error) {
-	endpoint := "api/public/matchmaking?regions=oce-east"
-	data, err := slapapiGet(endpoint, env, apikey)
+// Get the number of players in the public matchmaking queue and in matches
+// for the regions. If no regions are given the client's default regions are
+// used
+func (c *Client) GetQueueStatus(ctx context.Context, regions ...string) (*PubsQueue, error) {
+	if len(regions) == 0 {
+		regions = c.regions
+	}
+	query := url.Values{}
+	query.Set("regions", strings.Join(regions, ","))
+	endpoint := "api/public/matchmaking?" + query.Encode()
+	data, err := c.get(ctx, endpoint, queueStatusCacheTTL)
 	if err != nil {
-		return nil, errors.Wrap(err, "slapapiGet")
+		return nil, errors.Wrap(err, "c.get")
 	}
 	resp := matchmakingresp{}
-	json.Unmarshal(data, &resp)
+	err = decode(data, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
 	return &resp.Playlists, nil
 }
@@ -1,36 +1,72 @@
 package slapshotapi
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
-func slapapiGet(
+// Make a single request to the endpoint, returning an *APIError if the
+// response status isn't 200. If body isn't nil it's sent as JSON
+func (c *Client) request(
+	ctx context.Context,
+	method string,
 	endpoint string,
-	env string,
-	key string,
+	body any,
 ) ([]byte, error) {
-	if env != "api" && env != "staging" {
-		return nil, errors.New("Invalid Env specified, must be 'api' or 'staging'")
+	url := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "json.Marshal")
+		}
+		reqBody = bytes.NewReader(data)
 	}
-	baseurl := fmt.Sprintf("https://%s.slapshot.gg/%s", env, endpoint)
-	req, err := http.NewRequest("GET", baseurl, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, errors.Wrap(err, "http.NewRequest")
+		return nil, errors.Wrap(err, "http.NewRequestWithContext")
 	}
 	req.Header.Add("accept", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", key))
-	res, err := http.DefaultClient.Do(req)
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.key))
+	start := time.Now()
+	res, err := c.http.Do(req)
 	if err != nil {
-		return nil, errors.Wrap(err, "http.DefaultClient.Do")
+		observeRequest(endpoint, "error", start)
+		return nil, errors.Wrap(err, "c.http.Do")
 	}
+	observeRequest(endpoint, strconv.Itoa(res.StatusCode), start)
 	defer res.Body.Close()
-	body, err := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, res.Body)
+		return nil, &APIError{
+			Endpoint:   endpoint,
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+		}
+	}
+	data, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, errors.Wrap(err, "io.ReadAll")
 	}
-	return body, nil
+	return data, nil
+}
+
+// Unmarshal the response data into v
+func decode(data []byte, v any) error {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return errors.Wrap(err, "json.Unmarshal")
+	}
+	return nil
 }
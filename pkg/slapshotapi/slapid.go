@@ -1,24 +1,36 @@
 package slapshotapi
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
+// How long a steam user's SlapID is cached for. It never changes once the
+// account has played
+const slapIDCacheTTL = 24 * time.Hour
+
 type idresp struct {
 	ID uint32 `json:"id"`
 }
 
-// Get the SlapID of the steam user
-func GetSlapID(steamid string, apikey string, env string) (uint32, error) {
-	endpoint := "api/public/players/steam/%s"
-	data, err := slapapiGet(fmt.Sprintf(endpoint, steamid), env, apikey)
+// Get the SlapID of the steam user. Returns ErrNotFound if the steam account
+// hasn't played slapshot
+func (c *Client) GetSlapID(ctx context.Context, steamid string) (uint32, error) {
+	endpoint := fmt.Sprintf("api/public/players/steam/%s", steamid)
+	data, err := c.get(ctx, endpoint, slapIDCacheTTL)
 	if err != nil {
-		return 0, errors.Wrap(err, "slapapiGet")
+		return 0, errors.Wrap(err, "c.get")
 	}
 	resp := idresp{}
-	json.Unmarshal(data, &resp)
+	err = decode(data, &resp)
+	if err != nil {
+		return 0, errors.Wrap(err, "decode")
+	}
+	if resp.ID == 0 {
+		return 0, errors.Wrap(ErrNotFound, "resp.ID")
+	}
 	return resp.ID, nil
 }
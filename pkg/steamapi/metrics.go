@@ -0,0 +1,22 @@
+package steamapi
+
+import (
+	"gosl/pkg/metrics"
+	"time"
+)
+
+var (
+	apiRequests = metrics.NewCounterVec("gosl_steam_api_requests_total",
+		"Requests made to the Steam API, by endpoint and result (status code or error)",
+		"endpoint", "result")
+	apiDuration = metrics.NewHistogramVec("gosl_steam_api_request_duration_seconds",
+		"Time taken for requests to the Steam API", nil, "endpoint")
+)
+
+// Record a request to the endpoint started at the time provided.
+// Parts of the endpoint containing IDs are replaced so they share a series
+func observeRequest(endpoint string, result string, start time.Time) {
+	endpoint = metrics.Normalize(endpoint, "/")
+	apiRequests.Inc(endpoint, result)
+	apiDuration.ObserveDuration(time.Since(start), endpoint)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -23,10 +25,13 @@ func steamapiGet(
 	if err != nil {
 		return nil, errors.Wrap(err, "http.NewRequest")
 	}
+	start := time.Now()
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
+		observeRequest(endpoint, "error", start)
 		return nil, errors.Wrap(err, "http.DefaultClient.Do")
 	}
+	observeRequest(endpoint, strconv.Itoa(res.StatusCode), start)
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
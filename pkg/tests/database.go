@@ -4,36 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"io/fs"
 	"os"
 	"path/filepath"
 
+	"gosl/pkg/migrations"
+
 	"github.com/pkg/errors"
-	"github.com/pressly/goose/v3"
 
 	_ "modernc.org/sqlite"
 )
 
-func findMigrations() (*fs.FS, error) {
-	dir, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-
-	for {
-		if _, err := os.Stat(filepath.Join(dir, "Makefile")); err == nil {
-			migrationsdir := os.DirFS(filepath.Join(dir, "cmd", "migrate", "migrations"))
-			return &migrationsdir, nil
-		}
-
-		parent := filepath.Dir(dir)
-		if parent == dir { // Reached root
-			return nil, errors.New("Unable to locate migrations directory")
-		}
-		dir = parent
-	}
-}
-
 func findTestData() (string, error) {
 	dir, err := os.Getwd()
 	if err != nil {
@@ -54,13 +34,9 @@ func findTestData() (string, error) {
 }
 
 func migrateTestDB(wconn *sql.DB, version int64) error {
-	migrations, err := findMigrations()
-	if err != nil {
-		return errors.Wrap(err, "findMigrations")
-	}
-	provider, err := goose.NewProvider(goose.DialectSQLite3, wconn, *migrations)
+	provider, err := migrations.NewProvider(wconn)
 	if err != nil {
-		return errors.Wrap(err, "goose.NewProvider")
+		return errors.Wrap(err, "migrations.NewProvider")
 	}
 	ctx := context.Background()
 	if _, err := provider.UpTo(ctx, version); err != nil {